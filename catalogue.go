@@ -0,0 +1,73 @@
+// +build windows
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/prometheus-community/windows_exporter/collector"
+)
+
+// Output formats of --collectors.print.
+const (
+	printFormatList     = "list"
+	printFormatJSON     = "json"
+	printFormatMarkdown = "markdown"
+)
+
+// legacyPrintArgs rewrites a bare --collectors.print, which used to be a
+// boolean flag, to --collectors.print=list.
+func legacyPrintArgs(args []string) []string {
+	rewritten := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "--collectors.print" {
+			arg = "--collectors.print=" + printFormatList
+		}
+		rewritten[i] = arg
+	}
+	return rewritten
+}
+
+// printCollectorList prints the names of all available collectors.
+func printCollectorList(w io.Writer) {
+	collectorNames := collector.Available()
+	sort.Strings(collectorNames)
+	fmt.Fprintf(w, "Available collectors:\n")
+	for _, n := range collectorNames {
+		fmt.Fprintf(w, " - %s\n", n)
+	}
+}
+
+// printMetricCatalogue prints the metrics exposed by the given collectors in
+// the given format.
+func printMetricCatalogue(w io.Writer, format string, collectors map[string]collector.Collector) error {
+	names := keys(collectors)
+	sort.Strings(names)
+
+	catalogue := make([]collector.CollectorInfo, 0, len(names))
+	for _, name := range names {
+		info, err := collector.Describe(name, collectors[name])
+		if err != nil {
+			return err
+		}
+		catalogue = append(catalogue, info)
+	}
+
+	switch format {
+	case printFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(catalogue)
+	case printFormatMarkdown:
+		for _, info := range catalogue {
+			if _, err := fmt.Fprintf(w, "## %s\n\n%s\n", info.Name, info.MarkdownTable()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
@@ -0,0 +1,26 @@
+// +build windows
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLegacyPrintArgs(t *testing.T) {
+	cases := []struct {
+		input    []string
+		expected []string
+	}{
+		{[]string{}, []string{}},
+		{[]string{"--collectors.print"}, []string{"--collectors.print=list"}},
+		{[]string{"--collectors.print=json", "--collectors.enabled=cpu"}, []string{"--collectors.print=json", "--collectors.enabled=cpu"}},
+		{[]string{"--collectors.enabled=cpu", "--collectors.print"}, []string{"--collectors.enabled=cpu", "--collectors.print=list"}},
+	}
+
+	for _, c := range cases {
+		if got := legacyPrintArgs(c.input); !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("%v: expected %v, got %v", c.input, c.expected, got)
+		}
+	}
+}
@@ -5,16 +5,14 @@ package collector
 import (
 	"errors"
 
-	"github.com/StackExchange/wmi"
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 func init() {
-	registerCollector("ad", NewADCollector)
+	registerCollector("ad", NewADCollector, "DirectoryServices")
 }
 
-// A ADCollector is a Prometheus collector for WMI Win32_PerfRawData_DirectoryServices_DirectoryServices metrics
+// A ADCollector is a Prometheus collector for Perflib DirectoryServices metrics
 type ADCollector struct {
 	AddressBookOperationsTotal                          *prometheus.Desc
 	AddressBookClientSessions                           *prometheus.Desc
@@ -455,8 +453,7 @@ func NewADCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *ADCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting ad metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
@@ -464,850 +461,940 @@ func (c *ADCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) e
 
 // Win32_PerfRawData_DirectoryServices_DirectoryServices docs:
 // - https://msdn.microsoft.com/en-us/library/ms803980.aspx
-type Win32_PerfRawData_DirectoryServices_DirectoryServices struct {
+type directoryServices struct {
 	Name string
 
-	ABANRPersec                                                      uint32
-	ABBrowsesPersec                                                  uint32
-	ABClientSessions                                                 uint32
-	ABMatchesPersec                                                  uint32
-	ABPropertyReadsPersec                                            uint32
-	ABProxyLookupsPersec                                             uint32
-	ABSearchesPersec                                                 uint32
-	ApproximatehighestDNT                                            uint32
-	ATQEstimatedQueueDelay                                           uint32
-	ATQOutstandingQueuedRequests                                     uint32
-	ATQRequestLatency                                                uint32
-	ATQThreadsLDAP                                                   uint32
-	ATQThreadsOther                                                  uint32
-	ATQThreadsTotal                                                  uint32
-	BasesearchesPersec                                               uint32
-	DatabaseaddsPersec                                               uint32
-	DatabasedeletesPersec                                            uint32
-	DatabasemodifysPersec                                            uint32
-	DatabaserecyclesPersec                                           uint32
-	DigestBindsPersec                                                uint32
-	DRAHighestUSNCommittedHighpart                                   uint64
-	DRAHighestUSNCommittedLowpart                                    uint64
-	DRAHighestUSNIssuedHighpart                                      uint64
-	DRAHighestUSNIssuedLowpart                                       uint64
-	DRAInboundBytesCompressedBetweenSitesAfterCompressionPersec      uint32
-	DRAInboundBytesCompressedBetweenSitesAfterCompressionSinceBoot   uint32
-	DRAInboundBytesCompressedBetweenSitesBeforeCompressionPersec     uint32
-	DRAInboundBytesCompressedBetweenSitesBeforeCompressionSinceBoot  uint32
-	DRAInboundBytesNotCompressedWithinSitePersec                     uint32
-	DRAInboundBytesNotCompressedWithinSiteSinceBoot                  uint32
-	DRAInboundBytesTotalPersec                                       uint32
-	DRAInboundBytesTotalSinceBoot                                    uint32
-	DRAInboundFullSyncObjectsRemaining                               uint32
-	DRAInboundLinkValueUpdatesRemaininginPacket                      uint32
-	DRAInboundObjectsAppliedPersec                                   uint32
-	DRAInboundObjectsFilteredPersec                                  uint32
-	DRAInboundObjectsPersec                                          uint32
-	DRAInboundObjectUpdatesRemaininginPacket                         uint32
-	DRAInboundPropertiesAppliedPersec                                uint32
-	DRAInboundPropertiesFilteredPersec                               uint32
-	DRAInboundPropertiesTotalPersec                                  uint32
-	DRAInboundTotalUpdatesRemaininginPacket                          uint32
-	DRAInboundValuesDNsonlyPersec                                    uint32
-	DRAInboundValuesTotalPersec                                      uint32
-	DRAOutboundBytesCompressedBetweenSitesAfterCompressionPersec     uint32
-	DRAOutboundBytesCompressedBetweenSitesAfterCompressionSinceBoot  uint32
-	DRAOutboundBytesCompressedBetweenSitesBeforeCompressionPersec    uint32
-	DRAOutboundBytesCompressedBetweenSitesBeforeCompressionSinceBoot uint32
-	DRAOutboundBytesNotCompressedWithinSitePersec                    uint32
-	DRAOutboundBytesNotCompressedWithinSiteSinceBoot                 uint32
-	DRAOutboundBytesTotalPersec                                      uint32
-	DRAOutboundBytesTotalSinceBoot                                   uint32
-	DRAOutboundObjectsFilteredPersec                                 uint32
-	DRAOutboundObjectsPersec                                         uint32
-	DRAOutboundPropertiesPersec                                      uint32
-	DRAOutboundValuesDNsonlyPersec                                   uint32
-	DRAOutboundValuesTotalPersec                                     uint32
-	DRAPendingReplicationOperations                                  uint32
-	DRAPendingReplicationSynchronizations                            uint32
-	DRASyncFailuresonSchemaMismatch                                  uint32
-	DRASyncRequestsMade                                              uint32
-	DRASyncRequestsSuccessful                                        uint32
-	DRAThreadsGettingNCChanges                                       uint32
-	DRAThreadsGettingNCChangesHoldingSemaphore                       uint32
-	DSClientBindsPersec                                              uint32
-	DSClientNameTranslationsPersec                                   uint32
-	DSDirectoryReadsPersec                                           uint32
-	DSDirectorySearchesPersec                                        uint32
-	DSDirectoryWritesPersec                                          uint32
-	DSMonitorListSize                                                uint32
-	DSNameCachehitrate                                               uint32
-	DSNameCachehitrate_Base                                          uint32
-	DSNotifyQueueSize                                                uint32
-	DSPercentReadsfromDRA                                            uint32
-	DSPercentReadsfromKCC                                            uint32
-	DSPercentReadsfromLSA                                            uint32
-	DSPercentReadsfromNSPI                                           uint32
-	DSPercentReadsfromNTDSAPI                                        uint32
-	DSPercentReadsfromSAM                                            uint32
-	DSPercentReadsOther                                              uint32
-	DSPercentSearchesfromDRA                                         uint32
-	DSPercentSearchesfromKCC                                         uint32
-	DSPercentSearchesfromLDAP                                        uint32
-	DSPercentSearchesfromLSA                                         uint32
-	DSPercentSearchesfromNSPI                                        uint32
-	DSPercentSearchesfromNTDSAPI                                     uint32
-	DSPercentSearchesfromSAM                                         uint32
-	DSPercentSearchesOther                                           uint32
-	DSPercentWritesfromDRA                                           uint32
-	DSPercentWritesfromKCC                                           uint32
-	DSPercentWritesfromLDAP                                          uint32
-	DSPercentWritesfromLSA                                           uint32
-	DSPercentWritesfromNSPI                                          uint32
-	DSPercentWritesfromNTDSAPI                                       uint32
-	DSPercentWritesfromSAM                                           uint32
-	DSPercentWritesOther                                             uint32
-	DSSearchsuboperationsPersec                                      uint32
-	DSSecurityDescriptorPropagationsEvents                           uint32
-	DSSecurityDescriptorPropagatorAverageExclusionTime               uint32
-	DSSecurityDescriptorPropagatorRuntimeQueue                       uint32
-	DSSecurityDescriptorsuboperationsPersec                          uint32
-	DSServerBindsPersec                                              uint32
-	DSServerNameTranslationsPersec                                   uint32
-	DSThreadsinUse                                                   uint32
-	ExternalBindsPersec                                              uint32
-	FastBindsPersec                                                  uint32
-	LDAPActiveThreads                                                uint32
-	LDAPBindTime                                                     uint32
-	LDAPClientSessions                                               uint32
-	LDAPClosedConnectionsPersec                                      uint32
-	LDAPNewConnectionsPersec                                         uint32
-	LDAPNewSSLConnectionsPersec                                      uint32
-	LDAPSearchesPersec                                               uint32
-	LDAPSuccessfulBindsPersec                                        uint32
-	LDAPUDPoperationsPersec                                          uint32
-	LDAPWritesPersec                                                 uint32
-	LinkValuesCleanedPersec                                          uint32
-	NegotiatedBindsPersec                                            uint32
-	NTLMBindsPersec                                                  uint32
-	OnelevelsearchesPersec                                           uint32
-	PhantomsCleanedPersec                                            uint32
-	PhantomsVisitedPersec                                            uint32
-	SAMAccountGroupEvaluationLatency                                 uint32
-	SAMDisplayInformationQueriesPersec                               uint32
-	SAMDomainLocalGroupMembershipEvaluationsPersec                   uint32
-	SAMEnumerationsPersec                                            uint32
-	SAMGCEvaluationsPersec                                           uint32
-	SAMGlobalGroupMembershipEvaluationsPersec                        uint32
-	SAMMachineCreationAttemptsPersec                                 uint32
-	SAMMembershipChangesPersec                                       uint32
-	SAMNonTransitiveMembershipEvaluationsPersec                      uint32
-	SAMPasswordChangesPersec                                         uint32
-	SAMResourceGroupEvaluationLatency                                uint32
-	SAMSuccessfulComputerCreationsPersecIncludesallrequests          uint32
-	SAMSuccessfulUserCreationsPersec                                 uint32
-	SAMTransitiveMembershipEvaluationsPersec                         uint32
-	SAMUniversalGroupMembershipEvaluationsPersec                     uint32
-	SAMUserCreationAttemptsPersec                                    uint32
-	SimpleBindsPersec                                                uint32
-	SubtreesearchesPersec                                            uint32
-	TombstonesGarbageCollectedPersec                                 uint32
-	TombstonesVisitedPersec                                          uint32
-	Transitiveoperationsmillisecondsrun                              uint32
-	TransitiveoperationsPersec                                       uint32
-	TransitivesuboperationsPersec                                    uint32
+	ABANRPersec                                                  float64 `perflib:"AB ANR/sec"`
+	ABBrowsesPersec                                              float64 `perflib:"AB Browses/sec"`
+	ABClientSessions                                             float64 `perflib:"AB Client Sessions"`
+	ABMatchesPersec                                              float64 `perflib:"AB Matches/sec"`
+	ABPropertyReadsPersec                                        float64 `perflib:"AB Property Reads/sec"`
+	ABProxyLookupsPersec                                         float64 `perflib:"AB Proxy Lookups/sec"`
+	ABSearchesPersec                                             float64 `perflib:"AB Searches/sec"`
+	ApproximatehighestDNT                                        float64 `perflib:"Approximate highest DNT"`
+	ATQEstimatedQueueDelay                                       float64 `perflib:"ATQ Estimated Queue Delay"`
+	ATQOutstandingQueuedRequests                                 float64 `perflib:"ATQ Outstanding Queued Requests"`
+	ATQRequestLatency                                            float64 `perflib:"ATQ Request Latency"`
+	ATQThreadsLDAP                                               float64 `perflib:"ATQ Threads LDAP"`
+	ATQThreadsOther                                              float64 `perflib:"ATQ Threads Other"`
+	BasesearchesPersec                                           float64 `perflib:"Base searches/sec"`
+	DatabaseaddsPersec                                           float64 `perflib:"Database adds/sec"`
+	DatabasedeletesPersec                                        float64 `perflib:"Database deletes/sec"`
+	DatabasemodifysPersec                                        float64 `perflib:"Database modifys/sec"`
+	DatabaserecyclesPersec                                       float64 `perflib:"Database recycles/sec"`
+	DigestBindsPersec                                            float64 `perflib:"Digest Binds/sec"`
+	DRAHighestUSNCommittedHighpart                               float64 `perflib:"DRA Highest USN Committed (High part)"`
+	DRAHighestUSNCommittedLowpart                                float64 `perflib:"DRA Highest USN Committed (Low part)"`
+	DRAHighestUSNIssuedHighpart                                  float64 `perflib:"DRA Highest USN Issued (High part)"`
+	DRAHighestUSNIssuedLowpart                                   float64 `perflib:"DRA Highest USN Issued (Low part)"`
+	DRAInboundBytesCompressedBetweenSitesAfterCompressionPersec  float64 `perflib:"DRA Inbound Bytes Compressed (Between Sites, After Compression)/sec"`
+	DRAInboundBytesNotCompressedWithinSitePersec                 float64 `perflib:"DRA Inbound Bytes Not Compressed (Within Site)/sec"`
+	DRAInboundFullSyncObjectsRemaining                           float64 `perflib:"DRA Inbound Full Sync Objects Remaining"`
+	DRAInboundLinkValueUpdatesRemaininginPacket                  float64 `perflib:"DRA Inbound Link Value Updates Remaining in Packet"`
+	DRAInboundObjectsAppliedPersec                               float64 `perflib:"DRA Inbound Objects Applied/sec"`
+	DRAInboundObjectsFilteredPersec                              float64 `perflib:"DRA Inbound Objects Filtered/sec"`
+	DRAInboundPropertiesAppliedPersec                            float64 `perflib:"DRA Inbound Properties Applied/sec"`
+	DRAInboundPropertiesFilteredPersec                           float64 `perflib:"DRA Inbound Properties Filtered/sec"`
+	DRAOutboundBytesCompressedBetweenSitesAfterCompressionPersec float64 `perflib:"DRA Outbound Bytes Compressed (Between Sites, After Compression)/sec"`
+	DRAOutboundBytesNotCompressedWithinSitePersec                float64 `perflib:"DRA Outbound Bytes Not Compressed (Within Site)/sec"`
+	DRAPendingReplicationOperations                              float64 `perflib:"DRA Pending Replication Operations"`
+	DRAPendingReplicationSynchronizations                        float64 `perflib:"DRA Pending Replication Synchronizations"`
+	DRASyncFailuresonSchemaMismatch                              float64 `perflib:"DRA Sync Failures on Schema Mismatch"`
+	DRASyncRequestsMade                                          float64 `perflib:"DRA Sync Requests Made"`
+	DRASyncRequestsSuccessful                                    float64 `perflib:"DRA Sync Requests Successful"`
+	DSClientBindsPersec                                          float64 `perflib:"DS Client Binds/sec"`
+	DSClientNameTranslationsPersec                               float64 `perflib:"DS Client Name Translations/sec"`
+	DSMonitorListSize                                            float64 `perflib:"DS Monitor List Size"`
+	DSNameCachehitrate                                           float64 `perflib:"DS Name Cache hit rate"`
+	DSNameCachehitrateBase                                       float64 `perflib:"DS Name Cache hit rate,base"`
+	DSNotifyQueueSize                                            float64 `perflib:"DS Notify Queue Size"`
+	DSPercentReadsfromDRA                                        float64 `perflib:"DS % Reads from DRA"`
+	DSPercentReadsfromKCC                                        float64 `perflib:"DS % Reads from KCC"`
+	DSPercentReadsfromLSA                                        float64 `perflib:"DS % Reads from LSA"`
+	DSPercentReadsfromNSPI                                       float64 `perflib:"DS % Reads from NSPI"`
+	DSPercentReadsfromNTDSAPI                                    float64 `perflib:"DS % Reads from NTDSAPI"`
+	DSPercentReadsfromSAM                                        float64 `perflib:"DS % Reads from SAM"`
+	DSPercentReadsOther                                          float64 `perflib:"DS % Reads Other"`
+	DSPercentSearchesfromDRA                                     float64 `perflib:"DS % Searches from DRA"`
+	DSPercentSearchesfromKCC                                     float64 `perflib:"DS % Searches from KCC"`
+	DSPercentSearchesfromLDAP                                    float64 `perflib:"DS % Searches from LDAP"`
+	DSPercentSearchesfromLSA                                     float64 `perflib:"DS % Searches from LSA"`
+	DSPercentSearchesfromNSPI                                    float64 `perflib:"DS % Searches from NSPI"`
+	DSPercentSearchesfromNTDSAPI                                 float64 `perflib:"DS % Searches from NTDSAPI"`
+	DSPercentSearchesfromSAM                                     float64 `perflib:"DS % Searches from SAM"`
+	DSPercentSearchesOther                                       float64 `perflib:"DS % Searches Other"`
+	DSPercentWritesfromDRA                                       float64 `perflib:"DS % Writes from DRA"`
+	DSPercentWritesfromKCC                                       float64 `perflib:"DS % Writes from KCC"`
+	DSPercentWritesfromLDAP                                      float64 `perflib:"DS % Writes from LDAP"`
+	DSPercentWritesfromLSA                                       float64 `perflib:"DS % Writes from LSA"`
+	DSPercentWritesfromNSPI                                      float64 `perflib:"DS % Writes from NSPI"`
+	DSPercentWritesfromNTDSAPI                                   float64 `perflib:"DS % Writes from NTDSAPI"`
+	DSPercentWritesfromSAM                                       float64 `perflib:"DS % Writes from SAM"`
+	DSPercentWritesOther                                         float64 `perflib:"DS % Writes Other"`
+	DSSearchsuboperationsPersec                                  float64 `perflib:"DS Search sub-operations/sec"`
+	DSSecurityDescriptorPropagationsEvents                       float64 `perflib:"DS Security Descriptor Propagations Events"`
+	DSSecurityDescriptorPropagatorAverageExclusionTime           float64 `perflib:"DS Security Descriptor Propagator Average Exclusion Time"`
+	DSSecurityDescriptorPropagatorRuntimeQueue                   float64 `perflib:"DS Security Descriptor Propagator Runtime Queue"`
+	DSSecurityDescriptorsuboperationsPersec                      float64 `perflib:"DS Security Descriptor sub-operations/sec"`
+	DSServerBindsPersec                                          float64 `perflib:"DS Server Binds/sec"`
+	DSServerNameTranslationsPersec                               float64 `perflib:"DS Server Name Translations/sec"`
+	DSThreadsinUse                                               float64 `perflib:"DS Threads in Use"`
+	ExternalBindsPersec                                          float64 `perflib:"External Binds/sec"`
+	FastBindsPersec                                              float64 `perflib:"Fast Binds/sec"`
+	LDAPActiveThreads                                            float64 `perflib:"LDAP Active Threads"`
+	LDAPBindTime                                                 float64 `perflib:"LDAP Bind Time"`
+	LDAPClosedConnectionsPersec                                  float64 `perflib:"LDAP Closed Connections/sec"`
+	LDAPNewConnectionsPersec                                     float64 `perflib:"LDAP New Connections/sec"`
+	LDAPNewSSLConnectionsPersec                                  float64 `perflib:"LDAP New SSL Connections/sec"`
+	LDAPSearchesPersec                                           float64 `perflib:"LDAP Searches/sec"`
+	LDAPSuccessfulBindsPersec                                    float64 `perflib:"LDAP Successful Binds/sec"`
+	LDAPUDPoperationsPersec                                      float64 `perflib:"LDAP UDP operations/sec"`
+	LDAPWritesPersec                                             float64 `perflib:"LDAP Writes/sec"`
+	LinkValuesCleanedPersec                                      float64 `perflib:"Link Values Cleaned/sec"`
+	NegotiatedBindsPersec                                        float64 `perflib:"Negotiated Binds/sec"`
+	NTLMBindsPersec                                              float64 `perflib:"NTLM Binds/sec"`
+	OnelevelsearchesPersec                                       float64 `perflib:"Onelevel searches/sec"`
+	PhantomsCleanedPersec                                        float64 `perflib:"Phantoms Cleaned/sec"`
+	PhantomsVisitedPersec                                        float64 `perflib:"Phantoms Visited/sec"`
+	SAMAccountGroupEvaluationLatency                             float64 `perflib:"SAM Account Group Evaluation Latency"`
+	SAMDisplayInformationQueriesPersec                           float64 `perflib:"SAM Display Information Queries/sec"`
+	SAMDomainLocalGroupMembershipEvaluationsPersec               float64 `perflib:"SAM Domain Local Group Membership Evaluations/sec"`
+	SAMEnumerationsPersec                                        float64 `perflib:"SAM Enumerations/sec"`
+	SAMGCEvaluationsPersec                                       float64 `perflib:"SAM GC Evaluations/sec"`
+	SAMGlobalGroupMembershipEvaluationsPersec                    float64 `perflib:"SAM Global Group Membership Evaluations/sec"`
+	SAMMachineCreationAttemptsPersec                             float64 `perflib:"SAM Machine Creation Attempts/sec"`
+	SAMMembershipChangesPersec                                   float64 `perflib:"SAM Membership Changes/sec"`
+	SAMNonTransitiveMembershipEvaluationsPersec                  float64 `perflib:"SAM Non-Transitive Membership Evaluations/sec"`
+	SAMPasswordChangesPersec                                     float64 `perflib:"SAM Password Changes/sec"`
+	SAMResourceGroupEvaluationLatency                            float64 `perflib:"SAM Resource Group Evaluation Latency"`
+	SAMSuccessfulComputerCreationsPersecIncludesallrequests      float64 `perflib:"SAM Successful Computer Creations/sec: Includes all requests"`
+	SAMSuccessfulUserCreationsPersec                             float64 `perflib:"SAM Successful User Creations/sec"`
+	SAMTransitiveMembershipEvaluationsPersec                     float64 `perflib:"SAM Transitive Membership Evaluations/sec"`
+	SAMUniversalGroupMembershipEvaluationsPersec                 float64 `perflib:"SAM Universal Group Membership Evaluations/sec"`
+	SAMUserCreationAttemptsPersec                                float64 `perflib:"SAM User Creation Attempts/sec"`
+	SimpleBindsPersec                                            float64 `perflib:"Simple Binds/sec"`
+	SubtreesearchesPersec                                        float64 `perflib:"Subtree searches/sec"`
+	TombstonesGarbageCollectedPersec                             float64 `perflib:"Tombstones Garbage Collected/sec"`
+	TombstonesVisitedPersec                                      float64 `perflib:"Tombstones Visited/sec"`
 }
 
-func (c *ADCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
-	var dst []Win32_PerfRawData_DirectoryServices_DirectoryServices
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+func (c *ADCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var instances []directoryServices
+	present, err := unmarshalObject(ctx.perfObjects["DirectoryServices"], &instances)
+	if err != nil {
 		return nil, err
 	}
-	if len(dst) == 0 {
-		return nil, errors.New("WMI query returned empty result set")
+	// AD LDS instances are reported besides the NTDS instance of the domain
+	// controller.
+	var ntds *directoryServices
+	for i := range instances {
+		if instances[i].Name == "NTDS" {
+			ntds = &instances[i]
+			break
+		}
+	}
+	if ntds == nil {
+		return nil, errors.New("perflib query for DirectoryServices returned no NTDS instance")
 	}
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.AddressBookOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].ABANRPersec),
+		&ntds.ABANRPersec,
 		"ambiguous_name_resolution",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.AddressBookOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].ABBrowsesPersec),
+		&ntds.ABBrowsesPersec,
 		"browse",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.AddressBookOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].ABMatchesPersec),
+		&ntds.ABMatchesPersec,
 		"find",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.AddressBookOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].ABPropertyReadsPersec),
+		&ntds.ABPropertyReadsPersec,
 		"property_read",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.AddressBookOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].ABSearchesPersec),
+		&ntds.ABSearchesPersec,
 		"search",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.AddressBookOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].ABProxyLookupsPersec),
+		&ntds.ABProxyLookupsPersec,
 		"proxy_search",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.AddressBookClientSessions,
 		prometheus.GaugeValue,
-		float64(dst[0].ABClientSessions),
+		&ntds.ABClientSessions,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ApproximateHighestDistinguishedNameTag,
 		prometheus.GaugeValue,
-		float64(dst[0].ApproximatehighestDNT),
+		&ntds.ApproximatehighestDNT,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
-		c.AtqEstimatedDelaySeconds,
-		prometheus.GaugeValue,
-		float64(dst[0].ATQEstimatedQueueDelay)/1000,
-	)
-	ch <- prometheus.MustNewConstMetric(
+	if present.has(&ntds.ATQEstimatedQueueDelay) {
+		ch <- prometheus.MustNewConstMetric(
+			c.AtqEstimatedDelaySeconds,
+			prometheus.GaugeValue,
+			ntds.ATQEstimatedQueueDelay/1000,
+		)
+	}
+	present.emit(
+		ch,
 		c.AtqOutstandingRequests,
 		prometheus.GaugeValue,
-		float64(dst[0].ATQOutstandingQueuedRequests),
+		&ntds.ATQOutstandingQueuedRequests,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.AtqAverageRequestLatency,
 		prometheus.GaugeValue,
-		float64(dst[0].ATQRequestLatency),
+		&ntds.ATQRequestLatency,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.AtqCurrentThreads,
 		prometheus.GaugeValue,
-		float64(dst[0].ATQThreadsLDAP),
+		&ntds.ATQThreadsLDAP,
 		"ldap",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.AtqCurrentThreads,
 		prometheus.GaugeValue,
-		float64(dst[0].ATQThreadsOther),
+		&ntds.ATQThreadsOther,
 		"other",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SearchesTotal,
 		prometheus.CounterValue,
-		float64(dst[0].BasesearchesPersec),
+		&ntds.BasesearchesPersec,
 		"base",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SearchesTotal,
 		prometheus.CounterValue,
-		float64(dst[0].SubtreesearchesPersec),
+		&ntds.SubtreesearchesPersec,
 		"subtree",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SearchesTotal,
 		prometheus.CounterValue,
-		float64(dst[0].OnelevelsearchesPersec),
+		&ntds.OnelevelsearchesPersec,
 		"one_level",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DatabaseOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DatabaseaddsPersec),
+		&ntds.DatabaseaddsPersec,
 		"add",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DatabaseOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DatabasedeletesPersec),
+		&ntds.DatabasedeletesPersec,
 		"delete",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DatabaseOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DatabasemodifysPersec),
+		&ntds.DatabasemodifysPersec,
 		"modify",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DatabaseOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DatabaserecyclesPersec),
+		&ntds.DatabaserecyclesPersec,
 		"recycle",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.BindsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DigestBindsPersec),
+		&ntds.DigestBindsPersec,
 		"digest",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.BindsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSClientBindsPersec),
+		&ntds.DSClientBindsPersec,
 		"ds_client",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.BindsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSServerBindsPersec),
+		&ntds.DSServerBindsPersec,
 		"ds_server",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.BindsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].ExternalBindsPersec),
+		&ntds.ExternalBindsPersec,
 		"external",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.BindsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].FastBindsPersec),
+		&ntds.FastBindsPersec,
 		"fast",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.BindsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].NegotiatedBindsPersec),
+		&ntds.NegotiatedBindsPersec,
 		"negotiate",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.BindsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].NTLMBindsPersec),
+		&ntds.NTLMBindsPersec,
 		"ntlm",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.BindsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].SimpleBindsPersec),
+		&ntds.SimpleBindsPersec,
 		"simple",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.BindsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].LDAPSuccessfulBindsPersec),
+		&ntds.LDAPSuccessfulBindsPersec,
 		"ldap",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
-		c.ReplicationHighestUsn,
-		prometheus.CounterValue,
-		float64(dst[0].DRAHighestUSNCommittedHighpart<<32)+float64(dst[0].DRAHighestUSNCommittedLowpart),
-		"committed",
-	)
-	ch <- prometheus.MustNewConstMetric(
-		c.ReplicationHighestUsn,
-		prometheus.CounterValue,
-		float64(dst[0].DRAHighestUSNIssuedHighpart<<32)+float64(dst[0].DRAHighestUSNIssuedLowpart),
-		"issued",
-	)
+	if present.has(&ntds.DRAHighestUSNCommittedHighpart, &ntds.DRAHighestUSNCommittedLowpart) {
+		ch <- prometheus.MustNewConstMetric(
+			c.ReplicationHighestUsn,
+			prometheus.CounterValue,
+			ntds.DRAHighestUSNCommittedHighpart*(1<<32)+ntds.DRAHighestUSNCommittedLowpart,
+			"committed",
+		)
+	}
+	if present.has(&ntds.DRAHighestUSNIssuedHighpart, &ntds.DRAHighestUSNIssuedLowpart) {
+		ch <- prometheus.MustNewConstMetric(
+			c.ReplicationHighestUsn,
+			prometheus.CounterValue,
+			ntds.DRAHighestUSNIssuedHighpart*(1<<32)+ntds.DRAHighestUSNIssuedLowpart,
+			"issued",
+		)
+	}
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.IntersiteReplicationDataBytesTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DRAInboundBytesCompressedBetweenSitesAfterCompressionPersec),
+		&ntds.DRAInboundBytesCompressedBetweenSitesAfterCompressionPersec,
 		"inbound",
 	)
 	// The pre-compression data size seems to have little value? Skipping for now
 	// ch <- prometheus.MustNewConstMetric(
 	// 	c.IntersiteReplicationDataBytesTotal,
 	// 	prometheus.CounterValue,
-	// 	float64(dst[0].DRAInboundBytesCompressedBetweenSitesBeforeCompressionPersec),
+	// 	ntds.DRAInboundBytesCompressedBetweenSitesBeforeCompressionPersec,
 	// 	"inbound",
 	// )
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.IntersiteReplicationDataBytesTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DRAOutboundBytesCompressedBetweenSitesAfterCompressionPersec),
+		&ntds.DRAOutboundBytesCompressedBetweenSitesAfterCompressionPersec,
 		"outbound",
 	)
 	// ch <- prometheus.MustNewConstMetric(
 	// 	c.IntersiteReplicationDataBytesTotal,
 	// 	prometheus.CounterValue,
-	// 	float64(dst[0].DRAOutboundBytesCompressedBetweenSitesBeforeCompressionPersec),
+	// 	ntds.DRAOutboundBytesCompressedBetweenSitesBeforeCompressionPersec,
 	// 	"outbound",
 	// )
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.IntrasiteReplicationDataBytesTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DRAInboundBytesNotCompressedWithinSitePersec),
+		&ntds.DRAInboundBytesNotCompressedWithinSitePersec,
 		"inbound",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.IntrasiteReplicationDataBytesTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DRAOutboundBytesNotCompressedWithinSitePersec),
+		&ntds.DRAOutboundBytesNotCompressedWithinSitePersec,
 		"outbound",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ReplicationInboundSyncObjectsRemaining,
 		prometheus.GaugeValue,
-		float64(dst[0].DRAInboundFullSyncObjectsRemaining),
+		&ntds.DRAInboundFullSyncObjectsRemaining,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ReplicationInboundLinkValueUpdatesRemaining,
 		prometheus.GaugeValue,
-		float64(dst[0].DRAInboundLinkValueUpdatesRemaininginPacket),
+		&ntds.DRAInboundLinkValueUpdatesRemaininginPacket,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ReplicationInboundObjectsUpdatedTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DRAInboundObjectsAppliedPersec),
+		&ntds.DRAInboundObjectsAppliedPersec,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ReplicationInboundObjectsFilteredTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DRAInboundObjectsFilteredPersec),
+		&ntds.DRAInboundObjectsFilteredPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ReplicationInboundPropertiesUpdatedTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DRAInboundPropertiesAppliedPersec),
+		&ntds.DRAInboundPropertiesAppliedPersec,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ReplicationInboundPropertiesFilteredTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DRAInboundPropertiesFilteredPersec),
+		&ntds.DRAInboundPropertiesFilteredPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ReplicationPendingOperations,
 		prometheus.GaugeValue,
-		float64(dst[0].DRAPendingReplicationOperations),
+		&ntds.DRAPendingReplicationOperations,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ReplicationPendingSynchronizations,
 		prometheus.GaugeValue,
-		float64(dst[0].DRAPendingReplicationSynchronizations),
+		&ntds.DRAPendingReplicationSynchronizations,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ReplicationSyncRequestsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DRASyncRequestsMade),
+		&ntds.DRASyncRequestsMade,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ReplicationSyncRequestsSuccessTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DRASyncRequestsSuccessful),
+		&ntds.DRASyncRequestsSuccessful,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ReplicationSyncRequestsSchemaMismatchFailureTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DRASyncFailuresonSchemaMismatch),
+		&ntds.DRASyncFailuresonSchemaMismatch,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.NameTranslationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSClientNameTranslationsPersec),
+		&ntds.DSClientNameTranslationsPersec,
 		"client",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.NameTranslationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSServerNameTranslationsPersec),
+		&ntds.DSServerNameTranslationsPersec,
 		"server",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ChangeMonitorsRegistered,
 		prometheus.GaugeValue,
-		float64(dst[0].DSMonitorListSize),
+		&ntds.DSMonitorListSize,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ChangeMonitorUpdatesPending,
 		prometheus.GaugeValue,
-		float64(dst[0].DSNotifyQueueSize),
+		&ntds.DSNotifyQueueSize,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.NameCacheHitsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSNameCachehitrate),
+		&ntds.DSNameCachehitrate,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.NameCacheLookupsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSNameCachehitrate_Base),
+		&ntds.DSNameCachehitrateBase,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentReadsfromDRA),
+		&ntds.DSPercentReadsfromDRA,
 		"read",
 		"replication_agent",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentReadsfromKCC),
+		&ntds.DSPercentReadsfromKCC,
 		"read",
 		"knowledge_consistency_checker",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentReadsfromLSA),
+		&ntds.DSPercentReadsfromLSA,
 		"read",
 		"local_security_authority",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentReadsfromNSPI),
+		&ntds.DSPercentReadsfromNSPI,
 		"read",
 		"name_service_provider_interface",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentReadsfromNTDSAPI),
+		&ntds.DSPercentReadsfromNTDSAPI,
 		"read",
 		"directory_service_api",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentReadsfromSAM),
+		&ntds.DSPercentReadsfromSAM,
 		"read",
 		"security_account_manager",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentReadsOther),
+		&ntds.DSPercentReadsOther,
 		"read",
 		"other",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentSearchesfromDRA),
+		&ntds.DSPercentSearchesfromDRA,
 		"search",
 		"replication_agent",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentSearchesfromKCC),
+		&ntds.DSPercentSearchesfromKCC,
 		"search",
 		"knowledge_consistency_checker",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentSearchesfromLDAP),
+		&ntds.DSPercentSearchesfromLDAP,
 		"search",
 		"ldap",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentSearchesfromLSA),
+		&ntds.DSPercentSearchesfromLSA,
 		"search",
 		"local_security_authority",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentSearchesfromNSPI),
+		&ntds.DSPercentSearchesfromNSPI,
 		"search",
 		"name_service_provider_interface",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentSearchesfromNTDSAPI),
+		&ntds.DSPercentSearchesfromNTDSAPI,
 		"search",
 		"directory_service_api",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentSearchesfromSAM),
+		&ntds.DSPercentSearchesfromSAM,
 		"search",
 		"security_account_manager",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentSearchesOther),
+		&ntds.DSPercentSearchesOther,
 		"search",
 		"other",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentWritesfromDRA),
+		&ntds.DSPercentWritesfromDRA,
 		"write",
 		"replication_agent",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentWritesfromKCC),
+		&ntds.DSPercentWritesfromKCC,
 		"write",
 		"knowledge_consistency_checker",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentWritesfromLDAP),
+		&ntds.DSPercentWritesfromLDAP,
 		"write",
 		"ldap",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentWritesfromLSA),
+		&ntds.DSPercentWritesfromLSA,
 		"write",
 		"local_security_authority",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentWritesfromNSPI),
+		&ntds.DSPercentWritesfromNSPI,
 		"write",
 		"name_service_provider_interface",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentWritesfromNTDSAPI),
+		&ntds.DSPercentWritesfromNTDSAPI,
 		"write",
 		"directory_service_api",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentWritesfromSAM),
+		&ntds.DSPercentWritesfromSAM,
 		"write",
 		"security_account_manager",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSPercentWritesOther),
+		&ntds.DSPercentWritesOther,
 		"write",
 		"other",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectorySearchSuboperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSSearchsuboperationsPersec),
+		&ntds.DSSearchsuboperationsPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SecurityDescriptorPropagationEventsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSSecurityDescriptorsuboperationsPersec),
+		&ntds.DSSecurityDescriptorsuboperationsPersec,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SecurityDescriptorPropagationEventsQueued,
 		prometheus.GaugeValue,
-		float64(dst[0].DSSecurityDescriptorPropagationsEvents),
+		&ntds.DSSecurityDescriptorPropagationsEvents,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SecurityDescriptorPropagationAccessWaitTotalSeconds,
 		prometheus.GaugeValue,
-		float64(dst[0].DSSecurityDescriptorPropagatorAverageExclusionTime),
+		&ntds.DSSecurityDescriptorPropagatorAverageExclusionTime,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SecurityDescriptorPropagationItemsQueuedTotal,
 		prometheus.CounterValue,
-		float64(dst[0].DSSecurityDescriptorPropagatorRuntimeQueue),
+		&ntds.DSSecurityDescriptorPropagatorRuntimeQueue,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirectoryServiceThreads,
 		prometheus.GaugeValue,
-		float64(dst[0].DSThreadsinUse),
+		&ntds.DSThreadsinUse,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.LdapClosedConnectionsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].LDAPClosedConnectionsPersec),
+		&ntds.LDAPClosedConnectionsPersec,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.LdapOpenedConnectionsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].LDAPNewConnectionsPersec),
+		&ntds.LDAPNewConnectionsPersec,
 		"ldap",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.LdapOpenedConnectionsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].LDAPNewSSLConnectionsPersec),
+		&ntds.LDAPNewSSLConnectionsPersec,
 		"ldaps",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.LdapActiveThreads,
 		prometheus.GaugeValue,
-		float64(dst[0].LDAPActiveThreads),
+		&ntds.LDAPActiveThreads,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
-		c.LdapLastBindTimeSeconds,
-		prometheus.GaugeValue,
-		float64(dst[0].LDAPBindTime)/1000,
-	)
+	if present.has(&ntds.LDAPBindTime) {
+		ch <- prometheus.MustNewConstMetric(
+			c.LdapLastBindTimeSeconds,
+			prometheus.GaugeValue,
+			ntds.LDAPBindTime/1000,
+		)
+	}
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.LdapSearchesTotal,
 		prometheus.CounterValue,
-		float64(dst[0].LDAPSearchesPersec),
+		&ntds.LDAPSearchesPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.LdapUdpOperationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].LDAPUDPoperationsPersec),
+		&ntds.LDAPUDPoperationsPersec,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.LdapWritesTotal,
 		prometheus.CounterValue,
-		float64(dst[0].LDAPWritesPersec),
+		&ntds.LDAPWritesPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.LinkValuesCleanedTotal,
 		prometheus.CounterValue,
-		float64(dst[0].LinkValuesCleanedPersec),
+		&ntds.LinkValuesCleanedPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.PhantomObjectsCleanedTotal,
 		prometheus.CounterValue,
-		float64(dst[0].PhantomsCleanedPersec),
+		&ntds.PhantomsCleanedPersec,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.PhantomObjectsVisitedTotal,
 		prometheus.CounterValue,
-		float64(dst[0].PhantomsVisitedPersec),
+		&ntds.PhantomsVisitedPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SamGroupMembershipEvaluationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].SAMGlobalGroupMembershipEvaluationsPersec),
+		&ntds.SAMGlobalGroupMembershipEvaluationsPersec,
 		"global",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SamGroupMembershipEvaluationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].SAMDomainLocalGroupMembershipEvaluationsPersec),
+		&ntds.SAMDomainLocalGroupMembershipEvaluationsPersec,
 		"domain_local",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SamGroupMembershipEvaluationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].SAMUniversalGroupMembershipEvaluationsPersec),
+		&ntds.SAMUniversalGroupMembershipEvaluationsPersec,
 		"universal",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SamGroupMembershipGlobalCatalogEvaluationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].SAMGCEvaluationsPersec),
+		&ntds.SAMGCEvaluationsPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SamGroupMembershipEvaluationsNontransitiveTotal,
 		prometheus.CounterValue,
-		float64(dst[0].SAMNonTransitiveMembershipEvaluationsPersec),
+		&ntds.SAMNonTransitiveMembershipEvaluationsPersec,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SamGroupMembershipEvaluationsTransitiveTotal,
 		prometheus.CounterValue,
-		float64(dst[0].SAMTransitiveMembershipEvaluationsPersec),
+		&ntds.SAMTransitiveMembershipEvaluationsPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SamGroupEvaluationLatency,
 		prometheus.GaugeValue,
-		float64(dst[0].SAMAccountGroupEvaluationLatency),
+		&ntds.SAMAccountGroupEvaluationLatency,
 		"account_group",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SamGroupEvaluationLatency,
 		prometheus.GaugeValue,
-		float64(dst[0].SAMResourceGroupEvaluationLatency),
+		&ntds.SAMResourceGroupEvaluationLatency,
 		"resource_group",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SamComputerCreationRequestsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].SAMSuccessfulComputerCreationsPersecIncludesallrequests),
+		&ntds.SAMSuccessfulComputerCreationsPersecIncludesallrequests,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SamComputerCreationSuccessfulRequestsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].SAMMachineCreationAttemptsPersec),
+		&ntds.SAMMachineCreationAttemptsPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SamUserCreationRequestsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].SAMUserCreationAttemptsPersec),
+		&ntds.SAMUserCreationAttemptsPersec,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SamUserCreationSuccessfulRequestsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].SAMSuccessfulUserCreationsPersec),
+		&ntds.SAMSuccessfulUserCreationsPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SamQueryDisplayRequestsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].SAMDisplayInformationQueriesPersec),
+		&ntds.SAMDisplayInformationQueriesPersec,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SamEnumerationsTotal,
 		prometheus.CounterValue,
-		float64(dst[0].SAMEnumerationsPersec),
+		&ntds.SAMEnumerationsPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SamMembershipChangesTotal,
 		prometheus.CounterValue,
-		float64(dst[0].SAMMembershipChangesPersec),
+		&ntds.SAMMembershipChangesPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SamPasswordChangesTotal,
 		prometheus.CounterValue,
-		float64(dst[0].SAMPasswordChangesPersec),
+		&ntds.SAMPasswordChangesPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.TombstonedObjectsCollectedTotal,
 		prometheus.CounterValue,
-		float64(dst[0].TombstonesGarbageCollectedPersec),
+		&ntds.TombstonesGarbageCollectedPersec,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.TombstonedObjectsVisitedTotal,
 		prometheus.CounterValue,
-		float64(dst[0].TombstonesVisitedPersec),
+		&ntds.TombstonesVisitedPersec,
 	)
 
 	return nil, nil
@@ -100,89 +100,100 @@ func newADFSCollector() (Collector, error) {
 
 type perflibADFS struct {
 	AdLoginConnectionFailures        float64 `perflib:"AD login Connection Failures"`
-	CertificateAuthentications       float64 `perflib:"Certificate Authentications"`
-	DeviceAuthentications            float64 `perflib:"Device Authentications"`
-	ExtranetAccountLockouts          float64 `perflib:"Extranet Account Lockouts"`
+	CertificateAuthentications       float64 `perflib:"Certificate Authentications,optional"`
+	DeviceAuthentications            float64 `perflib:"Device Authentications,optional"`
+	ExtranetAccountLockouts          float64 `perflib:"Extranet Account Lockouts,optional"`
 	FederatedAuthentications         float64 `perflib:"Federated Authentications"`
-	PassportAuthentications          float64 `perflib:"Microsoft Passport Authentications"`
+	PassportAuthentications          float64 `perflib:"Microsoft Passport Authentications,optional"`
 	PassiveRequests                  float64 `perflib:"Passive Requests"`
-	PasswordChangeFailed             float64 `perflib:"Password Change Failed Requests"`
-	PasswordChangeSucceeded          float64 `perflib:"Password Change Successful Requests"`
+	PasswordChangeFailed             float64 `perflib:"Password Change Failed Requests,optional"`
+	PasswordChangeSucceeded          float64 `perflib:"Password Change Successful Requests,optional"`
 	TokenRequests                    float64 `perflib:"Token Requests"`
 	WindowsIntegratedAuthentications float64 `perflib:"Windows Integrated Authentications"`
 }
 
 func (c *adfsCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
 	var adfsData []perflibADFS
-	err := unmarshalObject(ctx.perfObjects["AD FS"], &adfsData)
+	present, err := unmarshalObject(ctx.perfObjects["AD FS"], &adfsData)
 	if err != nil {
 		return err
 	}
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.adLoginConnectionFailures,
 		prometheus.CounterValue,
-		adfsData[0].AdLoginConnectionFailures,
+		&adfsData[0].AdLoginConnectionFailures,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.certificateAuthentications,
 		prometheus.CounterValue,
-		adfsData[0].CertificateAuthentications,
+		&adfsData[0].CertificateAuthentications,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.deviceAuthentications,
 		prometheus.CounterValue,
-		adfsData[0].DeviceAuthentications,
+		&adfsData[0].DeviceAuthentications,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.extranetAccountLockouts,
 		prometheus.CounterValue,
-		adfsData[0].ExtranetAccountLockouts,
+		&adfsData[0].ExtranetAccountLockouts,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.federatedAuthentications,
 		prometheus.CounterValue,
-		adfsData[0].FederatedAuthentications,
+		&adfsData[0].FederatedAuthentications,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.passportAuthentications,
 		prometheus.CounterValue,
-		adfsData[0].PassportAuthentications,
+		&adfsData[0].PassportAuthentications,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.passiveRequests,
 		prometheus.CounterValue,
-		adfsData[0].PassiveRequests,
+		&adfsData[0].PassiveRequests,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.passwordChangeFailed,
 		prometheus.CounterValue,
-		adfsData[0].PasswordChangeFailed,
+		&adfsData[0].PasswordChangeFailed,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.passwordChangeSucceeded,
 		prometheus.CounterValue,
-		adfsData[0].PasswordChangeSucceeded,
+		&adfsData[0].PasswordChangeSucceeded,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.tokenRequests,
 		prometheus.CounterValue,
-		adfsData[0].TokenRequests,
+		&adfsData[0].TokenRequests,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.windowsIntegratedAuthentications,
 		prometheus.CounterValue,
-		adfsData[0].WindowsIntegratedAuthentications,
+		&adfsData[0].WindowsIntegratedAuthentications,
 	)
 	return nil
 }
@@ -4,6 +4,10 @@ import (
 	"testing"
 )
 
+func TestADFSCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "adfs", newADFSCollector)
+}
+
 func BenchmarkADFSCollector(b *testing.B) {
 	benchmarkCollector(b, "adfs", newADFSCollector)
 }
@@ -3,7 +3,6 @@
 package collector
 
 import (
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -227,8 +226,7 @@ func newCacheCollector() (Collector, error) {
 
 // Collect implements the Collector interface
 func (c *CacheCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ctx, ch); err != nil {
-		log.Error("failed collecting cache metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
@@ -270,182 +268,212 @@ type perflibCache struct {
 
 func (c *CacheCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []perflibCache // Single-instance class, array is required but will have single entry.
-	if err := unmarshalObject(ctx.perfObjects["Cache"], &dst); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["Cache"], &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.AsyncCopyReadsTotal,
 		prometheus.CounterValue,
-		dst[0].AsyncCopyReadsTotal,
+		&dst[0].AsyncCopyReadsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.AsyncDataMapsTotal,
 		prometheus.CounterValue,
-		dst[0].AsyncDataMapsTotal,
+		&dst[0].AsyncDataMapsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.AsyncFastReadsTotal,
 		prometheus.CounterValue,
-		dst[0].AsyncFastReadsTotal,
+		&dst[0].AsyncFastReadsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.AsyncMDLReadsTotal,
 		prometheus.CounterValue,
-		dst[0].AsyncMDLReadsTotal,
+		&dst[0].AsyncMDLReadsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.AsyncPinReadsTotal,
 		prometheus.CounterValue,
-		dst[0].AsyncPinReadsTotal,
+		&dst[0].AsyncPinReadsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.CopyReadHitsTotal,
 		prometheus.GaugeValue,
-		dst[0].CopyReadHitsTotal,
+		&dst[0].CopyReadHitsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.CopyReadsTotal,
 		prometheus.CounterValue,
-		dst[0].CopyReadsTotal,
+		&dst[0].CopyReadsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DataFlushesTotal,
 		prometheus.CounterValue,
-		dst[0].DataFlushesTotal,
+		&dst[0].DataFlushesTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DataFlushPagesTotal,
 		prometheus.CounterValue,
-		dst[0].DataFlushPagesTotal,
+		&dst[0].DataFlushPagesTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DataMapHitsPercent,
 		prometheus.GaugeValue,
-		dst[0].DataMapHitsPercent,
+		&dst[0].DataMapHitsPercent,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DataMapPinsTotal,
 		prometheus.CounterValue,
-		dst[0].DataMapPinsTotal,
+		&dst[0].DataMapPinsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DataMapsTotal,
 		prometheus.CounterValue,
-		dst[0].DataMapsTotal,
+		&dst[0].DataMapsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirtyPages,
 		prometheus.GaugeValue,
-		dst[0].DirtyPages,
+		&dst[0].DirtyPages,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DirtyPageThreshold,
 		prometheus.GaugeValue,
-		dst[0].DirtyPageThreshold,
+		&dst[0].DirtyPageThreshold,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.FastReadNotPossiblesTotal,
 		prometheus.CounterValue,
-		dst[0].FastReadNotPossiblesTotal,
+		&dst[0].FastReadNotPossiblesTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.FastReadResourceMissesTotal,
 		prometheus.CounterValue,
-		dst[0].FastReadResourceMissesTotal,
+		&dst[0].FastReadResourceMissesTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.FastReadsTotal,
 		prometheus.CounterValue,
-		dst[0].FastReadsTotal,
+		&dst[0].FastReadsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.LazyWriteFlushesTotal,
 		prometheus.CounterValue,
-		dst[0].LazyWriteFlushesTotal,
+		&dst[0].LazyWriteFlushesTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.LazyWritePagesTotal,
 		prometheus.CounterValue,
-		dst[0].LazyWritePagesTotal,
+		&dst[0].LazyWritePagesTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.MDLReadHitsTotal,
 		prometheus.CounterValue,
-		dst[0].MDLReadHitsTotal,
+		&dst[0].MDLReadHitsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.MDLReadsTotal,
 		prometheus.CounterValue,
-		dst[0].MDLReadsTotal,
+		&dst[0].MDLReadsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.PinReadHitsTotal,
 		prometheus.CounterValue,
-		dst[0].PinReadHitsTotal,
+		&dst[0].PinReadHitsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.PinReadsTotal,
 		prometheus.CounterValue,
-		dst[0].PinReadsTotal,
+		&dst[0].PinReadsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ReadAheadsTotal,
 		prometheus.CounterValue,
-		dst[0].ReadAheadsTotal,
+		&dst[0].ReadAheadsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SyncCopyReadsTotal,
 		prometheus.CounterValue,
-		dst[0].SyncCopyReadsTotal,
+		&dst[0].SyncCopyReadsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SyncDataMapsTotal,
 		prometheus.CounterValue,
-		dst[0].SyncDataMapsTotal,
+		&dst[0].SyncDataMapsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SyncFastReadsTotal,
 		prometheus.CounterValue,
-		dst[0].SyncFastReadsTotal,
+		&dst[0].SyncFastReadsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SyncMDLReadsTotal,
 		prometheus.CounterValue,
-		dst[0].SyncMDLReadsTotal,
+		&dst[0].SyncMDLReadsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SyncPinReadsTotal,
 		prometheus.CounterValue,
-		dst[0].SyncPinReadsTotal,
+		&dst[0].SyncPinReadsTotal,
 	)
 
 	return nil, nil
@@ -0,0 +1,13 @@
+package collector
+
+import (
+	"testing"
+)
+
+func TestCacheCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "cache", newCacheCollector)
+}
+
+func BenchmarkCacheCollector(b *testing.B) {
+	benchmarkCollector(b, "cache", newCacheCollector)
+}
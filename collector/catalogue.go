@@ -0,0 +1,220 @@
+// +build windows
+
+//go:generate go run ../tools/metric-types
+
+package collector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MetricInfo describes a metric exposed by a collector.
+type MetricInfo struct {
+	Name   string   `json:"name"`
+	Type   string   `json:"type"`
+	Help   string   `json:"help"`
+	Labels []string `json:"labels"`
+	Unit   string   `json:"unit,omitempty"`
+}
+
+// CollectorInfo describes the metrics exposed by a collector.
+type CollectorInfo struct {
+	Name    string       `json:"name"`
+	Metrics []MetricInfo `json:"metrics"`
+}
+
+// metricDescriber is implemented by collectors which do not keep all of their
+// descriptors in *prometheus.Desc fields. All other collectors are described
+// by the Describe and metricTypes methods generated by tools/metric-types.
+type metricDescriber interface {
+	describeMetrics() ([]MetricInfo, error)
+}
+
+// typedDescriber is implemented by the collectors with generated Describe and
+// metricTypes methods.
+type typedDescriber interface {
+	Describe(ch chan<- *prometheus.Desc)
+	metricTypes() map[*prometheus.Desc]string
+}
+
+// units maps metric name suffixes, after removing _total, to base units.
+var units = []struct {
+	suffix string
+	unit   string
+}{
+	{"_seconds", "seconds"},
+	{"_bytes", "bytes"},
+	{"_celsius", "celsius"},
+	{"_kelvin", "kelvin"},
+	{"_hertz", "hertz"},
+	{"_mhz", "megahertz"},
+	{"_percent", "percent"},
+	{"_ratio", "ratio"},
+}
+
+// Describe returns the metrics exposed by the collector c, registered as name.
+func Describe(name string, c Collector) (CollectorInfo, error) {
+	info := CollectorInfo{Name: name}
+
+	if d, ok := c.(metricDescriber); ok {
+		metrics, err := d.describeMetrics()
+		if err != nil {
+			return info, fmt.Errorf("failed describing collector %s: %v", name, err)
+		}
+		info.Metrics = metrics
+		return info, nil
+	}
+
+	d, ok := c.(typedDescriber)
+	if !ok {
+		return info, fmt.Errorf("failed describing collector %s: %T has no Describe method", name, c)
+	}
+	types := d.metricTypes()
+	ch := make(chan *prometheus.Desc)
+	go func() {
+		defer close(ch)
+		d.Describe(ch)
+	}()
+	seen := map[string]bool{}
+	for desc := range ch {
+		// Descriptors may be left nil, e.g. for metrics not available on
+		// the host.
+		if desc == nil {
+			continue
+		}
+		metric, err := describeMetric(desc, types[desc])
+		if err != nil {
+			// Drain the channel, so that Describe returns.
+			for range ch {
+			}
+			return info, fmt.Errorf("failed describing collector %s: %v", name, err)
+		}
+		if seen[metric.Name] {
+			continue
+		}
+		seen[metric.Name] = true
+		info.Metrics = append(info.Metrics, metric)
+	}
+	return info, nil
+}
+
+const (
+	// maxLabels is the maximum number of variable labels of a descriptor
+	// describeMetric looks for.
+	maxLabels = 32
+	// labelPosition prefixes the position of a variable label in the value
+	// describeMetric sets it to.
+	labelPosition = "__position_"
+)
+
+// describeMetric returns the description of the metrics of type typ created
+// from desc. prometheus.Desc has no accessors, so a metric is created from
+// it, and gathered into a metric family.
+func describeMetric(desc *prometheus.Desc, typ string) (MetricInfo, error) {
+	// The number of variable labels isn't known either. The values hold
+	// their positions, as gathered labels are sorted by name.
+	var (
+		metric prometheus.Metric
+		err    error
+		values []string
+	)
+	for n := 0; n <= maxLabels; n++ {
+		if metric, err = sampleMetric(desc, typ, values); err == nil {
+			break
+		}
+		values = append(values, labelPosition+strconv.Itoa(n))
+	}
+	if err != nil {
+		return MetricInfo{}, err
+	}
+
+	reg := prometheus.NewRegistry()
+	if err := reg.Register(sampleCollector{desc: desc, metric: metric}); err != nil {
+		return MetricInfo{}, err
+	}
+	families, err := reg.Gather()
+	if err != nil {
+		return MetricInfo{}, err
+	}
+	if len(families) != 1 || len(families[0].Metric) != 1 {
+		return MetricInfo{}, fmt.Errorf("unexpected metrics gathered from %s", desc)
+	}
+	family := families[0]
+
+	labels := make([]string, len(values))
+	for _, pair := range family.Metric[0].Label {
+		// Constant labels have values which aren't positions.
+		if !strings.HasPrefix(pair.GetValue(), labelPosition) {
+			continue
+		}
+		if i, err := strconv.Atoi(strings.TrimPrefix(pair.GetValue(), labelPosition)); err == nil && i < len(labels) {
+			labels[i] = pair.GetName()
+		}
+	}
+	return MetricInfo{
+		Name:   family.GetName(),
+		Type:   typ,
+		Help:   family.GetHelp(),
+		Labels: labels,
+		Unit:   metricUnit(family.GetName()),
+	}, nil
+}
+
+// sampleMetric returns a metric of type typ created from desc with the label
+// values, which fails if the number of values isn't the number of variable
+// labels of desc.
+func sampleMetric(desc *prometheus.Desc, typ string, values []string) (prometheus.Metric, error) {
+	switch typ {
+	case "counter":
+		return prometheus.NewConstMetric(desc, prometheus.CounterValue, 0, values...)
+	case "gauge":
+		return prometheus.NewConstMetric(desc, prometheus.GaugeValue, 0, values...)
+	case "untyped":
+		return prometheus.NewConstMetric(desc, prometheus.UntypedValue, 0, values...)
+	case "histogram":
+		return prometheus.NewConstHistogram(desc, 0, 0, nil, values...)
+	case "summary":
+		return prometheus.NewConstSummary(desc, 0, 0, nil, values...)
+	}
+	return nil, fmt.Errorf("unknown type %q of %s", typ, desc)
+}
+
+// sampleCollector collects a single metric.
+type sampleCollector struct {
+	desc   *prometheus.Desc
+	metric prometheus.Metric
+}
+
+func (c sampleCollector) Describe(ch chan<- *prometheus.Desc) { ch <- c.desc }
+func (c sampleCollector) Collect(ch chan<- prometheus.Metric) { ch <- c.metric }
+
+func metricUnit(name string) string {
+	name = strings.TrimSuffix(name, "_total")
+	for _, u := range units {
+		if strings.HasSuffix(name, u.suffix) {
+			return u.unit
+		}
+	}
+	return ""
+}
+
+// MarkdownTable returns the metrics of the collector as a Markdown table, in
+// the format used by docs/collector.*.md.
+func (c CollectorInfo) MarkdownTable() string {
+	var b strings.Builder
+	b.WriteString("Name | Description | Type | Labels\n")
+	b.WriteString("-----|-------------|------|-------\n")
+	for _, m := range c.Metrics {
+		labels := "None"
+		if len(m.Labels) > 0 {
+			labels = "`" + strings.Join(m.Labels, "`, `") + "`"
+		}
+		help := strings.Replace(strings.Join(strings.Fields(m.Help), " "), "|", `\|`, -1)
+		fmt.Fprintf(&b, "`%s` | %s | %s | %s\n", m.Name, help, m.Type, labels)
+	}
+	return b.String()
+}
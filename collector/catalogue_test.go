@@ -0,0 +1,149 @@
+package collector
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+func TestDescribeMetric(t *testing.T) {
+	desc := prometheus.NewDesc(
+		"windows_test_read_bytes_total",
+		`Bytes "read", by | device`,
+		[]string{"device", "mode"},
+		prometheus.Labels{"const": "value"},
+	)
+	got, err := describeMetric(desc, "counter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := MetricInfo{
+		Name:   "windows_test_read_bytes_total",
+		Type:   "counter",
+		Help:   `Bytes "read", by | device`,
+		Labels: []string{"device", "mode"},
+		Unit:   "bytes",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+
+	info := CollectorInfo{Name: "test", Metrics: []MetricInfo{got, {Name: "windows_test_up", Type: "gauge", Help: "Up.", Labels: []string{}}}}
+	table := info.MarkdownTable()
+	expectedTable := "Name | Description | Type | Labels\n" +
+		"-----|-------------|------|-------\n" +
+		"`windows_test_read_bytes_total` | Bytes \"read\", by \\| device | counter | `device`, `mode`\n" +
+		"`windows_test_up` | Up. | gauge | None\n"
+	if table != expectedTable {
+		t.Errorf("expected table:\n%s\ngot:\n%s", expectedTable, table)
+	}
+}
+
+// TestCollectorDocs verifies that the metrics tables in docs/collector.*.md
+// list the metrics described by the collectors. The descriptions already in
+// a table are kept, as they are usually more helpful than the help of the
+// metric. Run with -update to regenerate the tables.
+func TestCollectorDocs(t *testing.T) {
+	parseFlagDefaults.Do(func() {
+		if _, err := kingpin.CommandLine.Parse([]string{}); err != nil {
+			t.Fatalf("failed to parse flag defaults: %v", err)
+		}
+	})
+
+	names := make([]string, 0, len(builders))
+	for name := range builders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		c, err := Build(name)
+		if err != nil {
+			t.Errorf("failed to build collector %s: %v", name, err)
+			continue
+		}
+		info, err := Describe(name, c)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if len(info.Metrics) == 0 {
+			t.Errorf("collector %s does not describe any metrics", name)
+		}
+
+		docFile := filepath.Join("..", "docs", "collector."+name+".md")
+		doc, err := ioutil.ReadFile(docFile)
+		if err != nil {
+			t.Errorf("failed to read documentation of collector %s: %v", name, err)
+			continue
+		}
+		start, end, ok := metricsTable(string(doc))
+		if !ok {
+			t.Errorf("%s: no metrics table found below the \"## Metrics\" heading", docFile)
+			continue
+		}
+		want := string(doc[start:end])
+		descriptions := tableDescriptions(want)
+		for i, m := range info.Metrics {
+			if d, ok := descriptions[m.Name]; ok {
+				info.Metrics[i].Help = d
+			}
+		}
+		got := info.MarkdownTable()
+		if want == got {
+			continue
+		}
+		if *updateGolden {
+			updated := string(doc[:start]) + got + string(doc[end:])
+			if err := ioutil.WriteFile(docFile, []byte(updated), 0644); err != nil {
+				t.Errorf("failed to update %s: %v", docFile, err)
+			}
+			continue
+		}
+		t.Errorf("metrics table in %s does not match collector %s (run with -update to regenerate):\n%s", docFile, name, lineDiff(want, got))
+	}
+}
+
+// tableDescriptions returns the descriptions of a metrics table, by metric
+// name.
+func tableDescriptions(table string) map[string]string {
+	descriptions := make(map[string]string)
+	for _, row := range strings.Split(table, "\n") {
+		if !strings.HasPrefix(row, "`") {
+			continue
+		}
+		columns := strings.Split(row, " | ")
+		if len(columns) < 4 {
+			continue
+		}
+		name := strings.Trim(columns[0], "`")
+		description := strings.Join(columns[1:len(columns)-2], " | ")
+		descriptions[name] = strings.Replace(description, `\|`, "|", -1)
+	}
+	return descriptions
+}
+
+// metricsTable returns the position of the first table following the
+// "## Metrics" heading of a collector documentation page.
+func metricsTable(doc string) (int, int, bool) {
+	heading := strings.Index(doc, "\n## Metrics\n")
+	if heading < 0 {
+		return 0, 0, false
+	}
+	offset := strings.Index(doc[heading:], "\nName | Description")
+	if offset < 0 {
+		return 0, 0, false
+	}
+	start := heading + offset + 1
+	end := strings.Index(doc[start:], "\n\n")
+	if end < 0 {
+		return start, len(doc), true
+	}
+	return start, start + end + 1, true
+}
@@ -0,0 +1,2283 @@
+// Code generated by tools/metric-types; DO NOT EDIT.
+
+// +build windows
+
+package collector
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *ADCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.AddressBookOperationsTotal
+	ch <- c.AddressBookClientSessions
+	ch <- c.ApproximateHighestDistinguishedNameTag
+	ch <- c.AtqEstimatedDelaySeconds
+	ch <- c.AtqOutstandingRequests
+	ch <- c.AtqAverageRequestLatency
+	ch <- c.AtqCurrentThreads
+	ch <- c.SearchesTotal
+	ch <- c.DatabaseOperationsTotal
+	ch <- c.BindsTotal
+	ch <- c.ReplicationHighestUsn
+	ch <- c.IntersiteReplicationDataBytesTotal
+	ch <- c.IntrasiteReplicationDataBytesTotal
+	ch <- c.ReplicationInboundSyncObjectsRemaining
+	ch <- c.ReplicationInboundLinkValueUpdatesRemaining
+	ch <- c.ReplicationInboundObjectsUpdatedTotal
+	ch <- c.ReplicationInboundObjectsFilteredTotal
+	ch <- c.ReplicationInboundPropertiesUpdatedTotal
+	ch <- c.ReplicationInboundPropertiesFilteredTotal
+	ch <- c.ReplicationPendingOperations
+	ch <- c.ReplicationPendingSynchronizations
+	ch <- c.ReplicationSyncRequestsTotal
+	ch <- c.ReplicationSyncRequestsSuccessTotal
+	ch <- c.ReplicationSyncRequestsSchemaMismatchFailureTotal
+	ch <- c.DirectoryOperationsTotal
+	ch <- c.NameTranslationsTotal
+	ch <- c.ChangeMonitorsRegistered
+	ch <- c.ChangeMonitorUpdatesPending
+	ch <- c.NameCacheHitsTotal
+	ch <- c.NameCacheLookupsTotal
+	ch <- c.DirectorySearchSuboperationsTotal
+	ch <- c.SecurityDescriptorPropagationEventsTotal
+	ch <- c.SecurityDescriptorPropagationEventsQueued
+	ch <- c.SecurityDescriptorPropagationAccessWaitTotalSeconds
+	ch <- c.SecurityDescriptorPropagationItemsQueuedTotal
+	ch <- c.DirectoryServiceThreads
+	ch <- c.LdapClosedConnectionsTotal
+	ch <- c.LdapOpenedConnectionsTotal
+	ch <- c.LdapActiveThreads
+	ch <- c.LdapLastBindTimeSeconds
+	ch <- c.LdapSearchesTotal
+	ch <- c.LdapUdpOperationsTotal
+	ch <- c.LdapWritesTotal
+	ch <- c.LinkValuesCleanedTotal
+	ch <- c.PhantomObjectsCleanedTotal
+	ch <- c.PhantomObjectsVisitedTotal
+	ch <- c.SamGroupMembershipEvaluationsTotal
+	ch <- c.SamGroupMembershipGlobalCatalogEvaluationsTotal
+	ch <- c.SamGroupMembershipEvaluationsNontransitiveTotal
+	ch <- c.SamGroupMembershipEvaluationsTransitiveTotal
+	ch <- c.SamGroupEvaluationLatency
+	ch <- c.SamComputerCreationRequestsTotal
+	ch <- c.SamComputerCreationSuccessfulRequestsTotal
+	ch <- c.SamUserCreationRequestsTotal
+	ch <- c.SamUserCreationSuccessfulRequestsTotal
+	ch <- c.SamQueryDisplayRequestsTotal
+	ch <- c.SamEnumerationsTotal
+	ch <- c.SamMembershipChangesTotal
+	ch <- c.SamPasswordChangesTotal
+	ch <- c.TombstonedObjectsCollectedTotal
+	ch <- c.TombstonedObjectsVisitedTotal
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *ADCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.AddressBookOperationsTotal:                          "counter",
+		c.AddressBookClientSessions:                           "gauge",
+		c.ApproximateHighestDistinguishedNameTag:              "gauge",
+		c.AtqEstimatedDelaySeconds:                            "gauge",
+		c.AtqOutstandingRequests:                              "gauge",
+		c.AtqAverageRequestLatency:                            "gauge",
+		c.AtqCurrentThreads:                                   "gauge",
+		c.SearchesTotal:                                       "counter",
+		c.DatabaseOperationsTotal:                             "counter",
+		c.BindsTotal:                                          "counter",
+		c.ReplicationHighestUsn:                               "counter",
+		c.IntersiteReplicationDataBytesTotal:                  "counter",
+		c.IntrasiteReplicationDataBytesTotal:                  "counter",
+		c.ReplicationInboundSyncObjectsRemaining:              "gauge",
+		c.ReplicationInboundLinkValueUpdatesRemaining:         "gauge",
+		c.ReplicationInboundObjectsUpdatedTotal:               "counter",
+		c.ReplicationInboundObjectsFilteredTotal:              "counter",
+		c.ReplicationInboundPropertiesUpdatedTotal:            "counter",
+		c.ReplicationInboundPropertiesFilteredTotal:           "counter",
+		c.ReplicationPendingOperations:                        "gauge",
+		c.ReplicationPendingSynchronizations:                  "gauge",
+		c.ReplicationSyncRequestsTotal:                        "counter",
+		c.ReplicationSyncRequestsSuccessTotal:                 "counter",
+		c.ReplicationSyncRequestsSchemaMismatchFailureTotal:   "counter",
+		c.DirectoryOperationsTotal:                            "counter",
+		c.NameTranslationsTotal:                               "counter",
+		c.ChangeMonitorsRegistered:                            "gauge",
+		c.ChangeMonitorUpdatesPending:                         "gauge",
+		c.NameCacheHitsTotal:                                  "counter",
+		c.NameCacheLookupsTotal:                               "counter",
+		c.DirectorySearchSuboperationsTotal:                   "counter",
+		c.SecurityDescriptorPropagationEventsTotal:            "counter",
+		c.SecurityDescriptorPropagationEventsQueued:           "gauge",
+		c.SecurityDescriptorPropagationAccessWaitTotalSeconds: "gauge",
+		c.SecurityDescriptorPropagationItemsQueuedTotal:       "counter",
+		c.DirectoryServiceThreads:                             "gauge",
+		c.LdapClosedConnectionsTotal:                          "counter",
+		c.LdapOpenedConnectionsTotal:                          "counter",
+		c.LdapActiveThreads:                                   "gauge",
+		c.LdapLastBindTimeSeconds:                             "gauge",
+		c.LdapSearchesTotal:                                   "counter",
+		c.LdapUdpOperationsTotal:                              "counter",
+		c.LdapWritesTotal:                                     "counter",
+		c.LinkValuesCleanedTotal:                              "counter",
+		c.PhantomObjectsCleanedTotal:                          "counter",
+		c.PhantomObjectsVisitedTotal:                          "counter",
+		c.SamGroupMembershipEvaluationsTotal:                  "counter",
+		c.SamGroupMembershipGlobalCatalogEvaluationsTotal:     "counter",
+		c.SamGroupMembershipEvaluationsNontransitiveTotal:     "counter",
+		c.SamGroupMembershipEvaluationsTransitiveTotal:        "counter",
+		c.SamGroupEvaluationLatency:                           "gauge",
+		c.SamComputerCreationRequestsTotal:                    "counter",
+		c.SamComputerCreationSuccessfulRequestsTotal:          "counter",
+		c.SamUserCreationRequestsTotal:                        "counter",
+		c.SamUserCreationSuccessfulRequestsTotal:              "counter",
+		c.SamQueryDisplayRequestsTotal:                        "counter",
+		c.SamEnumerationsTotal:                                "counter",
+		c.SamMembershipChangesTotal:                           "counter",
+		c.SamPasswordChangesTotal:                             "counter",
+		c.TombstonedObjectsCollectedTotal:                     "counter",
+		c.TombstonedObjectsVisitedTotal:                       "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *CSCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.PhysicalMemoryBytes
+	ch <- c.LogicalProcessors
+	ch <- c.Hostname
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *CSCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.PhysicalMemoryBytes: "gauge",
+		c.LogicalProcessors:   "gauge",
+		c.Hostname:            "gauge",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *CacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.AsyncCopyReadsTotal
+	ch <- c.AsyncDataMapsTotal
+	ch <- c.AsyncFastReadsTotal
+	ch <- c.AsyncMDLReadsTotal
+	ch <- c.AsyncPinReadsTotal
+	ch <- c.CopyReadHitsTotal
+	ch <- c.CopyReadsTotal
+	ch <- c.DataFlushesTotal
+	ch <- c.DataFlushPagesTotal
+	ch <- c.DataMapHitsPercent
+	ch <- c.DataMapPinsTotal
+	ch <- c.DataMapsTotal
+	ch <- c.DirtyPages
+	ch <- c.DirtyPageThreshold
+	ch <- c.FastReadNotPossiblesTotal
+	ch <- c.FastReadResourceMissesTotal
+	ch <- c.FastReadsTotal
+	ch <- c.LazyWriteFlushesTotal
+	ch <- c.LazyWritePagesTotal
+	ch <- c.MDLReadHitsTotal
+	ch <- c.MDLReadsTotal
+	ch <- c.PinReadHitsTotal
+	ch <- c.PinReadsTotal
+	ch <- c.ReadAheadsTotal
+	ch <- c.SyncCopyReadsTotal
+	ch <- c.SyncDataMapsTotal
+	ch <- c.SyncFastReadsTotal
+	ch <- c.SyncMDLReadsTotal
+	ch <- c.SyncPinReadsTotal
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *CacheCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.AsyncCopyReadsTotal:         "counter",
+		c.AsyncDataMapsTotal:          "counter",
+		c.AsyncFastReadsTotal:         "counter",
+		c.AsyncMDLReadsTotal:          "counter",
+		c.AsyncPinReadsTotal:          "counter",
+		c.CopyReadHitsTotal:           "gauge",
+		c.CopyReadsTotal:              "counter",
+		c.DataFlushesTotal:            "counter",
+		c.DataFlushPagesTotal:         "counter",
+		c.DataMapHitsPercent:          "gauge",
+		c.DataMapPinsTotal:            "counter",
+		c.DataMapsTotal:               "counter",
+		c.DirtyPages:                  "gauge",
+		c.DirtyPageThreshold:          "gauge",
+		c.FastReadNotPossiblesTotal:   "counter",
+		c.FastReadResourceMissesTotal: "counter",
+		c.FastReadsTotal:              "counter",
+		c.LazyWriteFlushesTotal:       "counter",
+		c.LazyWritePagesTotal:         "counter",
+		c.MDLReadHitsTotal:            "counter",
+		c.MDLReadsTotal:               "counter",
+		c.PinReadHitsTotal:            "counter",
+		c.PinReadsTotal:               "counter",
+		c.ReadAheadsTotal:             "counter",
+		c.SyncCopyReadsTotal:          "counter",
+		c.SyncDataMapsTotal:           "counter",
+		c.SyncFastReadsTotal:          "counter",
+		c.SyncMDLReadsTotal:           "counter",
+		c.SyncPinReadsTotal:           "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *ContainerMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.ContainerAvailable
+	ch <- c.ContainersCount
+	ch <- c.UsageCommitBytes
+	ch <- c.UsageCommitPeakBytes
+	ch <- c.UsagePrivateWorkingSetBytes
+	ch <- c.RuntimeTotal
+	ch <- c.RuntimeUser
+	ch <- c.RuntimeKernel
+	ch <- c.BytesReceived
+	ch <- c.BytesSent
+	ch <- c.PacketsReceived
+	ch <- c.PacketsSent
+	ch <- c.DroppedPacketsIncoming
+	ch <- c.DroppedPacketsOutgoing
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *ContainerMetricsCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.ContainerAvailable:          "counter",
+		c.ContainersCount:             "gauge",
+		c.UsageCommitBytes:            "gauge",
+		c.UsageCommitPeakBytes:        "gauge",
+		c.UsagePrivateWorkingSetBytes: "gauge",
+		c.RuntimeTotal:                "counter",
+		c.RuntimeUser:                 "counter",
+		c.RuntimeKernel:               "counter",
+		c.BytesReceived:               "counter",
+		c.BytesSent:                   "counter",
+		c.PacketsReceived:             "counter",
+		c.PacketsSent:                 "counter",
+		c.DroppedPacketsIncoming:      "counter",
+		c.DroppedPacketsOutgoing:      "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *CpuInfoCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.CpuInfo
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *CpuInfoCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.CpuInfo: "gauge",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *DFSRCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.ConnectionBandwidthSavingsUsingDFSReplicationTotal
+	ch <- c.ConnectionBytesReceivedTotal
+	ch <- c.ConnectionCompressedSizeOfFilesReceivedTotal
+	ch <- c.ConnectionFilesReceivedTotal
+	ch <- c.ConnectionRDCBytesReceivedTotal
+	ch <- c.ConnectionRDCCompressedSizeOfFilesReceivedTotal
+	ch <- c.ConnectionRDCSizeOfFilesReceivedTotal
+	ch <- c.ConnectionRDCNumberofFilesReceivedTotal
+	ch <- c.ConnectionSizeOfFilesReceivedTotal
+	ch <- c.FolderBandwidthSavingsUsingDFSReplicationTotal
+	ch <- c.FolderCompressedSizeOfFilesReceivedTotal
+	ch <- c.FolderConflictBytesCleanedupTotal
+	ch <- c.FolderConflictBytesGeneratedTotal
+	ch <- c.FolderConflictFilesCleanedUpTotal
+	ch <- c.FolderConflictFilesGeneratedTotal
+	ch <- c.FolderConflictFolderCleanupsCompletedTotal
+	ch <- c.FolderConflictSpaceInUse
+	ch <- c.FolderDeletedSpaceInUse
+	ch <- c.FolderDeletedBytesCleanedUpTotal
+	ch <- c.FolderDeletedBytesGeneratedTotal
+	ch <- c.FolderDeletedFilesCleanedUpTotal
+	ch <- c.FolderDeletedFilesGeneratedTotal
+	ch <- c.FolderFileInstallsRetriedTotal
+	ch <- c.FolderFileInstallsSucceededTotal
+	ch <- c.FolderFilesReceivedTotal
+	ch <- c.FolderRDCBytesReceivedTotal
+	ch <- c.FolderRDCCompressedSizeOfFilesReceivedTotal
+	ch <- c.FolderRDCNumberofFilesReceivedTotal
+	ch <- c.FolderRDCSizeOfFilesReceivedTotal
+	ch <- c.FolderSizeOfFilesReceivedTotal
+	ch <- c.FolderStagingSpaceInUse
+	ch <- c.FolderStagingBytesCleanedUpTotal
+	ch <- c.FolderStagingBytesGeneratedTotal
+	ch <- c.FolderStagingFilesCleanedUpTotal
+	ch <- c.FolderStagingFilesGeneratedTotal
+	ch <- c.FolderUpdatesDroppedTotal
+	ch <- c.VolumeDatabaseLookupsTotal
+	ch <- c.VolumeDatabaseCommitsTotal
+	ch <- c.VolumeUSNJournalUnreadPercentage
+	ch <- c.VolumeUSNJournalRecordsAcceptedTotal
+	ch <- c.VolumeUSNJournalRecordsReadTotal
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *DFSRCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.ConnectionBandwidthSavingsUsingDFSReplicationTotal: "counter",
+		c.ConnectionBytesReceivedTotal:                       "counter",
+		c.ConnectionCompressedSizeOfFilesReceivedTotal:       "counter",
+		c.ConnectionFilesReceivedTotal:                       "counter",
+		c.ConnectionRDCBytesReceivedTotal:                    "counter",
+		c.ConnectionRDCCompressedSizeOfFilesReceivedTotal:    "counter",
+		c.ConnectionRDCSizeOfFilesReceivedTotal:              "counter",
+		c.ConnectionRDCNumberofFilesReceivedTotal:            "counter",
+		c.ConnectionSizeOfFilesReceivedTotal:                 "counter",
+		c.FolderBandwidthSavingsUsingDFSReplicationTotal:     "counter",
+		c.FolderCompressedSizeOfFilesReceivedTotal:           "counter",
+		c.FolderConflictBytesCleanedupTotal:                  "counter",
+		c.FolderConflictBytesGeneratedTotal:                  "counter",
+		c.FolderConflictFilesCleanedUpTotal:                  "counter",
+		c.FolderConflictFilesGeneratedTotal:                  "counter",
+		c.FolderConflictFolderCleanupsCompletedTotal:         "counter",
+		c.FolderConflictSpaceInUse:                           "gauge",
+		c.FolderDeletedSpaceInUse:                            "gauge",
+		c.FolderDeletedBytesCleanedUpTotal:                   "counter",
+		c.FolderDeletedBytesGeneratedTotal:                   "counter",
+		c.FolderDeletedFilesCleanedUpTotal:                   "counter",
+		c.FolderDeletedFilesGeneratedTotal:                   "counter",
+		c.FolderFileInstallsRetriedTotal:                     "counter",
+		c.FolderFileInstallsSucceededTotal:                   "counter",
+		c.FolderFilesReceivedTotal:                           "counter",
+		c.FolderRDCBytesReceivedTotal:                        "counter",
+		c.FolderRDCCompressedSizeOfFilesReceivedTotal:        "counter",
+		c.FolderRDCNumberofFilesReceivedTotal:                "counter",
+		c.FolderRDCSizeOfFilesReceivedTotal:                  "counter",
+		c.FolderSizeOfFilesReceivedTotal:                     "counter",
+		c.FolderStagingSpaceInUse:                            "gauge",
+		c.FolderStagingBytesCleanedUpTotal:                   "counter",
+		c.FolderStagingBytesGeneratedTotal:                   "counter",
+		c.FolderStagingFilesCleanedUpTotal:                   "counter",
+		c.FolderStagingFilesGeneratedTotal:                   "counter",
+		c.FolderUpdatesDroppedTotal:                          "counter",
+		c.VolumeDatabaseLookupsTotal:                         "counter",
+		c.VolumeDatabaseCommitsTotal:                         "counter",
+		c.VolumeUSNJournalUnreadPercentage:                   "gauge",
+		c.VolumeUSNJournalRecordsAcceptedTotal:               "counter",
+		c.VolumeUSNJournalRecordsReadTotal:                   "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *DNSCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.ZoneTransferRequestsReceived
+	ch <- c.ZoneTransferRequestsSent
+	ch <- c.ZoneTransferResponsesReceived
+	ch <- c.ZoneTransferSuccessReceived
+	ch <- c.ZoneTransferSuccessSent
+	ch <- c.ZoneTransferFailures
+	ch <- c.MemoryUsedBytes
+	ch <- c.DynamicUpdatesQueued
+	ch <- c.DynamicUpdatesReceived
+	ch <- c.DynamicUpdatesFailures
+	ch <- c.NotifyReceived
+	ch <- c.NotifySent
+	ch <- c.SecureUpdateFailures
+	ch <- c.SecureUpdateReceived
+	ch <- c.Queries
+	ch <- c.Responses
+	ch <- c.RecursiveQueries
+	ch <- c.RecursiveQueryFailures
+	ch <- c.RecursiveQuerySendTimeouts
+	ch <- c.WinsQueries
+	ch <- c.WinsResponses
+	ch <- c.UnmatchedResponsesReceived
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *DNSCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.ZoneTransferRequestsReceived:  "counter",
+		c.ZoneTransferRequestsSent:      "counter",
+		c.ZoneTransferResponsesReceived: "counter",
+		c.ZoneTransferSuccessReceived:   "counter",
+		c.ZoneTransferSuccessSent:       "counter",
+		c.ZoneTransferFailures:          "counter",
+		c.MemoryUsedBytes:               "gauge",
+		c.DynamicUpdatesQueued:          "gauge",
+		c.DynamicUpdatesReceived:        "counter",
+		c.DynamicUpdatesFailures:        "counter",
+		c.NotifyReceived:                "counter",
+		c.NotifySent:                    "counter",
+		c.SecureUpdateFailures:          "counter",
+		c.SecureUpdateReceived:          "counter",
+		c.Queries:                       "counter",
+		c.Responses:                     "counter",
+		c.RecursiveQueries:              "counter",
+		c.RecursiveQueryFailures:        "counter",
+		c.RecursiveQuerySendTimeouts:    "counter",
+		c.WinsQueries:                   "counter",
+		c.WinsResponses:                 "counter",
+		c.UnmatchedResponsesReceived:    "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *DhcpCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.PacketsReceivedTotal
+	ch <- c.DuplicatesDroppedTotal
+	ch <- c.PacketsExpiredTotal
+	ch <- c.ActiveQueueLength
+	ch <- c.ConflictCheckQueueLength
+	ch <- c.DiscoversTotal
+	ch <- c.OffersTotal
+	ch <- c.RequestsTotal
+	ch <- c.InformsTotal
+	ch <- c.AcksTotal
+	ch <- c.NacksTotal
+	ch <- c.DeclinesTotal
+	ch <- c.ReleasesTotal
+	ch <- c.OfferQueueLength
+	ch <- c.DeniedDueToMatch
+	ch <- c.DeniedDueToNonMatch
+	ch <- c.FailoverBndupdSentTotal
+	ch <- c.FailoverBndupdReceivedTotal
+	ch <- c.FailoverBndackSentTotal
+	ch <- c.FailoverBndackReceivedTotal
+	ch <- c.FailoverBndupdPendingOutboundQueue
+	ch <- c.FailoverTransitionsCommunicationinterruptedState
+	ch <- c.FailoverTransitionsPartnerdownState
+	ch <- c.FailoverTransitionsRecoverState
+	ch <- c.FailoverBndupdDropped
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *DhcpCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.PacketsReceivedTotal:               "counter",
+		c.DuplicatesDroppedTotal:             "counter",
+		c.PacketsExpiredTotal:                "counter",
+		c.ActiveQueueLength:                  "gauge",
+		c.ConflictCheckQueueLength:           "gauge",
+		c.DiscoversTotal:                     "counter",
+		c.OffersTotal:                        "counter",
+		c.RequestsTotal:                      "counter",
+		c.InformsTotal:                       "counter",
+		c.AcksTotal:                          "counter",
+		c.NacksTotal:                         "counter",
+		c.DeclinesTotal:                      "counter",
+		c.ReleasesTotal:                      "counter",
+		c.OfferQueueLength:                   "gauge",
+		c.DeniedDueToMatch:                   "counter",
+		c.DeniedDueToNonMatch:                "counter",
+		c.FailoverBndupdSentTotal:            "counter",
+		c.FailoverBndupdReceivedTotal:        "counter",
+		c.FailoverBndackSentTotal:            "counter",
+		c.FailoverBndackReceivedTotal:        "counter",
+		c.FailoverBndupdPendingOutboundQueue: "gauge",
+		c.FailoverTransitionsCommunicationinterruptedState: "counter",
+		c.FailoverTransitionsPartnerdownState:              "counter",
+		c.FailoverTransitionsRecoverState:                  "counter",
+		c.FailoverBndupdDropped:                            "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *FSRMQuotaCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.QuotasCount
+	ch <- c.PeakUsage
+	ch <- c.Size
+	ch <- c.Usage
+	ch <- c.Description
+	ch <- c.Disabled
+	ch <- c.MatchesTemplate
+	ch <- c.SoftLimit
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *FSRMQuotaCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.QuotasCount:     "gauge",
+		c.PeakUsage:       "gauge",
+		c.Size:            "gauge",
+		c.Usage:           "gauge",
+		c.Description:     "gauge",
+		c.Disabled:        "gauge",
+		c.MatchesTemplate: "gauge",
+		c.SoftLimit:       "gauge",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *HyperVCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.HealthCritical
+	ch <- c.HealthOk
+	ch <- c.PhysicalPagesAllocated
+	ch <- c.PreferredNUMANodeIndex
+	ch <- c.RemotePhysicalPages
+	ch <- c.AddressSpaces
+	ch <- c.AttachedDevices
+	ch <- c.DepositedPages
+	ch <- c.DeviceDMAErrors
+	ch <- c.DeviceInterruptErrors
+	ch <- c.DeviceInterruptThrottleEvents
+	ch <- c.GPAPages
+	ch <- c.GPASpaceModifications
+	ch <- c.IOTLBFlushCost
+	ch <- c.IOTLBFlushes
+	ch <- c.RecommendedVirtualTLBSize
+	ch <- c.SkippedTimerTicks
+	ch <- c.Value1Gdevicepages
+	ch <- c.Value1GGPApages
+	ch <- c.Value2Mdevicepages
+	ch <- c.Value2MGPApages
+	ch <- c.Value4Kdevicepages
+	ch <- c.Value4KGPApages
+	ch <- c.VirtualTLBFlushEntires
+	ch <- c.VirtualTLBPages
+	ch <- c.LogicalProcessors
+	ch <- c.VirtualProcessors
+	ch <- c.HostGuestRunTime
+	ch <- c.HostHypervisorRunTime
+	ch <- c.HostRemoteRunTime
+	ch <- c.HostTotalRunTime
+	ch <- c.VMGuestRunTime
+	ch <- c.VMHypervisorRunTime
+	ch <- c.VMRemoteRunTime
+	ch <- c.VMTotalRunTime
+	ch <- c.BroadcastPacketsReceived
+	ch <- c.BroadcastPacketsSent
+	ch <- c.Bytes
+	ch <- c.BytesReceived
+	ch <- c.BytesSent
+	ch <- c.DirectedPacketsReceived
+	ch <- c.DirectedPacketsSent
+	ch <- c.DroppedPacketsIncoming
+	ch <- c.DroppedPacketsOutgoing
+	ch <- c.ExtensionsDroppedPacketsIncoming
+	ch <- c.ExtensionsDroppedPacketsOutgoing
+	ch <- c.LearnedMacAddresses
+	ch <- c.MulticastPacketsReceived
+	ch <- c.MulticastPacketsSent
+	ch <- c.NumberofSendChannelMoves
+	ch <- c.NumberofVMQMoves
+	ch <- c.PacketsFlooded
+	ch <- c.Packets
+	ch <- c.PacketsReceived
+	ch <- c.PurgedMacAddresses
+	ch <- c.AdapterBytesDropped
+	ch <- c.AdapterBytesReceived
+	ch <- c.AdapterBytesSent
+	ch <- c.AdapterFramesDropped
+	ch <- c.AdapterFramesReceived
+	ch <- c.AdapterFramesSent
+	ch <- c.VMStorageErrorCount
+	ch <- c.VMStorageQueueLength
+	ch <- c.VMStorageReadBytes
+	ch <- c.VMStorageReadOperations
+	ch <- c.VMStorageWriteBytes
+	ch <- c.VMStorageWriteOperations
+	ch <- c.VMNetworkBytesReceived
+	ch <- c.VMNetworkBytesSent
+	ch <- c.VMNetworkDroppedPacketsIncoming
+	ch <- c.VMNetworkDroppedPacketsOutgoing
+	ch <- c.VMNetworkPacketsReceived
+	ch <- c.VMNetworkPacketsSent
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *HyperVCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.HealthCritical:                   "gauge",
+		c.HealthOk:                         "gauge",
+		c.PhysicalPagesAllocated:           "gauge",
+		c.PreferredNUMANodeIndex:           "gauge",
+		c.RemotePhysicalPages:              "gauge",
+		c.AddressSpaces:                    "gauge",
+		c.AttachedDevices:                  "gauge",
+		c.DepositedPages:                   "gauge",
+		c.DeviceDMAErrors:                  "gauge",
+		c.DeviceInterruptErrors:            "gauge",
+		c.DeviceInterruptThrottleEvents:    "gauge",
+		c.GPAPages:                         "gauge",
+		c.GPASpaceModifications:            "counter",
+		c.IOTLBFlushCost:                   "gauge",
+		c.IOTLBFlushes:                     "counter",
+		c.RecommendedVirtualTLBSize:        "gauge",
+		c.SkippedTimerTicks:                "gauge",
+		c.Value1Gdevicepages:               "gauge",
+		c.Value1GGPApages:                  "gauge",
+		c.Value2Mdevicepages:               "gauge",
+		c.Value2MGPApages:                  "gauge",
+		c.Value4Kdevicepages:               "gauge",
+		c.Value4KGPApages:                  "gauge",
+		c.VirtualTLBFlushEntires:           "counter",
+		c.VirtualTLBPages:                  "gauge",
+		c.LogicalProcessors:                "gauge",
+		c.VirtualProcessors:                "gauge",
+		c.HostGuestRunTime:                 "gauge",
+		c.HostHypervisorRunTime:            "gauge",
+		c.HostRemoteRunTime:                "gauge",
+		c.HostTotalRunTime:                 "gauge",
+		c.VMGuestRunTime:                   "gauge",
+		c.VMHypervisorRunTime:              "gauge",
+		c.VMRemoteRunTime:                  "gauge",
+		c.VMTotalRunTime:                   "gauge",
+		c.BroadcastPacketsReceived:         "counter",
+		c.BroadcastPacketsSent:             "counter",
+		c.Bytes:                            "counter",
+		c.BytesReceived:                    "counter",
+		c.BytesSent:                        "counter",
+		c.DirectedPacketsReceived:          "counter",
+		c.DirectedPacketsSent:              "counter",
+		c.DroppedPacketsIncoming:           "counter",
+		c.DroppedPacketsOutgoing:           "counter",
+		c.ExtensionsDroppedPacketsIncoming: "counter",
+		c.ExtensionsDroppedPacketsOutgoing: "counter",
+		c.LearnedMacAddresses:              "counter",
+		c.MulticastPacketsReceived:         "counter",
+		c.MulticastPacketsSent:             "counter",
+		c.NumberofSendChannelMoves:         "counter",
+		c.NumberofVMQMoves:                 "counter",
+		c.PacketsFlooded:                   "counter",
+		c.Packets:                          "counter",
+		c.PacketsReceived:                  "counter",
+		c.PurgedMacAddresses:               "counter",
+		c.AdapterBytesDropped:              "gauge",
+		c.AdapterBytesReceived:             "counter",
+		c.AdapterBytesSent:                 "counter",
+		c.AdapterFramesDropped:             "counter",
+		c.AdapterFramesReceived:            "counter",
+		c.AdapterFramesSent:                "counter",
+		c.VMStorageErrorCount:              "counter",
+		c.VMStorageQueueLength:             "counter",
+		c.VMStorageReadBytes:               "counter",
+		c.VMStorageReadOperations:          "counter",
+		c.VMStorageWriteBytes:              "counter",
+		c.VMStorageWriteOperations:         "counter",
+		c.VMNetworkBytesReceived:           "counter",
+		c.VMNetworkBytesSent:               "counter",
+		c.VMNetworkDroppedPacketsIncoming:  "counter",
+		c.VMNetworkDroppedPacketsOutgoing:  "counter",
+		c.VMNetworkPacketsReceived:         "counter",
+		c.VMNetworkPacketsSent:             "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *IISCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.CurrentAnonymousUsers
+	ch <- c.CurrentBlockedAsyncIORequests
+	ch <- c.CurrentCGIRequests
+	ch <- c.CurrentConnections
+	ch <- c.CurrentISAPIExtensionRequests
+	ch <- c.CurrentNonAnonymousUsers
+	ch <- c.TotalBytesReceived
+	ch <- c.TotalBytesSent
+	ch <- c.TotalAnonymousUsers
+	ch <- c.TotalBlockedAsyncIORequests
+	ch <- c.TotalCGIRequests
+	ch <- c.TotalConnectionAttemptsAllInstances
+	ch <- c.TotalRequests
+	ch <- c.TotalFilesReceived
+	ch <- c.TotalFilesSent
+	ch <- c.TotalISAPIExtensionRequests
+	ch <- c.TotalLockedErrors
+	ch <- c.TotalLogonAttempts
+	ch <- c.TotalNonAnonymousUsers
+	ch <- c.TotalNotFoundErrors
+	ch <- c.TotalRejectedAsyncIORequests
+	ch <- c.CurrentApplicationPoolState
+	ch <- c.CurrentApplicationPoolUptime
+	ch <- c.CurrentWorkerProcesses
+	ch <- c.MaximumWorkerProcesses
+	ch <- c.RecentWorkerProcessFailures
+	ch <- c.TimeSinceLastWorkerProcessFailure
+	ch <- c.TotalApplicationPoolRecycles
+	ch <- c.TotalApplicationPoolUptime
+	ch <- c.TotalWorkerProcessesCreated
+	ch <- c.TotalWorkerProcessFailures
+	ch <- c.TotalWorkerProcessPingFailures
+	ch <- c.TotalWorkerProcessShutdownFailures
+	ch <- c.TotalWorkerProcessStartupFailures
+	ch <- c.ActiveFlushedEntries
+	ch <- c.FileCacheMemoryUsage
+	ch <- c.MaximumFileCacheMemoryUsage
+	ch <- c.FileCacheFlushesTotal
+	ch <- c.FileCacheQueriesTotal
+	ch <- c.FileCacheHitsTotal
+	ch <- c.FilesCached
+	ch <- c.FilesCachedTotal
+	ch <- c.FilesFlushedTotal
+	ch <- c.URICacheFlushesTotal
+	ch <- c.URICacheQueriesTotal
+	ch <- c.URICacheHitsTotal
+	ch <- c.URIsCached
+	ch <- c.URIsCachedTotal
+	ch <- c.URIsFlushedTotal
+	ch <- c.MetadataCached
+	ch <- c.MetadataCacheFlushes
+	ch <- c.MetadataCacheQueriesTotal
+	ch <- c.MetadataCacheHitsTotal
+	ch <- c.MetadataCachedTotal
+	ch <- c.MetadataFlushedTotal
+	ch <- c.OutputCacheActiveFlushedItems
+	ch <- c.OutputCacheItems
+	ch <- c.OutputCacheMemoryUsage
+	ch <- c.OutputCacheQueriesTotal
+	ch <- c.OutputCacheHitsTotal
+	ch <- c.OutputCacheFlushedItemsTotal
+	ch <- c.OutputCacheFlushesTotal
+	ch <- c.Threads
+	ch <- c.MaximumThreads
+	ch <- c.RequestsTotal
+	ch <- c.RequestsActive
+	ch <- c.RequestErrorsTotal
+	ch <- c.WebSocketRequestsActive
+	ch <- c.WebSocketConnectionAttempts
+	ch <- c.WebSocketConnectionsAccepted
+	ch <- c.WebSocketConnectionsRejected
+	ch <- c.ServiceCache_ActiveFlushedEntries
+	ch <- c.ServiceCache_FileCacheMemoryUsage
+	ch <- c.ServiceCache_MaximumFileCacheMemoryUsage
+	ch <- c.ServiceCache_FileCacheFlushesTotal
+	ch <- c.ServiceCache_FileCacheQueriesTotal
+	ch <- c.ServiceCache_FileCacheHitsTotal
+	ch <- c.ServiceCache_FilesCached
+	ch <- c.ServiceCache_FilesCachedTotal
+	ch <- c.ServiceCache_FilesFlushedTotal
+	ch <- c.ServiceCache_URICacheFlushesTotal
+	ch <- c.ServiceCache_URICacheQueriesTotal
+	ch <- c.ServiceCache_URICacheHitsTotal
+	ch <- c.ServiceCache_URIsCached
+	ch <- c.ServiceCache_URIsCachedTotal
+	ch <- c.ServiceCache_URIsFlushedTotal
+	ch <- c.ServiceCache_MetadataCached
+	ch <- c.ServiceCache_MetadataCacheFlushes
+	ch <- c.ServiceCache_MetadataCacheQueriesTotal
+	ch <- c.ServiceCache_MetadataCacheHitsTotal
+	ch <- c.ServiceCache_MetadataCachedTotal
+	ch <- c.ServiceCache_MetadataFlushedTotal
+	ch <- c.ServiceCache_OutputCacheActiveFlushedItems
+	ch <- c.ServiceCache_OutputCacheItems
+	ch <- c.ServiceCache_OutputCacheMemoryUsage
+	ch <- c.ServiceCache_OutputCacheQueriesTotal
+	ch <- c.ServiceCache_OutputCacheHitsTotal
+	ch <- c.ServiceCache_OutputCacheFlushedItemsTotal
+	ch <- c.ServiceCache_OutputCacheFlushesTotal
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *IISCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.CurrentAnonymousUsers:                      "gauge",
+		c.CurrentBlockedAsyncIORequests:              "gauge",
+		c.CurrentCGIRequests:                         "gauge",
+		c.CurrentConnections:                         "gauge",
+		c.CurrentISAPIExtensionRequests:              "gauge",
+		c.CurrentNonAnonymousUsers:                   "gauge",
+		c.TotalBytesReceived:                         "counter",
+		c.TotalBytesSent:                             "counter",
+		c.TotalAnonymousUsers:                        "counter",
+		c.TotalBlockedAsyncIORequests:                "counter",
+		c.TotalCGIRequests:                           "counter",
+		c.TotalConnectionAttemptsAllInstances:        "counter",
+		c.TotalRequests:                              "counter",
+		c.TotalFilesReceived:                         "counter",
+		c.TotalFilesSent:                             "counter",
+		c.TotalISAPIExtensionRequests:                "counter",
+		c.TotalLockedErrors:                          "counter",
+		c.TotalLogonAttempts:                         "counter",
+		c.TotalNonAnonymousUsers:                     "counter",
+		c.TotalNotFoundErrors:                        "counter",
+		c.TotalRejectedAsyncIORequests:               "counter",
+		c.CurrentApplicationPoolState:                "gauge",
+		c.CurrentApplicationPoolUptime:               "gauge",
+		c.CurrentWorkerProcesses:                     "gauge",
+		c.MaximumWorkerProcesses:                     "gauge",
+		c.RecentWorkerProcessFailures:                "gauge",
+		c.TimeSinceLastWorkerProcessFailure:          "gauge",
+		c.TotalApplicationPoolRecycles:               "counter",
+		c.TotalApplicationPoolUptime:                 "counter",
+		c.TotalWorkerProcessesCreated:                "counter",
+		c.TotalWorkerProcessFailures:                 "counter",
+		c.TotalWorkerProcessPingFailures:             "counter",
+		c.TotalWorkerProcessShutdownFailures:         "counter",
+		c.TotalWorkerProcessStartupFailures:          "counter",
+		c.ActiveFlushedEntries:                       "gauge",
+		c.FileCacheMemoryUsage:                       "gauge",
+		c.MaximumFileCacheMemoryUsage:                "counter",
+		c.FileCacheFlushesTotal:                      "counter",
+		c.FileCacheQueriesTotal:                      "counter",
+		c.FileCacheHitsTotal:                         "counter",
+		c.FilesCached:                                "gauge",
+		c.FilesCachedTotal:                           "counter",
+		c.FilesFlushedTotal:                          "counter",
+		c.URICacheFlushesTotal:                       "counter",
+		c.URICacheQueriesTotal:                       "counter",
+		c.URICacheHitsTotal:                          "counter",
+		c.URIsCached:                                 "gauge",
+		c.URIsCachedTotal:                            "counter",
+		c.URIsFlushedTotal:                           "counter",
+		c.MetadataCached:                             "gauge",
+		c.MetadataCacheFlushes:                       "counter",
+		c.MetadataCacheQueriesTotal:                  "counter",
+		c.MetadataCacheHitsTotal:                     "counter",
+		c.MetadataCachedTotal:                        "counter",
+		c.MetadataFlushedTotal:                       "counter",
+		c.OutputCacheActiveFlushedItems:              "counter",
+		c.OutputCacheItems:                           "counter",
+		c.OutputCacheMemoryUsage:                     "counter",
+		c.OutputCacheQueriesTotal:                    "counter",
+		c.OutputCacheHitsTotal:                       "counter",
+		c.OutputCacheFlushedItemsTotal:               "counter",
+		c.OutputCacheFlushesTotal:                    "counter",
+		c.Threads:                                    "gauge",
+		c.MaximumThreads:                             "counter",
+		c.RequestsTotal:                              "counter",
+		c.RequestsActive:                             "counter",
+		c.RequestErrorsTotal:                         "counter",
+		c.WebSocketRequestsActive:                    "counter",
+		c.WebSocketConnectionAttempts:                "counter",
+		c.WebSocketConnectionsAccepted:               "counter",
+		c.WebSocketConnectionsRejected:               "counter",
+		c.ServiceCache_ActiveFlushedEntries:          "gauge",
+		c.ServiceCache_FileCacheMemoryUsage:          "gauge",
+		c.ServiceCache_MaximumFileCacheMemoryUsage:   "counter",
+		c.ServiceCache_FileCacheFlushesTotal:         "counter",
+		c.ServiceCache_FileCacheQueriesTotal:         "counter",
+		c.ServiceCache_FileCacheHitsTotal:            "counter",
+		c.ServiceCache_FilesCached:                   "gauge",
+		c.ServiceCache_FilesCachedTotal:              "counter",
+		c.ServiceCache_FilesFlushedTotal:             "counter",
+		c.ServiceCache_URICacheFlushesTotal:          "counter",
+		c.ServiceCache_URICacheQueriesTotal:          "counter",
+		c.ServiceCache_URICacheHitsTotal:             "counter",
+		c.ServiceCache_URIsCached:                    "gauge",
+		c.ServiceCache_URIsCachedTotal:               "counter",
+		c.ServiceCache_URIsFlushedTotal:              "counter",
+		c.ServiceCache_MetadataCached:                "gauge",
+		c.ServiceCache_MetadataCacheFlushes:          "counter",
+		c.ServiceCache_MetadataCacheQueriesTotal:     "counter",
+		c.ServiceCache_MetadataCacheHitsTotal:        "counter",
+		c.ServiceCache_MetadataCachedTotal:           "counter",
+		c.ServiceCache_MetadataFlushedTotal:          "counter",
+		c.ServiceCache_OutputCacheActiveFlushedItems: "counter",
+		c.ServiceCache_OutputCacheItems:              "counter",
+		c.ServiceCache_OutputCacheMemoryUsage:        "counter",
+		c.ServiceCache_OutputCacheQueriesTotal:       "counter",
+		c.ServiceCache_OutputCacheHitsTotal:          "counter",
+		c.ServiceCache_OutputCacheFlushedItemsTotal:  "counter",
+		c.ServiceCache_OutputCacheFlushesTotal:       "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *LogicalDiskCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.RequestsQueued
+	ch <- c.ReadBytesTotal
+	ch <- c.ReadsTotal
+	ch <- c.WriteBytesTotal
+	ch <- c.WritesTotal
+	ch <- c.ReadTime
+	ch <- c.WriteTime
+	ch <- c.TotalSpace
+	ch <- c.FreeSpace
+	ch <- c.IdleTime
+	ch <- c.SplitIOs
+	ch <- c.ReadLatency
+	ch <- c.WriteLatency
+	ch <- c.ReadWriteLatency
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *LogicalDiskCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.RequestsQueued:   "gauge",
+		c.ReadBytesTotal:   "counter",
+		c.ReadsTotal:       "counter",
+		c.WriteBytesTotal:  "counter",
+		c.WritesTotal:      "counter",
+		c.ReadTime:         "counter",
+		c.WriteTime:        "counter",
+		c.TotalSpace:       "gauge",
+		c.FreeSpace:        "gauge",
+		c.IdleTime:         "counter",
+		c.SplitIOs:         "counter",
+		c.ReadLatency:      "counter",
+		c.WriteLatency:     "counter",
+		c.ReadWriteLatency: "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *LogonCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.LogonType
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *LogonCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.LogonType: "gauge",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *MSSQLCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.mssqlScrapeDurationDesc
+	ch <- c.mssqlScrapeSuccessDesc
+	ch <- c.AccessMethodsAUcleanupbatches
+	ch <- c.AccessMethodsAUcleanups
+	ch <- c.AccessMethodsByreferenceLobCreateCount
+	ch <- c.AccessMethodsByreferenceLobUseCount
+	ch <- c.AccessMethodsCountLobReadahead
+	ch <- c.AccessMethodsCountPullInRow
+	ch <- c.AccessMethodsCountPushOffRow
+	ch <- c.AccessMethodsDeferreddroppedAUs
+	ch <- c.AccessMethodsDeferredDroppedrowsets
+	ch <- c.AccessMethodsDroppedrowsetcleanups
+	ch <- c.AccessMethodsDroppedrowsetsskipped
+	ch <- c.AccessMethodsExtentDeallocations
+	ch <- c.AccessMethodsExtentsAllocated
+	ch <- c.AccessMethodsFailedAUcleanupbatches
+	ch <- c.AccessMethodsFailedleafpagecookie
+	ch <- c.AccessMethodsFailedtreepagecookie
+	ch <- c.AccessMethodsForwardedRecords
+	ch <- c.AccessMethodsFreeSpacePageFetches
+	ch <- c.AccessMethodsFreeSpaceScans
+	ch <- c.AccessMethodsFullScans
+	ch <- c.AccessMethodsIndexSearches
+	ch <- c.AccessMethodsInSysXactwaits
+	ch <- c.AccessMethodsLobHandleCreateCount
+	ch <- c.AccessMethodsLobHandleDestroyCount
+	ch <- c.AccessMethodsLobSSProviderCreateCount
+	ch <- c.AccessMethodsLobSSProviderDestroyCount
+	ch <- c.AccessMethodsLobSSProviderTruncationCount
+	ch <- c.AccessMethodsMixedpageallocations
+	ch <- c.AccessMethodsPagecompressionattempts
+	ch <- c.AccessMethodsPageDeallocations
+	ch <- c.AccessMethodsPagesAllocated
+	ch <- c.AccessMethodsPagescompressed
+	ch <- c.AccessMethodsPageSplits
+	ch <- c.AccessMethodsProbeScans
+	ch <- c.AccessMethodsRangeScans
+	ch <- c.AccessMethodsScanPointRevalidations
+	ch <- c.AccessMethodsSkippedGhostedRecords
+	ch <- c.AccessMethodsTableLockEscalations
+	ch <- c.AccessMethodsUsedleafpagecookie
+	ch <- c.AccessMethodsUsedtreepagecookie
+	ch <- c.AccessMethodsWorkfilesCreated
+	ch <- c.AccessMethodsWorktablesCreated
+	ch <- c.AccessMethodsWorktablesFromCacheHits
+	ch <- c.AccessMethodsWorktablesFromCacheLookups
+	ch <- c.AvailReplicaBytesReceivedfromReplica
+	ch <- c.AvailReplicaBytesSenttoReplica
+	ch <- c.AvailReplicaBytesSenttoTransport
+	ch <- c.AvailReplicaFlowControl
+	ch <- c.AvailReplicaFlowControlTimems
+	ch <- c.AvailReplicaReceivesfromReplica
+	ch <- c.AvailReplicaResentMessages
+	ch <- c.AvailReplicaSendstoReplica
+	ch <- c.AvailReplicaSendstoTransport
+	ch <- c.BufManBackgroundwriterpages
+	ch <- c.BufManBuffercachehits
+	ch <- c.BufManBuffercachelookups
+	ch <- c.BufManCheckpointpages
+	ch <- c.BufManDatabasepages
+	ch <- c.BufManExtensionallocatedpages
+	ch <- c.BufManExtensionfreepages
+	ch <- c.BufManExtensioninuseaspercentage
+	ch <- c.BufManExtensionoutstandingIOcounter
+	ch <- c.BufManExtensionpageevictions
+	ch <- c.BufManExtensionpagereads
+	ch <- c.BufManExtensionpageunreferencedtime
+	ch <- c.BufManExtensionpagewrites
+	ch <- c.BufManFreeliststalls
+	ch <- c.BufManIntegralControllerSlope
+	ch <- c.BufManLazywrites
+	ch <- c.BufManPagelifeexpectancy
+	ch <- c.BufManPagelookups
+	ch <- c.BufManPagereads
+	ch <- c.BufManPagewrites
+	ch <- c.BufManReadaheadpages
+	ch <- c.BufManReadaheadtime
+	ch <- c.BufManTargetpages
+	ch <- c.DBReplicaDatabaseFlowControlDelay
+	ch <- c.DBReplicaDatabaseFlowControls
+	ch <- c.DBReplicaFileBytesReceived
+	ch <- c.DBReplicaGroupCommits
+	ch <- c.DBReplicaGroupCommitTime
+	ch <- c.DBReplicaLogApplyPendingQueue
+	ch <- c.DBReplicaLogApplyReadyQueue
+	ch <- c.DBReplicaLogBytesCompressed
+	ch <- c.DBReplicaLogBytesDecompressed
+	ch <- c.DBReplicaLogBytesReceived
+	ch <- c.DBReplicaLogCompressionCachehits
+	ch <- c.DBReplicaLogCompressionCachemisses
+	ch <- c.DBReplicaLogCompressions
+	ch <- c.DBReplicaLogDecompressions
+	ch <- c.DBReplicaLogremainingforundo
+	ch <- c.DBReplicaLogSendQueue
+	ch <- c.DBReplicaMirroredWriteTransactions
+	ch <- c.DBReplicaRecoveryQueue
+	ch <- c.DBReplicaRedoblocked
+	ch <- c.DBReplicaRedoBytesRemaining
+	ch <- c.DBReplicaRedoneBytes
+	ch <- c.DBReplicaRedones
+	ch <- c.DBReplicaTotalLogrequiringundo
+	ch <- c.DBReplicaTransactionDelay
+	ch <- c.DatabasesActiveParallelredothreads
+	ch <- c.DatabasesActiveTransactions
+	ch <- c.DatabasesBackupPerRestoreThroughput
+	ch <- c.DatabasesBulkCopyRows
+	ch <- c.DatabasesBulkCopyThroughput
+	ch <- c.DatabasesCommittableentries
+	ch <- c.DatabasesDataFilesSizeKB
+	ch <- c.DatabasesDBCCLogicalScanBytes
+	ch <- c.DatabasesGroupCommitTime
+	ch <- c.DatabasesLogBytesFlushed
+	ch <- c.DatabasesLogCacheHits
+	ch <- c.DatabasesLogCacheLookups
+	ch <- c.DatabasesLogCacheReads
+	ch <- c.DatabasesLogFilesSizeKB
+	ch <- c.DatabasesLogFilesUsedSizeKB
+	ch <- c.DatabasesLogFlushes
+	ch <- c.DatabasesLogFlushWaits
+	ch <- c.DatabasesLogFlushWaitTime
+	ch <- c.DatabasesLogFlushWriteTimems
+	ch <- c.DatabasesLogGrowths
+	ch <- c.DatabasesLogPoolCacheMisses
+	ch <- c.DatabasesLogPoolDiskReads
+	ch <- c.DatabasesLogPoolHashDeletes
+	ch <- c.DatabasesLogPoolHashInserts
+	ch <- c.DatabasesLogPoolInvalidHashEntry
+	ch <- c.DatabasesLogPoolLogScanPushes
+	ch <- c.DatabasesLogPoolLogWriterPushes
+	ch <- c.DatabasesLogPoolPushEmptyFreePool
+	ch <- c.DatabasesLogPoolPushLowMemory
+	ch <- c.DatabasesLogPoolPushNoFreeBuffer
+	ch <- c.DatabasesLogPoolReqBehindTrunc
+	ch <- c.DatabasesLogPoolRequestsOldVLF
+	ch <- c.DatabasesLogPoolRequests
+	ch <- c.DatabasesLogPoolTotalActiveLogSize
+	ch <- c.DatabasesLogPoolTotalSharedPoolSize
+	ch <- c.DatabasesLogShrinks
+	ch <- c.DatabasesLogTruncations
+	ch <- c.DatabasesPercentLogUsed
+	ch <- c.DatabasesReplPendingXacts
+	ch <- c.DatabasesReplTransRate
+	ch <- c.DatabasesShrinkDataMovementBytes
+	ch <- c.DatabasesTrackedtransactions
+	ch <- c.DatabasesTransactions
+	ch <- c.DatabasesWriteTransactions
+	ch <- c.DatabasesXTPControllerDLCLatencyPerFetch
+	ch <- c.DatabasesXTPControllerDLCPeakLatency
+	ch <- c.DatabasesXTPControllerLogProcessed
+	ch <- c.DatabasesXTPMemoryUsedKB
+	ch <- c.GenStatsActiveTempTables
+	ch <- c.GenStatsConnectionReset
+	ch <- c.GenStatsEventNotificationsDelayedDrop
+	ch <- c.GenStatsHTTPAuthenticatedRequests
+	ch <- c.GenStatsLogicalConnections
+	ch <- c.GenStatsLogins
+	ch <- c.GenStatsLogouts
+	ch <- c.GenStatsMarsDeadlocks
+	ch <- c.GenStatsNonatomicyieldrate
+	ch <- c.GenStatsProcessesblocked
+	ch <- c.GenStatsSOAPEmptyRequests
+	ch <- c.GenStatsSOAPMethodInvocations
+	ch <- c.GenStatsSOAPSessionInitiateRequests
+	ch <- c.GenStatsSOAPSessionTerminateRequests
+	ch <- c.GenStatsSOAPSQLRequests
+	ch <- c.GenStatsSOAPWSDLRequests
+	ch <- c.GenStatsSQLTraceIOProviderLockWaits
+	ch <- c.GenStatsTempdbrecoveryunitid
+	ch <- c.GenStatsTempdbrowsetid
+	ch <- c.GenStatsTempTablesCreationRate
+	ch <- c.GenStatsTempTablesForDestruction
+	ch <- c.GenStatsTraceEventNotificationQueue
+	ch <- c.GenStatsTransactions
+	ch <- c.GenStatsUserConnections
+	ch <- c.LocksWaitTime
+	ch <- c.LocksCount
+	ch <- c.LocksLockRequests
+	ch <- c.LocksLockTimeouts
+	ch <- c.LocksLockTimeoutstimeout0
+	ch <- c.LocksLockWaits
+	ch <- c.LocksLockWaitTimems
+	ch <- c.LocksNumberofDeadlocks
+	ch <- c.MemMgrConnectionMemoryKB
+	ch <- c.MemMgrDatabaseCacheMemoryKB
+	ch <- c.MemMgrExternalbenefitofmemory
+	ch <- c.MemMgrFreeMemoryKB
+	ch <- c.MemMgrGrantedWorkspaceMemoryKB
+	ch <- c.MemMgrLockBlocks
+	ch <- c.MemMgrLockBlocksAllocated
+	ch <- c.MemMgrLockMemoryKB
+	ch <- c.MemMgrLockOwnerBlocks
+	ch <- c.MemMgrLockOwnerBlocksAllocated
+	ch <- c.MemMgrLogPoolMemoryKB
+	ch <- c.MemMgrMaximumWorkspaceMemoryKB
+	ch <- c.MemMgrMemoryGrantsOutstanding
+	ch <- c.MemMgrMemoryGrantsPending
+	ch <- c.MemMgrOptimizerMemoryKB
+	ch <- c.MemMgrReservedServerMemoryKB
+	ch <- c.MemMgrSQLCacheMemoryKB
+	ch <- c.MemMgrStolenServerMemoryKB
+	ch <- c.MemMgrTargetServerMemoryKB
+	ch <- c.MemMgrTotalServerMemoryKB
+	ch <- c.SQLStatsAutoParamAttempts
+	ch <- c.SQLStatsBatchRequests
+	ch <- c.SQLStatsFailedAutoParams
+	ch <- c.SQLStatsForcedParameterizations
+	ch <- c.SQLStatsGuidedplanexecutions
+	ch <- c.SQLStatsMisguidedplanexecutions
+	ch <- c.SQLStatsSafeAutoParams
+	ch <- c.SQLStatsSQLAttentionrate
+	ch <- c.SQLStatsSQLCompilations
+	ch <- c.SQLStatsSQLReCompilations
+	ch <- c.SQLStatsUnsafeAutoParams
+	ch <- c.SQLErrorsTotal
+	ch <- c.TransactionsTempDbFreeSpaceBytes
+	ch <- c.TransactionsLongestTransactionRunningSeconds
+	ch <- c.TransactionsNonSnapshotVersionActiveTotal
+	ch <- c.TransactionsSnapshotActiveTotal
+	ch <- c.TransactionsActive
+	ch <- c.TransactionsUpdateConflictsTotal
+	ch <- c.TransactionsUpdateSnapshotActiveTotal
+	ch <- c.TransactionsVersionCleanupRateBytes
+	ch <- c.TransactionsVersionGenerationRateBytes
+	ch <- c.TransactionsVersionStoreSizeBytes
+	ch <- c.TransactionsVersionStoreUnits
+	ch <- c.TransactionsVersionStoreCreationUnits
+	ch <- c.TransactionsVersionStoreTruncationUnits
+	ch <- c.WaitStatsLockWaits
+	ch <- c.WaitStatsMemoryGrantQueueWaits
+	ch <- c.WaitStatsThreadSafeMemoryObjectsWaits
+	ch <- c.WaitStatsLogWriteWaits
+	ch <- c.WaitStatsLogBufferWaits
+	ch <- c.WaitStatsNetworkIOWaits
+	ch <- c.WaitStatsPageIOLatchWaits
+	ch <- c.WaitStatsPageLatchWaits
+	ch <- c.WaitStatsNonpageLatchWaits
+	ch <- c.WaitStatsWaitForTheWorkerWaits
+	ch <- c.WaitStatsWorkspaceSynchronizationWaits
+	ch <- c.WaitStatsTransactionOwnershipWaits
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *MSSQLCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.mssqlScrapeDurationDesc:                      "gauge",
+		c.mssqlScrapeSuccessDesc:                       "gauge",
+		c.AccessMethodsAUcleanupbatches:                "counter",
+		c.AccessMethodsAUcleanups:                      "counter",
+		c.AccessMethodsByreferenceLobCreateCount:       "counter",
+		c.AccessMethodsByreferenceLobUseCount:          "counter",
+		c.AccessMethodsCountLobReadahead:               "counter",
+		c.AccessMethodsCountPullInRow:                  "counter",
+		c.AccessMethodsCountPushOffRow:                 "counter",
+		c.AccessMethodsDeferreddroppedAUs:              "gauge",
+		c.AccessMethodsDeferredDroppedrowsets:          "gauge",
+		c.AccessMethodsDroppedrowsetcleanups:           "counter",
+		c.AccessMethodsDroppedrowsetsskipped:           "counter",
+		c.AccessMethodsExtentDeallocations:             "counter",
+		c.AccessMethodsExtentsAllocated:                "counter",
+		c.AccessMethodsFailedAUcleanupbatches:          "counter",
+		c.AccessMethodsFailedleafpagecookie:            "counter",
+		c.AccessMethodsFailedtreepagecookie:            "counter",
+		c.AccessMethodsForwardedRecords:                "counter",
+		c.AccessMethodsFreeSpacePageFetches:            "counter",
+		c.AccessMethodsFreeSpaceScans:                  "counter",
+		c.AccessMethodsFullScans:                       "counter",
+		c.AccessMethodsIndexSearches:                   "counter",
+		c.AccessMethodsInSysXactwaits:                  "counter",
+		c.AccessMethodsLobHandleCreateCount:            "counter",
+		c.AccessMethodsLobHandleDestroyCount:           "counter",
+		c.AccessMethodsLobSSProviderCreateCount:        "counter",
+		c.AccessMethodsLobSSProviderDestroyCount:       "counter",
+		c.AccessMethodsLobSSProviderTruncationCount:    "counter",
+		c.AccessMethodsMixedpageallocations:            "counter",
+		c.AccessMethodsPagecompressionattempts:         "counter",
+		c.AccessMethodsPageDeallocations:               "counter",
+		c.AccessMethodsPagesAllocated:                  "counter",
+		c.AccessMethodsPagescompressed:                 "counter",
+		c.AccessMethodsPageSplits:                      "counter",
+		c.AccessMethodsProbeScans:                      "counter",
+		c.AccessMethodsRangeScans:                      "counter",
+		c.AccessMethodsScanPointRevalidations:          "counter",
+		c.AccessMethodsSkippedGhostedRecords:           "counter",
+		c.AccessMethodsTableLockEscalations:            "counter",
+		c.AccessMethodsUsedleafpagecookie:              "counter",
+		c.AccessMethodsUsedtreepagecookie:              "counter",
+		c.AccessMethodsWorkfilesCreated:                "counter",
+		c.AccessMethodsWorktablesCreated:               "counter",
+		c.AccessMethodsWorktablesFromCacheHits:         "counter",
+		c.AccessMethodsWorktablesFromCacheLookups:      "counter",
+		c.AvailReplicaBytesReceivedfromReplica:         "counter",
+		c.AvailReplicaBytesSenttoReplica:               "counter",
+		c.AvailReplicaBytesSenttoTransport:             "counter",
+		c.AvailReplicaFlowControl:                      "counter",
+		c.AvailReplicaFlowControlTimems:                "counter",
+		c.AvailReplicaReceivesfromReplica:              "counter",
+		c.AvailReplicaResentMessages:                   "counter",
+		c.AvailReplicaSendstoReplica:                   "counter",
+		c.AvailReplicaSendstoTransport:                 "counter",
+		c.BufManBackgroundwriterpages:                  "counter",
+		c.BufManBuffercachehits:                        "gauge",
+		c.BufManBuffercachelookups:                     "gauge",
+		c.BufManCheckpointpages:                        "counter",
+		c.BufManDatabasepages:                          "gauge",
+		c.BufManExtensionallocatedpages:                "gauge",
+		c.BufManExtensionfreepages:                     "gauge",
+		c.BufManExtensioninuseaspercentage:             "gauge",
+		c.BufManExtensionoutstandingIOcounter:          "gauge",
+		c.BufManExtensionpageevictions:                 "counter",
+		c.BufManExtensionpagereads:                     "counter",
+		c.BufManExtensionpageunreferencedtime:          "gauge",
+		c.BufManExtensionpagewrites:                    "counter",
+		c.BufManFreeliststalls:                         "counter",
+		c.BufManIntegralControllerSlope:                "gauge",
+		c.BufManLazywrites:                             "counter",
+		c.BufManPagelifeexpectancy:                     "gauge",
+		c.BufManPagelookups:                            "counter",
+		c.BufManPagereads:                              "counter",
+		c.BufManPagewrites:                             "counter",
+		c.BufManReadaheadpages:                         "counter",
+		c.BufManReadaheadtime:                          "counter",
+		c.BufManTargetpages:                            "gauge",
+		c.DBReplicaDatabaseFlowControlDelay:            "gauge",
+		c.DBReplicaDatabaseFlowControls:                "counter",
+		c.DBReplicaFileBytesReceived:                   "counter",
+		c.DBReplicaGroupCommits:                        "counter",
+		c.DBReplicaGroupCommitTime:                     "gauge",
+		c.DBReplicaLogApplyPendingQueue:                "gauge",
+		c.DBReplicaLogApplyReadyQueue:                  "gauge",
+		c.DBReplicaLogBytesCompressed:                  "counter",
+		c.DBReplicaLogBytesDecompressed:                "counter",
+		c.DBReplicaLogBytesReceived:                    "counter",
+		c.DBReplicaLogCompressionCachehits:             "counter",
+		c.DBReplicaLogCompressionCachemisses:           "counter",
+		c.DBReplicaLogCompressions:                     "counter",
+		c.DBReplicaLogDecompressions:                   "counter",
+		c.DBReplicaLogremainingforundo:                 "gauge",
+		c.DBReplicaLogSendQueue:                        "gauge",
+		c.DBReplicaMirroredWriteTransactions:           "counter",
+		c.DBReplicaRecoveryQueue:                       "gauge",
+		c.DBReplicaRedoblocked:                         "counter",
+		c.DBReplicaRedoBytesRemaining:                  "gauge",
+		c.DBReplicaRedoneBytes:                         "counter",
+		c.DBReplicaRedones:                             "counter",
+		c.DBReplicaTotalLogrequiringundo:               "gauge",
+		c.DBReplicaTransactionDelay:                    "gauge",
+		c.DatabasesActiveParallelredothreads:           "gauge",
+		c.DatabasesActiveTransactions:                  "gauge",
+		c.DatabasesBackupPerRestoreThroughput:          "counter",
+		c.DatabasesBulkCopyRows:                        "counter",
+		c.DatabasesBulkCopyThroughput:                  "counter",
+		c.DatabasesCommittableentries:                  "gauge",
+		c.DatabasesDataFilesSizeKB:                     "gauge",
+		c.DatabasesDBCCLogicalScanBytes:                "counter",
+		c.DatabasesGroupCommitTime:                     "counter",
+		c.DatabasesLogBytesFlushed:                     "counter",
+		c.DatabasesLogCacheHits:                        "gauge",
+		c.DatabasesLogCacheLookups:                     "gauge",
+		c.DatabasesLogCacheReads:                       "counter",
+		c.DatabasesLogFilesSizeKB:                      "gauge",
+		c.DatabasesLogFilesUsedSizeKB:                  "gauge",
+		c.DatabasesLogFlushes:                          "counter",
+		c.DatabasesLogFlushWaits:                       "counter",
+		c.DatabasesLogFlushWaitTime:                    "gauge",
+		c.DatabasesLogFlushWriteTimems:                 "gauge",
+		c.DatabasesLogGrowths:                          "gauge",
+		c.DatabasesLogPoolCacheMisses:                  "counter",
+		c.DatabasesLogPoolDiskReads:                    "counter",
+		c.DatabasesLogPoolHashDeletes:                  "counter",
+		c.DatabasesLogPoolHashInserts:                  "counter",
+		c.DatabasesLogPoolInvalidHashEntry:             "counter",
+		c.DatabasesLogPoolLogScanPushes:                "counter",
+		c.DatabasesLogPoolLogWriterPushes:              "counter",
+		c.DatabasesLogPoolPushEmptyFreePool:            "counter",
+		c.DatabasesLogPoolPushLowMemory:                "counter",
+		c.DatabasesLogPoolPushNoFreeBuffer:             "counter",
+		c.DatabasesLogPoolReqBehindTrunc:               "counter",
+		c.DatabasesLogPoolRequestsOldVLF:               "counter",
+		c.DatabasesLogPoolRequests:                     "counter",
+		c.DatabasesLogPoolTotalActiveLogSize:           "gauge",
+		c.DatabasesLogPoolTotalSharedPoolSize:          "gauge",
+		c.DatabasesLogShrinks:                          "gauge",
+		c.DatabasesLogTruncations:                      "gauge",
+		c.DatabasesPercentLogUsed:                      "gauge",
+		c.DatabasesReplPendingXacts:                    "gauge",
+		c.DatabasesReplTransRate:                       "counter",
+		c.DatabasesShrinkDataMovementBytes:             "counter",
+		c.DatabasesTrackedtransactions:                 "counter",
+		c.DatabasesTransactions:                        "counter",
+		c.DatabasesWriteTransactions:                   "counter",
+		c.DatabasesXTPControllerDLCLatencyPerFetch:     "gauge",
+		c.DatabasesXTPControllerDLCPeakLatency:         "gauge",
+		c.DatabasesXTPControllerLogProcessed:           "counter",
+		c.DatabasesXTPMemoryUsedKB:                     "gauge",
+		c.GenStatsActiveTempTables:                     "gauge",
+		c.GenStatsConnectionReset:                      "counter",
+		c.GenStatsEventNotificationsDelayedDrop:        "gauge",
+		c.GenStatsHTTPAuthenticatedRequests:            "gauge",
+		c.GenStatsLogicalConnections:                   "gauge",
+		c.GenStatsLogins:                               "counter",
+		c.GenStatsLogouts:                              "counter",
+		c.GenStatsMarsDeadlocks:                        "gauge",
+		c.GenStatsNonatomicyieldrate:                   "counter",
+		c.GenStatsProcessesblocked:                     "gauge",
+		c.GenStatsSOAPEmptyRequests:                    "gauge",
+		c.GenStatsSOAPMethodInvocations:                "gauge",
+		c.GenStatsSOAPSessionInitiateRequests:          "gauge",
+		c.GenStatsSOAPSessionTerminateRequests:         "gauge",
+		c.GenStatsSOAPSQLRequests:                      "gauge",
+		c.GenStatsSOAPWSDLRequests:                     "gauge",
+		c.GenStatsSQLTraceIOProviderLockWaits:          "gauge",
+		c.GenStatsTempdbrecoveryunitid:                 "gauge",
+		c.GenStatsTempdbrowsetid:                       "gauge",
+		c.GenStatsTempTablesCreationRate:               "counter",
+		c.GenStatsTempTablesForDestruction:             "gauge",
+		c.GenStatsTraceEventNotificationQueue:          "gauge",
+		c.GenStatsTransactions:                         "gauge",
+		c.GenStatsUserConnections:                      "gauge",
+		c.LocksWaitTime:                                "gauge",
+		c.LocksCount:                                   "gauge",
+		c.LocksLockRequests:                            "counter",
+		c.LocksLockTimeouts:                            "counter",
+		c.LocksLockTimeoutstimeout0:                    "counter",
+		c.LocksLockWaits:                               "counter",
+		c.LocksLockWaitTimems:                          "gauge",
+		c.LocksNumberofDeadlocks:                       "counter",
+		c.MemMgrConnectionMemoryKB:                     "gauge",
+		c.MemMgrDatabaseCacheMemoryKB:                  "gauge",
+		c.MemMgrExternalbenefitofmemory:                "gauge",
+		c.MemMgrFreeMemoryKB:                           "gauge",
+		c.MemMgrGrantedWorkspaceMemoryKB:               "gauge",
+		c.MemMgrLockBlocks:                             "gauge",
+		c.MemMgrLockBlocksAllocated:                    "gauge",
+		c.MemMgrLockMemoryKB:                           "gauge",
+		c.MemMgrLockOwnerBlocks:                        "gauge",
+		c.MemMgrLockOwnerBlocksAllocated:               "gauge",
+		c.MemMgrLogPoolMemoryKB:                        "gauge",
+		c.MemMgrMaximumWorkspaceMemoryKB:               "gauge",
+		c.MemMgrMemoryGrantsOutstanding:                "gauge",
+		c.MemMgrMemoryGrantsPending:                    "gauge",
+		c.MemMgrOptimizerMemoryKB:                      "gauge",
+		c.MemMgrReservedServerMemoryKB:                 "gauge",
+		c.MemMgrSQLCacheMemoryKB:                       "gauge",
+		c.MemMgrStolenServerMemoryKB:                   "gauge",
+		c.MemMgrTargetServerMemoryKB:                   "gauge",
+		c.MemMgrTotalServerMemoryKB:                    "gauge",
+		c.SQLStatsAutoParamAttempts:                    "counter",
+		c.SQLStatsBatchRequests:                        "counter",
+		c.SQLStatsFailedAutoParams:                     "counter",
+		c.SQLStatsForcedParameterizations:              "counter",
+		c.SQLStatsGuidedplanexecutions:                 "counter",
+		c.SQLStatsMisguidedplanexecutions:              "counter",
+		c.SQLStatsSafeAutoParams:                       "counter",
+		c.SQLStatsSQLAttentionrate:                     "counter",
+		c.SQLStatsSQLCompilations:                      "counter",
+		c.SQLStatsSQLReCompilations:                    "counter",
+		c.SQLStatsUnsafeAutoParams:                     "counter",
+		c.SQLErrorsTotal:                               "counter",
+		c.TransactionsTempDbFreeSpaceBytes:             "gauge",
+		c.TransactionsLongestTransactionRunningSeconds: "gauge",
+		c.TransactionsNonSnapshotVersionActiveTotal:    "counter",
+		c.TransactionsSnapshotActiveTotal:              "counter",
+		c.TransactionsActive:                           "gauge",
+		c.TransactionsUpdateConflictsTotal:             "counter",
+		c.TransactionsUpdateSnapshotActiveTotal:        "counter",
+		c.TransactionsVersionCleanupRateBytes:          "gauge",
+		c.TransactionsVersionGenerationRateBytes:       "gauge",
+		c.TransactionsVersionStoreSizeBytes:            "gauge",
+		c.TransactionsVersionStoreUnits:                "counter",
+		c.TransactionsVersionStoreCreationUnits:        "counter",
+		c.TransactionsVersionStoreTruncationUnits:      "counter",
+		c.WaitStatsLockWaits:                           "counter",
+		c.WaitStatsMemoryGrantQueueWaits:               "counter",
+		c.WaitStatsThreadSafeMemoryObjectsWaits:        "counter",
+		c.WaitStatsLogWriteWaits:                       "counter",
+		c.WaitStatsLogBufferWaits:                      "counter",
+		c.WaitStatsNetworkIOWaits:                      "counter",
+		c.WaitStatsPageIOLatchWaits:                    "counter",
+		c.WaitStatsPageLatchWaits:                      "counter",
+		c.WaitStatsNonpageLatchWaits:                   "counter",
+		c.WaitStatsWaitForTheWorkerWaits:               "counter",
+		c.WaitStatsWorkspaceSynchronizationWaits:       "counter",
+		c.WaitStatsTransactionOwnershipWaits:           "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *MemoryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.AvailableBytes
+	ch <- c.CacheBytes
+	ch <- c.CacheBytesPeak
+	ch <- c.CacheFaultsTotal
+	ch <- c.CommitLimit
+	ch <- c.CommittedBytes
+	ch <- c.DemandZeroFaultsTotal
+	ch <- c.FreeAndZeroPageListBytes
+	ch <- c.FreeSystemPageTableEntries
+	ch <- c.ModifiedPageListBytes
+	ch <- c.PageFaultsTotal
+	ch <- c.SwapPageReadsTotal
+	ch <- c.SwapPagesReadTotal
+	ch <- c.SwapPagesWrittenTotal
+	ch <- c.SwapPageOperationsTotal
+	ch <- c.SwapPageWritesTotal
+	ch <- c.PoolNonpagedAllocsTotal
+	ch <- c.PoolNonpagedBytes
+	ch <- c.PoolPagedAllocsTotal
+	ch <- c.PoolPagedBytes
+	ch <- c.PoolPagedResidentBytes
+	ch <- c.StandbyCacheCoreBytes
+	ch <- c.StandbyCacheNormalPriorityBytes
+	ch <- c.StandbyCacheReserveBytes
+	ch <- c.SystemCacheResidentBytes
+	ch <- c.SystemCodeResidentBytes
+	ch <- c.SystemCodeTotalBytes
+	ch <- c.SystemDriverResidentBytes
+	ch <- c.SystemDriverTotalBytes
+	ch <- c.TransitionFaultsTotal
+	ch <- c.TransitionPagesRepurposedTotal
+	ch <- c.WriteCopiesTotal
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *MemoryCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.AvailableBytes:                  "gauge",
+		c.CacheBytes:                      "gauge",
+		c.CacheBytesPeak:                  "gauge",
+		c.CacheFaultsTotal:                "gauge",
+		c.CommitLimit:                     "gauge",
+		c.CommittedBytes:                  "gauge",
+		c.DemandZeroFaultsTotal:           "gauge",
+		c.FreeAndZeroPageListBytes:        "gauge",
+		c.FreeSystemPageTableEntries:      "gauge",
+		c.ModifiedPageListBytes:           "gauge",
+		c.PageFaultsTotal:                 "gauge",
+		c.SwapPageReadsTotal:              "gauge",
+		c.SwapPagesReadTotal:              "gauge",
+		c.SwapPagesWrittenTotal:           "gauge",
+		c.SwapPageOperationsTotal:         "gauge",
+		c.SwapPageWritesTotal:             "gauge",
+		c.PoolNonpagedAllocsTotal:         "gauge",
+		c.PoolNonpagedBytes:               "gauge",
+		c.PoolPagedAllocsTotal:            "gauge",
+		c.PoolPagedBytes:                  "gauge",
+		c.PoolPagedResidentBytes:          "gauge",
+		c.StandbyCacheCoreBytes:           "gauge",
+		c.StandbyCacheNormalPriorityBytes: "gauge",
+		c.StandbyCacheReserveBytes:        "gauge",
+		c.SystemCacheResidentBytes:        "gauge",
+		c.SystemCodeResidentBytes:         "gauge",
+		c.SystemCodeTotalBytes:            "gauge",
+		c.SystemDriverResidentBytes:       "gauge",
+		c.SystemDriverTotalBytes:          "gauge",
+		c.TransitionFaultsTotal:           "gauge",
+		c.TransitionPagesRepurposedTotal:  "gauge",
+		c.WriteCopiesTotal:                "gauge",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *NETFramework_NETCLRExceptionsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.NumberofExcepsThrown
+	ch <- c.NumberofFilters
+	ch <- c.NumberofFinallys
+	ch <- c.ThrowToCatchDepth
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *NETFramework_NETCLRExceptionsCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.NumberofExcepsThrown: "counter",
+		c.NumberofFilters:      "counter",
+		c.NumberofFinallys:     "counter",
+		c.ThrowToCatchDepth:    "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *NETFramework_NETCLRInteropCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.NumberofCCWs
+	ch <- c.Numberofmarshalling
+	ch <- c.NumberofStubs
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *NETFramework_NETCLRInteropCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.NumberofCCWs:        "counter",
+		c.Numberofmarshalling: "counter",
+		c.NumberofStubs:       "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *NETFramework_NETCLRJitCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.NumberofMethodsJitted
+	ch <- c.TimeinJit
+	ch <- c.StandardJitFailures
+	ch <- c.TotalNumberofILBytesJitted
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *NETFramework_NETCLRJitCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.NumberofMethodsJitted:      "counter",
+		c.TimeinJit:                  "gauge",
+		c.StandardJitFailures:        "gauge",
+		c.TotalNumberofILBytesJitted: "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *NETFramework_NETCLRLoadingCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.BytesinLoaderHeap
+	ch <- c.Currentappdomains
+	ch <- c.CurrentAssemblies
+	ch <- c.CurrentClassesLoaded
+	ch <- c.TotalAppdomains
+	ch <- c.Totalappdomainsunloaded
+	ch <- c.TotalAssemblies
+	ch <- c.TotalClassesLoaded
+	ch <- c.TotalNumberofLoadFailures
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *NETFramework_NETCLRLoadingCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.BytesinLoaderHeap:         "gauge",
+		c.Currentappdomains:         "gauge",
+		c.CurrentAssemblies:         "gauge",
+		c.CurrentClassesLoaded:      "gauge",
+		c.TotalAppdomains:           "counter",
+		c.Totalappdomainsunloaded:   "counter",
+		c.TotalAssemblies:           "counter",
+		c.TotalClassesLoaded:        "counter",
+		c.TotalNumberofLoadFailures: "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *NETFramework_NETCLRLocksAndThreadsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.CurrentQueueLength
+	ch <- c.NumberofcurrentlogicalThreads
+	ch <- c.NumberofcurrentphysicalThreads
+	ch <- c.Numberofcurrentrecognizedthreads
+	ch <- c.Numberoftotalrecognizedthreads
+	ch <- c.QueueLengthPeak
+	ch <- c.TotalNumberofContentions
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *NETFramework_NETCLRLocksAndThreadsCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.CurrentQueueLength:               "gauge",
+		c.NumberofcurrentlogicalThreads:    "gauge",
+		c.NumberofcurrentphysicalThreads:   "gauge",
+		c.Numberofcurrentrecognizedthreads: "gauge",
+		c.Numberoftotalrecognizedthreads:   "counter",
+		c.QueueLengthPeak:                  "counter",
+		c.TotalNumberofContentions:         "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *NETFramework_NETCLRMemoryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.AllocatedBytes
+	ch <- c.FinalizationSurvivors
+	ch <- c.HeapSize
+	ch <- c.PromotedBytes
+	ch <- c.NumberGCHandles
+	ch <- c.NumberCollections
+	ch <- c.NumberInducedGC
+	ch <- c.NumberofPinnedObjects
+	ch <- c.NumberofSinkBlocksinuse
+	ch <- c.NumberTotalCommittedBytes
+	ch <- c.NumberTotalreservedBytes
+	ch <- c.TimeinGC
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *NETFramework_NETCLRMemoryCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.AllocatedBytes:            "counter",
+		c.FinalizationSurvivors:     "gauge",
+		c.HeapSize:                  "gauge",
+		c.PromotedBytes:             "gauge",
+		c.NumberGCHandles:           "gauge",
+		c.NumberCollections:         "counter",
+		c.NumberInducedGC:           "counter",
+		c.NumberofPinnedObjects:     "gauge",
+		c.NumberofSinkBlocksinuse:   "gauge",
+		c.NumberTotalCommittedBytes: "gauge",
+		c.NumberTotalreservedBytes:  "gauge",
+		c.TimeinGC:                  "gauge",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *NETFramework_NETCLRRemotingCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Channels
+	ch <- c.ContextBoundClassesLoaded
+	ch <- c.ContextBoundObjects
+	ch <- c.ContextProxies
+	ch <- c.Contexts
+	ch <- c.TotalRemoteCalls
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *NETFramework_NETCLRRemotingCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.Channels:                  "counter",
+		c.ContextBoundClassesLoaded: "gauge",
+		c.ContextBoundObjects:       "counter",
+		c.ContextProxies:            "counter",
+		c.Contexts:                  "gauge",
+		c.TotalRemoteCalls:          "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *NETFramework_NETCLRSecurityCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.NumberLinkTimeChecks
+	ch <- c.TimeinRTchecks
+	ch <- c.StackWalkDepth
+	ch <- c.TotalRuntimeChecks
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *NETFramework_NETCLRSecurityCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.NumberLinkTimeChecks: "counter",
+		c.TimeinRTchecks:       "gauge",
+		c.StackWalkDepth:       "gauge",
+		c.TotalRuntimeChecks:   "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *NetworkCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.BytesReceivedTotal
+	ch <- c.BytesSentTotal
+	ch <- c.BytesTotal
+	ch <- c.PacketsOutboundDiscarded
+	ch <- c.PacketsOutboundErrors
+	ch <- c.PacketsTotal
+	ch <- c.PacketsReceivedDiscarded
+	ch <- c.PacketsReceivedErrors
+	ch <- c.PacketsReceivedTotal
+	ch <- c.PacketsReceivedUnknown
+	ch <- c.PacketsSentTotal
+	ch <- c.CurrentBandwidth
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *NetworkCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.BytesReceivedTotal:       "counter",
+		c.BytesSentTotal:           "counter",
+		c.BytesTotal:               "counter",
+		c.PacketsOutboundDiscarded: "counter",
+		c.PacketsOutboundErrors:    "counter",
+		c.PacketsTotal:             "counter",
+		c.PacketsReceivedDiscarded: "counter",
+		c.PacketsReceivedErrors:    "counter",
+		c.PacketsReceivedTotal:     "counter",
+		c.PacketsReceivedUnknown:   "counter",
+		c.PacketsSentTotal:         "counter",
+		c.CurrentBandwidth:         "gauge",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *OSCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.OSInformation
+	ch <- c.PhysicalMemoryFreeBytes
+	ch <- c.PagingFreeBytes
+	ch <- c.VirtualMemoryFreeBytes
+	ch <- c.ProcessesLimit
+	ch <- c.ProcessMemoryLimitBytes
+	ch <- c.Processes
+	ch <- c.Users
+	ch <- c.PagingLimitBytes
+	ch <- c.VirtualMemoryBytes
+	ch <- c.VisibleMemoryBytes
+	ch <- c.Time
+	ch <- c.Timezone
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *OSCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.OSInformation:           "gauge",
+		c.PhysicalMemoryFreeBytes: "gauge",
+		c.PagingFreeBytes:         "gauge",
+		c.VirtualMemoryFreeBytes:  "gauge",
+		c.ProcessesLimit:          "gauge",
+		c.ProcessMemoryLimitBytes: "gauge",
+		c.Processes:               "gauge",
+		c.Users:                   "gauge",
+		c.PagingLimitBytes:        "gauge",
+		c.VirtualMemoryBytes:      "gauge",
+		c.VisibleMemoryBytes:      "gauge",
+		c.Time:                    "gauge",
+		c.Timezone:                "gauge",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *RemoteFxCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.BaseTCPRTT
+	ch <- c.BaseUDPRTT
+	ch <- c.CurrentTCPBandwidth
+	ch <- c.CurrentTCPRTT
+	ch <- c.CurrentUDPBandwidth
+	ch <- c.CurrentUDPRTT
+	ch <- c.TotalReceivedBytes
+	ch <- c.TotalSentBytes
+	ch <- c.UDPPacketsReceivedPersec
+	ch <- c.UDPPacketsSentPersec
+	ch <- c.AverageEncodingTime
+	ch <- c.FrameQuality
+	ch <- c.FramesSkippedPerSecondInsufficientResources
+	ch <- c.GraphicsCompressionratio
+	ch <- c.InputFramesPerSecond
+	ch <- c.OutputFramesPerSecond
+	ch <- c.SourceFramesPerSecond
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *RemoteFxCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.BaseTCPRTT:               "gauge",
+		c.BaseUDPRTT:               "gauge",
+		c.CurrentTCPBandwidth:      "gauge",
+		c.CurrentTCPRTT:            "gauge",
+		c.CurrentUDPBandwidth:      "gauge",
+		c.CurrentUDPRTT:            "gauge",
+		c.TotalReceivedBytes:       "counter",
+		c.TotalSentBytes:           "counter",
+		c.UDPPacketsReceivedPersec: "counter",
+		c.UDPPacketsSentPersec:     "counter",
+		c.AverageEncodingTime:      "gauge",
+		c.FrameQuality:             "gauge",
+		c.FramesSkippedPerSecondInsufficientResources: "counter",
+		c.GraphicsCompressionratio:                    "gauge",
+		c.InputFramesPerSecond:                        "counter",
+		c.OutputFramesPerSecond:                       "counter",
+		c.SourceFramesPerSecond:                       "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *SMTPCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.BadmailedMessagesBadPickupFileTotal
+	ch <- c.BadmailedMessagesHopCountExceededTotal
+	ch <- c.BadmailedMessagesNDROfDSNTotal
+	ch <- c.BadmailedMessagesNoRecipientsTotal
+	ch <- c.BadmailedMessagesTriggeredViaEventTotal
+	ch <- c.BytesSentTotal
+	ch <- c.BytesReceivedTotal
+	ch <- c.CategorizerQueueLength
+	ch <- c.ConnectionErrorsTotal
+	ch <- c.CurrentMessagesInLocalDelivery
+	ch <- c.DirectoryDropsTotal
+	ch <- c.DNSQueriesTotal
+	ch <- c.DSNFailuresTotal
+	ch <- c.ETRNMessagesTotal
+	ch <- c.InboundConnectionsCurrent
+	ch <- c.InboundConnectionsTotal
+	ch <- c.LocalQueueLength
+	ch <- c.LocalRetryQueueLength
+	ch <- c.MailFilesOpen
+	ch <- c.MessageBytesReceivedTotal
+	ch <- c.MessageBytesSentTotal
+	ch <- c.MessageDeliveryRetriesTotal
+	ch <- c.MessageSendRetriesTotal
+	ch <- c.MessagesCurrentlyUndeliverable
+	ch <- c.MessagesDeliveredTotal
+	ch <- c.MessagesPendingRouting
+	ch <- c.MessagesReceivedTotal
+	ch <- c.MessagesRefusedForAddressObjectsTotal
+	ch <- c.MessagesRefusedForMailObjectsTotal
+	ch <- c.MessagesRefusedForSizeTotal
+	ch <- c.MessagesSentTotal
+	ch <- c.MessagesSubmittedTotal
+	ch <- c.NDRsGeneratedTotal
+	ch <- c.OutboundConnectionsCurrent
+	ch <- c.OutboundConnectionsRefusedTotal
+	ch <- c.OutboundConnectionsTotal
+	ch <- c.QueueFilesOpen
+	ch <- c.PickupDirectoryMessagesRetrievedTotal
+	ch <- c.RemoteQueueLength
+	ch <- c.RemoteRetryQueueLength
+	ch <- c.RoutingTableLookupsTotal
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *SMTPCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.BadmailedMessagesBadPickupFileTotal:     "counter",
+		c.BadmailedMessagesHopCountExceededTotal:  "counter",
+		c.BadmailedMessagesNDROfDSNTotal:          "counter",
+		c.BadmailedMessagesNoRecipientsTotal:      "counter",
+		c.BadmailedMessagesTriggeredViaEventTotal: "counter",
+		c.BytesSentTotal:                          "counter",
+		c.BytesReceivedTotal:                      "counter",
+		c.CategorizerQueueLength:                  "gauge",
+		c.ConnectionErrorsTotal:                   "counter",
+		c.CurrentMessagesInLocalDelivery:          "gauge",
+		c.DirectoryDropsTotal:                     "counter",
+		c.DNSQueriesTotal:                         "counter",
+		c.DSNFailuresTotal:                        "counter",
+		c.ETRNMessagesTotal:                       "counter",
+		c.InboundConnectionsCurrent:               "gauge",
+		c.InboundConnectionsTotal:                 "counter",
+		c.LocalQueueLength:                        "gauge",
+		c.LocalRetryQueueLength:                   "gauge",
+		c.MailFilesOpen:                           "gauge",
+		c.MessageBytesReceivedTotal:               "counter",
+		c.MessageBytesSentTotal:                   "counter",
+		c.MessageDeliveryRetriesTotal:             "counter",
+		c.MessageSendRetriesTotal:                 "counter",
+		c.MessagesCurrentlyUndeliverable:          "gauge",
+		c.MessagesDeliveredTotal:                  "counter",
+		c.MessagesPendingRouting:                  "gauge",
+		c.MessagesReceivedTotal:                   "counter",
+		c.MessagesRefusedForAddressObjectsTotal:   "counter",
+		c.MessagesRefusedForMailObjectsTotal:      "counter",
+		c.MessagesRefusedForSizeTotal:             "counter",
+		c.MessagesSentTotal:                       "counter",
+		c.MessagesSubmittedTotal:                  "counter",
+		c.NDRsGeneratedTotal:                      "counter",
+		c.OutboundConnectionsCurrent:              "gauge",
+		c.OutboundConnectionsRefusedTotal:         "counter",
+		c.OutboundConnectionsTotal:                "counter",
+		c.QueueFilesOpen:                          "gauge",
+		c.PickupDirectoryMessagesRetrievedTotal:   "counter",
+		c.RemoteQueueLength:                       "gauge",
+		c.RemoteRetryQueueLength:                  "gauge",
+		c.RoutingTableLookupsTotal:                "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *SystemCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.ContextSwitchesTotal
+	ch <- c.ExceptionDispatchesTotal
+	ch <- c.ProcessorQueueLength
+	ch <- c.SystemCallsTotal
+	ch <- c.SystemUpTime
+	ch <- c.Threads
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *SystemCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.ContextSwitchesTotal:     "counter",
+		c.ExceptionDispatchesTotal: "counter",
+		c.ProcessorQueueLength:     "gauge",
+		c.SystemCallsTotal:         "counter",
+		c.SystemUpTime:             "gauge",
+		c.Threads:                  "gauge",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *TCPCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.ConnectionFailures
+	ch <- c.ConnectionsActive
+	ch <- c.ConnectionsEstablished
+	ch <- c.ConnectionsPassive
+	ch <- c.ConnectionsReset
+	ch <- c.SegmentsTotal
+	ch <- c.SegmentsReceivedTotal
+	ch <- c.SegmentsRetransmittedTotal
+	ch <- c.SegmentsSentTotal
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *TCPCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.ConnectionFailures:         "counter",
+		c.ConnectionsActive:          "counter",
+		c.ConnectionsEstablished:     "gauge",
+		c.ConnectionsPassive:         "counter",
+		c.ConnectionsReset:           "counter",
+		c.SegmentsTotal:              "counter",
+		c.SegmentsReceivedTotal:      "counter",
+		c.SegmentsRetransmittedTotal: "counter",
+		c.SegmentsSentTotal:          "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *TerminalServicesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.LocalSessionCount
+	ch <- c.ConnectionBrokerPerformance
+	ch <- c.HandleCount
+	ch <- c.PageFaultsPersec
+	ch <- c.PageFileBytes
+	ch <- c.PageFileBytesPeak
+	ch <- c.PercentPrivilegedTime
+	ch <- c.PercentProcessorTime
+	ch <- c.PercentUserTime
+	ch <- c.PoolNonpagedBytes
+	ch <- c.PoolPagedBytes
+	ch <- c.PrivateBytes
+	ch <- c.ThreadCount
+	ch <- c.VirtualBytes
+	ch <- c.VirtualBytesPeak
+	ch <- c.WorkingSet
+	ch <- c.WorkingSetPeak
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *TerminalServicesCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.LocalSessionCount:           "gauge",
+		c.ConnectionBrokerPerformance: "counter",
+		c.HandleCount:                 "gauge",
+		c.PageFaultsPersec:            "counter",
+		c.PageFileBytes:               "gauge",
+		c.PageFileBytesPeak:           "gauge",
+		c.PercentPrivilegedTime:       "counter",
+		c.PercentProcessorTime:        "counter",
+		c.PercentUserTime:             "counter",
+		c.PoolNonpagedBytes:           "gauge",
+		c.PoolPagedBytes:              "gauge",
+		c.PrivateBytes:                "gauge",
+		c.ThreadCount:                 "gauge",
+		c.VirtualBytes:                "gauge",
+		c.VirtualBytesPeak:            "gauge",
+		c.WorkingSet:                  "gauge",
+		c.WorkingSetPeak:              "gauge",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *TimeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.ClockFrequencyAdjustmentPPBTotal
+	ch <- c.ComputedTimeOffset
+	ch <- c.NTPClientTimeSourceCount
+	ch <- c.NTPRoundtripDelay
+	ch <- c.NTPServerIncomingRequestsTotal
+	ch <- c.NTPServerOutgoingResponsesTotal
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *TimeCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.ClockFrequencyAdjustmentPPBTotal: "counter",
+		c.ComputedTimeOffset:               "gauge",
+		c.NTPClientTimeSourceCount:         "gauge",
+		c.NTPRoundtripDelay:                "gauge",
+		c.NTPServerIncomingRequestsTotal:   "counter",
+		c.NTPServerOutgoingResponsesTotal:  "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *VmwareCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.MemActive
+	ch <- c.MemBallooned
+	ch <- c.MemLimit
+	ch <- c.MemMapped
+	ch <- c.MemOverhead
+	ch <- c.MemReservation
+	ch <- c.MemShared
+	ch <- c.MemSharedSaved
+	ch <- c.MemShares
+	ch <- c.MemSwapped
+	ch <- c.MemTargetSize
+	ch <- c.MemUsed
+	ch <- c.CpuLimitMHz
+	ch <- c.CpuReservationMHz
+	ch <- c.CpuShares
+	ch <- c.CpuStolenTotal
+	ch <- c.CpuTimeTotal
+	ch <- c.EffectiveVMSpeedMHz
+	ch <- c.HostProcessorSpeedMHz
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *VmwareCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.MemActive:             "gauge",
+		c.MemBallooned:          "gauge",
+		c.MemLimit:              "gauge",
+		c.MemMapped:             "gauge",
+		c.MemOverhead:           "gauge",
+		c.MemReservation:        "gauge",
+		c.MemShared:             "gauge",
+		c.MemSharedSaved:        "gauge",
+		c.MemShares:             "gauge",
+		c.MemSwapped:            "gauge",
+		c.MemTargetSize:         "gauge",
+		c.MemUsed:               "gauge",
+		c.CpuLimitMHz:           "gauge",
+		c.CpuReservationMHz:     "gauge",
+		c.CpuShares:             "gauge",
+		c.CpuStolenTotal:        "counter",
+		c.CpuTimeTotal:          "counter",
+		c.EffectiveVMSpeedMHz:   "gauge",
+		c.HostProcessorSpeedMHz: "gauge",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *Win32_PerfRawData_MSMQ_MSMQQueueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.BytesinJournalQueue
+	ch <- c.BytesinQueue
+	ch <- c.MessagesinJournalQueue
+	ch <- c.MessagesinQueue
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *Win32_PerfRawData_MSMQ_MSMQQueueCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.BytesinJournalQueue:    "gauge",
+		c.BytesinQueue:           "gauge",
+		c.MessagesinJournalQueue: "gauge",
+		c.MessagesinQueue:        "gauge",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *adfsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.adLoginConnectionFailures
+	ch <- c.certificateAuthentications
+	ch <- c.deviceAuthentications
+	ch <- c.extranetAccountLockouts
+	ch <- c.federatedAuthentications
+	ch <- c.passportAuthentications
+	ch <- c.passiveRequests
+	ch <- c.passwordChangeFailed
+	ch <- c.passwordChangeSucceeded
+	ch <- c.tokenRequests
+	ch <- c.windowsIntegratedAuthentications
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *adfsCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.adLoginConnectionFailures:        "counter",
+		c.certificateAuthentications:       "counter",
+		c.deviceAuthentications:            "counter",
+		c.extranetAccountLockouts:          "counter",
+		c.federatedAuthentications:         "counter",
+		c.passportAuthentications:          "counter",
+		c.passiveRequests:                  "counter",
+		c.passwordChangeFailed:             "counter",
+		c.passwordChangeSucceeded:          "counter",
+		c.tokenRequests:                    "counter",
+		c.windowsIntegratedAuthentications: "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *cpuCollectorBasic) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.CStateSecondsTotal
+	ch <- c.TimeTotal
+	ch <- c.InterruptsTotal
+	ch <- c.DPCsTotal
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *cpuCollectorBasic) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.CStateSecondsTotal: "counter",
+		c.TimeTotal:          "counter",
+		c.InterruptsTotal:    "counter",
+		c.DPCsTotal:          "counter",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *cpuCollectorFull) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.CStateSecondsTotal
+	ch <- c.TimeTotal
+	ch <- c.InterruptsTotal
+	ch <- c.DPCsTotal
+	ch <- c.ClockInterruptsTotal
+	ch <- c.IdleBreakEventsTotal
+	ch <- c.ParkingStatus
+	ch <- c.ProcessorFrequencyMHz
+	ch <- c.ProcessorPerformance
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *cpuCollectorFull) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.CStateSecondsTotal:    "counter",
+		c.TimeTotal:             "counter",
+		c.InterruptsTotal:       "counter",
+		c.DPCsTotal:             "counter",
+		c.ClockInterruptsTotal:  "counter",
+		c.IdleBreakEventsTotal:  "counter",
+		c.ParkingStatus:         "gauge",
+		c.ProcessorFrequencyMHz: "gauge",
+		c.ProcessorPerformance:  "gauge",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *exchangeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.LDAPReadTime
+	ch <- c.LDAPSearchTime
+	ch <- c.LDAPWriteTime
+	ch <- c.LDAPTimeoutErrorsPerSec
+	ch <- c.LongRunningLDAPOperationsPerMin
+	ch <- c.ExternalActiveRemoteDeliveryQueueLength
+	ch <- c.InternalActiveRemoteDeliveryQueueLength
+	ch <- c.ActiveMailboxDeliveryQueueLength
+	ch <- c.RetryMailboxDeliveryQueueLength
+	ch <- c.UnreachableQueueLength
+	ch <- c.ExternalLargestDeliveryQueueLength
+	ch <- c.InternalLargestDeliveryQueueLength
+	ch <- c.PoisonQueueLength
+	ch <- c.MailboxServerLocatorAverageLatency
+	ch <- c.AverageAuthenticationLatency
+	ch <- c.AverageCASProcessingLatency
+	ch <- c.MailboxServerProxyFailureRate
+	ch <- c.OutstandingProxyRequests
+	ch <- c.ProxyRequestsPerSec
+	ch <- c.ActiveSyncRequestsPerSec
+	ch <- c.PingCommandsPending
+	ch <- c.SyncCommandsPerSec
+	ch <- c.AvailabilityRequestsSec
+	ch <- c.CurrentUniqueUsers
+	ch <- c.OWARequestsPerSec
+	ch <- c.AutodiscoverRequestsPerSec
+	ch <- c.ActiveTasks
+	ch <- c.CompletedTasks
+	ch <- c.QueuedTasks
+	ch <- c.YieldedTasks
+	ch <- c.IsActive
+	ch <- c.RPCAveragedLatency
+	ch <- c.RPCRequests
+	ch <- c.ActiveUserCount
+	ch <- c.ConnectionCount
+	ch <- c.RPCOperationsPerSec
+	ch <- c.UserCount
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *exchangeCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.LDAPReadTime:                            "counter",
+		c.LDAPSearchTime:                          "counter",
+		c.LDAPWriteTime:                           "counter",
+		c.LDAPTimeoutErrorsPerSec:                 "counter",
+		c.LongRunningLDAPOperationsPerMin:         "counter",
+		c.ExternalActiveRemoteDeliveryQueueLength: "gauge",
+		c.InternalActiveRemoteDeliveryQueueLength: "gauge",
+		c.ActiveMailboxDeliveryQueueLength:        "gauge",
+		c.RetryMailboxDeliveryQueueLength:         "gauge",
+		c.UnreachableQueueLength:                  "gauge",
+		c.ExternalLargestDeliveryQueueLength:      "gauge",
+		c.InternalLargestDeliveryQueueLength:      "gauge",
+		c.PoisonQueueLength:                       "gauge",
+		c.MailboxServerLocatorAverageLatency:      "gauge",
+		c.AverageAuthenticationLatency:            "gauge",
+		c.AverageCASProcessingLatency:             "gauge",
+		c.MailboxServerProxyFailureRate:           "gauge",
+		c.OutstandingProxyRequests:                "gauge",
+		c.ProxyRequestsPerSec:                     "counter",
+		c.ActiveSyncRequestsPerSec:                "counter",
+		c.PingCommandsPending:                     "gauge",
+		c.SyncCommandsPerSec:                      "counter",
+		c.AvailabilityRequestsSec:                 "counter",
+		c.CurrentUniqueUsers:                      "gauge",
+		c.OWARequestsPerSec:                       "counter",
+		c.AutodiscoverRequestsPerSec:              "counter",
+		c.ActiveTasks:                             "gauge",
+		c.CompletedTasks:                          "counter",
+		c.QueuedTasks:                             "counter",
+		c.YieldedTasks:                            "counter",
+		c.IsActive:                                "gauge",
+		c.RPCAveragedLatency:                      "gauge",
+		c.RPCRequests:                             "gauge",
+		c.ActiveUserCount:                         "gauge",
+		c.ConnectionCount:                         "gauge",
+		c.RPCOperationsPerSec:                     "counter",
+		c.UserCount:                               "gauge",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *processCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.StartTime
+	ch <- c.CPUTimeTotal
+	ch <- c.HandleCount
+	ch <- c.IOBytesTotal
+	ch <- c.IOOperationsTotal
+	ch <- c.PageFaultsTotal
+	ch <- c.PageFileBytes
+	ch <- c.PoolBytes
+	ch <- c.PriorityBase
+	ch <- c.PrivateBytes
+	ch <- c.ThreadCount
+	ch <- c.VirtualBytes
+	ch <- c.WorkingSetPrivate
+	ch <- c.WorkingSetPeak
+	ch <- c.WorkingSet
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *processCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.StartTime:         "gauge",
+		c.CPUTimeTotal:      "counter",
+		c.HandleCount:       "gauge",
+		c.IOBytesTotal:      "counter",
+		c.IOOperationsTotal: "counter",
+		c.PageFaultsTotal:   "counter",
+		c.PageFileBytes:     "gauge",
+		c.PoolBytes:         "gauge",
+		c.PriorityBase:      "gauge",
+		c.PrivateBytes:      "gauge",
+		c.ThreadCount:       "gauge",
+		c.VirtualBytes:      "gauge",
+		c.WorkingSetPrivate: "gauge",
+		c.WorkingSetPeak:    "gauge",
+		c.WorkingSet:        "gauge",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *serviceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Information
+	ch <- c.State
+	ch <- c.StartMode
+	ch <- c.Status
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *serviceCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.Information: "gauge",
+		c.State:       "gauge",
+		c.StartMode:   "gauge",
+		c.Status:      "gauge",
+	}
+}
+
+// Describe sends the descriptors of the metrics exposed by the collector.
+func (c *thermalZoneCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.PercentPassiveLimit
+	ch <- c.Temperature
+	ch <- c.ThrottleReasons
+}
+
+// metricTypes returns the type of the metrics exposed for each descriptor.
+func (c *thermalZoneCollector) metricTypes() map[*prometheus.Desc]string {
+	return map[*prometheus.Desc]string{
+		c.PercentPassiveLimit: "gauge",
+		c.Temperature:         "gauge",
+		c.ThrottleReasons:     "gauge",
+	}
+}
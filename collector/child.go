@@ -0,0 +1,201 @@
+// +build windows
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var (
+	childScrapeDurationDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "exporter", "child_collector_duration_seconds"),
+		"windows_exporter: Duration of a child collection.",
+		[]string{"collector", "child"},
+		nil,
+	)
+	childScrapeSuccessDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "exporter", "child_collector_success"),
+		"windows_exporter: Whether the child collector was successful.",
+		[]string{"collector", "child"},
+		nil,
+	)
+)
+
+// childCollector is a part of a collector, usually covering a single perflib
+// object or WMI class, which can be enabled and disabled on its own.
+type childCollector struct {
+	name string
+	// Shown by --collectors.<collector>.list, e.g. the perflib object or
+	// WMI class the child collector reads.
+	description string
+	collect     func(ctx *ScrapeContext, ch chan<- prometheus.Metric) error
+}
+
+// childCollectorFlags holds the flags selecting the child collectors of a
+// collector.
+type childCollectorFlags struct {
+	collector string
+	available []string
+
+	enabled *string
+	list    *bool
+
+	deprecatedEnabledName string
+	deprecatedListName    string
+	deprecatedEnabled     *string
+	deprecatedList        *bool
+}
+
+// registerChildCollectorFlags registers the --collectors.<collector>.enabled
+// and --collectors.<collector>.list flags on the default kingpin application.
+// All available child collectors are enabled by default.
+func registerChildCollectorFlags(collector string, available ...string) *childCollectorFlags {
+	f := &childCollectorFlags{
+		collector: collector,
+		available: available,
+	}
+	f.enabled = kingpin.Flag(
+		"collectors."+collector+".enabled",
+		fmt.Sprintf("Comma-separated list of %s child collectors to use. Defaults to all, if not specified.", collector),
+	).Default(strings.Join(available, ",")).String()
+	f.list = kingpin.Flag(
+		"collectors."+collector+".list",
+		fmt.Sprintf("If true, print available %s child collectors and exit. Only displays if the %s collector is enabled.", collector, collector),
+	).Bool()
+	return f
+}
+
+// withDeprecatedFlags registers hidden aliases for the flags previously used
+// by the collector to select and list its child collectors.
+func (f *childCollectorFlags) withDeprecatedFlags(enabledName, listName string) *childCollectorFlags {
+	f.deprecatedEnabledName = enabledName
+	f.deprecatedEnabled = kingpin.Flag(enabledName, fmt.Sprintf("DEPRECATED: Use --collectors.%s.enabled.", f.collector)).Hidden().String()
+	if listName != "" {
+		f.deprecatedListName = listName
+		f.deprecatedList = kingpin.Flag(listName, fmt.Sprintf("DEPRECATED: Use --collectors.%s.list.", f.collector)).Hidden().Bool()
+	}
+	return f
+}
+
+// enabledNames returns the names of the enabled child collectors, in the
+// order they are available in.
+func (f *childCollectorFlags) enabledNames() ([]string, error) {
+	enabled := *f.enabled
+	if f.deprecatedEnabled != nil && *f.deprecatedEnabled != "" {
+		log.With("flag", f.deprecatedEnabledName, "replacement", "collectors."+f.collector+".enabled").Warn("The flag is deprecated, use its replacement instead")
+		enabled = *f.deprecatedEnabled
+	}
+	if enabled == "" {
+		return f.available, nil
+	}
+
+	requested := expandEnabledChildCollectors(enabled)
+	for _, name := range requested {
+		if !find(f.available, name) {
+			return nil, fmt.Errorf("unknown %s child collector %q, available are: %s", f.collector, name, strings.Join(f.available, ", "))
+		}
+	}
+	names := make([]string, 0, len(requested))
+	for _, name := range f.available {
+		if find(requested, name) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// build returns the enabled child collectors. If the listing flag is set, the
+// available child collectors are printed and the program exits.
+func (f *childCollectorFlags) build(children []childCollector) (*childCollectors, error) {
+	if *f.list || (f.deprecatedList != nil && *f.deprecatedList) {
+		fmt.Printf("%-24s %s\n", "Child collector", "Description")
+		for _, child := range children {
+			fmt.Printf("%-24s %s\n", child.name, child.description)
+		}
+		os.Exit(0)
+	}
+
+	names, err := f.enabledNames()
+	if err != nil {
+		return nil, err
+	}
+	c := &childCollectors{collector: f.collector}
+	for _, child := range children {
+		if find(names, child.name) {
+			c.enabled = append(c.enabled, child)
+		}
+	}
+	return c, nil
+}
+
+// childCollectors runs the enabled child collectors of a collector.
+type childCollectors struct {
+	collector string
+	enabled   []childCollector
+}
+
+// collect runs all enabled child collectors concurrently, and reports the
+// duration and success of each. An error is returned if any of them failed.
+func (c *childCollectors) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
+	var (
+		wg       sync.WaitGroup
+		mtx      sync.Mutex
+		failures []string
+	)
+	wg.Add(len(c.enabled))
+	for _, child := range c.enabled {
+		go func(child childCollector) {
+			defer wg.Done()
+			if !c.execute(ctx, child, ch) {
+				mtx.Lock()
+				failures = append(failures, child.name)
+				mtx.Unlock()
+			}
+		}(child)
+	}
+	wg.Wait()
+
+	if len(failures) > 0 {
+		return errors.New("child collectors failed: " + strings.Join(failures, ", "))
+	}
+	return nil
+}
+
+func (c *childCollectors) execute(ctx *ScrapeContext, child childCollector, ch chan<- prometheus.Metric) bool {
+	begin := time.Now()
+	err := child.collect(ctx, ch)
+	duration := time.Since(begin).Seconds()
+
+	var success float64
+	l := log.With("collector", c.collector, "child", child.name, "duration", duration)
+	key := c.collector + "/" + child.name
+	if err != nil {
+		log.CollectorErrors.Error(key, l, "Child collector failed", err)
+	} else {
+		log.CollectorErrors.Success(key, l)
+		l.Debug("Child collector succeeded")
+		success = 1
+	}
+	ch <- prometheus.MustNewConstMetric(
+		childScrapeDurationDesc,
+		prometheus.GaugeValue,
+		duration,
+		c.collector, child.name,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		childScrapeSuccessDesc,
+		prometheus.GaugeValue,
+		success,
+		c.collector, child.name,
+	)
+	return err == nil
+}
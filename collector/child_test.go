@@ -0,0 +1,93 @@
+package collector
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestChildCollectorFlagsEnabledNames(t *testing.T) {
+	available := []string{"connection", "folder", "volume"}
+	cases := []struct {
+		name       string
+		enabled    string
+		deprecated string
+		expected   []string
+		err        bool
+	}{
+		{"default", "connection,folder,volume", "", available, false},
+		{"empty", "", "", available, false},
+		{"available order", "volume,connection", "", []string{"connection", "volume"}, false},
+		{"duplicates", "folder,folder", "", []string{"folder"}, false},
+		{"deprecated flag wins", "connection,folder,volume", "volume", []string{"volume"}, false},
+		{"unknown", "connection,share", "", nil, true},
+	}
+
+	for _, c := range cases {
+		enabled, deprecated := c.enabled, c.deprecated
+		f := &childCollectorFlags{
+			collector:             "dfsr",
+			available:             available,
+			enabled:               &enabled,
+			deprecatedEnabledName: "collectors.dfsr.sources-enabled",
+			deprecatedEnabled:     &deprecated,
+		}
+		got, err := f.enabledNames()
+		if c.err {
+			if err == nil {
+				t.Errorf("%s: expected an error, but got %v", c.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, got)
+		}
+	}
+}
+
+func TestChildCollectorsCollect(t *testing.T) {
+	c := &childCollectors{
+		collector: "test",
+		enabled: []childCollector{
+			{name: "ok", collect: func(*ScrapeContext, chan<- prometheus.Metric) error { return nil }},
+			{name: "broken", collect: func(*ScrapeContext, chan<- prometheus.Metric) error { return errors.New("broken") }},
+		},
+	}
+
+	ch := make(chan prometheus.Metric, 10)
+	err := c.collect(&ScrapeContext{}, ch)
+	close(ch)
+	if err == nil || err.Error() != "child collectors failed: broken" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	success := map[string]float64{}
+	for m := range ch {
+		if m.Desc() != childScrapeSuccessDesc {
+			continue
+		}
+		var pb dto.Metric
+		if err := m.Write(&pb); err != nil {
+			t.Fatal(err)
+		}
+		labels := map[string]string{}
+		for _, l := range pb.GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		if labels["collector"] != "test" {
+			t.Errorf("unexpected collector label %q", labels["collector"])
+		}
+		success[labels["child"]] = pb.GetGauge().GetValue()
+	}
+	expected := map[string]float64{"ok": 1, "broken": 0}
+	if !reflect.DeepEqual(success, expected) {
+		t.Errorf("expected %v, got %v", expected, success)
+	}
+}
@@ -27,25 +27,25 @@ const (
 func getWindowsVersion() float64 {
 	k, err := registry.OpenKey(registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, registry.QUERY_VALUE)
 	if err != nil {
-		log.Warn("Couldn't open registry", err)
+		log.WithError(err).Warn("Couldn't open registry to determine current Windows version")
 		return 0
 	}
 	defer func() {
 		err = k.Close()
 		if err != nil {
-			log.Warnf("Failed to close registry key: %v", err)
+			log.WithError(err).Warn("Failed to close registry key")
 		}
 	}()
 
 	currentv, _, err := k.GetStringValue("CurrentVersion")
 	if err != nil {
-		log.Warn("Couldn't open registry to determine current Windows version:", err)
+		log.WithError(err).Warn("Couldn't open registry to determine current Windows version")
 		return 0
 	}
 
 	currentv_flt, err := strconv.ParseFloat(currentv, 64)
 
-	log.Debugf("Detected Windows version %f\n", currentv_flt)
+	log.With("version", currentv_flt).Debug("Detected Windows version")
 
 	return currentv_flt
 }
@@ -55,6 +55,8 @@ type collectorBuilder func() (Collector, error)
 var (
 	builders                = make(map[string]collectorBuilder)
 	perfCounterDependencies = make(map[string]string)
+	unresolvedPerfObjects   = make(map[string][]string)
+	perfObjectsByCollector  = make(map[string][]string)
 )
 
 func registerCollector(name string, builder collectorBuilder, perfCounterNames ...string) {
@@ -64,10 +66,28 @@ func registerCollector(name string, builder collectorBuilder, perfCounterNames .
 
 func addPerfCounterDependencies(name string, perfCounterNames []string) {
 	perfIndicies := make([]string, 0, len(perfCounterNames))
+	objects := make([]string, 0, len(perfCounterNames))
+	var unresolved []string
 	for _, cn := range perfCounterNames {
-		perfIndicies = append(perfIndicies, MapCounterToIndex(cn))
+		index, ok := perflibNames.lookupIndex(cn)
+		if !ok {
+			unresolved = append(unresolved, cn)
+			continue
+		}
+		perfObjectNames[index] = cn
+		perfIndicies = append(perfIndicies, strconv.Itoa(int(index)))
+		objects = append(objects, cn)
 	}
 	perfCounterDependencies[name] = strings.Join(perfIndicies, " ")
+	perfObjectsByCollector[name] = objects
+	unresolvedPerfObjects[name] = unresolved
+}
+
+// UnresolvedPerfObjects returns the names of the perflib objects the collector
+// depends on which were not found in the perflib name tables. The metrics
+// read from them are missing.
+func UnresolvedPerfObjects(collector string) []string {
+	return unresolvedPerfObjects[collector]
 }
 
 func Available() []string {
@@ -77,13 +97,58 @@ func Available() []string {
 	}
 	return cs
 }
+
+// FlagCollector returns the collector reading a flag, which is named
+// collector.<collector>.* or collectors.<collector>.*, or "" if the flag is
+// not specific to a collector.
+func FlagCollector(flag string) string {
+	parts := strings.SplitN(flag, ".", 3)
+	if len(parts) != 3 || (parts[0] != "collector" && parts[0] != "collectors") {
+		return ""
+	}
+	if _, ok := builders[parts[1]]; !ok {
+		return ""
+	}
+	return parts[1]
+}
 func Build(collector string) (Collector, error) {
 	builder, exists := builders[collector]
 	if !exists {
 		return nil, fmt.Errorf("Unknown collector %q", collector)
 	}
-	return builder()
+	c, err := builder()
+	if err != nil {
+		return nil, err
+	}
+	if unresolved := unresolvedPerfObjects[collector]; len(unresolved) > 0 {
+		log.With("collector", collector, "perf_object", strings.Join(unresolved, ",")).Warn("Perflib objects used by the collector were not found, their metrics are missing")
+	}
+	return c, nil
+}
+
+// queryError is the error of one of the perflib objects or WMI classes a
+// collector queries, logged with the name of the query.
+type queryError struct {
+	query string
+	err   error
+}
+
+func (e *queryError) Error() string { return e.err.Error() }
+func (e *queryError) Unwrap() error { return e.err }
+
+// LogFields returns the fields log.WithError adds to the log entry.
+func (e *queryError) LogFields() []interface{} {
+	return []interface{}{"query", e.query}
 }
+
+// withQuery returns err annotated with the query which failed, or nil.
+func withQuery(query string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &queryError{query: query, err: err}
+}
+
 func getPerfQuery(collectors []string) string {
 	parts := make([]string, 0, len(collectors))
 	for _, c := range collectors {
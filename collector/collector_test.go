@@ -35,6 +35,22 @@ func TestExpandChildCollectors(t *testing.T) {
 	}
 }
 
+func TestFlagCollector(t *testing.T) {
+	for flag, expected := range map[string]string{
+		"collector.process.include":  "process",
+		"collector.iis.site-include": "iis",
+		"collectors.mssql.enabled":   "mssql",
+		"collector.unknown.include":  "",
+		"collectors.enabled":         "",
+		"wmi.max-concurrent-queries": "",
+		"telemetry.addr":             "",
+	} {
+		if got := FlagCollector(flag); got != expected {
+			t.Errorf("%s: expected %q, got %q", flag, expected, got)
+		}
+	}
+}
+
 func benchmarkCollector(b *testing.B, name string, collectFunc func() (Collector, error)) {
 	// Create perflib scrape context. Some perflib collectors required a correct context,
 	// or will fail during benchmark.
@@ -132,8 +132,7 @@ func NewContainerMetricsCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *ContainerMetricsCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting ContainerMetricsCollector metrics:", desc, err)
+	if _, err := c.collect(ch); err != nil {
 		return err
 	}
 	return nil
@@ -143,7 +142,7 @@ func (c *ContainerMetricsCollector) Collect(ctx *ScrapeContext, ch chan<- promet
 func containerClose(c hcsshim.Container) {
 	err := c.Close()
 	if err != nil {
-		log.Error(err)
+		log.WithError(err).Error("Failed to close HCS container")
 	}
 }
 
@@ -152,7 +151,7 @@ func (c *ContainerMetricsCollector) collect(ch chan<- prometheus.Metric) (*prome
 	// Types Container is passed to get the containers compute systems only
 	containers, err := hcsshim.GetContainers(hcsshim.ComputeSystemQuery{Types: []string{"Container"}})
 	if err != nil {
-		log.Error("Err in Getting containers:", err)
+		log.WithError(err).Error("Failed to get containers")
 		return nil, err
 	}
 
@@ -173,13 +172,13 @@ func (c *ContainerMetricsCollector) collect(ch chan<- prometheus.Metric) (*prome
 			defer containerClose(container)
 		}
 		if err != nil {
-			log.Error("err in opening container: ", containerDetails.ID, err)
+			log.With("container_id", containerDetails.ID).WithError(err).Error("Failed to open container")
 			continue
 		}
 
 		cstats, err := container.Statistics()
 		if err != nil {
-			log.Error("err in fetching container Statistics: ", containerDetails.ID, err)
+			log.With("container_id", containerDetails.ID).WithError(err).Error("Failed to fetch container statistics")
 			continue
 		}
 		containerIdWithPrefix := getContainerIdWithPrefix(containerDetails)
@@ -228,7 +227,7 @@ func (c *ContainerMetricsCollector) collect(ch chan<- prometheus.Metric) (*prome
 		)
 
 		if len(cstats.Network) == 0 {
-			log.Info("No Network Stats for container: ", containerDetails.ID)
+			log.With("container_id", containerDetails.ID).Info("No network statistics for container")
 			continue
 		}
 
@@ -157,77 +157,88 @@ type perflibProcessor struct {
 
 func (c *cpuCollectorBasic) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
 	data := make([]perflibProcessor, 0)
-	err := unmarshalObject(ctx.perfObjects["Processor"], &data)
+	present, err := unmarshalObject(ctx.perfObjects["Processor"], &data)
 	if err != nil {
 		return err
 	}
 
-	for _, cpu := range data {
+	for i := range data {
+		cpu := &data[i]
 		if strings.Contains(strings.ToLower(cpu.Name), "_total") {
 			continue
 		}
 		core := cpu.Name
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.CStateSecondsTotal,
 			prometheus.CounterValue,
-			cpu.PercentC1Time,
+			&cpu.PercentC1Time,
 			core, "c1",
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.CStateSecondsTotal,
 			prometheus.CounterValue,
-			cpu.PercentC2Time,
+			&cpu.PercentC2Time,
 			core, "c2",
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.CStateSecondsTotal,
 			prometheus.CounterValue,
-			cpu.PercentC3Time,
+			&cpu.PercentC3Time,
 			core, "c3",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TimeTotal,
 			prometheus.CounterValue,
-			cpu.PercentIdleTime,
+			&cpu.PercentIdleTime,
 			core, "idle",
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TimeTotal,
 			prometheus.CounterValue,
-			cpu.PercentInterruptTime,
+			&cpu.PercentInterruptTime,
 			core, "interrupt",
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TimeTotal,
 			prometheus.CounterValue,
-			cpu.PercentDPCTime,
+			&cpu.PercentDPCTime,
 			core, "dpc",
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TimeTotal,
 			prometheus.CounterValue,
-			cpu.PercentPrivilegedTime,
+			&cpu.PercentPrivilegedTime,
 			core, "privileged",
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TimeTotal,
 			prometheus.CounterValue,
-			cpu.PercentUserTime,
+			&cpu.PercentUserTime,
 			core, "user",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.InterruptsTotal,
 			prometheus.CounterValue,
-			cpu.Interrupts,
+			&cpu.Interrupts,
 			core,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DPCsTotal,
 			prometheus.CounterValue,
-			cpu.DPCsQueued,
+			&cpu.DPCsQueued,
 			core,
 		)
 	}
@@ -254,119 +265,135 @@ type perflibProcessorInformation struct {
 	PerformanceLimitPercent  float64 `perflib:"% Performance Limit"`
 	PriorityTimeSeconds      float64 `perflib:"% Priority Time"`
 	PrivilegedTimeSeconds    float64 `perflib:"% Privileged Time"`
-	PrivilegedUtilitySeconds float64 `perflib:"% Privileged Utility"`
+	PrivilegedUtilitySeconds float64 `perflib:"% Privileged Utility,optional"`
 	ProcessorFrequencyMHz    float64 `perflib:"Processor Frequency"`
-	ProcessorPerformance     float64 `perflib:"% Processor Performance"`
+	ProcessorPerformance     float64 `perflib:"% Processor Performance,optional"`
 	ProcessorTimeSeconds     float64 `perflib:"% Processor Time"`
-	ProcessorUtilityRate     float64 `perflib:"% Processor Utility"`
+	ProcessorUtilityRate     float64 `perflib:"% Processor Utility,optional"`
 	UserTimeSeconds          float64 `perflib:"% User Time"`
 }
 
 func (c *cpuCollectorFull) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
 	data := make([]perflibProcessorInformation, 0)
-	err := unmarshalObject(ctx.perfObjects["Processor Information"], &data)
+	present, err := unmarshalObject(ctx.perfObjects["Processor Information"], &data)
 	if err != nil {
 		return err
 	}
-
-	for _, cpu := range data {
+	for i := range data {
+		cpu := &data[i]
 		if strings.Contains(strings.ToLower(cpu.Name), "_total") {
 			continue
 		}
 		core := cpu.Name
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.CStateSecondsTotal,
 			prometheus.CounterValue,
-			cpu.C1TimeSeconds,
+			&cpu.C1TimeSeconds,
 			core, "c1",
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.CStateSecondsTotal,
 			prometheus.CounterValue,
-			cpu.C2TimeSeconds,
+			&cpu.C2TimeSeconds,
 			core, "c2",
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.CStateSecondsTotal,
 			prometheus.CounterValue,
-			cpu.C3TimeSeconds,
+			&cpu.C3TimeSeconds,
 			core, "c3",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TimeTotal,
 			prometheus.CounterValue,
-			cpu.IdleTimeSeconds,
+			&cpu.IdleTimeSeconds,
 			core, "idle",
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TimeTotal,
 			prometheus.CounterValue,
-			cpu.InterruptTimeSeconds,
+			&cpu.InterruptTimeSeconds,
 			core, "interrupt",
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TimeTotal,
 			prometheus.CounterValue,
-			cpu.DPCTimeSeconds,
+			&cpu.DPCTimeSeconds,
 			core, "dpc",
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TimeTotal,
 			prometheus.CounterValue,
-			cpu.PrivilegedTimeSeconds,
+			&cpu.PrivilegedTimeSeconds,
 			core, "privileged",
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TimeTotal,
 			prometheus.CounterValue,
-			cpu.UserTimeSeconds,
+			&cpu.UserTimeSeconds,
 			core, "user",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.InterruptsTotal,
 			prometheus.CounterValue,
-			cpu.InterruptsTotal,
+			&cpu.InterruptsTotal,
 			core,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DPCsTotal,
 			prometheus.CounterValue,
-			cpu.DPCsQueuedTotal,
+			&cpu.DPCsQueuedTotal,
 			core,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ClockInterruptsTotal,
 			prometheus.CounterValue,
-			cpu.ClockInterruptsTotal,
+			&cpu.ClockInterruptsTotal,
 			core,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.IdleBreakEventsTotal,
 			prometheus.CounterValue,
-			cpu.IdleBreakEventsTotal,
+			&cpu.IdleBreakEventsTotal,
 			core,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ParkingStatus,
 			prometheus.GaugeValue,
-			cpu.ParkingStatus,
+			&cpu.ParkingStatus,
 			core,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ProcessorFrequencyMHz,
 			prometheus.GaugeValue,
-			cpu.ProcessorFrequencyMHz,
+			&cpu.ProcessorFrequencyMHz,
 			core,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		// Not available on all processors, e.g. in some virtual machines.
+		present.emit(
+			ch,
 			c.ProcessorPerformance,
 			prometheus.GaugeValue,
-			cpu.ProcessorPerformance,
+			&cpu.ProcessorPerformance,
 			core,
 		)
 	}
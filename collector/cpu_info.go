@@ -6,9 +6,8 @@ import (
 	"errors"
 	"strconv"
 	"strings"
+	"time"
 
-	"github.com/StackExchange/wmi"
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -16,14 +15,13 @@ func init() {
 	registerCollector("cpu_info", newCpuInfoCollector)
 }
 
-// If you are adding additional labels to the metric, make sure that they get added in here as well. See below for explanation.
-const (
-	win32ProcessorQuery = "SELECT Architecture, DeviceId, Description, Family, L2CacheSize, L3CacheSize, Name FROM Win32_Processor"
-)
+var cpuInfoCacheTTL = registerWMICacheFlag("cpu_info", 5*time.Minute)
 
 // A CpuInfoCollector is a Prometheus collector for a few WMI metrics in Win32_Processor
 type CpuInfoCollector struct {
 	CpuInfo *prometheus.Desc
+
+	cache *wmiCache
 }
 
 func newCpuInfoCollector() (Collector, error) {
@@ -41,6 +39,7 @@ func newCpuInfoCollector() (Collector, error) {
 				"name"},
 			nil,
 		),
+		cache: newWMICache("cpu_info", *cpuInfoCacheTTL),
 	}, nil
 }
 
@@ -57,8 +56,7 @@ type win32_Processor struct {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *CpuInfoCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting cpu_info metrics:", desc, err)
+	if _, err := c.collect(ch); err != nil {
 		return err
 	}
 	return nil
@@ -66,10 +64,12 @@ func (c *CpuInfoCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metr
 
 func (c *CpuInfoCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []win32_Processor
-	// We use a static query here because the provided methods in wmi.go all issue a SELECT *;
-	// This results in the time consuming LoadPercentage field being read which seems to measure each CPU
-	// serially over a 1 second interval, so the scrape time is at least 1s * num_sockets
-	if err := wmi.Query(win32ProcessorQuery, &dst); err != nil {
+	// Only the fields of win32_Processor are selected. Selecting all properties
+	// would read the time consuming LoadPercentage field, which seems to measure
+	// each CPU serially over a 1 second interval, so the scrape time would be at
+	// least 1s * num_sockets.
+	q := querySelectForClass(&dst, "Win32_Processor", "")
+	if err := c.cache.query(q, &dst); err != nil {
 		return nil, err
 	}
 	if len(dst) == 0 {
@@ -0,0 +1,14 @@
+package collector
+
+import (
+	"testing"
+)
+
+func TestCpuInfoCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "cpu_info", newCpuInfoCollector)
+}
+
+func BenchmarkCpuInfoCollector(b *testing.B) {
+	// No context name required as collector source is WMI
+	benchmarkCollector(b, "", newCpuInfoCollector)
+}
@@ -4,6 +4,10 @@ import (
 	"testing"
 )
 
+func TestCPUCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "cpu", newCPUCollector)
+}
+
 func BenchmarkCPUCollector(b *testing.B) {
 	benchmarkCollector(b, "cpu", newCPUCollector)
 }
@@ -4,7 +4,6 @@ package collector
 
 import (
 	"github.com/prometheus-community/windows_exporter/headers/sysinfoapi"
-	"github.com/prometheus-community/windows_exporter/log"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -52,8 +51,7 @@ func NewCSCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *CSCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting cs metrics:", desc, err)
+	if _, err := c.collect(ch); err != nil {
 		return err
 	}
 	return nil
@@ -5,15 +5,15 @@ package collector
 import (
 	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
-	"gopkg.in/alecthomas/kingpin.v2"
 )
 
-var dfsrEnabledCollectors = kingpin.Flag("collectors.dfsr.sources-enabled", "Comma-seperated list of DFSR Perflib sources to use.").Default("connection,folder,volume").String()
+var dfsrChildFlags = registerChildCollectorFlags("dfsr", "connection", "folder", "volume").
+	withDeprecatedFlags("collectors.dfsr.sources-enabled", "")
 
 func init() {
 	// Perflib sources are dynamic, depending on the enabled child collectors
 	var perflibDependencies []string
-	for _, source := range expandEnabledChildCollectors(*dfsrEnabledCollectors) {
+	for _, source := range dfsrChildFlags.available {
 		perflibDependencies = append(perflibDependencies, dfsrGetPerfObjectName(source))
 	}
 
@@ -69,12 +69,9 @@ type DFSRCollector struct {
 	VolumeUSNJournalRecordsAcceptedTotal *prometheus.Desc
 	VolumeUSNJournalRecordsReadTotal     *prometheus.Desc
 
-	// Map of child collector functions used during collection
-	dfsrChildCollectors []dfsrCollectorFunc
+	children *childCollectors
 }
 
-type dfsrCollectorFunc func(ctx *ScrapeContext, ch chan<- prometheus.Metric) error
-
 // Map Perflib sources to DFSR collector names
 // E.G. volume -> DFS Replication Service Volumes
 func dfsrGetPerfObjectName(collector string) string {
@@ -93,10 +90,13 @@ func dfsrGetPerfObjectName(collector string) string {
 
 // NewDFSRCollector is registered
 func NewDFSRCollector() (Collector, error) {
-	log.Info("dfsr collector is in an experimental state! Metrics for this collector have not been tested.")
+	log.With("collector", "dfsr").Info("dfsr collector is in an experimental state! Metrics for this collector have not been tested.")
 	const subsystem = "dfsr"
 
-	enabled := expandEnabledChildCollectors(*dfsrEnabledCollectors)
+	enabled, err := dfsrChildFlags.enabledNames()
+	if err != nil {
+		return nil, err
+	}
 	perfCounters := make([]string, 0, len(enabled))
 	for _, c := range enabled {
 		perfCounters = append(perfCounters, dfsrGetPerfObjectName(c))
@@ -395,39 +395,22 @@ func NewDFSRCollector() (Collector, error) {
 		),
 	}
 
-	dfsrCollector.dfsrChildCollectors = dfsrCollector.getDFSRChildCollectors(enabled)
-
-	return &dfsrCollector, nil
-}
-
-// Maps enabled child collectors names to their relevant collection function,
-// for use in DFSRCollector.Collect()
-func (c *DFSRCollector) getDFSRChildCollectors(enabledCollectors []string) []dfsrCollectorFunc {
-	var dfsrCollectors []dfsrCollectorFunc
-	for _, collector := range enabledCollectors {
-		switch collector {
-		case "connection":
-			dfsrCollectors = append(dfsrCollectors, c.collectConnection)
-		case "folder":
-			dfsrCollectors = append(dfsrCollectors, c.collectFolder)
-		case "volume":
-			dfsrCollectors = append(dfsrCollectors, c.collectVolume)
-		}
+	dfsrCollector.children, err = dfsrChildFlags.build([]childCollector{
+		{"connection", dfsrGetPerfObjectName("connection"), dfsrCollector.collectConnection},
+		{"folder", dfsrGetPerfObjectName("folder"), dfsrCollector.collectFolder},
+		{"volume", dfsrGetPerfObjectName("volume"), dfsrCollector.collectVolume},
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return dfsrCollectors
+	return &dfsrCollector, nil
 }
 
 // Collect implements the Collector interface.
 // Sends metric values for each metric to the provided prometheus Metric channel.
 func (c *DFSRCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	for _, fn := range c.dfsrChildCollectors {
-		err := fn(ctx, ch)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	return c.children.collect(ctx, ch)
 }
 
 // Perflib: "DFS Replication Service Connections"
@@ -447,71 +430,82 @@ type PerflibDFSRConnection struct {
 
 func (c *DFSRCollector) collectConnection(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
 	var dst []PerflibDFSRConnection
-	if err := unmarshalObject(ctx.perfObjects["DFS Replication Connections"], &dst); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["DFS Replication Connections"], &dst)
+	if err != nil {
 		return err
 	}
 
-	for _, connection := range dst {
-		ch <- prometheus.MustNewConstMetric(
+	for i := range dst {
+		connection := &dst[i]
+		present.emit(
+			ch,
 			c.ConnectionBandwidthSavingsUsingDFSReplicationTotal,
 			prometheus.CounterValue,
-			connection.BandwidthSavingsUsingDFSReplicationTotal,
+			&connection.BandwidthSavingsUsingDFSReplicationTotal,
 			connection.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ConnectionBytesReceivedTotal,
 			prometheus.CounterValue,
-			connection.BytesReceivedTotal,
+			&connection.BytesReceivedTotal,
 			connection.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ConnectionCompressedSizeOfFilesReceivedTotal,
 			prometheus.CounterValue,
-			connection.CompressedSizeOfFilesReceivedTotal,
+			&connection.CompressedSizeOfFilesReceivedTotal,
 			connection.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ConnectionFilesReceivedTotal,
 			prometheus.CounterValue,
-			connection.FilesReceivedTotal,
+			&connection.FilesReceivedTotal,
 			connection.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ConnectionRDCBytesReceivedTotal,
 			prometheus.CounterValue,
-			connection.RDCBytesReceivedTotal,
+			&connection.RDCBytesReceivedTotal,
 			connection.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ConnectionRDCCompressedSizeOfFilesReceivedTotal,
 			prometheus.CounterValue,
-			connection.RDCCompressedSizeOfFilesReceivedTotal,
+			&connection.RDCCompressedSizeOfFilesReceivedTotal,
 			connection.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ConnectionRDCSizeOfFilesReceivedTotal,
 			prometheus.CounterValue,
-			connection.RDCSizeOfFilesReceivedTotal,
+			&connection.RDCSizeOfFilesReceivedTotal,
 			connection.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ConnectionRDCNumberofFilesReceivedTotal,
 			prometheus.CounterValue,
-			connection.RDCNumberofFilesReceivedTotal,
+			&connection.RDCNumberofFilesReceivedTotal,
 			connection.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ConnectionSizeOfFilesReceivedTotal,
 			prometheus.CounterValue,
-			connection.SizeOfFilesReceivedTotal,
+			&connection.SizeOfFilesReceivedTotal,
 			connection.Name,
 		)
 
@@ -554,197 +548,226 @@ type PerflibDFSRFolder struct {
 
 func (c *DFSRCollector) collectFolder(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
 	var dst []PerflibDFSRFolder
-	if err := unmarshalObject(ctx.perfObjects["DFS Replicated Folders"], &dst); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["DFS Replicated Folders"], &dst)
+	if err != nil {
 		return err
 	}
 
-	for _, folder := range dst {
-		ch <- prometheus.MustNewConstMetric(
+	for i := range dst {
+		folder := &dst[i]
+		present.emit(
+			ch,
 			c.FolderBandwidthSavingsUsingDFSReplicationTotal,
 			prometheus.CounterValue,
-			folder.BandwidthSavingsUsingDFSReplicationTotal,
+			&folder.BandwidthSavingsUsingDFSReplicationTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderCompressedSizeOfFilesReceivedTotal,
 			prometheus.CounterValue,
-			folder.CompressedSizeOfFilesReceivedTotal,
+			&folder.CompressedSizeOfFilesReceivedTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderConflictBytesCleanedupTotal,
 			prometheus.CounterValue,
-			folder.ConflictBytesCleanedupTotal,
+			&folder.ConflictBytesCleanedupTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderConflictBytesGeneratedTotal,
 			prometheus.CounterValue,
-			folder.ConflictBytesGeneratedTotal,
+			&folder.ConflictBytesGeneratedTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderConflictFilesCleanedUpTotal,
 			prometheus.CounterValue,
-			folder.ConflictFilesCleanedUpTotal,
+			&folder.ConflictFilesCleanedUpTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderConflictFilesGeneratedTotal,
 			prometheus.CounterValue,
-			folder.ConflictFilesGeneratedTotal,
+			&folder.ConflictFilesGeneratedTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderConflictFolderCleanupsCompletedTotal,
 			prometheus.CounterValue,
-			folder.ConflictFolderCleanupsCompletedTotal,
+			&folder.ConflictFolderCleanupsCompletedTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderConflictSpaceInUse,
 			prometheus.GaugeValue,
-			folder.ConflictSpaceInUse,
+			&folder.ConflictSpaceInUse,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderDeletedSpaceInUse,
 			prometheus.GaugeValue,
-			folder.DeletedSpaceInUse,
+			&folder.DeletedSpaceInUse,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderDeletedBytesCleanedUpTotal,
 			prometheus.CounterValue,
-			folder.DeletedBytesCleanedUpTotal,
+			&folder.DeletedBytesCleanedUpTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderDeletedBytesGeneratedTotal,
 			prometheus.CounterValue,
-			folder.DeletedBytesGeneratedTotal,
+			&folder.DeletedBytesGeneratedTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderDeletedFilesCleanedUpTotal,
 			prometheus.CounterValue,
-			folder.DeletedFilesCleanedUpTotal,
+			&folder.DeletedFilesCleanedUpTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderDeletedFilesGeneratedTotal,
 			prometheus.CounterValue,
-			folder.DeletedFilesGeneratedTotal,
+			&folder.DeletedFilesGeneratedTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderFileInstallsRetriedTotal,
 			prometheus.CounterValue,
-			folder.FileInstallsRetriedTotal,
+			&folder.FileInstallsRetriedTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderFileInstallsSucceededTotal,
 			prometheus.CounterValue,
-			folder.FileInstallsSucceededTotal,
+			&folder.FileInstallsSucceededTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderFilesReceivedTotal,
 			prometheus.CounterValue,
-			folder.FilesReceivedTotal,
+			&folder.FilesReceivedTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderRDCBytesReceivedTotal,
 			prometheus.CounterValue,
-			folder.RDCBytesReceivedTotal,
+			&folder.RDCBytesReceivedTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderRDCCompressedSizeOfFilesReceivedTotal,
 			prometheus.CounterValue,
-			folder.RDCCompressedSizeOfFilesReceivedTotal,
+			&folder.RDCCompressedSizeOfFilesReceivedTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderRDCNumberofFilesReceivedTotal,
 			prometheus.CounterValue,
-			folder.RDCNumberofFilesReceivedTotal,
+			&folder.RDCNumberofFilesReceivedTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderRDCSizeOfFilesReceivedTotal,
 			prometheus.CounterValue,
-			folder.RDCSizeOfFilesReceivedTotal,
+			&folder.RDCSizeOfFilesReceivedTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderSizeOfFilesReceivedTotal,
 			prometheus.CounterValue,
-			folder.SizeOfFilesReceivedTotal,
+			&folder.SizeOfFilesReceivedTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderStagingSpaceInUse,
 			prometheus.GaugeValue,
-			folder.StagingSpaceInUse,
+			&folder.StagingSpaceInUse,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderStagingBytesCleanedUpTotal,
 			prometheus.CounterValue,
-			folder.StagingBytesCleanedUpTotal,
+			&folder.StagingBytesCleanedUpTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderStagingBytesGeneratedTotal,
 			prometheus.CounterValue,
-			folder.StagingBytesGeneratedTotal,
+			&folder.StagingBytesGeneratedTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderStagingFilesCleanedUpTotal,
 			prometheus.CounterValue,
-			folder.StagingFilesCleanedUpTotal,
+			&folder.StagingFilesCleanedUpTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderStagingFilesGeneratedTotal,
 			prometheus.CounterValue,
-			folder.StagingFilesGeneratedTotal,
+			&folder.StagingFilesGeneratedTotal,
 			folder.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FolderUpdatesDroppedTotal,
 			prometheus.CounterValue,
-			folder.UpdatesDroppedTotal,
+			&folder.UpdatesDroppedTotal,
 			folder.Name,
 		)
 	}
@@ -764,43 +787,50 @@ type PerflibDFSRVolume struct {
 
 func (c *DFSRCollector) collectVolume(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
 	var dst []PerflibDFSRVolume
-	if err := unmarshalObject(ctx.perfObjects["DFS Replication Service Volumes"], &dst); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["DFS Replication Service Volumes"], &dst)
+	if err != nil {
 		return err
 	}
 
-	for _, volume := range dst {
-		ch <- prometheus.MustNewConstMetric(
+	for i := range dst {
+		volume := &dst[i]
+		present.emit(
+			ch,
 			c.VolumeDatabaseLookupsTotal,
 			prometheus.CounterValue,
-			volume.DatabaseLookupsTotal,
+			&volume.DatabaseLookupsTotal,
 			volume.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.VolumeDatabaseCommitsTotal,
 			prometheus.CounterValue,
-			volume.DatabaseCommitsTotal,
+			&volume.DatabaseCommitsTotal,
 			volume.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.VolumeUSNJournalRecordsAcceptedTotal,
 			prometheus.CounterValue,
-			volume.USNJournalRecordsAcceptedTotal,
+			&volume.USNJournalRecordsAcceptedTotal,
 			volume.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.VolumeUSNJournalRecordsReadTotal,
 			prometheus.CounterValue,
-			volume.USNJournalRecordsReadTotal,
+			&volume.USNJournalRecordsReadTotal,
 			volume.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.VolumeUSNJournalUnreadPercentage,
 			prometheus.GaugeValue,
-			volume.USNJournalUnreadPercentage,
+			&volume.USNJournalUnreadPercentage,
 			volume.Name,
 		)
 
@@ -4,6 +4,10 @@ import (
 	"testing"
 )
 
+func TestDFSRCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "dfsr", NewDFSRCollector)
+}
+
 func BenchmarkDFSRCollector(b *testing.B) {
 	benchmarkCollector(b, "dfsr", NewDFSRCollector)
 }
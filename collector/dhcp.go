@@ -216,171 +216,197 @@ type dhcpPerf struct {
 	DeniedDueToMatch                                 float64 `perflib:"Denied due to match."`
 	DeniedDueToNonMatch                              float64 `perflib:"Denied due to match."`
 	OfferQueueLength                                 float64 `perflib:"Offer Queue Length"`
-	FailoverBndupdSentTotal                          float64 `perflib:"Failover: BndUpd sent/sec."`
-	FailoverBndupdReceivedTotal                      float64 `perflib:"Failover: BndUpd received/sec."`
-	FailoverBndackSentTotal                          float64 `perflib:"Failover: BndAck sent/sec."`
-	FailoverBndackReceivedTotal                      float64 `perflib:"Failover: BndAck received/sec."`
-	FailoverBndupdPendingOutboundQueue               float64 `perflib:"Failover: BndUpd pending in outbound queue."`
-	FailoverTransitionsCommunicationinterruptedState float64 `perflib:"Failover: Transitions to COMMUNICATION-INTERRUPTED state."`
-	FailoverTransitionsPartnerdownState              float64 `perflib:"Failover: Transitions to PARTNER-DOWN state."`
-	FailoverTransitionsRecoverState                  float64 `perflib:"Failover: Transitions to RECOVER state."`
-	FailoverBndupdDropped                            float64 `perflib:"Failover: BndUpd Dropped."`
+	FailoverBndupdSentTotal                          float64 `perflib:"Failover: BndUpd sent/sec.,optional"`
+	FailoverBndupdReceivedTotal                      float64 `perflib:"Failover: BndUpd received/sec.,optional"`
+	FailoverBndackSentTotal                          float64 `perflib:"Failover: BndAck sent/sec.,optional"`
+	FailoverBndackReceivedTotal                      float64 `perflib:"Failover: BndAck received/sec.,optional"`
+	FailoverBndupdPendingOutboundQueue               float64 `perflib:"Failover: BndUpd pending in outbound queue.,optional"`
+	FailoverTransitionsCommunicationinterruptedState float64 `perflib:"Failover: Transitions to COMMUNICATION-INTERRUPTED state.,optional"`
+	FailoverTransitionsPartnerdownState              float64 `perflib:"Failover: Transitions to PARTNER-DOWN state.,optional"`
+	FailoverTransitionsRecoverState                  float64 `perflib:"Failover: Transitions to RECOVER state.,optional"`
+	FailoverBndupdDropped                            float64 `perflib:"Failover: BndUpd Dropped.,optional"`
 }
 
 func (c *DhcpCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
 	var perflib []dhcpPerf
-	if err := unmarshalObject(ctx.perfObjects["DHCP Server"], &perflib); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["DHCP Server"], &perflib)
+	if err != nil {
 		return err
 	}
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.PacketsReceivedTotal,
 		prometheus.CounterValue,
-		perflib[0].PacketsReceivedTotal,
+		&perflib[0].PacketsReceivedTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DuplicatesDroppedTotal,
 		prometheus.CounterValue,
-		perflib[0].DuplicatesDroppedTotal,
+		&perflib[0].DuplicatesDroppedTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.PacketsExpiredTotal,
 		prometheus.CounterValue,
-		perflib[0].PacketsExpiredTotal,
+		&perflib[0].PacketsExpiredTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ActiveQueueLength,
 		prometheus.GaugeValue,
-		perflib[0].ActiveQueueLength,
+		&perflib[0].ActiveQueueLength,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ConflictCheckQueueLength,
 		prometheus.GaugeValue,
-		perflib[0].ConflictCheckQueueLength,
+		&perflib[0].ConflictCheckQueueLength,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DiscoversTotal,
 		prometheus.CounterValue,
-		perflib[0].DiscoversTotal,
+		&perflib[0].DiscoversTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.OffersTotal,
 		prometheus.CounterValue,
-		perflib[0].OffersTotal,
+		&perflib[0].OffersTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.RequestsTotal,
 		prometheus.CounterValue,
-		perflib[0].RequestsTotal,
+		&perflib[0].RequestsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.InformsTotal,
 		prometheus.CounterValue,
-		perflib[0].InformsTotal,
+		&perflib[0].InformsTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.AcksTotal,
 		prometheus.CounterValue,
-		perflib[0].AcksTotal,
+		&perflib[0].AcksTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.NacksTotal,
 		prometheus.CounterValue,
-		perflib[0].NacksTotal,
+		&perflib[0].NacksTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DeclinesTotal,
 		prometheus.CounterValue,
-		perflib[0].DeclinesTotal,
+		&perflib[0].DeclinesTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ReleasesTotal,
 		prometheus.CounterValue,
-		perflib[0].ReleasesTotal,
+		&perflib[0].ReleasesTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.OfferQueueLength,
 		prometheus.GaugeValue,
-		perflib[0].OfferQueueLength,
+		&perflib[0].OfferQueueLength,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DeniedDueToMatch,
 		prometheus.CounterValue,
-		perflib[0].DeniedDueToMatch,
+		&perflib[0].DeniedDueToMatch,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DeniedDueToNonMatch,
 		prometheus.CounterValue,
-		perflib[0].DeniedDueToNonMatch,
+		&perflib[0].DeniedDueToNonMatch,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.FailoverBndupdSentTotal,
 		prometheus.CounterValue,
-		perflib[0].FailoverBndupdSentTotal,
+		&perflib[0].FailoverBndupdSentTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.FailoverBndupdReceivedTotal,
 		prometheus.CounterValue,
-		perflib[0].FailoverBndupdReceivedTotal,
+		&perflib[0].FailoverBndupdReceivedTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.FailoverBndackSentTotal,
 		prometheus.CounterValue,
-		perflib[0].FailoverBndackSentTotal,
+		&perflib[0].FailoverBndackSentTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.FailoverBndackReceivedTotal,
 		prometheus.CounterValue,
-		perflib[0].FailoverBndackReceivedTotal,
+		&perflib[0].FailoverBndackReceivedTotal,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.FailoverBndupdPendingOutboundQueue,
 		prometheus.GaugeValue,
-		perflib[0].FailoverBndupdPendingOutboundQueue,
+		&perflib[0].FailoverBndupdPendingOutboundQueue,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.FailoverTransitionsCommunicationinterruptedState,
 		prometheus.CounterValue,
-		perflib[0].FailoverTransitionsCommunicationinterruptedState,
+		&perflib[0].FailoverTransitionsCommunicationinterruptedState,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.FailoverTransitionsPartnerdownState,
 		prometheus.CounterValue,
-		perflib[0].FailoverTransitionsPartnerdownState,
+		&perflib[0].FailoverTransitionsPartnerdownState,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.FailoverTransitionsRecoverState,
 		prometheus.CounterValue,
-		perflib[0].FailoverTransitionsRecoverState,
+		&perflib[0].FailoverTransitionsRecoverState,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.FailoverBndupdDropped,
 		prometheus.CounterValue,
-		perflib[0].FailoverBndupdDropped,
+		&perflib[0].FailoverBndupdDropped,
 	)
 
 	return nil
@@ -4,6 +4,10 @@ import (
 	"testing"
 )
 
+func TestDHCPCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "dhcp", NewDhcpCollector)
+}
+
 func BenchmarkDHCPCollector(b *testing.B) {
 	benchmarkCollector(b, "dhcp", NewDhcpCollector)
 }
@@ -5,16 +5,14 @@ package collector
 import (
 	"errors"
 
-	"github.com/StackExchange/wmi"
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 func init() {
-	registerCollector("dns", NewDNSCollector)
+	registerCollector("dns", NewDNSCollector, "DNS")
 }
 
-// A DNSCollector is a Prometheus collector for WMI Win32_PerfRawData_DNS_DNS metrics
+// A DNSCollector is a Prometheus collector for Perflib DNS metrics
 type DNSCollector struct {
 	ZoneTransferRequestsReceived  *prometheus.Desc
 	ZoneTransferRequestsSent      *prometheus.Desc
@@ -182,8 +180,7 @@ func NewDNSCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *DNSCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting dns metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
@@ -192,306 +189,345 @@ func (c *DNSCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric)
 // Win32_PerfRawData_DNS_DNS docs:
 // - https://msdn.microsoft.com/en-us/library/ms803992.aspx?f=255&MSPPError=-2147217396
 // - https://technet.microsoft.com/en-us/library/cc977686.aspx
-type Win32_PerfRawData_DNS_DNS struct {
-	AXFRRequestReceived            uint32
-	AXFRRequestSent                uint32
-	AXFRResponseReceived           uint32
-	AXFRSuccessReceived            uint32
-	AXFRSuccessSent                uint32
-	CachingMemory                  uint32
-	DatabaseNodeMemory             uint32
-	DynamicUpdateNoOperation       uint32
-	DynamicUpdateQueued            uint32
-	DynamicUpdateRejected          uint32
-	DynamicUpdateTimeOuts          uint32
-	DynamicUpdateWrittentoDatabase uint32
-	IXFRRequestReceived            uint32
-	IXFRRequestSent                uint32
-	IXFRResponseReceived           uint32
-	IXFRSuccessSent                uint32
-	IXFRTCPSuccessReceived         uint32
-	IXFRUDPSuccessReceived         uint32
-	NbstatMemory                   uint32
-	NotifyReceived                 uint32
-	NotifySent                     uint32
-	RecordFlowMemory               uint32
-	RecursiveQueries               uint32
-	RecursiveQueryFailure          uint32
-	RecursiveSendTimeOuts          uint32
-	SecureUpdateFailure            uint32
-	SecureUpdateReceived           uint32
-	TCPMessageMemory               uint32
-	TCPQueryReceived               uint32
-	TCPResponseSent                uint32
-	UDPMessageMemory               uint32
-	UDPQueryReceived               uint32
-	UDPResponseSent                uint32
-	UnmatchedResponsesReceived     uint32
-	WINSLookupReceived             uint32
-	WINSResponseSent               uint32
-	WINSReverseLookupReceived      uint32
-	WINSReverseResponseSent        uint32
-	ZoneTransferFailure            uint32
-	ZoneTransferSOARequestSent     uint32
+type dnsPerflib struct {
+	AXFRRequestReceived            float64 `perflib:"AXFR Request Received"`
+	AXFRRequestSent                float64 `perflib:"AXFR Request Sent"`
+	AXFRResponseReceived           float64 `perflib:"AXFR Response Received"`
+	AXFRSuccessReceived            float64 `perflib:"AXFR Success Received"`
+	AXFRSuccessSent                float64 `perflib:"AXFR Success Sent"`
+	CachingMemory                  float64 `perflib:"Caching Memory"`
+	DatabaseNodeMemory             float64 `perflib:"Database Node Memory"`
+	DynamicUpdateNoOperation       float64 `perflib:"Dynamic Update NoOperation"`
+	DynamicUpdateQueued            float64 `perflib:"Dynamic Update Queued"`
+	DynamicUpdateRejected          float64 `perflib:"Dynamic Update Rejected"`
+	DynamicUpdateTimeOuts          float64 `perflib:"Dynamic Update TimeOuts"`
+	DynamicUpdateWrittentoDatabase float64 `perflib:"Dynamic Update Written to Database"`
+	IXFRRequestReceived            float64 `perflib:"IXFR Request Received"`
+	IXFRRequestSent                float64 `perflib:"IXFR Request Sent"`
+	IXFRResponseReceived           float64 `perflib:"IXFR Response Received"`
+	IXFRSuccessSent                float64 `perflib:"IXFR Success Sent"`
+	IXFRTCPSuccessReceived         float64 `perflib:"IXFR TCP Success Received"`
+	NbstatMemory                   float64 `perflib:"Nbstat Memory"`
+	NotifyReceived                 float64 `perflib:"Notify Received"`
+	NotifySent                     float64 `perflib:"Notify Sent"`
+	RecordFlowMemory               float64 `perflib:"Record Flow Memory"`
+	RecursiveQueries               float64 `perflib:"Recursive Queries"`
+	RecursiveQueryFailure          float64 `perflib:"Recursive Query Failure"`
+	RecursiveSendTimeOuts          float64 `perflib:"Recursive Send TimeOuts"`
+	SecureUpdateFailure            float64 `perflib:"Secure Update Failure"`
+	SecureUpdateReceived           float64 `perflib:"Secure Update Received"`
+	TCPMessageMemory               float64 `perflib:"TCP Message Memory"`
+	TCPQueryReceived               float64 `perflib:"TCP Query Received"`
+	TCPResponseSent                float64 `perflib:"TCP Response Sent"`
+	UDPMessageMemory               float64 `perflib:"UDP Message Memory"`
+	UDPQueryReceived               float64 `perflib:"UDP Query Received"`
+	UDPResponseSent                float64 `perflib:"UDP Response Sent"`
+	UnmatchedResponsesReceived     float64 `perflib:"Unmatched Responses Received"`
+	WINSLookupReceived             float64 `perflib:"WINS Lookup Received"`
+	WINSResponseSent               float64 `perflib:"WINS Response Sent"`
+	WINSReverseLookupReceived      float64 `perflib:"WINS Reverse Lookup Received"`
+	WINSReverseResponseSent        float64 `perflib:"WINS Reverse Response Sent"`
+	ZoneTransferFailure            float64 `perflib:"Zone Transfer Failure"`
+	ZoneTransferSOARequestSent     float64 `perflib:"Zone Transfer SOA Request Sent"`
 }
 
-func (c *DNSCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
-	var dst []Win32_PerfRawData_DNS_DNS
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+func (c *DNSCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []dnsPerflib
+	present, err := unmarshalObject(ctx.perfObjects["DNS"], &dst)
+	if err != nil {
 		return nil, err
 	}
 	if len(dst) == 0 {
-		return nil, errors.New("WMI query returned empty result set")
+		return nil, errors.New("perflib query for DNS returned empty result set")
 	}
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ZoneTransferRequestsReceived,
 		prometheus.CounterValue,
-		float64(dst[0].AXFRRequestReceived),
+		&dst[0].AXFRRequestReceived,
 		"full",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ZoneTransferRequestsReceived,
 		prometheus.CounterValue,
-		float64(dst[0].IXFRRequestReceived),
+		&dst[0].IXFRRequestReceived,
 		"incremental",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ZoneTransferRequestsSent,
 		prometheus.CounterValue,
-		float64(dst[0].AXFRRequestSent),
+		&dst[0].AXFRRequestSent,
 		"full",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ZoneTransferRequestsSent,
 		prometheus.CounterValue,
-		float64(dst[0].IXFRRequestSent),
+		&dst[0].IXFRRequestSent,
 		"incremental",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ZoneTransferRequestsSent,
 		prometheus.CounterValue,
-		float64(dst[0].ZoneTransferSOARequestSent),
+		&dst[0].ZoneTransferSOARequestSent,
 		"soa",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ZoneTransferResponsesReceived,
 		prometheus.CounterValue,
-		float64(dst[0].AXFRResponseReceived),
+		&dst[0].AXFRResponseReceived,
 		"full",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ZoneTransferResponsesReceived,
 		prometheus.CounterValue,
-		float64(dst[0].IXFRResponseReceived),
+		&dst[0].IXFRResponseReceived,
 		"incremental",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ZoneTransferSuccessReceived,
 		prometheus.CounterValue,
-		float64(dst[0].AXFRSuccessReceived),
+		&dst[0].AXFRSuccessReceived,
 		"full",
 		"tcp",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ZoneTransferSuccessReceived,
 		prometheus.CounterValue,
-		float64(dst[0].IXFRTCPSuccessReceived),
+		&dst[0].IXFRTCPSuccessReceived,
 		"incremental",
 		"tcp",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ZoneTransferSuccessReceived,
 		prometheus.CounterValue,
-		float64(dst[0].IXFRTCPSuccessReceived),
+		&dst[0].IXFRTCPSuccessReceived,
 		"incremental",
 		"udp",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ZoneTransferSuccessSent,
 		prometheus.CounterValue,
-		float64(dst[0].AXFRSuccessSent),
+		&dst[0].AXFRSuccessSent,
 		"full",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ZoneTransferSuccessSent,
 		prometheus.CounterValue,
-		float64(dst[0].IXFRSuccessSent),
+		&dst[0].IXFRSuccessSent,
 		"incremental",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ZoneTransferFailures,
 		prometheus.CounterValue,
-		float64(dst[0].ZoneTransferFailure),
+		&dst[0].ZoneTransferFailure,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.MemoryUsedBytes,
 		prometheus.GaugeValue,
-		float64(dst[0].CachingMemory),
+		&dst[0].CachingMemory,
 		"caching",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.MemoryUsedBytes,
 		prometheus.GaugeValue,
-		float64(dst[0].DatabaseNodeMemory),
+		&dst[0].DatabaseNodeMemory,
 		"database_node",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.MemoryUsedBytes,
 		prometheus.GaugeValue,
-		float64(dst[0].NbstatMemory),
+		&dst[0].NbstatMemory,
 		"nbstat",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.MemoryUsedBytes,
 		prometheus.GaugeValue,
-		float64(dst[0].RecordFlowMemory),
+		&dst[0].RecordFlowMemory,
 		"record_flow",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.MemoryUsedBytes,
 		prometheus.GaugeValue,
-		float64(dst[0].TCPMessageMemory),
+		&dst[0].TCPMessageMemory,
 		"tcp_message",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.MemoryUsedBytes,
 		prometheus.GaugeValue,
-		float64(dst[0].UDPMessageMemory),
+		&dst[0].UDPMessageMemory,
 		"udp_message",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DynamicUpdatesReceived,
 		prometheus.CounterValue,
-		float64(dst[0].DynamicUpdateNoOperation),
+		&dst[0].DynamicUpdateNoOperation,
 		"noop",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DynamicUpdatesReceived,
 		prometheus.CounterValue,
-		float64(dst[0].DynamicUpdateWrittentoDatabase),
+		&dst[0].DynamicUpdateWrittentoDatabase,
 		"written",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DynamicUpdatesQueued,
 		prometheus.GaugeValue,
-		float64(dst[0].DynamicUpdateQueued),
+		&dst[0].DynamicUpdateQueued,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DynamicUpdatesFailures,
 		prometheus.CounterValue,
-		float64(dst[0].DynamicUpdateRejected),
+		&dst[0].DynamicUpdateRejected,
 		"rejected",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DynamicUpdatesFailures,
 		prometheus.CounterValue,
-		float64(dst[0].DynamicUpdateTimeOuts),
+		&dst[0].DynamicUpdateTimeOuts,
 		"timeout",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.NotifyReceived,
 		prometheus.CounterValue,
-		float64(dst[0].NotifyReceived),
+		&dst[0].NotifyReceived,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.NotifySent,
 		prometheus.CounterValue,
-		float64(dst[0].NotifySent),
+		&dst[0].NotifySent,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.RecursiveQueries,
 		prometheus.CounterValue,
-		float64(dst[0].RecursiveQueries),
+		&dst[0].RecursiveQueries,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.RecursiveQueryFailures,
 		prometheus.CounterValue,
-		float64(dst[0].RecursiveQueryFailure),
+		&dst[0].RecursiveQueryFailure,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.RecursiveQuerySendTimeouts,
 		prometheus.CounterValue,
-		float64(dst[0].RecursiveSendTimeOuts),
+		&dst[0].RecursiveSendTimeOuts,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.Queries,
 		prometheus.CounterValue,
-		float64(dst[0].TCPQueryReceived),
+		&dst[0].TCPQueryReceived,
 		"tcp",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.Queries,
 		prometheus.CounterValue,
-		float64(dst[0].UDPQueryReceived),
+		&dst[0].UDPQueryReceived,
 		"udp",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.Responses,
 		prometheus.CounterValue,
-		float64(dst[0].TCPResponseSent),
+		&dst[0].TCPResponseSent,
 		"tcp",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.Responses,
 		prometheus.CounterValue,
-		float64(dst[0].UDPResponseSent),
+		&dst[0].UDPResponseSent,
 		"udp",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.UnmatchedResponsesReceived,
 		prometheus.CounterValue,
-		float64(dst[0].UnmatchedResponsesReceived),
+		&dst[0].UnmatchedResponsesReceived,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.WinsQueries,
 		prometheus.CounterValue,
-		float64(dst[0].WINSLookupReceived),
+		&dst[0].WINSLookupReceived,
 		"forward",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.WinsQueries,
 		prometheus.CounterValue,
-		float64(dst[0].WINSReverseLookupReceived),
+		&dst[0].WINSReverseLookupReceived,
 		"reverse",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.WinsResponses,
 		prometheus.CounterValue,
-		float64(dst[0].WINSResponseSent),
+		&dst[0].WINSResponseSent,
 		"forward",
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.WinsResponses,
 		prometheus.CounterValue,
-		float64(dst[0].WINSReverseResponseSent),
+		&dst[0].WINSReverseResponseSent,
 		"reverse",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SecureUpdateFailures,
 		prometheus.CounterValue,
-		float64(dst[0].SecureUpdateFailure),
+		&dst[0].SecureUpdateFailure,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SecureUpdateReceived,
 		prometheus.CounterValue,
-		float64(dst[0].SecureUpdateReceived),
+		&dst[0].SecureUpdateReceived,
 	)
 
 	return nil, nil
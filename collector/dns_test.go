@@ -1,9 +1,331 @@
 package collector
 
 import (
+	"errors"
 	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+func TestDNSCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "dns", NewDNSCollector)
+}
+
+func TestDNSCollectorParity(t *testing.T) {
+	testCollectorParity(t, "dns", NewDNSCollector, func(c Collector, ch chan<- prometheus.Metric) error {
+		_, err := c.(*DNSCollector).collectWMI(ch)
+		return err
+	})
+}
+
 func BenchmarkDNSCollector(b *testing.B) {
 	benchmarkCollector(b, "dns", NewDNSCollector)
 }
+
+// The WMI implementation the collector was moved from, kept to check that
+// the perflib implementation exposes the same metrics.
+
+type Win32_PerfRawData_DNS_DNS struct {
+	AXFRRequestReceived            uint32
+	AXFRRequestSent                uint32
+	AXFRResponseReceived           uint32
+	AXFRSuccessReceived            uint32
+	AXFRSuccessSent                uint32
+	CachingMemory                  uint32
+	DatabaseNodeMemory             uint32
+	DynamicUpdateNoOperation       uint32
+	DynamicUpdateQueued            uint32
+	DynamicUpdateRejected          uint32
+	DynamicUpdateTimeOuts          uint32
+	DynamicUpdateWrittentoDatabase uint32
+	IXFRRequestReceived            uint32
+	IXFRRequestSent                uint32
+	IXFRResponseReceived           uint32
+	IXFRSuccessSent                uint32
+	IXFRTCPSuccessReceived         uint32
+	IXFRUDPSuccessReceived         uint32
+	NbstatMemory                   uint32
+	NotifyReceived                 uint32
+	NotifySent                     uint32
+	RecordFlowMemory               uint32
+	RecursiveQueries               uint32
+	RecursiveQueryFailure          uint32
+	RecursiveSendTimeOuts          uint32
+	SecureUpdateFailure            uint32
+	SecureUpdateReceived           uint32
+	TCPMessageMemory               uint32
+	TCPQueryReceived               uint32
+	TCPResponseSent                uint32
+	UDPMessageMemory               uint32
+	UDPQueryReceived               uint32
+	UDPResponseSent                uint32
+	UnmatchedResponsesReceived     uint32
+	WINSLookupReceived             uint32
+	WINSResponseSent               uint32
+	WINSReverseLookupReceived      uint32
+	WINSReverseResponseSent        uint32
+	ZoneTransferFailure            uint32
+	ZoneTransferSOARequestSent     uint32
+}
+
+func (c *DNSCollector) collectWMI(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []Win32_PerfRawData_DNS_DNS
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
+		return nil, err
+	}
+	if len(dst) == 0 {
+		return nil, errors.New("WMI query returned empty result set")
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.ZoneTransferRequestsReceived,
+		prometheus.CounterValue,
+		float64(dst[0].AXFRRequestReceived),
+		"full",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.ZoneTransferRequestsReceived,
+		prometheus.CounterValue,
+		float64(dst[0].IXFRRequestReceived),
+		"incremental",
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.ZoneTransferRequestsSent,
+		prometheus.CounterValue,
+		float64(dst[0].AXFRRequestSent),
+		"full",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.ZoneTransferRequestsSent,
+		prometheus.CounterValue,
+		float64(dst[0].IXFRRequestSent),
+		"incremental",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.ZoneTransferRequestsSent,
+		prometheus.CounterValue,
+		float64(dst[0].ZoneTransferSOARequestSent),
+		"soa",
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.ZoneTransferResponsesReceived,
+		prometheus.CounterValue,
+		float64(dst[0].AXFRResponseReceived),
+		"full",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.ZoneTransferResponsesReceived,
+		prometheus.CounterValue,
+		float64(dst[0].IXFRResponseReceived),
+		"incremental",
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.ZoneTransferSuccessReceived,
+		prometheus.CounterValue,
+		float64(dst[0].AXFRSuccessReceived),
+		"full",
+		"tcp",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.ZoneTransferSuccessReceived,
+		prometheus.CounterValue,
+		float64(dst[0].IXFRTCPSuccessReceived),
+		"incremental",
+		"tcp",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.ZoneTransferSuccessReceived,
+		prometheus.CounterValue,
+		float64(dst[0].IXFRTCPSuccessReceived),
+		"incremental",
+		"udp",
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.ZoneTransferSuccessSent,
+		prometheus.CounterValue,
+		float64(dst[0].AXFRSuccessSent),
+		"full",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.ZoneTransferSuccessSent,
+		prometheus.CounterValue,
+		float64(dst[0].IXFRSuccessSent),
+		"incremental",
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.ZoneTransferFailures,
+		prometheus.CounterValue,
+		float64(dst[0].ZoneTransferFailure),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.MemoryUsedBytes,
+		prometheus.GaugeValue,
+		float64(dst[0].CachingMemory),
+		"caching",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.MemoryUsedBytes,
+		prometheus.GaugeValue,
+		float64(dst[0].DatabaseNodeMemory),
+		"database_node",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.MemoryUsedBytes,
+		prometheus.GaugeValue,
+		float64(dst[0].NbstatMemory),
+		"nbstat",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.MemoryUsedBytes,
+		prometheus.GaugeValue,
+		float64(dst[0].RecordFlowMemory),
+		"record_flow",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.MemoryUsedBytes,
+		prometheus.GaugeValue,
+		float64(dst[0].TCPMessageMemory),
+		"tcp_message",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.MemoryUsedBytes,
+		prometheus.GaugeValue,
+		float64(dst[0].UDPMessageMemory),
+		"udp_message",
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.DynamicUpdatesReceived,
+		prometheus.CounterValue,
+		float64(dst[0].DynamicUpdateNoOperation),
+		"noop",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.DynamicUpdatesReceived,
+		prometheus.CounterValue,
+		float64(dst[0].DynamicUpdateWrittentoDatabase),
+		"written",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.DynamicUpdatesQueued,
+		prometheus.GaugeValue,
+		float64(dst[0].DynamicUpdateQueued),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.DynamicUpdatesFailures,
+		prometheus.CounterValue,
+		float64(dst[0].DynamicUpdateRejected),
+		"rejected",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.DynamicUpdatesFailures,
+		prometheus.CounterValue,
+		float64(dst[0].DynamicUpdateTimeOuts),
+		"timeout",
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.NotifyReceived,
+		prometheus.CounterValue,
+		float64(dst[0].NotifyReceived),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.NotifySent,
+		prometheus.CounterValue,
+		float64(dst[0].NotifySent),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.RecursiveQueries,
+		prometheus.CounterValue,
+		float64(dst[0].RecursiveQueries),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.RecursiveQueryFailures,
+		prometheus.CounterValue,
+		float64(dst[0].RecursiveQueryFailure),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.RecursiveQuerySendTimeouts,
+		prometheus.CounterValue,
+		float64(dst[0].RecursiveSendTimeOuts),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.Queries,
+		prometheus.CounterValue,
+		float64(dst[0].TCPQueryReceived),
+		"tcp",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.Queries,
+		prometheus.CounterValue,
+		float64(dst[0].UDPQueryReceived),
+		"udp",
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.Responses,
+		prometheus.CounterValue,
+		float64(dst[0].TCPResponseSent),
+		"tcp",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.Responses,
+		prometheus.CounterValue,
+		float64(dst[0].UDPResponseSent),
+		"udp",
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.UnmatchedResponsesReceived,
+		prometheus.CounterValue,
+		float64(dst[0].UnmatchedResponsesReceived),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.WinsQueries,
+		prometheus.CounterValue,
+		float64(dst[0].WINSLookupReceived),
+		"forward",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.WinsQueries,
+		prometheus.CounterValue,
+		float64(dst[0].WINSReverseLookupReceived),
+		"reverse",
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.WinsResponses,
+		prometheus.CounterValue,
+		float64(dst[0].WINSResponseSent),
+		"forward",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.WinsResponses,
+		prometheus.CounterValue,
+		float64(dst[0].WINSReverseResponseSent),
+		"reverse",
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.SecureUpdateFailures,
+		prometheus.CounterValue,
+		float64(dst[0].SecureUpdateFailure),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.SecureUpdateReceived,
+		prometheus.CounterValue,
+		float64(dst[0].SecureUpdateReceived),
+	)
+
+	return nil, nil
+}
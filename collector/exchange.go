@@ -4,12 +4,9 @@ package collector
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
-	"gopkg.in/alecthomas/kingpin.v2"
 )
 
 func init() {
@@ -65,32 +62,19 @@ type exchangeCollector struct {
 	RPCOperationsPerSec                     *prometheus.Desc
 	UserCount                               *prometheus.Desc
 
-	enabledCollectors []string
+	children *childCollectors
 }
 
-var (
-	// All available collector functions
-	exchangeAllCollectorNames = []string{
-		"ADAccessProcesses",
-		"TransportQueues",
-		"HttpProxy",
-		"ActiveSync",
-		"AvailabilityService",
-		"OutlookWebAccess",
-		"Autodiscover",
-		"WorkloadManagement",
-		"RpcClientAccess",
-	}
-
-	argExchangeListAllCollectors = kingpin.Flag(
-		"collectors.exchange.list",
-		"List the collectors along with their perflib object name/ids",
-	).Bool()
-
-	argExchangeCollectorsEnabled = kingpin.Flag(
-		"collectors.exchange.enabled",
-		"Comma-separated list of collectors to use. Defaults to all, if not specified.",
-	).Default("").String()
+var exchangeChildFlags = registerChildCollectorFlags("exchange",
+	"ADAccessProcesses",
+	"TransportQueues",
+	"HttpProxy",
+	"ActiveSync",
+	"AvailabilityService",
+	"OutlookWebAccess",
+	"Autodiscover",
+	"WorkloadManagement",
+	"RpcClientAccess",
 )
 
 // newExchangeCollector returns a new Collector
@@ -144,42 +128,22 @@ func newExchangeCollector() (Collector, error) {
 		MailboxServerProxyFailureRate:           desc("http_proxy_mailbox_proxy_failure_rate", "% of failures between this CAS and MBX servers over the last 200 samples", "name"),
 		PingCommandsPending:                     desc("activesync_ping_cmds_pending", "Number of ping commands currently pending in the queue"),
 		SyncCommandsPerSec:                      desc("activesync_sync_cmds_total", "Number of sync commands processed per second. Clients use this command to synchronize items within a folder"),
-
-		enabledCollectors: make([]string, 0, len(exchangeAllCollectorNames)),
-	}
-
-	collectorDesc := map[string]string{
-		"ADAccessProcesses":   "[19108] MSExchange ADAccess Processes",
-		"TransportQueues":     "[20524] MSExchangeTransport Queues",
-		"HttpProxy":           "[36934] MSExchange HttpProxy",
-		"ActiveSync":          "[25138] MSExchange ActiveSync",
-		"AvailabilityService": "[24914] MSExchange Availability Service",
-		"OutlookWebAccess":    "[24618] MSExchange OWA",
-		"Autodiscover":        "[29240] MSExchange Autodiscover",
-		"WorkloadManagement":  "[19430] MSExchange WorkloadManagement Workloads",
-		"RpcClientAccess":     "[29336] MSExchange RpcClientAccess",
 	}
 
-	if *argExchangeListAllCollectors {
-		fmt.Printf("%-32s %-32s\n", "Collector Name", "[PerfID] Perflib Object")
-		for _, cname := range exchangeAllCollectorNames {
-			fmt.Printf("%-32s %-32s\n", cname, collectorDesc[cname])
-		}
-		os.Exit(0)
-	}
-
-	if *argExchangeCollectorsEnabled == "" {
-		for _, collectorName := range exchangeAllCollectorNames {
-			c.enabledCollectors = append(c.enabledCollectors, collectorName)
-		}
-	} else {
-		for _, collectorName := range strings.Split(*argExchangeCollectorsEnabled, ",") {
-			if find(exchangeAllCollectorNames, collectorName) {
-				c.enabledCollectors = append(c.enabledCollectors, collectorName)
-			} else {
-				return nil, fmt.Errorf("Unknown exchange collector: %s", collectorName)
-			}
-		}
+	var err error
+	c.children, err = exchangeChildFlags.build([]childCollector{
+		{"ADAccessProcesses", "[19108] MSExchange ADAccess Processes", c.collectADAccessProcesses},
+		{"TransportQueues", "[20524] MSExchangeTransport Queues", c.collectTransportQueues},
+		{"HttpProxy", "[36934] MSExchange HttpProxy", c.collectHTTPProxy},
+		{"ActiveSync", "[25138] MSExchange ActiveSync", c.collectActiveSync},
+		{"AvailabilityService", "[24914] MSExchange Availability Service", c.collectAvailabilityService},
+		{"OutlookWebAccess", "[24618] MSExchange OWA", c.collectOWA},
+		{"Autodiscover", "[29240] MSExchange Autodiscover", c.collectAutoDiscover},
+		{"WorkloadManagement", "[19430] MSExchange WorkloadManagement Workloads", c.collectWorkloadManagementWorkloads},
+		{"RpcClientAccess", "[29336] MSExchange RpcClientAccess", c.collectRPC},
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &c, nil
@@ -187,26 +151,7 @@ func newExchangeCollector() (Collector, error) {
 
 // Collect collects exchange metrics and sends them to prometheus
 func (c *exchangeCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-
-	collectorFuncs := map[string]func(ctx *ScrapeContext, ch chan<- prometheus.Metric) error{
-		"ADAccessProcesses":   c.collectADAccessProcesses,
-		"TransportQueues":     c.collectTransportQueues,
-		"HttpProxy":           c.collectHTTPProxy,
-		"ActiveSync":          c.collectActiveSync,
-		"AvailabilityService": c.collectAvailabilityService,
-		"OutlookWebAccess":    c.collectOWA,
-		"Autodiscover":        c.collectAutoDiscover,
-		"WorkloadManagement":  c.collectWorkloadManagementWorkloads,
-		"RpcClientAccess":     c.collectRPC,
-	}
-
-	for _, collectorName := range c.enabledCollectors {
-		if err := collectorFuncs[collectorName](ctx, ch); err != nil {
-			log.Errorf("Error in %s: %s", collectorName, err)
-			return err
-		}
-	}
-	return nil
+	return c.children.collect(ctx, ch)
 }
 
 // Perflib: [19108] MSExchange ADAccess Processes
@@ -222,12 +167,14 @@ type perflibADAccessProcesses struct {
 
 func (c *exchangeCollector) collectADAccessProcesses(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
 	var data []perflibADAccessProcesses
-	if err := unmarshalObject(ctx.perfObjects["MSExchange ADAccess Processes"], &data); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["MSExchange ADAccess Processes"], &data)
+	if err != nil {
 		return err
 	}
 
 	labelUseCount := make(map[string]int)
-	for _, proc := range data {
+	for i := range data {
+		proc := &data[i]
 		labelName := c.toLabelName(proc.Name)
 		if strings.HasSuffix(labelName, "_total") {
 			continue
@@ -239,36 +186,45 @@ func (c *exchangeCollector) collectADAccessProcesses(ctx *ScrapeContext, ch chan
 		if labelUseCount[labelName] > 1 {
 			labelName = fmt.Sprintf("%s_%d", labelName, labelUseCount[labelName])
 		}
-		ch <- prometheus.MustNewConstMetric(
-			c.LDAPReadTime,
-			prometheus.CounterValue,
-			c.msToSec(proc.LDAPReadTime),
-			labelName,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.LDAPSearchTime,
-			prometheus.CounterValue,
-			c.msToSec(proc.LDAPSearchTime),
-			labelName,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.LDAPWriteTime,
-			prometheus.CounterValue,
-			c.msToSec(proc.LDAPWriteTime),
-			labelName,
-		)
-		ch <- prometheus.MustNewConstMetric(
+		if present.has(&proc.LDAPReadTime) {
+			ch <- prometheus.MustNewConstMetric(
+				c.LDAPReadTime,
+				prometheus.CounterValue,
+				c.msToSec(proc.LDAPReadTime),
+				labelName,
+			)
+		}
+		if present.has(&proc.LDAPSearchTime) {
+			ch <- prometheus.MustNewConstMetric(
+				c.LDAPSearchTime,
+				prometheus.CounterValue,
+				c.msToSec(proc.LDAPSearchTime),
+				labelName,
+			)
+		}
+		if present.has(&proc.LDAPWriteTime) {
+			ch <- prometheus.MustNewConstMetric(
+				c.LDAPWriteTime,
+				prometheus.CounterValue,
+				c.msToSec(proc.LDAPWriteTime),
+				labelName,
+			)
+		}
+		present.emit(
+			ch,
 			c.LDAPTimeoutErrorsPerSec,
 			prometheus.CounterValue,
-			proc.LDAPTimeoutErrorsPerSec,
-			labelName,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.LongRunningLDAPOperationsPerMin,
-			prometheus.CounterValue,
-			proc.LongRunningLDAPOperationsPerMin*60,
+			&proc.LDAPTimeoutErrorsPerSec,
 			labelName,
 		)
+		if present.has(&proc.LongRunningLDAPOperationsPerMin) {
+			ch <- prometheus.MustNewConstMetric(
+				c.LongRunningLDAPOperationsPerMin,
+				prometheus.CounterValue,
+				proc.LongRunningLDAPOperationsPerMin*60,
+				labelName,
+			)
+		}
 	}
 	return nil
 }
@@ -280,15 +236,18 @@ type perflibAvailabilityService struct {
 
 func (c *exchangeCollector) collectAvailabilityService(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
 	var data []perflibAvailabilityService
-	if err := unmarshalObject(ctx.perfObjects["MSExchange Availability Service"], &data); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["MSExchange Availability Service"], &data)
+	if err != nil {
 		return err
 	}
 
-	for _, availservice := range data {
-		ch <- prometheus.MustNewConstMetric(
+	for i := range data {
+		availservice := &data[i]
+		present.emit(
+			ch,
 			c.AvailabilityRequestsSec,
 			prometheus.CounterValue,
-			availservice.RequestsSec,
+			&availservice.RequestsSec,
 		)
 	}
 	return nil
@@ -308,46 +267,56 @@ type perflibHTTPProxy struct {
 
 func (c *exchangeCollector) collectHTTPProxy(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
 	var data []perflibHTTPProxy
-	if err := unmarshalObject(ctx.perfObjects["MSExchange HttpProxy"], &data); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["MSExchange HttpProxy"], &data)
+	if err != nil {
 		return err
 	}
 
-	for _, instance := range data {
+	for i := range data {
+		instance := &data[i]
 		labelName := c.toLabelName(instance.Name)
-		ch <- prometheus.MustNewConstMetric(
-			c.MailboxServerLocatorAverageLatency,
-			prometheus.GaugeValue,
-			c.msToSec(instance.MailboxServerLocatorAverageLatency),
-			labelName,
-		)
-		ch <- prometheus.MustNewConstMetric(
+		if present.has(&instance.MailboxServerLocatorAverageLatency) {
+			ch <- prometheus.MustNewConstMetric(
+				c.MailboxServerLocatorAverageLatency,
+				prometheus.GaugeValue,
+				c.msToSec(instance.MailboxServerLocatorAverageLatency),
+				labelName,
+			)
+		}
+		present.emit(
+			ch,
 			c.AverageAuthenticationLatency,
 			prometheus.GaugeValue,
-			instance.AverageAuthenticationLatency,
+			&instance.AverageAuthenticationLatency,
 			labelName,
 		)
-		ch <- prometheus.MustNewConstMetric(
-			c.AverageCASProcessingLatency,
-			prometheus.GaugeValue,
-			c.msToSec(instance.AverageCASProcessingLatency),
-			labelName,
-		)
-		ch <- prometheus.MustNewConstMetric(
+		if present.has(&instance.AverageCASProcessingLatency) {
+			ch <- prometheus.MustNewConstMetric(
+				c.AverageCASProcessingLatency,
+				prometheus.GaugeValue,
+				c.msToSec(instance.AverageCASProcessingLatency),
+				labelName,
+			)
+		}
+		present.emit(
+			ch,
 			c.MailboxServerProxyFailureRate,
 			prometheus.GaugeValue,
-			instance.MailboxServerProxyFailureRate,
+			&instance.MailboxServerProxyFailureRate,
 			labelName,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.OutstandingProxyRequests,
 			prometheus.GaugeValue,
-			instance.OutstandingProxyRequests,
+			&instance.OutstandingProxyRequests,
 			labelName,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ProxyRequestsPerSec,
 			prometheus.CounterValue,
-			instance.ProxyRequestsPerSec,
+			&instance.ProxyRequestsPerSec,
 			labelName,
 		)
 	}
@@ -362,20 +331,24 @@ type perflibOWA struct {
 
 func (c *exchangeCollector) collectOWA(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
 	var data []perflibOWA
-	if err := unmarshalObject(ctx.perfObjects["MSExchange OWA"], &data); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["MSExchange OWA"], &data)
+	if err != nil {
 		return err
 	}
 
-	for _, owa := range data {
-		ch <- prometheus.MustNewConstMetric(
+	for i := range data {
+		owa := &data[i]
+		present.emit(
+			ch,
 			c.CurrentUniqueUsers,
 			prometheus.GaugeValue,
-			owa.CurrentUniqueUsers,
+			&owa.CurrentUniqueUsers,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.OWARequestsPerSec,
 			prometheus.CounterValue,
-			owa.RequestsPerSec,
+			&owa.RequestsPerSec,
 		)
 	}
 	return nil
@@ -390,25 +363,30 @@ type perflibActiveSync struct {
 
 func (c *exchangeCollector) collectActiveSync(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
 	var data []perflibActiveSync
-	if err := unmarshalObject(ctx.perfObjects["MSExchange ActiveSync"], &data); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["MSExchange ActiveSync"], &data)
+	if err != nil {
 		return err
 	}
 
-	for _, instance := range data {
-		ch <- prometheus.MustNewConstMetric(
+	for i := range data {
+		instance := &data[i]
+		present.emit(
+			ch,
 			c.ActiveSyncRequestsPerSec,
 			prometheus.CounterValue,
-			instance.RequestsPerSec,
+			&instance.RequestsPerSec,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PingCommandsPending,
 			prometheus.GaugeValue,
-			instance.PingCommandsPending,
+			&instance.PingCommandsPending,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.SyncCommandsPerSec,
 			prometheus.CounterValue,
-			instance.SyncCommandsPerSec,
+			&instance.SyncCommandsPerSec,
 		)
 	}
 	return nil
@@ -426,40 +404,49 @@ type perflibRPCClientAccess struct {
 
 func (c *exchangeCollector) collectRPC(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
 	var data []perflibRPCClientAccess
-	if err := unmarshalObject(ctx.perfObjects["MSExchange RpcClientAccess"], &data); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["MSExchange RpcClientAccess"], &data)
+	if err != nil {
 		return err
 	}
 
-	for _, rpc := range data {
-		ch <- prometheus.MustNewConstMetric(
-			c.RPCAveragedLatency,
-			prometheus.GaugeValue,
-			c.msToSec(rpc.RPCAveragedLatency),
-		)
-		ch <- prometheus.MustNewConstMetric(
+	for i := range data {
+		rpc := &data[i]
+		if present.has(&rpc.RPCAveragedLatency) {
+			ch <- prometheus.MustNewConstMetric(
+				c.RPCAveragedLatency,
+				prometheus.GaugeValue,
+				c.msToSec(rpc.RPCAveragedLatency),
+			)
+		}
+		present.emit(
+			ch,
 			c.RPCRequests,
 			prometheus.GaugeValue,
-			rpc.RPCRequests,
+			&rpc.RPCRequests,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ActiveUserCount,
 			prometheus.GaugeValue,
-			rpc.ActiveUserCount,
+			&rpc.ActiveUserCount,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ConnectionCount,
 			prometheus.GaugeValue,
-			rpc.ConnectionCount,
+			&rpc.ConnectionCount,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.RPCOperationsPerSec,
 			prometheus.CounterValue,
-			rpc.RPCOperationsPerSec,
+			&rpc.RPCOperationsPerSec,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.UserCount,
 			prometheus.GaugeValue,
-			rpc.UserCount,
+			&rpc.UserCount,
 		)
 	}
 
@@ -482,61 +469,71 @@ type perflibTransportQueues struct {
 
 func (c *exchangeCollector) collectTransportQueues(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
 	var data []perflibTransportQueues
-	if err := unmarshalObject(ctx.perfObjects["MSExchangeTransport Queues"], &data); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["MSExchangeTransport Queues"], &data)
+	if err != nil {
 		return err
 	}
 
-	for _, queue := range data {
+	for i := range data {
+		queue := &data[i]
 		labelName := c.toLabelName(queue.Name)
 		if strings.HasSuffix(labelName, "_total") {
 			continue
 		}
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ExternalActiveRemoteDeliveryQueueLength,
 			prometheus.GaugeValue,
-			queue.ExternalActiveRemoteDeliveryQueueLength,
+			&queue.ExternalActiveRemoteDeliveryQueueLength,
 			labelName,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.InternalActiveRemoteDeliveryQueueLength,
 			prometheus.GaugeValue,
-			queue.InternalActiveRemoteDeliveryQueueLength,
+			&queue.InternalActiveRemoteDeliveryQueueLength,
 			labelName,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ActiveMailboxDeliveryQueueLength,
 			prometheus.GaugeValue,
-			queue.ActiveMailboxDeliveryQueueLength,
+			&queue.ActiveMailboxDeliveryQueueLength,
 			labelName,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.RetryMailboxDeliveryQueueLength,
 			prometheus.GaugeValue,
-			queue.RetryMailboxDeliveryQueueLength,
+			&queue.RetryMailboxDeliveryQueueLength,
 			labelName,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.UnreachableQueueLength,
 			prometheus.GaugeValue,
-			queue.UnreachableQueueLength,
+			&queue.UnreachableQueueLength,
 			labelName,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ExternalLargestDeliveryQueueLength,
 			prometheus.GaugeValue,
-			queue.ExternalLargestDeliveryQueueLength,
+			&queue.ExternalLargestDeliveryQueueLength,
 			labelName,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.InternalLargestDeliveryQueueLength,
 			prometheus.GaugeValue,
-			queue.InternalLargestDeliveryQueueLength,
+			&queue.InternalLargestDeliveryQueueLength,
 			labelName,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PoisonQueueLength,
 			prometheus.GaugeValue,
-			queue.PoisonQueueLength,
+			&queue.PoisonQueueLength,
 			labelName,
 		)
 	}
@@ -556,43 +553,50 @@ type perflibWorkloadManagementWorkloads struct {
 
 func (c *exchangeCollector) collectWorkloadManagementWorkloads(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
 	var data []perflibWorkloadManagementWorkloads
-	if err := unmarshalObject(ctx.perfObjects["MSExchange WorkloadManagement Workloads"], &data); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["MSExchange WorkloadManagement Workloads"], &data)
+	if err != nil {
 		return err
 	}
 
-	for _, instance := range data {
+	for i := range data {
+		instance := &data[i]
 		labelName := c.toLabelName(instance.Name)
 		if strings.HasSuffix(labelName, "_total") {
 			continue
 		}
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ActiveTasks,
 			prometheus.GaugeValue,
-			instance.ActiveTasks,
+			&instance.ActiveTasks,
 			labelName,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.CompletedTasks,
 			prometheus.CounterValue,
-			instance.CompletedTasks,
+			&instance.CompletedTasks,
 			labelName,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.QueuedTasks,
 			prometheus.CounterValue,
-			instance.QueuedTasks,
+			&instance.QueuedTasks,
 			labelName,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.YieldedTasks,
 			prometheus.CounterValue,
-			instance.YieldedTasks,
+			&instance.YieldedTasks,
 			labelName,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.IsActive,
 			prometheus.GaugeValue,
-			instance.IsActive,
+			&instance.IsActive,
 			labelName,
 		)
 	}
@@ -607,14 +611,17 @@ type perflibAutodiscover struct {
 
 func (c *exchangeCollector) collectAutoDiscover(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
 	var data []perflibAutodiscover
-	if err := unmarshalObject(ctx.perfObjects["MSExchangeAutodiscover"], &data); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["MSExchangeAutodiscover"], &data)
+	if err != nil {
 		return err
 	}
-	for _, autodisc := range data {
-		ch <- prometheus.MustNewConstMetric(
+	for i := range data {
+		autodisc := &data[i]
+		present.emit(
+			ch,
 			c.AutodiscoverRequestsPerSec,
 			prometheus.CounterValue,
-			autodisc.RequestsPerSec,
+			&autodisc.RequestsPerSec,
 		)
 	}
 	return nil
@@ -4,6 +4,10 @@ import (
 	"testing"
 )
 
+func TestExchangeCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "exchange", newExchangeCollector)
+}
+
 func BenchmarkExchangeCollector(b *testing.B) {
 	benchmarkCollector(b, "exchange", newExchangeCollector)
 }
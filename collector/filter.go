@@ -0,0 +1,312 @@
+package collector
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus-community/windows_exporter/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// filterFlags holds the include and exclude flags of an instanceFilter.
+//
+// Each flag may be given multiple times. A value is either a regular
+// expression matched against the instance name, or a label matcher of the
+// form label=value (exact match) or label=~regexp, where label is one of the
+// labels the collector exposes for the instance.
+type filterFlags struct {
+	labels []string
+
+	includeName string
+	excludeName string
+	include     *[]string
+	exclude     *[]string
+
+	deprecatedIncludeName string
+	deprecatedExcludeName string
+	deprecatedInclude     *string
+	deprecatedExclude     *string
+}
+
+// registerFilterFlags registers the <prefix>include and <prefix>exclude flags
+// on the default kingpin application. The first label is the one matched by
+// plain regular expressions.
+func registerFilterFlags(prefix string, noun string, labels ...string) *filterFlags {
+	f := &filterFlags{
+		labels:      labels,
+		includeName: prefix + "include",
+		excludeName: prefix + "exclude",
+		include:     new([]string),
+		exclude:     new([]string),
+	}
+	kingpin.Flag(
+		f.includeName,
+		fmt.Sprintf("Regexp of %s to include, or a label matcher on one of %s, e.g. '%s=value' or '%s=~regexp'. May be given multiple times. %s must match at least one include rule and no exclude rule to be included.", noun, strings.Join(labels, ", "), labels[0], labels[0], noun),
+	).SetValue(newFilterRulesValue(labels, f.include))
+	kingpin.Flag(
+		f.excludeName,
+		fmt.Sprintf("Regexp of %s to exclude, or a label matcher on one of %s, e.g. '%s=value' or '%s=~regexp'. May be given multiple times. %s must match at least one include rule and no exclude rule to be included.", noun, strings.Join(labels, ", "), labels[0], labels[0], noun),
+	).SetValue(newFilterRulesValue(labels, f.exclude))
+	return f
+}
+
+// withDeprecatedFlags registers hidden aliases for the whitelist/blacklist
+// flags previously used by the collector.
+func (f *filterFlags) withDeprecatedFlags(includeName, excludeName string) *filterFlags {
+	f.deprecatedIncludeName = includeName
+	f.deprecatedExcludeName = excludeName
+	f.deprecatedInclude = new(string)
+	f.deprecatedExclude = new(string)
+	kingpin.Flag(includeName, fmt.Sprintf("DEPRECATED: Use --%s.", f.includeName)).Hidden().SetValue(&filterRuleValue{labels: f.labels, rule: f.deprecatedInclude})
+	kingpin.Flag(excludeName, fmt.Sprintf("DEPRECATED: Use --%s.", f.excludeName)).Hidden().SetValue(&filterRuleValue{labels: f.labels, rule: f.deprecatedExclude})
+	return f
+}
+
+// filterRulesValue is a repeatable kingpin value holding filter rules, which
+// are parsed when set so that invalid rules are rejected with the flags.
+type filterRulesValue struct {
+	labels []string
+	rules  *[]string
+}
+
+func newFilterRulesValue(labels []string, rules *[]string) *filterRulesValue {
+	return &filterRulesValue{labels: labels, rules: rules}
+}
+
+func (v *filterRulesValue) Set(rule string) error {
+	if err := v.Validate(rule); err != nil {
+		return err
+	}
+	*v.rules = append(*v.rules, rule)
+	return nil
+}
+
+// Validate returns an error if rule is not a valid filter rule, without
+// adding it.
+func (v *filterRulesValue) Validate(rule string) error {
+	if rule == "" {
+		return nil
+	}
+	_, err := parseFilterRule(v.labels, rule)
+	return err
+}
+
+func (v *filterRulesValue) String() string {
+	return strings.Join(*v.rules, ",")
+}
+
+func (v *filterRulesValue) IsCumulative() bool {
+	return true
+}
+
+// Reset removes all rules, before the flags are parsed once more with the
+// values of a configuration file.
+func (v *filterRulesValue) Reset() {
+	*v.rules = nil
+}
+
+// filterRuleValue is the single rule counterpart of filterRulesValue, used by
+// the deprecated flags.
+type filterRuleValue struct {
+	labels []string
+	rule   *string
+}
+
+func (v *filterRuleValue) Set(rule string) error {
+	if err := v.Validate(rule); err != nil {
+		return err
+	}
+	*v.rule = rule
+	return nil
+}
+
+// Validate returns an error if rule is not a valid filter rule, without
+// setting it.
+func (v *filterRuleValue) Validate(rule string) error {
+	if rule == "" {
+		return nil
+	}
+	_, err := parseFilterRule(v.labels, rule)
+	return err
+}
+
+func (v *filterRuleValue) String() string {
+	return *v.rule
+}
+
+// build compiles the configured rules into an instanceFilter. The rules of
+// the deprecated flags are marked, see matchesRaw.
+func (f *filterFlags) build() (*instanceFilter, error) {
+	filter, err := newInstanceFilter(f.labels, *f.include, *f.exclude)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range []struct {
+		name, replacement string
+		rule              *string
+		rules             *[]filterRule
+	}{
+		{f.deprecatedIncludeName, f.includeName, f.deprecatedInclude, &filter.include},
+		{f.deprecatedExcludeName, f.excludeName, f.deprecatedExclude, &filter.exclude},
+	} {
+		if d.rule == nil || *d.rule == "" {
+			continue
+		}
+		log.With("flag", d.name, "replacement", d.replacement).Warn("The flag is deprecated, use its replacement instead")
+		rule, err := parseFilterRule(f.labels, *d.rule)
+		if err != nil {
+			return nil, err
+		}
+		rule.deprecated = true
+		*d.rules = append(*d.rules, rule)
+	}
+	return filter, nil
+}
+
+// instanceFilter decides which instances (processes, volumes, sites, ...) a
+// collector reports. An instance is included if it matches at least one
+// include rule, or no include rules are configured, and no exclude rule.
+type instanceFilter struct {
+	include []filterRule
+	exclude []filterRule
+}
+
+type filterRule struct {
+	// Index into the label values passed to instanceFilter.matches.
+	label   int
+	pattern *regexp.Regexp
+	// literal is the only value matched by pattern, if it matches one.
+	literal *string
+	// deprecated is true for the rule of a deprecated flag.
+	deprecated bool
+}
+
+func newInstanceFilter(labels []string, include []string, exclude []string) (*instanceFilter, error) {
+	var (
+		f   instanceFilter
+		err error
+	)
+	if f.include, err = parseFilterRules(labels, include); err != nil {
+		return nil, err
+	}
+	if f.exclude, err = parseFilterRules(labels, exclude); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func parseFilterRules(labels []string, rules []string) ([]filterRule, error) {
+	parsed := make([]filterRule, 0, len(rules))
+	for _, r := range rules {
+		if r == "" {
+			continue
+		}
+		rule, err := parseFilterRule(labels, r)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, rule)
+	}
+	return parsed, nil
+}
+
+func parseFilterRule(labels []string, rule string) (filterRule, error) {
+	for i, label := range labels {
+		if !strings.HasPrefix(rule, label+"=") {
+			continue
+		}
+		value := strings.TrimPrefix(rule, label+"=")
+		expr := regexp.QuoteMeta(value)
+		if strings.HasPrefix(value, "~") {
+			expr = value[1:]
+		}
+		return newFilterRule(i, rule, expr)
+	}
+	return newFilterRule(0, rule, rule)
+}
+
+func newFilterRule(label int, rule string, expr string) (filterRule, error) {
+	pattern, err := compileAnchored(expr)
+	if err != nil {
+		return filterRule{}, fmt.Errorf("invalid filter %q: %v", rule, err)
+	}
+	r := filterRule{label: label, pattern: pattern}
+	if unanchored, err := regexp.Compile(expr); err == nil {
+		if literal, complete := unanchored.LiteralPrefix(); complete {
+			r.literal = &literal
+		}
+	}
+	return r, nil
+}
+
+func compileAnchored(expr string) (*regexp.Regexp, error) {
+	return regexp.Compile(fmt.Sprintf("^(?:%s)$", expr))
+}
+
+// isEmpty returns true if the filter includes every instance.
+func (f *instanceFilter) isEmpty() bool {
+	return len(f.include) == 0 && len(f.exclude) == 0
+}
+
+// matches reports whether an instance with the given label values, in the
+// order of the labels the filter was created with, should be included.
+func (f *instanceFilter) matches(values ...string) bool {
+	return f.matchesRaw(values[0], values...)
+}
+
+// matchesRaw is matches for collectors whose first label value is derived
+// from the raw instance name, e.g. without the "#<n>" index suffix. The rules
+// of the deprecated flags were written for the raw name, so they are matched
+// against raw instead of the first label value.
+func (f *instanceFilter) matchesRaw(raw string, values ...string) bool {
+	rawValues := append([]string{raw}, values[1:]...)
+	for _, r := range f.exclude {
+		if r.matchesDeprecated(values, rawValues) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, r := range f.include {
+		if r.matchesDeprecated(values, rawValues) {
+			return true
+		}
+	}
+	return false
+}
+
+// includedValues returns the values of the label with the given index
+// included by the filter, if every include rule matches a single value of
+// that label. Collectors use them to narrow down queries; the instances
+// returned must still be checked with matches.
+func (f *instanceFilter) includedValues(label int) ([]string, bool) {
+	if len(f.include) == 0 {
+		return nil, false
+	}
+	values := make([]string, 0, len(f.include))
+	for _, r := range f.include {
+		if r.label != label || r.literal == nil {
+			return nil, false
+		}
+		values = append(values, *r.literal)
+	}
+	return values, true
+}
+
+// matchesDeprecated matches the rule against rawValues if it is the rule of a
+// deprecated flag, and against values otherwise.
+func (r filterRule) matchesDeprecated(values, rawValues []string) bool {
+	if r.deprecated {
+		return r.matches(rawValues)
+	}
+	return r.matches(values)
+}
+
+func (r filterRule) matches(values []string) bool {
+	if r.label >= len(values) {
+		return false
+	}
+	return r.pattern.MatchString(values[r.label])
+}
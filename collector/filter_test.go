@@ -0,0 +1,129 @@
+package collector
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInstanceFilter(t *testing.T) {
+	labels := []string{"process", "process_id"}
+	cases := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		values   []string
+		expected bool
+	}{
+		{"no rules", nil, nil, []string{"svchost", "4"}, true},
+		{"empty rules", []string{""}, []string{""}, []string{"svchost", "4"}, true},
+		{"include regexp", []string{"svc.+"}, nil, []string{"svchost", "4"}, true},
+		{"include regexp is anchored", []string{"host"}, nil, []string{"svchost", "4"}, false},
+		{"include alternation is anchored", []string{"foo|svc"}, nil, []string{"svchost", "4"}, false},
+		{"second include rule", []string{"foo", "svchost"}, nil, []string{"svchost", "4"}, true},
+		{"exclude regexp", nil, []string{"svc.*"}, []string{"svchost", "4"}, false},
+		{"exclude wins", []string{".+"}, []string{"svchost"}, []string{"svchost", "4"}, false},
+		{"label exact", []string{"process_id=4"}, nil, []string{"svchost", "4"}, true},
+		{"label exact is literal", []string{"process=svc.+"}, nil, []string{"svchost", "4"}, false},
+		{"label exact mismatch", []string{"process_id=40"}, nil, []string{"svchost", "4"}, false},
+		{"label regexp", []string{"process_id=~[0-9]"}, nil, []string{"svchost", "4"}, true},
+		{"label exclude", nil, []string{"process_id=4"}, []string{"svchost", "4"}, false},
+		{"unknown label is a regexp", []string{"user=foo"}, nil, []string{"user=foo", "4"}, true},
+	}
+
+	for _, c := range cases {
+		f, err := newInstanceFilter(labels, c.include, c.exclude)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+			continue
+		}
+		if got := f.matches(c.values...); got != c.expected {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, got)
+		}
+	}
+}
+
+func TestInstanceFilterIncludedValues(t *testing.T) {
+	labels := []string{"name", "state"}
+	cases := []struct {
+		name     string
+		include  []string
+		expected []string
+		ok       bool
+	}{
+		{"no rules", nil, nil, false},
+		{"literal regexp", []string{"spooler"}, []string{"spooler"}, true},
+		{"label exact", []string{"name=sql.server", "name=w32time"}, []string{"sql.server", "w32time"}, true},
+		{"regexp", []string{"spooler", "sql.+"}, nil, false},
+		{"other label", []string{"spooler", "state=running"}, nil, false},
+	}
+
+	for _, c := range cases {
+		f, err := newInstanceFilter(labels, c.include, nil)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+			continue
+		}
+		got, ok := f.includedValues(0)
+		if ok != c.ok || !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("%s: expected %v %v, got %v %v", c.name, c.expected, c.ok, got, ok)
+		}
+	}
+}
+
+func TestInstanceFilterInvalid(t *testing.T) {
+	labels := []string{"site"}
+	for _, rule := range []string{"(", "site=~("} {
+		if _, err := newInstanceFilter(labels, []string{rule}, nil); err == nil {
+			t.Errorf("include %q: expected an error, but got ok", rule)
+		}
+		if _, err := newInstanceFilter(labels, nil, []string{rule}); err == nil {
+			t.Errorf("exclude %q: expected an error, but got ok", rule)
+		}
+	}
+}
+
+func TestFilterFlagsDeprecated(t *testing.T) {
+	include, exclude := []string{"a.*"}, []string{}
+	deprecatedInclude, deprecatedExclude := "b.*", "ab"
+	f := &filterFlags{
+		labels:                []string{"nic"},
+		includeName:           "collector.net.nic-include",
+		excludeName:           "collector.net.nic-exclude",
+		include:               &include,
+		exclude:               &exclude,
+		deprecatedIncludeName: "collector.net.nic-whitelist",
+		deprecatedExcludeName: "collector.net.nic-blacklist",
+		deprecatedInclude:     &deprecatedInclude,
+		deprecatedExclude:     &deprecatedExclude,
+	}
+	filter, err := f.build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for value, expected := range map[string]bool{"abc": true, "bcd": true, "ab": false, "cde": false} {
+		if got := filter.matches(value); got != expected {
+			t.Errorf("%q: expected %v, got %v", value, expected, got)
+		}
+	}
+}
+
+func TestFilterRulesValue(t *testing.T) {
+	var rules []string
+	v := newFilterRulesValue([]string{"process", "process_id"}, &rules)
+	for _, rule := range []string{"svc.+", "process_id=~4|8"} {
+		if err := v.Set(rule); err != nil {
+			t.Errorf("%q: %v", rule, err)
+		}
+	}
+	for _, rule := range []string{"(", "process_id=~("} {
+		if err := v.Validate(rule); err == nil {
+			t.Errorf("%q: expected an error, but got ok", rule)
+		}
+		if err := v.Set(rule); err == nil {
+			t.Errorf("%q: expected an error, but got ok", rule)
+		}
+	}
+	if expected := []string{"svc.+", "process_id=~4|8"}; !reflect.DeepEqual(rules, expected) {
+		t.Errorf("expected %v, got %v", expected, rules)
+	}
+}
@@ -1,8 +1,6 @@
 package collector
 
 import (
-	"github.com/StackExchange/wmi"
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -87,8 +85,7 @@ func newFSRMQuotaCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *FSRMQuotaCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting fsrmquota metrics:", desc, err)
+	if _, err := c.collect(ch); err != nil {
 		return err
 	}
 	return nil
@@ -113,11 +110,11 @@ type MSFT_FSRMQuota struct {
 
 func (c *FSRMQuotaCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []MSFT_FSRMQuota
-	q := queryAll(&dst)
+	q := querySelect(&dst, "")
 
 	var count int
 
-	if err := wmi.QueryNamespace(q, &dst, "root/microsoft/windows/fsrm"); err != nil {
+	if err := wmiQueryNamespace(q, &dst, "root/microsoft/windows/fsrm"); err != nil {
 		return nil, err
 	}
 
@@ -4,6 +4,10 @@ import (
 	"testing"
 )
 
+func TestFsrmQuotaCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "fsrmquota", newFSRMQuotaCollector)
+}
+
 func BenchmarkFsrmQuotaCollector(b *testing.B) {
 	benchmarkCollector(b, "fsrmquota", newFSRMQuotaCollector)
 }
@@ -0,0 +1,313 @@
+package collector
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/leoluk/perflib_exporter/perflib"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// Golden tests run a collector against recorded perflib objects and WMI
+// results from testdata/fixtures/<name>.json and compare the text exposition
+// of the emitted metrics with testdata/golden/<name>.prom.
+// Run `go test -run Golden -update` to rewrite the golden files.
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// fixture is the on-disk format of a recorded scrape. The perflib objects are
+// recorded in the format of perflib dumps.
+type fixture struct {
+	PerfDump
+	// WMI maps a WMI class name to the rows returned when querying it.
+	WMI map[string][]json.RawMessage `json:"wmi"`
+}
+
+func loadFixture(t *testing.T, name string) *fixture {
+	t.Helper()
+	b, err := ioutil.ReadFile(filepath.Join("testdata", "fixtures", name+".json"))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	var f fixture
+	if err := json.Unmarshal(b, &f); err != nil {
+		t.Fatalf("failed to parse fixture: %v", err)
+	}
+	return &f
+}
+
+func (f *fixture) scrapeContext(t *testing.T) *ScrapeContext {
+	t.Helper()
+	objs := make(map[string]*perflib.PerfObject, len(f.Perflib))
+	for _, o := range f.Perflib {
+		obj, err := o.PerfObject()
+		if err != nil {
+			t.Fatal(err)
+		}
+		objs[obj.Name] = obj
+	}
+	return &ScrapeContext{perfObjects: objs}
+}
+
+// query replays the recorded rows for the class named in the query. Where
+// clauses are not evaluated; fixtures should only contain the rows a real
+// query would have returned.
+func (f *fixture) query(query string, dst interface{}) error {
+	m := wqlClassPattern.FindStringSubmatch(query)
+	if m == nil {
+		return fmt.Errorf("unable to determine class of query %q", query)
+	}
+	rows, ok := f.WMI[m[1]]
+	if !ok {
+		return fmt.Errorf("no fixture data for class %s", m[1])
+	}
+	b, err := json.Marshal(rows)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dst)
+}
+
+// replayWMI redirects WMI queries to the fixture. The returned function restores
+// the original query functions.
+func (f *fixture) replayWMI() func() {
+	origQuery, origQueryNamespace := wmiQuery, wmiQueryNamespace
+	wmiQuery = func(query string, dst interface{}, _ ...interface{}) error {
+		return f.query(query, dst)
+	}
+	wmiQueryNamespace = func(query string, dst interface{}, _ string) error {
+		return f.query(query, dst)
+	}
+	return func() {
+		wmiQuery, wmiQueryNamespace = origQuery, origQueryNamespace
+	}
+}
+
+var parseFlagDefaults sync.Once
+
+// volatileMetrics are the metric families whose values differ between runs,
+// like the duration of child collectors. Their values are zeroed before the
+// output is compared with the golden file.
+var volatileMetrics = map[string]bool{
+	"windows_exporter_child_collector_duration_seconds": true,
+	"windows_mssql_collector_duration_seconds":          true,
+}
+
+// goldenExclusions lists the collectors without golden tests and why they
+// cannot replay a fixture.
+var goldenExclusions = map[string]string{
+	"container": "metrics are read from the Host Compute Service, not perflib or WMI",
+	"cs":        "metrics are read from the Win32 API (GetSystemInfo, GlobalMemoryStatusEx, GetComputerName)",
+	"os":        "metrics are read from the Win32 API, the registry and the paging files",
+	"textfile":  "metrics are read from text files, covered by the tests in textfile_test.go",
+}
+
+// TestCollectorGoldenCoverage checks that every collector either has a golden
+// file or is listed in goldenExclusions.
+func TestCollectorGoldenCoverage(t *testing.T) {
+	for _, name := range Available() {
+		_, err := os.Stat(filepath.Join("testdata", "golden", name+".prom"))
+		reason, excluded := goldenExclusions[name]
+		switch {
+		case err == nil && excluded:
+			t.Errorf("collector %s has a golden file but is excluded: %s", name, reason)
+		case err != nil && !excluded:
+			t.Errorf("collector %s has no golden file and is not listed in goldenExclusions", name)
+		}
+	}
+}
+
+// collectorAdapter exposes a Collector as an unchecked prometheus.Collector.
+type collectorAdapter struct {
+	t   *testing.T
+	c   Collector
+	ctx *ScrapeContext
+}
+
+func (a collectorAdapter) Describe(chan<- *prometheus.Desc) {}
+
+func (a collectorAdapter) Collect(ch chan<- prometheus.Metric) {
+	if err := a.c.Collect(a.ctx, ch); err != nil {
+		a.t.Errorf("collector returned error: %v", err)
+	}
+}
+
+// parseFlags parses the default flag values once, which the collectors read
+// when they are built.
+func parseFlags(t *testing.T) {
+	t.Helper()
+	parseFlagDefaults.Do(func() {
+		if _, err := kingpin.CommandLine.Parse([]string{}); err != nil {
+			t.Fatalf("failed to parse flag defaults: %v", err)
+		}
+	})
+}
+
+// scrapeText runs a single scrape of c and returns the text exposition of the
+// emitted metrics.
+func scrapeText(t *testing.T, c Collector, ctx *ScrapeContext) []byte {
+	t.Helper()
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(collectorAdapter{t: t, c: c, ctx: ctx})
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	for _, mf := range mfs {
+		if !volatileMetrics[mf.GetName()] {
+			continue
+		}
+		for _, m := range mf.Metric {
+			if m.Gauge != nil {
+				m.Gauge.Value = new(float64)
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	for _, mf := range mfs {
+		if _, err := expfmt.MetricFamilyToText(&out, mf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return out.Bytes()
+}
+
+// testCollectorGolden builds the collector with the default flag values, runs a
+// single scrape against the named fixture and compares the result with the
+// golden file of the same name.
+func testCollectorGolden(t *testing.T, name string, collectFunc func() (Collector, error)) {
+	t.Helper()
+	parseFlags(t)
+
+	f := loadFixture(t, name)
+	restore := f.replayWMI()
+	defer restore()
+
+	c, err := collectFunc()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := scrapeText(t, c, f.scrapeContext(t))
+
+	goldenFile := filepath.Join("testdata", "golden", name+".prom")
+	if *updateGolden {
+		if err := ioutil.WriteFile(goldenFile, got, 0644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+		return
+	}
+	want, err := ioutil.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("output of collector %s does not match %s (run with -update to regenerate):\n%s", name, goldenFile, lineDiff(string(want), string(got)))
+	}
+}
+
+// collectorFunc adapts a function to the Collector interface.
+type collectorFunc func(ctx *ScrapeContext, ch chan<- prometheus.Metric) error
+
+func (f collectorFunc) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
+	return f(ctx, ch)
+}
+
+// testCollectorParity checks that a collector moved from WMI to perflib exposes
+// the same series as before. The collector is scraped once on the perflib
+// objects of the named fixture, and legacy, the WMI implementation it
+// replaced, once on the WMI rows of the same fixture, which hold the same raw
+// values. Both scrapes must expose the same names, labels and values.
+func testCollectorParity(t *testing.T, name string, collectFunc func() (Collector, error), legacy func(c Collector, ch chan<- prometheus.Metric) error) {
+	t.Helper()
+	parseFlags(t)
+
+	f := loadFixture(t, name)
+	if len(f.WMI) == 0 {
+		t.Fatalf("fixture %s has no WMI rows", name)
+	}
+	restore := f.replayWMI()
+	defer restore()
+
+	c, err := collectFunc()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromPerflib := scrapeText(t, c, f.scrapeContext(t))
+	fromWMI := scrapeText(t, collectorFunc(func(_ *ScrapeContext, ch chan<- prometheus.Metric) error {
+		return legacy(c, ch)
+	}), &ScrapeContext{})
+	if !bytes.Equal(fromWMI, fromPerflib) {
+		t.Errorf("output of collector %s from perflib (+) differs from the WMI implementation (-):\n%s", name, lineDiff(string(fromWMI), string(fromPerflib)))
+	}
+}
+
+// lineDiff returns an ordered diff of the lines of want and got. Lines only
+// present in want are prefixed with "-", lines only present in got with "+",
+// and each hunk starts with the line numbers in want and got.
+func lineDiff(want, got string) string {
+	a := strings.Split(want, "\n")
+	b := strings.Split(got, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	inHunk := false
+	hunk := func(i, j int) {
+		if !inHunk {
+			fmt.Fprintf(&buf, "@@ want line %d, got line %d @@\n", i+1, j+1)
+			inHunk = true
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			inHunk = false
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			hunk(i, j)
+			fmt.Fprintf(&buf, "-%s\n", a[i])
+			i++
+		default:
+			hunk(i, j)
+			fmt.Fprintf(&buf, "+%s\n", b[j])
+			j++
+		}
+	}
+	return buf.String()
+}
+
+func TestLineDiff(t *testing.T) {
+	want := "a\nb\nc\nd\n"
+	got := "a\nc\nb\nd\ne\n"
+	expected := "@@ want line 2, got line 2 @@\n-b\n@@ want line 4, got line 3 @@\n+b\n@@ want line 5, got line 5 @@\n+e\n"
+	if diff := lineDiff(want, got); diff != expected {
+		t.Errorf("expected diff\n%s\ngot\n%s", expected, diff)
+	}
+}
@@ -5,7 +5,6 @@ package collector
 import (
 	"strings"
 
-	"github.com/StackExchange/wmi"
 	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -110,12 +109,27 @@ type HyperVCollector struct {
 	VMNetworkDroppedPacketsOutgoing *prometheus.Desc
 	VMNetworkPacketsReceived        *prometheus.Desc
 	VMNetworkPacketsSent            *prometheus.Desc
+
+	children *childCollectors
 }
 
+var hypervChildFlags = registerChildCollectorFlags("hyperv",
+	"health",
+	"vid",
+	"hv",
+	"processor",
+	"host_cpu",
+	"vm_cpu",
+	"switch",
+	"ethernet",
+	"storage",
+	"network",
+)
+
 // NewHyperVCollector ...
 func NewHyperVCollector() (Collector, error) {
 	buildSubsystemName := func(component string) string { return "hyperv_" + component }
-	return &HyperVCollector{
+	c := &HyperVCollector{
 		HealthCritical: prometheus.NewDesc(
 			prometheus.BuildFQName(Namespace, buildSubsystemName("health"), "critical"),
 			"This counter represents the number of virtual machines with critical health",
@@ -592,63 +606,39 @@ func NewHyperVCollector() (Collector, error) {
 			[]string{"vm_interface"},
 			nil,
 		),
-	}, nil
-}
-
-// Collect sends the metric values for each metric
-// to the provided prometheus Metric channel.
-func (c *HyperVCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collectVmHealth(ch); err != nil {
-		log.Error("failed collecting hyperV health status metrics:", desc, err)
-		return err
 	}
 
-	if desc, err := c.collectVmVid(ch); err != nil {
-		log.Error("failed collecting hyperV pages metrics:", desc, err)
-		return err
-	}
-
-	if desc, err := c.collectVmHv(ch); err != nil {
-		log.Error("failed collecting hyperV hv status metrics:", desc, err)
-		return err
-	}
-
-	if desc, err := c.collectVmProcessor(ch); err != nil {
-		log.Error("failed collecting hyperV processor metrics:", desc, err)
-		return err
-	}
-
-	if desc, err := c.collectHostCpuUsage(ch); err != nil {
-		log.Error("failed collecting hyperV host CPU metrics:", desc, err)
-		return err
-	}
-
-	if desc, err := c.collectVmCpuUsage(ch); err != nil {
-		log.Error("failed collecting hyperV VM CPU metrics:", desc, err)
-		return err
-	}
-
-	if desc, err := c.collectVmSwitch(ch); err != nil {
-		log.Error("failed collecting hyperV switch metrics:", desc, err)
-		return err
-	}
-
-	if desc, err := c.collectVmEthernet(ch); err != nil {
-		log.Error("failed collecting hyperV ethernet metrics:", desc, err)
-		return err
-	}
-
-	if desc, err := c.collectVmStorage(ch); err != nil {
-		log.Error("failed collecting hyperV virtual storage metrics:", desc, err)
-		return err
+	var err error
+	c.children, err = hypervChildFlags.build([]childCollector{
+		{"health", "Win32_PerfRawData_VmmsVirtualMachineStats_HyperVVirtualMachineHealthSummary", hypervChild(c.collectVmHealth)},
+		{"vid", "Win32_PerfRawData_VidPerfProvider_HyperVVMVidPartition", hypervChild(c.collectVmVid)},
+		{"hv", "Win32_PerfRawData_HvStats_HyperVHypervisorRootPartition", hypervChild(c.collectVmHv)},
+		{"processor", "Win32_PerfRawData_HvStats_HyperVHypervisor", hypervChild(c.collectVmProcessor)},
+		{"host_cpu", "Win32_PerfRawData_HvStats_HyperVHypervisorRootVirtualProcessor", hypervChild(c.collectHostCpuUsage)},
+		{"vm_cpu", "Win32_PerfRawData_HvStats_HyperVHypervisorVirtualProcessor", hypervChild(c.collectVmCpuUsage)},
+		{"switch", "Win32_PerfRawData_NvspSwitchStats_HyperVVirtualSwitch", hypervChild(c.collectVmSwitch)},
+		{"ethernet", "Win32_PerfRawData_EthernetPerfProvider_HyperVLegacyNetworkAdapter", hypervChild(c.collectVmEthernet)},
+		{"storage", "Win32_PerfRawData_Counters_HyperVVirtualStorageDevice", hypervChild(c.collectVmStorage)},
+		{"network", "Win32_PerfRawData_NvspNicStats_HyperVVirtualNetworkAdapter", hypervChild(c.collectVmNetwork)},
+	})
+	if err != nil {
+		return nil, err
 	}
+	return c, nil
+}
 
-	if desc, err := c.collectVmNetwork(ch); err != nil {
-		log.Error("failed collecting hyperV virtual network metrics:", desc, err)
+// hypervChild adapts a collection function to a child collector.
+func hypervChild(fn func(ch chan<- prometheus.Metric) (*prometheus.Desc, error)) func(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
+	return func(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
+		_, err := fn(ch)
 		return err
 	}
+}
 
-	return nil
+// Collect sends the metric values for each metric
+// to the provided prometheus Metric channel.
+func (c *HyperVCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
+	return c.children.collect(ctx, ch)
 }
 
 // Win32_PerfRawData_VmmsVirtualMachineStats_HyperVVirtualMachineHealthSummary vm health status
@@ -659,8 +649,8 @@ type Win32_PerfRawData_VmmsVirtualMachineStats_HyperVVirtualMachineHealthSummary
 
 func (c *HyperVCollector) collectVmHealth(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []Win32_PerfRawData_VmmsVirtualMachineStats_HyperVVirtualMachineHealthSummary
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
 		return nil, err
 	}
 
@@ -692,8 +682,8 @@ type Win32_PerfRawData_VidPerfProvider_HyperVVMVidPartition struct {
 
 func (c *HyperVCollector) collectVmVid(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []Win32_PerfRawData_VidPerfProvider_HyperVVMVidPartition
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
 		return nil, err
 	}
 
@@ -756,8 +746,8 @@ type Win32_PerfRawData_HvStats_HyperVHypervisorRootPartition struct {
 
 func (c *HyperVCollector) collectVmHv(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []Win32_PerfRawData_HvStats_HyperVHypervisorRootPartition
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
 		return nil, err
 	}
 
@@ -894,8 +884,8 @@ type Win32_PerfRawData_HvStats_HyperVHypervisor struct {
 
 func (c *HyperVCollector) collectVmProcessor(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []Win32_PerfRawData_HvStats_HyperVHypervisor
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
 		return nil, err
 	}
 
@@ -929,8 +919,8 @@ type Win32_PerfRawData_HvStats_HyperVHypervisorRootVirtualProcessor struct {
 
 func (c *HyperVCollector) collectHostCpuUsage(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []Win32_PerfRawData_HvStats_HyperVHypervisorRootVirtualProcessor
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
 		return nil, err
 	}
 
@@ -941,7 +931,7 @@ func (c *HyperVCollector) collectHostCpuUsage(ch chan<- prometheus.Metric) (*pro
 		// The name format is Root VP <core id>
 		parts := strings.Split(obj.Name, " ")
 		if len(parts) != 3 {
-			log.Warnf("Unexpected format of Name in collectHostCpuUsage: %q", obj.Name)
+			log.With("query", "host_cpu_usage", "name", obj.Name).Warn("Unexpected format of Name, skipping")
 			continue
 		}
 		coreId := parts[2]
@@ -990,8 +980,8 @@ type Win32_PerfRawData_HvStats_HyperVHypervisorVirtualProcessor struct {
 
 func (c *HyperVCollector) collectVmCpuUsage(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []Win32_PerfRawData_HvStats_HyperVHypervisorVirtualProcessor
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
 		return nil, err
 	}
 
@@ -1002,12 +992,12 @@ func (c *HyperVCollector) collectVmCpuUsage(ch chan<- prometheus.Metric) (*prome
 		// The name format is <VM Name>:Hv VP <vcore id>
 		parts := strings.Split(obj.Name, ":")
 		if len(parts) != 2 {
-			log.Warnf("Unexpected format of Name in collectVmCpuUsage: %q, expected %q. Skipping.", obj.Name, "<VM Name>:Hv VP <vcore id>")
+			log.With("query", "vm_cpu_usage", "name", obj.Name, "expected", "<VM Name>:Hv VP <vcore id>").Warn("Unexpected format of Name, skipping")
 			continue
 		}
 		coreParts := strings.Split(parts[1], " ")
 		if len(coreParts) != 3 {
-			log.Warnf("Unexpected format of core identifier in collectVmCpuUsage: %q, expected %q. Skipping.", parts[1], "Hv VP <vcore id>")
+			log.With("query", "vm_cpu_usage", "core", parts[1], "expected", "Hv VP <vcore id>").Warn("Unexpected format of core identifier, skipping")
 			continue
 		}
 		vmName := parts[0]
@@ -1077,8 +1067,8 @@ type Win32_PerfRawData_NvspSwitchStats_HyperVVirtualSwitch struct {
 
 func (c *HyperVCollector) collectVmSwitch(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []Win32_PerfRawData_NvspSwitchStats_HyperVVirtualSwitch
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
 		return nil, err
 	}
 
@@ -1236,8 +1226,8 @@ type Win32_PerfRawData_EthernetPerfProvider_HyperVLegacyNetworkAdapter struct {
 
 func (c *HyperVCollector) collectVmEthernet(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []Win32_PerfRawData_EthernetPerfProvider_HyperVLegacyNetworkAdapter
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
 		return nil, err
 	}
 
@@ -1306,8 +1296,8 @@ type Win32_PerfRawData_Counters_HyperVVirtualStorageDevice struct {
 
 func (c *HyperVCollector) collectVmStorage(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []Win32_PerfRawData_Counters_HyperVVirtualStorageDevice
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
 		return nil, err
 	}
 
@@ -1375,8 +1365,8 @@ type Win32_PerfRawData_NvspNicStats_HyperVVirtualNetworkAdapter struct {
 
 func (c *HyperVCollector) collectVmNetwork(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []Win32_PerfRawData_NvspNicStats_HyperVVirtualNetworkAdapter
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
 		return nil, err
 	}
 
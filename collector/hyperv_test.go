@@ -4,6 +4,10 @@ import (
 	"testing"
 )
 
+func TestHypervCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "hyperv", NewHyperVCollector)
+}
+
 func BenchmarkHypervCollector(b *testing.B) {
 	benchmarkCollector(b, "hyperv", NewHyperVCollector)
 }
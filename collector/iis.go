@@ -4,15 +4,12 @@ package collector
 
 import (
 	"errors"
-	"fmt"
 	"regexp"
 
 	"golang.org/x/sys/windows/registry"
 
-	"github.com/StackExchange/wmi"
 	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
-	"gopkg.in/alecthomas/kingpin.v2"
 )
 
 func init() {
@@ -27,28 +24,28 @@ type simple_version struct {
 func getIISVersion() simple_version {
 	k, err := registry.OpenKey(registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\InetStp\`, registry.QUERY_VALUE)
 	if err != nil {
-		log.Warn("Couldn't open registry to determine IIS version:", err)
+		log.WithError(err).Warn("Couldn't open registry to determine IIS version")
 		return simple_version{}
 	}
 	defer func() {
 		err = k.Close()
 		if err != nil {
-			log.Warnf("Failed to close registry key: %v", err)
+			log.WithError(err).Warn("Failed to close registry key")
 		}
 	}()
 
 	major, _, err := k.GetIntegerValue("MajorVersion")
 	if err != nil {
-		log.Warn("Couldn't open registry to determine IIS version:", err)
+		log.WithError(err).Warn("Couldn't open registry to determine IIS version")
 		return simple_version{}
 	}
 	minor, _, err := k.GetIntegerValue("MinorVersion")
 	if err != nil {
-		log.Warn("Couldn't open registry to determine IIS version:", err)
+		log.WithError(err).Warn("Couldn't open registry to determine IIS version")
 		return simple_version{}
 	}
 
-	log.Debugf("Detected IIS %d.%d\n", major, minor)
+	log.With("major", major, "minor", minor).Debug("Detected IIS version")
 
 	return simple_version{
 		major: major,
@@ -57,10 +54,10 @@ func getIISVersion() simple_version {
 }
 
 var (
-	siteWhitelist = kingpin.Flag("collector.iis.site-whitelist", "Regexp of sites to whitelist. Site name must both match whitelist and not match blacklist to be included.").Default(".+").String()
-	siteBlacklist = kingpin.Flag("collector.iis.site-blacklist", "Regexp of sites to blacklist. Site name must both match whitelist and not match blacklist to be included.").String()
-	appWhitelist  = kingpin.Flag("collector.iis.app-whitelist", "Regexp of apps to whitelist. App name must both match whitelist and not match blacklist to be included.").Default(".+").String()
-	appBlacklist  = kingpin.Flag("collector.iis.app-blacklist", "Regexp of apps to blacklist. App name must both match whitelist and not match blacklist to be included.").String()
+	siteFilterFlags = registerFilterFlags("collector.iis.site-", "sites", "site").
+			withDeprecatedFlags("collector.iis.site-whitelist", "collector.iis.site-blacklist")
+	appFilterFlags = registerFilterFlags("collector.iis.app-", "apps", "app").
+			withDeprecatedFlags("collector.iis.app-whitelist", "collector.iis.app-blacklist")
 )
 
 type IISCollector struct {
@@ -87,8 +84,7 @@ type IISCollector struct {
 	TotalNotFoundErrors                 *prometheus.Desc
 	TotalRejectedAsyncIORequests        *prometheus.Desc
 
-	siteWhitelistPattern *regexp.Regexp
-	siteBlacklistPattern *regexp.Regexp
+	siteFilter *instanceFilter
 
 	CurrentApplicationPoolState        *prometheus.Desc
 	CurrentApplicationPoolUptime       *prometheus.Desc
@@ -185,8 +181,7 @@ type IISCollector struct {
 	ServiceCache_OutputCacheFlushedItemsTotal  *prometheus.Desc
 	ServiceCache_OutputCacheFlushesTotal       *prometheus.Desc
 
-	appWhitelistPattern *regexp.Regexp
-	appBlacklistPattern *regexp.Regexp
+	appFilter *instanceFilter
 
 	iis_version simple_version
 }
@@ -195,6 +190,15 @@ type IISCollector struct {
 func NewIISCollector() (Collector, error) {
 	const subsystem = "iis"
 
+	siteFilter, err := siteFilterFlags.build()
+	if err != nil {
+		return nil, err
+	}
+	appFilter, err := appFilterFlags.build()
+	if err != nil {
+		return nil, err
+	}
+
 	buildIIS := &IISCollector{
 		// Websites
 		// Gauges
@@ -327,8 +331,7 @@ func NewIISCollector() (Collector, error) {
 			nil,
 		),
 
-		siteWhitelistPattern: regexp.MustCompile(fmt.Sprintf("^(?:%s)$", *siteWhitelist)),
-		siteBlacklistPattern: regexp.MustCompile(fmt.Sprintf("^(?:%s)$", *siteBlacklist)),
+		siteFilter: siteFilter,
 
 		// App Pools
 		// Guages
@@ -807,8 +810,7 @@ func NewIISCollector() (Collector, error) {
 			nil,
 		),
 
-		appWhitelistPattern: regexp.MustCompile(fmt.Sprintf("^(?:%s)$", *appWhitelist)),
-		appBlacklistPattern: regexp.MustCompile(fmt.Sprintf("^(?:%s)$", *appBlacklist)),
+		appFilter: appFilter,
 	}
 
 	buildIIS.iis_version = getIISVersion()
@@ -819,8 +821,7 @@ func NewIISCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *IISCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting iis metrics:", desc, err)
+	if _, err := c.collect(ch); err != nil {
 		return err
 	}
 	return nil
@@ -1000,15 +1001,13 @@ var workerProcessNameExtractor = regexp.MustCompile(`^(\d+)_(.+)$`)
 
 func (c *IISCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []Win32_PerfRawData_W3SVC_WebService
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
 		return nil, err
 	}
 
 	for _, site := range dst {
-		if site.Name == "_Total" ||
-			c.siteBlacklistPattern.MatchString(site.Name) ||
-			!c.siteWhitelistPattern.MatchString(site.Name) {
+		if site.Name == "_Total" || !c.siteFilter.matches(site.Name) {
 			continue
 		}
 
@@ -1253,15 +1252,13 @@ func (c *IISCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, e
 	}
 
 	var dst2 []Win32_PerfRawData_APPPOOLCountersProvider_APPPOOLWAS
-	q2 := queryAll(&dst2)
-	if err := wmi.Query(q2, &dst2); err != nil {
+	q2 := querySelect(&dst2, "")
+	if err := wmiQuery(q2, &dst2); err != nil {
 		return nil, err
 	}
 
 	for _, app := range dst2 {
-		if app.Name == "_Total" ||
-			c.appBlacklistPattern.MatchString(app.Name) ||
-			!c.appWhitelistPattern.MatchString(app.Name) {
+		if app.Name == "_Total" || !c.appFilter.matches(app.Name) {
 			continue
 		}
 
@@ -1370,16 +1367,14 @@ func (c *IISCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, e
 	}
 
 	var dst_worker []Win32_PerfRawData_W3SVCW3WPCounterProvider_W3SVCW3WP
-	q = queryAll(&dst_worker)
-	if err := wmi.Query(q, &dst_worker); err != nil {
+	q = querySelect(&dst_worker, "")
+	if err := wmiQuery(q, &dst_worker); err != nil {
 		return nil, err
 	}
 	for _, app := range dst_worker {
 		// Extract the apppool name from the format <PID>_<NAME>
 		name := workerProcessNameExtractor.ReplaceAllString(app.Name, "$2")
-		if name == "_Total" ||
-			c.appBlacklistPattern.MatchString(name) ||
-			!c.appWhitelistPattern.MatchString(name) {
+		if name == "_Total" || !c.appFilter.matches(name) {
 			continue
 		}
 
@@ -1652,16 +1647,14 @@ func (c *IISCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, e
 
 	if c.iis_version.major >= 8 {
 		var dst_worker_iis8 []Win32_PerfRawData_W3SVCW3WPCounterProvider_W3SVCW3WP_IIS8
-		q = queryAllForClass(&dst_worker_iis8, "Win32_PerfRawData_W3SVCW3WPCounterProvider_W3SVCW3WP")
-		if err := wmi.Query(q, &dst_worker_iis8); err != nil {
+		q = querySelectForClass(&dst_worker_iis8, "Win32_PerfRawData_W3SVCW3WPCounterProvider_W3SVCW3WP", "")
+		if err := wmiQuery(q, &dst_worker_iis8); err != nil {
 			return nil, err
 		}
 		for _, app := range dst_worker_iis8 {
 			// Extract the apppool name from the format <PID>_<NAME>
 			name := workerProcessNameExtractor.ReplaceAllString(app.Name, "$2")
-			if name == "_Total" ||
-				c.appBlacklistPattern.MatchString(name) ||
-				!c.appWhitelistPattern.MatchString(name) {
+			if name == "_Total" || !c.appFilter.matches(name) {
 				continue
 			}
 
@@ -1735,8 +1728,8 @@ func (c *IISCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, e
 	}
 
 	var dst_cache []Win32_PerfRawData_W3SVC_WebServiceCache
-	q = queryAll(&dst_cache)
-	if err := wmi.Query(q, &dst_cache); err != nil {
+	q = querySelect(&dst_cache, "")
+	if err := wmiQuery(q, &dst_cache); err != nil {
 		return nil, err
 	}
 
@@ -4,6 +4,18 @@ import (
 	"testing"
 )
 
+func TestIISCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "iis", func() (Collector, error) {
+		c, err := NewIISCollector()
+		if err != nil {
+			return nil, err
+		}
+		// The version is read from the registry, the fixture is of IIS 10.
+		c.(*IISCollector).iis_version = simple_version{major: 10}
+		return c, nil
+	})
+}
+
 func BenchmarkIISCollector(b *testing.B) {
 	benchmarkCollector(b, "iis", NewIISCollector)
 }
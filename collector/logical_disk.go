@@ -3,28 +3,15 @@
 package collector
 
 import (
-	"fmt"
-	"regexp"
-
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
-	"gopkg.in/alecthomas/kingpin.v2"
 )
 
 func init() {
 	registerCollector("logical_disk", NewLogicalDiskCollector, "LogicalDisk")
 }
 
-var (
-	volumeWhitelist = kingpin.Flag(
-		"collector.logical_disk.volume-whitelist",
-		"Regexp of volumes to whitelist. Volume name must both match whitelist and not match blacklist to be included.",
-	).Default(".+").String()
-	volumeBlacklist = kingpin.Flag(
-		"collector.logical_disk.volume-blacklist",
-		"Regexp of volumes to blacklist. Volume name must both match whitelist and not match blacklist to be included.",
-	).Default("").String()
-)
+var volumeFilterFlags = registerFilterFlags("collector.logical_disk.volume-", "volumes", "volume").
+	withDeprecatedFlags("collector.logical_disk.volume-whitelist", "collector.logical_disk.volume-blacklist")
 
 // A LogicalDiskCollector is a Prometheus collector for perflib logicalDisk metrics
 type LogicalDiskCollector struct {
@@ -43,14 +30,18 @@ type LogicalDiskCollector struct {
 	WriteLatency     *prometheus.Desc
 	ReadWriteLatency *prometheus.Desc
 
-	volumeWhitelistPattern *regexp.Regexp
-	volumeBlacklistPattern *regexp.Regexp
+	volumeFilter *instanceFilter
 }
 
 // NewLogicalDiskCollector ...
 func NewLogicalDiskCollector() (Collector, error) {
 	const subsystem = "logical_disk"
 
+	volumeFilter, err := volumeFilterFlags.build()
+	if err != nil {
+		return nil, err
+	}
+
 	return &LogicalDiskCollector{
 		RequestsQueued: prometheus.NewDesc(
 			prometheus.BuildFQName(Namespace, subsystem, "requests_queued"),
@@ -150,16 +141,14 @@ func NewLogicalDiskCollector() (Collector, error) {
 			nil,
 		),
 
-		volumeWhitelistPattern: regexp.MustCompile(fmt.Sprintf("^(?:%s)$", *volumeWhitelist)),
-		volumeBlacklistPattern: regexp.MustCompile(fmt.Sprintf("^(?:%s)$", *volumeBlacklist)),
+		volumeFilter: volumeFilter,
 	}, nil
 }
 
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *LogicalDiskCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ctx, ch); err != nil {
-		log.Error("failed collecting logical_disk metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
@@ -177,8 +166,8 @@ type logicalDisk struct {
 	DiskWritesPerSec       float64 `perflib:"Disk Writes/sec"`
 	PercentDiskReadTime    float64 `perflib:"% Disk Read Time"`
 	PercentDiskWriteTime   float64 `perflib:"% Disk Write Time"`
-	PercentFreeSpace       float64 `perflib:"% Free Space_Base"`
-	PercentFreeSpace_Base  float64 `perflib:"Free Megabytes"`
+	PercentFreeSpace       float64 `perflib:"% Free Space"`
+	PercentFreeSpace_Base  float64 `perflib:"% Free Space,base"`
 	PercentIdleTime        float64 `perflib:"% Idle Time"`
 	SplitIOPerSec          float64 `perflib:"Split IO/Sec"`
 	AvgDiskSecPerRead      float64 `perflib:"Avg. Disk sec/Read"`
@@ -188,112 +177,128 @@ type logicalDisk struct {
 
 func (c *LogicalDiskCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []logicalDisk
-	if err := unmarshalObject(ctx.perfObjects["LogicalDisk"], &dst); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["LogicalDisk"], &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	for _, volume := range dst {
-		if volume.Name == "_Total" ||
-			c.volumeBlacklistPattern.MatchString(volume.Name) ||
-			!c.volumeWhitelistPattern.MatchString(volume.Name) {
+	for i := range dst {
+		volume := &dst[i]
+		if volume.Name == "_Total" || !c.volumeFilter.matches(volume.Name) {
 			continue
 		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.RequestsQueued,
 			prometheus.GaugeValue,
-			volume.CurrentDiskQueueLength,
+			&volume.CurrentDiskQueueLength,
 			volume.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ReadBytesTotal,
 			prometheus.CounterValue,
-			volume.DiskReadBytesPerSec,
+			&volume.DiskReadBytesPerSec,
 			volume.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ReadsTotal,
 			prometheus.CounterValue,
-			volume.DiskReadsPerSec,
+			&volume.DiskReadsPerSec,
 			volume.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WriteBytesTotal,
 			prometheus.CounterValue,
-			volume.DiskWriteBytesPerSec,
+			&volume.DiskWriteBytesPerSec,
 			volume.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WritesTotal,
 			prometheus.CounterValue,
-			volume.DiskWritesPerSec,
+			&volume.DiskWritesPerSec,
 			volume.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ReadTime,
 			prometheus.CounterValue,
-			volume.PercentDiskReadTime,
+			&volume.PercentDiskReadTime,
 			volume.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WriteTime,
 			prometheus.CounterValue,
-			volume.PercentDiskWriteTime,
+			&volume.PercentDiskWriteTime,
 			volume.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.FreeSpace,
-			prometheus.GaugeValue,
-			volume.PercentFreeSpace_Base*1024*1024,
-			volume.Name,
-		)
+		if present.has(&volume.PercentFreeSpace) {
+			ch <- prometheus.MustNewConstMetric(
+				c.FreeSpace,
+				prometheus.GaugeValue,
+				volume.PercentFreeSpace*1024*1024,
+				volume.Name,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
-			c.TotalSpace,
-			prometheus.GaugeValue,
-			volume.PercentFreeSpace*1024*1024,
-			volume.Name,
-		)
+		if present.has(&volume.PercentFreeSpace_Base) {
+			ch <- prometheus.MustNewConstMetric(
+				c.TotalSpace,
+				prometheus.GaugeValue,
+				volume.PercentFreeSpace_Base*1024*1024,
+				volume.Name,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.IdleTime,
 			prometheus.CounterValue,
-			volume.PercentIdleTime,
+			&volume.PercentIdleTime,
 			volume.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.SplitIOs,
 			prometheus.CounterValue,
-			volume.SplitIOPerSec,
+			&volume.SplitIOPerSec,
 			volume.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ReadLatency,
 			prometheus.CounterValue,
-			volume.AvgDiskSecPerRead*ticksToSecondsScaleFactor,
+			&volume.AvgDiskSecPerRead,
 			volume.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WriteLatency,
 			prometheus.CounterValue,
-			volume.AvgDiskSecPerWrite*ticksToSecondsScaleFactor,
+			&volume.AvgDiskSecPerWrite,
 			volume.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ReadWriteLatency,
 			prometheus.CounterValue,
-			volume.AvgDiskSecPerTransfer*ticksToSecondsScaleFactor,
+			&volume.AvgDiskSecPerTransfer,
 			volume.Name,
 		)
 	}
@@ -4,10 +4,10 @@ import (
 	"testing"
 )
 
-func BenchmarkLogicalDiskCollector(b *testing.B) {
-	// Whitelist is not set in testing context (kingpin flags not parsed), causing the collector to skip all disks.
-	localVolumeWhitelist := ".+"
-	volumeWhitelist = &localVolumeWhitelist
+func TestLogicalDiskCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "logical_disk", NewLogicalDiskCollector)
+}
 
+func BenchmarkLogicalDiskCollector(b *testing.B) {
 	benchmarkCollector(b, "logical_disk", NewLogicalDiskCollector)
 }
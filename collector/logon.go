@@ -5,8 +5,6 @@ package collector
 import (
 	"errors"
 
-	"github.com/StackExchange/wmi"
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -36,8 +34,7 @@ func NewLogonCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *LogonCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting user metrics:", desc, err)
+	if _, err := c.collect(ch); err != nil {
 		return err
 	}
 	return nil
@@ -51,8 +48,8 @@ type Win32_LogonSession struct {
 
 func (c *LogonCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []Win32_LogonSession
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
 		return nil, err
 	}
 	if len(dst) == 0 {
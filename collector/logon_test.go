@@ -4,6 +4,10 @@ import (
 	"testing"
 )
 
+func TestLogonCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "logon", NewLogonCollector)
+}
+
 func BenchmarkLogonCollector(b *testing.B) {
 	// No context name required as collector source is WMI
 	benchmarkCollector(b, "", NewLogonCollector)
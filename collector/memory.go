@@ -6,7 +6,6 @@
 package collector
 
 import (
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -256,8 +255,7 @@ func NewMemoryCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *MemoryCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ctx, ch); err != nil {
-		log.Error("failed collecting memory metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
@@ -302,200 +300,233 @@ type memory struct {
 
 func (c *MemoryCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []memory
-	if err := unmarshalObject(ctx.perfObjects["Memory"], &dst); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["Memory"], &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.AvailableBytes,
 		prometheus.GaugeValue,
-		dst[0].AvailableBytes,
+		&dst[0].AvailableBytes,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.CacheBytes,
 		prometheus.GaugeValue,
-		dst[0].CacheBytes,
+		&dst[0].CacheBytes,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.CacheBytesPeak,
 		prometheus.GaugeValue,
-		dst[0].CacheBytesPeak,
+		&dst[0].CacheBytesPeak,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.CacheFaultsTotal,
 		prometheus.GaugeValue,
-		dst[0].CacheFaultsPersec,
+		&dst[0].CacheFaultsPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.CommitLimit,
 		prometheus.GaugeValue,
-		dst[0].CommitLimit,
+		&dst[0].CommitLimit,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.CommittedBytes,
 		prometheus.GaugeValue,
-		dst[0].CommittedBytes,
+		&dst[0].CommittedBytes,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.DemandZeroFaultsTotal,
 		prometheus.GaugeValue,
-		dst[0].DemandZeroFaultsPersec,
+		&dst[0].DemandZeroFaultsPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.FreeAndZeroPageListBytes,
 		prometheus.GaugeValue,
-		dst[0].FreeAndZeroPageListBytes,
+		&dst[0].FreeAndZeroPageListBytes,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.FreeSystemPageTableEntries,
 		prometheus.GaugeValue,
-		dst[0].FreeSystemPageTableEntries,
+		&dst[0].FreeSystemPageTableEntries,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ModifiedPageListBytes,
 		prometheus.GaugeValue,
-		dst[0].ModifiedPageListBytes,
+		&dst[0].ModifiedPageListBytes,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.PageFaultsTotal,
 		prometheus.GaugeValue,
-		dst[0].PageFaultsPersec,
+		&dst[0].PageFaultsPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SwapPageReadsTotal,
 		prometheus.GaugeValue,
-		dst[0].PageReadsPersec,
+		&dst[0].PageReadsPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SwapPagesReadTotal,
 		prometheus.GaugeValue,
-		dst[0].PagesInputPersec,
+		&dst[0].PagesInputPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SwapPagesWrittenTotal,
 		prometheus.GaugeValue,
-		dst[0].PagesOutputPersec,
+		&dst[0].PagesOutputPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SwapPageOperationsTotal,
 		prometheus.GaugeValue,
-		dst[0].PagesPersec,
+		&dst[0].PagesPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SwapPageWritesTotal,
 		prometheus.GaugeValue,
-		dst[0].PageWritesPersec,
+		&dst[0].PageWritesPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.PoolNonpagedAllocsTotal,
 		prometheus.GaugeValue,
-		dst[0].PoolNonpagedAllocs,
+		&dst[0].PoolNonpagedAllocs,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.PoolNonpagedBytes,
 		prometheus.GaugeValue,
-		dst[0].PoolNonpagedBytes,
+		&dst[0].PoolNonpagedBytes,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.PoolPagedAllocsTotal,
 		prometheus.GaugeValue,
-		dst[0].PoolPagedAllocs,
+		&dst[0].PoolPagedAllocs,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.PoolPagedBytes,
 		prometheus.GaugeValue,
-		dst[0].PoolPagedBytes,
+		&dst[0].PoolPagedBytes,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.PoolPagedResidentBytes,
 		prometheus.GaugeValue,
-		dst[0].PoolPagedResidentBytes,
+		&dst[0].PoolPagedResidentBytes,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.StandbyCacheCoreBytes,
 		prometheus.GaugeValue,
-		dst[0].StandbyCacheCoreBytes,
+		&dst[0].StandbyCacheCoreBytes,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.StandbyCacheNormalPriorityBytes,
 		prometheus.GaugeValue,
-		dst[0].StandbyCacheNormalPriorityBytes,
+		&dst[0].StandbyCacheNormalPriorityBytes,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.StandbyCacheReserveBytes,
 		prometheus.GaugeValue,
-		dst[0].StandbyCacheReserveBytes,
+		&dst[0].StandbyCacheReserveBytes,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SystemCacheResidentBytes,
 		prometheus.GaugeValue,
-		dst[0].SystemCacheResidentBytes,
+		&dst[0].SystemCacheResidentBytes,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SystemCodeResidentBytes,
 		prometheus.GaugeValue,
-		dst[0].SystemCodeResidentBytes,
+		&dst[0].SystemCodeResidentBytes,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SystemCodeTotalBytes,
 		prometheus.GaugeValue,
-		dst[0].SystemCodeTotalBytes,
+		&dst[0].SystemCodeTotalBytes,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SystemDriverResidentBytes,
 		prometheus.GaugeValue,
-		dst[0].SystemDriverResidentBytes,
+		&dst[0].SystemDriverResidentBytes,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SystemDriverTotalBytes,
 		prometheus.GaugeValue,
-		dst[0].SystemDriverTotalBytes,
+		&dst[0].SystemDriverTotalBytes,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.TransitionFaultsTotal,
 		prometheus.GaugeValue,
-		dst[0].TransitionFaultsPersec,
+		&dst[0].TransitionFaultsPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.TransitionPagesRepurposedTotal,
 		prometheus.GaugeValue,
-		dst[0].TransitionPagesRePurposedPersec,
+		&dst[0].TransitionPagesRePurposedPersec,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.WriteCopiesTotal,
 		prometheus.GaugeValue,
-		dst[0].WriteCopiesPersec,
+		&dst[0].WriteCopiesPersec,
 	)
 
 	return nil, nil
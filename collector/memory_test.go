@@ -4,6 +4,10 @@ import (
 	"testing"
 )
 
+func TestMemoryCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "memory", NewMemoryCollector)
+}
+
 func BenchmarkMemoryCollector(b *testing.B) {
 	benchmarkCollector(b, "memory", NewMemoryCollector)
 }
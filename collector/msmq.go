@@ -3,38 +3,58 @@
 package collector
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
 
-	"github.com/StackExchange/wmi"
 	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
 
 func init() {
-	registerCollector("msmq", NewMSMQCollector)
+	registerCollector("msmq", NewMSMQCollector, "MSMQ Queue")
 }
 
 var (
-	msmqWhereClause = kingpin.Flag("collector.msmq.msmq-where", "WQL 'where' clause to use in WMI metrics query. Limits the response to the msmqs you specify and reduces the size of the response.").String()
+	msmqWhereClause  = kingpin.Flag("collector.msmq.msmq-where", "DEPRECATED: Use --collector.msmq.queue-include.").Hidden().String()
+	queueFilterFlags = registerFilterFlags("collector.msmq.queue-", "queues", "name")
 )
 
-// A Win32_PerfRawData_MSMQ_MSMQQueueCollector is a Prometheus collector for WMI Win32_PerfRawData_MSMQ_MSMQQueue metrics
+// A Win32_PerfRawData_MSMQ_MSMQQueueCollector is a Prometheus collector for Perflib MSMQ Queue metrics
 type Win32_PerfRawData_MSMQ_MSMQQueueCollector struct {
 	BytesinJournalQueue    *prometheus.Desc
 	BytesinQueue           *prometheus.Desc
 	MessagesinJournalQueue *prometheus.Desc
 	MessagesinQueue        *prometheus.Desc
 
-	queryWhereClause string
+	queueFilter *instanceFilter
+	// whereFilter holds the rules translated from the deprecated
+	// --collector.msmq.msmq-where flag.
+	whereFilter *instanceFilter
 }
 
 // NewWin32_PerfRawData_MSMQ_MSMQQueueCollector ...
 func NewMSMQCollector() (Collector, error) {
 	const subsystem = "msmq"
 
-	if *msmqWhereClause == "" {
-		log.Warn("No where-clause specified for msmq collector. This will generate a very large number of metrics!")
+	queueFilter, err := queueFilterFlags.build()
+	if err != nil {
+		return nil, err
+	}
+
+	whereRules, err := msmqWhereRules(*msmqWhereClause)
+	if err != nil {
+		log.WithError(err).Warn("The --collector.msmq.msmq-where flag is deprecated and ignored, use --collector.msmq.queue-include instead")
+	} else if len(whereRules) > 0 {
+		log.With("queue_include", strings.Join(whereRules, " ")).Warn("The --collector.msmq.msmq-where flag is deprecated, use --collector.msmq.queue-include with the translated rules instead")
+	}
+	whereFilter, err := newInstanceFilter([]string{"name"}, whereRules, nil)
+	if err != nil {
+		return nil, err
+	}
+	if queueFilter.isEmpty() && whereFilter.isEmpty() {
+		log.With("collector", "msmq").Warn("No filter specified for msmq collector. This will generate a very large number of metrics!")
 	}
 
 	return &Win32_PerfRawData_MSMQ_MSMQQueueCollector{
@@ -62,63 +82,135 @@ func NewMSMQCollector() (Collector, error) {
 			[]string{"name"},
 			nil,
 		),
-		queryWhereClause: *msmqWhereClause,
+		queueFilter: queueFilter,
+		whereFilter: whereFilter,
 	}, nil
 }
 
+// msmqWhereCondition matches a condition of a WMI where clause on the queue
+// name, followed by OR or the end of the clause.
+var msmqWhereCondition = regexp.MustCompile(`(?i)^\s*name\s*(=|like)\s*(?:'((?:[^'\\]|\\.)*)'|"((?:[^"\\]|\\.)*)")\s*(?:or\s|$)`)
+
+// msmqWhereRules translates a WMI where clause of the form Name='value' or
+// Name LIKE 'pattern', joined by OR, to queue-include rules. Other clauses
+// can't be applied to the queues read from perflib, and return an error
+// telling why.
+func msmqWhereRules(where string) ([]string, error) {
+	var rules []string
+	for rest := strings.TrimSpace(where); rest != ""; {
+		m := msmqWhereCondition.FindStringSubmatch(rest)
+		if m == nil {
+			return nil, fmt.Errorf("clause %q can't be applied to the queues read from perflib, only Name = '...' and Name LIKE '...' conditions joined by OR are translated", where)
+		}
+		rest = strings.TrimSpace(rest[len(m[0]):])
+		value := wqlUnescape(m[2] + m[3])
+		if strings.EqualFold(m[1], "like") {
+			pattern, err := wqlLikeRegexp(value)
+			if err != nil {
+				return nil, fmt.Errorf("clause %q can't be applied to the queues read from perflib: %v", where, err)
+			}
+			rules = append(rules, "name=~"+pattern)
+			continue
+		}
+		rules = append(rules, "name="+strings.ToLower(value))
+	}
+	return rules, nil
+}
+
+// wqlUnescape returns the value of a WQL string literal without its escape
+// characters.
+func wqlUnescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// wqlLikeRegexp translates a WQL LIKE pattern to a regexp matching the lower
+// case queue names, as WQL compares strings case-insensitively.
+func wqlLikeRegexp(pattern string) (string, error) {
+	var b strings.Builder
+	for _, r := range strings.ToLower(pattern) {
+		switch r {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		case '[', ']', '^':
+			return "", fmt.Errorf("character ranges in LIKE pattern %q can't be translated", pattern)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return b.String(), nil
+}
+
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *Win32_PerfRawData_MSMQ_MSMQQueueCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting msmq metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
 }
 
-type Win32_PerfRawData_MSMQ_MSMQQueue struct {
+type msmqQueue struct {
 	Name string
 
-	BytesinJournalQueue    uint64
-	BytesinQueue           uint64
-	MessagesinJournalQueue uint64
-	MessagesinQueue        uint64
+	BytesinJournalQueue    float64 `perflib:"Bytes in Journal Queue"`
+	BytesinQueue           float64 `perflib:"Bytes in Queue"`
+	MessagesinJournalQueue float64 `perflib:"Messages in Journal Queue"`
+	MessagesinQueue        float64 `perflib:"Messages in Queue"`
 }
 
-func (c *Win32_PerfRawData_MSMQ_MSMQQueueCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
-	var dst []Win32_PerfRawData_MSMQ_MSMQQueue
-	q := queryAllWhere(&dst, c.queryWhereClause)
-	if err := wmi.Query(q, &dst); err != nil {
+func (c *Win32_PerfRawData_MSMQ_MSMQQueueCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []msmqQueue
+	present, err := unmarshalObject(ctx.perfObjects["MSMQ Queue"], &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	for _, msmq := range dst {
-		ch <- prometheus.MustNewConstMetric(
+	for i := range dst {
+		msmq := &dst[i]
+		name := strings.ToLower(msmq.Name)
+		if !c.queueFilter.matches(name) || !c.whereFilter.matches(name) {
+			continue
+		}
+
+		present.emit(
+			ch,
 			c.BytesinJournalQueue,
 			prometheus.GaugeValue,
-			float64(msmq.BytesinJournalQueue),
-			strings.ToLower(msmq.Name),
+			&msmq.BytesinJournalQueue,
+			name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BytesinQueue,
 			prometheus.GaugeValue,
-			float64(msmq.BytesinQueue),
-			strings.ToLower(msmq.Name),
+			&msmq.BytesinQueue,
+			name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MessagesinJournalQueue,
 			prometheus.GaugeValue,
-			float64(msmq.MessagesinJournalQueue),
-			strings.ToLower(msmq.Name),
+			&msmq.MessagesinJournalQueue,
+			name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MessagesinQueue,
 			prometheus.GaugeValue,
-			float64(msmq.MessagesinQueue),
-			strings.ToLower(msmq.Name),
+			&msmq.MessagesinQueue,
+			name,
 		)
 	}
 	return nil, nil
@@ -1,10 +1,116 @@
 package collector
 
 import (
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+func TestMsmqCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "msmq", NewMSMQCollector)
+}
+
+func TestMsmqCollectorParity(t *testing.T) {
+	testCollectorParity(t, "msmq", NewMSMQCollector, func(c Collector, ch chan<- prometheus.Metric) error {
+		_, err := c.(*Win32_PerfRawData_MSMQ_MSMQQueueCollector).collectWMI(ch)
+		return err
+	})
+}
+
 func BenchmarkMsmqCollector(b *testing.B) {
-	// No context name required as collector source is WMI
-	benchmarkCollector(b, "", NewMSMQCollector)
+	benchmarkCollector(b, "msmq", NewMSMQCollector)
+}
+
+func TestMsmqWhereRules(t *testing.T) {
+	cases := []struct {
+		where    string
+		expected []string
+	}{
+		{"", nil},
+		{`Name='host\\private$\\orders'`, []string{`name=host\private$\orders`}},
+		{`name = "Orders" OR Name LIKE '%private$\\invoice_%'`, []string{"name=orders", `name=~.*private\$\\invoice..*`}},
+	}
+	for _, c := range cases {
+		rules, err := msmqWhereRules(c.where)
+		if err != nil {
+			t.Errorf("%q: %v", c.where, err)
+			continue
+		}
+		if !reflect.DeepEqual(rules, c.expected) {
+			t.Errorf("%q: expected %q, got %q", c.where, c.expected, rules)
+		}
+		if _, err := newInstanceFilter([]string{"name"}, rules, nil); err != nil {
+			t.Errorf("%q: %v", c.where, err)
+		}
+	}
+
+	for _, where := range []string{
+		"MessagesinQueue > 0",
+		"Name='orders' AND MessagesinQueue > 0",
+		"Name LIKE '[a-c]%'",
+		"Name='orders' OR",
+	} {
+		if _, err := msmqWhereRules(where); err == nil {
+			t.Errorf("%q: expected an error, got none", where)
+		}
+	}
+}
+
+// The WMI implementation the collector was moved from, kept to check that
+// the perflib implementation exposes the same metrics.
+
+type Win32_PerfRawData_MSMQ_MSMQQueue struct {
+	Name string
+
+	BytesinJournalQueue    uint64
+	BytesinQueue           uint64
+	MessagesinJournalQueue uint64
+	MessagesinQueue        uint64
+}
+
+func (c *Win32_PerfRawData_MSMQ_MSMQQueueCollector) collectWMI(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []Win32_PerfRawData_MSMQ_MSMQQueue
+	// The msmq-where clause is translated to queue filter rules now, which
+	// are applied below.
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
+		return nil, err
+	}
+
+	for _, msmq := range dst {
+		if !c.queueFilter.matches(strings.ToLower(msmq.Name)) {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.BytesinJournalQueue,
+			prometheus.GaugeValue,
+			float64(msmq.BytesinJournalQueue),
+			strings.ToLower(msmq.Name),
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.BytesinQueue,
+			prometheus.GaugeValue,
+			float64(msmq.BytesinQueue),
+			strings.ToLower(msmq.Name),
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.MessagesinJournalQueue,
+			prometheus.GaugeValue,
+			float64(msmq.MessagesinJournalQueue),
+			strings.ToLower(msmq.Name),
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.MessagesinQueue,
+			prometheus.GaugeValue,
+			float64(msmq.MessagesinQueue),
+			strings.ToLower(msmq.Name),
+		)
+	}
+	return nil, nil
 }
@@ -3,9 +3,6 @@
 package collector
 
 import (
-	"errors"
-	"fmt"
-	"os"
 	"strings"
 	"sync"
 	"time"
@@ -13,20 +10,22 @@ import (
 	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sys/windows/registry"
-	"gopkg.in/alecthomas/kingpin.v2"
 )
 
-var (
-	mssqlEnabledCollectors = kingpin.Flag(
-		"collectors.mssql.classes-enabled",
-		"Comma-separated list of mssql WMI classes to use.").
-		Default(mssqlAvailableClassCollectors()).String()
-
-	mssqlPrintCollectors = kingpin.Flag(
-		"collectors.mssql.class-print",
-		"If true, print available mssql WMI classes and exit.  Only displays if the mssql collector is enabled.",
-	).Bool()
-)
+var mssqlChildFlags = registerChildCollectorFlags("mssql",
+	"accessmethods",
+	"availreplica",
+	"bufman",
+	"databases",
+	"dbreplica",
+	"genstats",
+	"locks",
+	"memmgr",
+	"sqlstats",
+	"sqlerrors",
+	"transactions",
+	"waitstats",
+).withDeprecatedFlags("collectors.mssql.classes-enabled", "collectors.mssql.class-print")
 
 type mssqlInstancesType map[string]string
 
@@ -40,19 +39,19 @@ func getMSSQLInstances() mssqlInstancesType {
 	regkey := `Software\Microsoft\Microsoft SQL Server\Instance Names\SQL`
 	k, err := registry.OpenKey(registry.LOCAL_MACHINE, regkey, registry.QUERY_VALUE)
 	if err != nil {
-		log.Warn("Couldn't open registry to determine SQL instances:", err)
+		log.WithError(err).Warn("Couldn't open registry to determine SQL instances")
 		return sqlDefaultInstance
 	}
 	defer func() {
 		err = k.Close()
 		if err != nil {
-			log.Warnf("Failed to close registry key: %v", err)
+			log.WithError(err).Warn("Failed to close registry key")
 		}
 	}()
 
 	instanceNames, err := k.ReadValueNames(0)
 	if err != nil {
-		log.Warnf("Can't ReadSubKeyNames %#v", err)
+		log.WithError(err).Warn("Couldn't read SQL instance names from registry")
 		return sqlDefaultInstance
 	}
 
@@ -62,33 +61,26 @@ func getMSSQLInstances() mssqlInstancesType {
 		}
 	}
 
-	log.Debugf("Detected MSSQL Instances: %#v\n", sqlInstances)
+	log.With("mssql_instances", sqlInstances).Debug("Detected MSSQL instances")
 
 	return sqlInstances
 }
 
-type mssqlCollectorsMap map[string]mssqlCollectorFunc
-
-func mssqlAvailableClassCollectors() string {
-	return "accessmethods,availreplica,bufman,databases,dbreplica,genstats,locks,memmgr,sqlstats,sqlerrors,transactions,waitstats"
-}
-
-func (c *MSSQLCollector) getMSSQLCollectors() mssqlCollectorsMap {
-	mssqlCollectors := make(mssqlCollectorsMap)
-	mssqlCollectors["accessmethods"] = c.collectAccessMethods
-	mssqlCollectors["availreplica"] = c.collectAvailabilityReplica
-	mssqlCollectors["bufman"] = c.collectBufferManager
-	mssqlCollectors["databases"] = c.collectDatabases
-	mssqlCollectors["dbreplica"] = c.collectDatabaseReplica
-	mssqlCollectors["genstats"] = c.collectGeneralStatistics
-	mssqlCollectors["locks"] = c.collectLocks
-	mssqlCollectors["memmgr"] = c.collectMemoryManager
-	mssqlCollectors["sqlstats"] = c.collectSQLStats
-	mssqlCollectors["sqlerrors"] = c.collectSQLErrors
-	mssqlCollectors["transactions"] = c.collectTransactions
-	mssqlCollectors["waitstats"] = c.collectWaitStats
-
-	return mssqlCollectors
+func (c *MSSQLCollector) getMSSQLCollectors() []childCollector {
+	return []childCollector{
+		c.childCollector("accessmethods", c.collectAccessMethods),
+		c.childCollector("availreplica", c.collectAvailabilityReplica),
+		c.childCollector("bufman", c.collectBufferManager),
+		c.childCollector("databases", c.collectDatabases),
+		c.childCollector("dbreplica", c.collectDatabaseReplica),
+		c.childCollector("genstats", c.collectGeneralStatistics),
+		c.childCollector("locks", c.collectLocks),
+		c.childCollector("memmgr", c.collectMemoryManager),
+		c.childCollector("sqlstats", c.collectSQLStats),
+		c.childCollector("sqlerrors", c.collectSQLErrors),
+		c.childCollector("transactions", c.collectTransactions),
+		c.childCollector("waitstats", c.collectWaitStats),
+	}
 }
 
 // mssqlGetPerfObjectName - Returns the name of the Windows Performance
@@ -399,9 +391,8 @@ type MSSQLCollector struct {
 	WaitStatsWorkspaceSynchronizationWaits *prometheus.Desc
 	WaitStatsTransactionOwnershipWaits     *prometheus.Desc
 
-	mssqlInstances             mssqlInstancesType
-	mssqlCollectors            mssqlCollectorsMap
-	mssqlChildCollectorFailure int
+	mssqlInstances mssqlInstancesType
+	children       *childCollectors
 }
 
 // NewMSSQLCollector ...
@@ -409,7 +400,10 @@ func NewMSSQLCollector() (Collector, error) {
 
 	const subsystem = "mssql"
 
-	enabled := expandEnabledChildCollectors(*mssqlEnabledCollectors)
+	enabled, err := mssqlChildFlags.enabledNames()
+	if err != nil {
+		return nil, err
+	}
 	mssqlInstances := getMSSQLInstances()
 	perfCounters := make([]string, 0, len(mssqlInstances)*len(enabled))
 	for instance := range mssqlInstances {
@@ -1894,14 +1888,9 @@ func NewMSSQLCollector() (Collector, error) {
 		mssqlInstances: mssqlInstances,
 	}
 
-	mssqlCollector.mssqlCollectors = mssqlCollector.getMSSQLCollectors()
-
-	if *mssqlPrintCollectors {
-		fmt.Printf("Available SQLServer Classes:\n")
-		for name := range mssqlCollector.mssqlCollectors {
-			fmt.Printf(" - %s\n", name)
-		}
-		os.Exit(0)
+	mssqlCollector.children, err = mssqlChildFlags.build(mssqlCollector.getMSSQLCollectors())
+	if err != nil {
+		return nil, err
 	}
 
 	return &mssqlCollector, nil
@@ -1909,22 +1898,44 @@ func NewMSSQLCollector() (Collector, error) {
 
 type mssqlCollectorFunc func(ctx *ScrapeContext, ch chan<- prometheus.Metric, sqlInstance string) (*prometheus.Desc, error)
 
-func (c *MSSQLCollector) execute(ctx *ScrapeContext, name string, fn mssqlCollectorFunc, ch chan<- prometheus.Metric, sqlInstance string, wg *sync.WaitGroup) {
-	// Reset failure counter on each scrape
-	c.mssqlChildCollectorFailure = 0
-	defer wg.Done()
+// childCollector returns a child collector running fn for every SQL instance.
+func (c *MSSQLCollector) childCollector(name string, fn mssqlCollectorFunc) childCollector {
+	return childCollector{
+		name:        name,
+		description: mssqlGetPerfObjectName("MSSQLSERVER", name),
+		collect: func(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
+			wg := sync.WaitGroup{}
+			errs := make(chan error, len(c.mssqlInstances))
+			for sqlInstance := range c.mssqlInstances {
+				wg.Add(1)
+				go func(sqlInstance string) {
+					defer wg.Done()
+					if err := c.execute(ctx, name, fn, ch, sqlInstance); err != nil {
+						errs <- err
+					}
+				}(sqlInstance)
+			}
+			wg.Wait()
+			close(errs)
+			return <-errs
+		},
+	}
+}
 
+func (c *MSSQLCollector) execute(ctx *ScrapeContext, name string, fn mssqlCollectorFunc, ch chan<- prometheus.Metric, sqlInstance string) error {
 	begin := time.Now()
 	_, err := fn(ctx, ch, sqlInstance)
 	duration := time.Since(begin)
 	var success float64
 
+	l := log.With("collector", "mssql", "child", name, "mssql_instance", sqlInstance, "duration", duration.Seconds())
+	key := "mssql/" + name + "/" + sqlInstance
 	if err != nil {
-		log.Errorf("mssql class collector %s failed after %fs: %s", name, duration.Seconds(), err)
+		log.CollectorErrors.Error(key, l, "mssql class collector failed", err)
 		success = 0
-		c.mssqlChildCollectorFailure++
 	} else {
-		log.Debugf("mssql class collector %s succeeded after %fs.", name, duration.Seconds())
+		log.CollectorErrors.Success(key, l)
+		l.Debug("mssql class collector succeeded")
 		success = 1
 	}
 	ch <- prometheus.MustNewConstMetric(
@@ -1939,29 +1950,13 @@ func (c *MSSQLCollector) execute(ctx *ScrapeContext, name string, fn mssqlCollec
 		success,
 		name, sqlInstance,
 	)
+	return err
 }
 
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *MSSQLCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	wg := sync.WaitGroup{}
-
-	enabled := expandEnabledChildCollectors(*mssqlEnabledCollectors)
-	for sqlInstance := range c.mssqlInstances {
-		for _, name := range enabled {
-			function := c.mssqlCollectors[name]
-
-			wg.Add(1)
-			go c.execute(ctx, name, function, ch, sqlInstance, &wg)
-		}
-	}
-	wg.Wait()
-
-	// this shoud return an error if any? some? children errord.
-	if c.mssqlChildCollectorFailure > 0 {
-		return errors.New("at least one child collector failed")
-	}
-	return nil
+	return c.children.collect(ctx, ch)
 }
 
 // Win32_PerfRawData_MSSQLSERVER_SQLServerAccessMethods docs:
@@ -1969,11 +1964,11 @@ func (c *MSSQLCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric
 type mssqlAccessMethods struct {
 	AUcleanupbatchesPersec        float64 `perflib:"AU cleanup batches/sec"`
 	AUcleanupsPersec              float64 `perflib:"AU cleanups/sec"`
-	ByreferenceLobCreateCount     float64 `perflib:"By-reference Lob Create Count"`
-	ByreferenceLobUseCount        float64 `perflib:"By-reference Lob Use Count"`
-	CountLobReadahead             float64 `perflib:"Count Lob Readahead"`
-	CountPullInRow                float64 `perflib:"Count Pull In Row"`
-	CountPushOffRow               float64 `perflib:"Count Push Off Row"`
+	ByreferenceLobCreateCount     float64 `perflib:"By-reference Lob Create Count,optional"`
+	ByreferenceLobUseCount        float64 `perflib:"By-reference Lob Use Count,optional"`
+	CountLobReadahead             float64 `perflib:"Count Lob Readahead,optional"`
+	CountPullInRow                float64 `perflib:"Count Pull In Row,optional"`
+	CountPushOffRow               float64 `perflib:"Count Push Off Row,optional"`
 	DeferreddroppedAUs            float64 `perflib:"Deferred dropped AUs"`
 	DeferredDroppedrowsets        float64 `perflib:"Deferred Dropped rowsets"`
 	DroppedrowsetcleanupsPersec   float64 `perflib:"Dropped rowset cleanups/sec"`
@@ -1988,7 +1983,7 @@ type mssqlAccessMethods struct {
 	FreeSpaceScansPersec          float64 `perflib:"FreeSpace Scans/sec"`
 	FullScansPersec               float64 `perflib:"Full Scans/sec"`
 	IndexSearchesPersec           float64 `perflib:"Index Searches/sec"`
-	InSysXactwaitsPersec          float64 `perflib:"InSysXact waits/sec"`
+	InSysXactwaitsPersec          float64 `perflib:"InSysXact waits/sec,optional"`
 	LobHandleCreateCount          float64 `perflib:"LobHandle Create Count"`
 	LobHandleDestroyCount         float64 `perflib:"LobHandle Destroy Count"`
 	LobSSProviderCreateCount      float64 `perflib:"LobSS Provider Create Count"`
@@ -2010,323 +2005,369 @@ type mssqlAccessMethods struct {
 	WorkfilesCreatedPersec        float64 `perflib:"Workfiles Created/sec"`
 	WorktablesCreatedPersec       float64 `perflib:"Worktables Created/sec"`
 	WorktablesFromCacheRatio      float64 `perflib:"Worktables From Cache Ratio"`
-	WorktablesFromCacheRatio_Base float64 `perflib:"Worktables From Cache Base_Base"`
+	WorktablesFromCacheRatio_Base float64 `perflib:"Worktables From Cache Ratio,base"`
 }
 
 func (c *MSSQLCollector) collectAccessMethods(ctx *ScrapeContext, ch chan<- prometheus.Metric, sqlInstance string) (*prometheus.Desc, error) {
 	var dst []mssqlAccessMethods
-	log.Debugf("mssql_accessmethods collector iterating sql instance %s.", sqlInstance)
+	log.With("child", "mssql_accessmethods", "mssql_instance", sqlInstance).Debug("Collecting sql instance")
 
-	if err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "accessmethods")], &dst); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "accessmethods")], &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	for _, v := range dst {
-		ch <- prometheus.MustNewConstMetric(
+	for i := range dst {
+		v := &dst[i]
+		present.emit(
+			ch,
 			c.AccessMethodsAUcleanupbatches,
 			prometheus.CounterValue,
-			v.AUcleanupbatchesPersec,
+			&v.AUcleanupbatchesPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsAUcleanups,
 			prometheus.CounterValue,
-			v.AUcleanupsPersec,
+			&v.AUcleanupsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsByreferenceLobCreateCount,
 			prometheus.CounterValue,
-			v.ByreferenceLobCreateCount,
+			&v.ByreferenceLobCreateCount,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsByreferenceLobUseCount,
 			prometheus.CounterValue,
-			v.ByreferenceLobUseCount,
+			&v.ByreferenceLobUseCount,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsCountLobReadahead,
 			prometheus.CounterValue,
-			v.CountLobReadahead,
+			&v.CountLobReadahead,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsCountPullInRow,
 			prometheus.CounterValue,
-			v.CountPullInRow,
+			&v.CountPullInRow,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsCountPushOffRow,
 			prometheus.CounterValue,
-			v.CountPushOffRow,
+			&v.CountPushOffRow,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsDeferreddroppedAUs,
 			prometheus.GaugeValue,
-			v.DeferreddroppedAUs,
+			&v.DeferreddroppedAUs,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsDeferredDroppedrowsets,
 			prometheus.GaugeValue,
-			v.DeferredDroppedrowsets,
+			&v.DeferredDroppedrowsets,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsDroppedrowsetcleanups,
 			prometheus.CounterValue,
-			v.DroppedrowsetcleanupsPersec,
+			&v.DroppedrowsetcleanupsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsDroppedrowsetsskipped,
 			prometheus.CounterValue,
-			v.DroppedrowsetsskippedPersec,
+			&v.DroppedrowsetsskippedPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsExtentDeallocations,
 			prometheus.CounterValue,
-			v.ExtentDeallocationsPersec,
+			&v.ExtentDeallocationsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsExtentsAllocated,
 			prometheus.CounterValue,
-			v.ExtentsAllocatedPersec,
+			&v.ExtentsAllocatedPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsFailedAUcleanupbatches,
 			prometheus.CounterValue,
-			v.FailedAUcleanupbatchesPersec,
+			&v.FailedAUcleanupbatchesPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsFailedleafpagecookie,
 			prometheus.CounterValue,
-			v.Failedleafpagecookie,
+			&v.Failedleafpagecookie,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsFailedtreepagecookie,
 			prometheus.CounterValue,
-			v.Failedtreepagecookie,
+			&v.Failedtreepagecookie,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsForwardedRecords,
 			prometheus.CounterValue,
-			v.ForwardedRecordsPersec,
+			&v.ForwardedRecordsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsFreeSpacePageFetches,
 			prometheus.CounterValue,
-			v.FreeSpacePageFetchesPersec,
+			&v.FreeSpacePageFetchesPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsFreeSpaceScans,
 			prometheus.CounterValue,
-			v.FreeSpaceScansPersec,
+			&v.FreeSpaceScansPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsFullScans,
 			prometheus.CounterValue,
-			v.FullScansPersec,
+			&v.FullScansPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsIndexSearches,
 			prometheus.CounterValue,
-			v.IndexSearchesPersec,
+			&v.IndexSearchesPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsInSysXactwaits,
 			prometheus.CounterValue,
-			v.InSysXactwaitsPersec,
+			&v.InSysXactwaitsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsLobHandleCreateCount,
 			prometheus.CounterValue,
-			v.LobHandleCreateCount,
+			&v.LobHandleCreateCount,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsLobHandleDestroyCount,
 			prometheus.CounterValue,
-			v.LobHandleDestroyCount,
+			&v.LobHandleDestroyCount,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsLobSSProviderCreateCount,
 			prometheus.CounterValue,
-			v.LobSSProviderCreateCount,
+			&v.LobSSProviderCreateCount,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsLobSSProviderDestroyCount,
 			prometheus.CounterValue,
-			v.LobSSProviderDestroyCount,
+			&v.LobSSProviderDestroyCount,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsLobSSProviderTruncationCount,
 			prometheus.CounterValue,
-			v.LobSSProviderTruncationCount,
+			&v.LobSSProviderTruncationCount,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsMixedpageallocations,
 			prometheus.CounterValue,
-			v.MixedpageallocationsPersec,
+			&v.MixedpageallocationsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsPagecompressionattempts,
 			prometheus.CounterValue,
-			v.PagecompressionattemptsPersec,
+			&v.PagecompressionattemptsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsPageDeallocations,
 			prometheus.CounterValue,
-			v.PageDeallocationsPersec,
+			&v.PageDeallocationsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsPagesAllocated,
 			prometheus.CounterValue,
-			v.PagesAllocatedPersec,
+			&v.PagesAllocatedPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsPagescompressed,
 			prometheus.CounterValue,
-			v.PagescompressedPersec,
+			&v.PagescompressedPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsPageSplits,
 			prometheus.CounterValue,
-			v.PageSplitsPersec,
+			&v.PageSplitsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsProbeScans,
 			prometheus.CounterValue,
-			v.ProbeScansPersec,
+			&v.ProbeScansPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsRangeScans,
 			prometheus.CounterValue,
-			v.RangeScansPersec,
+			&v.RangeScansPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsScanPointRevalidations,
 			prometheus.CounterValue,
-			v.ScanPointRevalidationsPersec,
+			&v.ScanPointRevalidationsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsSkippedGhostedRecords,
 			prometheus.CounterValue,
-			v.SkippedGhostedRecordsPersec,
+			&v.SkippedGhostedRecordsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsTableLockEscalations,
 			prometheus.CounterValue,
-			v.TableLockEscalationsPersec,
+			&v.TableLockEscalationsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsUsedleafpagecookie,
 			prometheus.CounterValue,
-			v.Usedleafpagecookie,
+			&v.Usedleafpagecookie,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsUsedtreepagecookie,
 			prometheus.CounterValue,
-			v.Usedtreepagecookie,
+			&v.Usedtreepagecookie,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsWorkfilesCreated,
 			prometheus.CounterValue,
-			v.WorkfilesCreatedPersec,
+			&v.WorkfilesCreatedPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsWorktablesCreated,
 			prometheus.CounterValue,
-			v.WorktablesCreatedPersec,
+			&v.WorktablesCreatedPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsWorktablesFromCacheHits,
 			prometheus.CounterValue,
-			v.WorktablesFromCacheRatio,
+			&v.WorktablesFromCacheRatio,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AccessMethodsWorktablesFromCacheLookups,
 			prometheus.CounterValue,
-			v.WorktablesFromCacheRatio_Base,
+			&v.WorktablesFromCacheRatio_Base,
 			sqlInstance,
 		)
 	}
@@ -2350,78 +2391,91 @@ type mssqlAvailabilityReplica struct {
 
 func (c *MSSQLCollector) collectAvailabilityReplica(ctx *ScrapeContext, ch chan<- prometheus.Metric, sqlInstance string) (*prometheus.Desc, error) {
 	var dst []mssqlAvailabilityReplica
-	log.Debugf("mssql_availreplica collector iterating sql instance %s.", sqlInstance)
+	log.With("child", "mssql_availreplica", "mssql_instance", sqlInstance).Debug("Collecting sql instance")
+
+	present, err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "availreplica")], &dst)
 
-	if err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "availreplica")], &dst); err != nil {
+	if err != nil {
 		return nil, err
 	}
 
-	for _, v := range dst {
+	for i := range dst {
+		v := &dst[i]
 		if strings.ToLower(v.Name) == "_total" {
 			continue
 		}
 		replicaName := v.Name
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AvailReplicaBytesReceivedfromReplica,
 			prometheus.CounterValue,
-			v.BytesReceivedfromReplicaPersec,
+			&v.BytesReceivedfromReplicaPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AvailReplicaBytesSenttoReplica,
 			prometheus.CounterValue,
-			v.BytesSenttoReplicaPersec,
+			&v.BytesSenttoReplicaPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AvailReplicaBytesSenttoTransport,
 			prometheus.CounterValue,
-			v.BytesSenttoTransportPersec,
+			&v.BytesSenttoTransportPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AvailReplicaFlowControl,
 			prometheus.CounterValue,
-			v.FlowControlPersec,
+			&v.FlowControlPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.AvailReplicaFlowControlTimems,
-			prometheus.CounterValue,
-			v.FlowControlTimemsPersec/1000.0,
-			sqlInstance, replicaName,
-		)
+		if present.has(&v.FlowControlTimemsPersec) {
+			ch <- prometheus.MustNewConstMetric(
+				c.AvailReplicaFlowControlTimems,
+				prometheus.CounterValue,
+				v.FlowControlTimemsPersec/1000.0,
+				sqlInstance, replicaName,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AvailReplicaReceivesfromReplica,
 			prometheus.CounterValue,
-			v.ReceivesfromReplicaPersec,
+			&v.ReceivesfromReplicaPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AvailReplicaResentMessages,
 			prometheus.CounterValue,
-			v.ResentMessagesPersec,
+			&v.ResentMessagesPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AvailReplicaSendstoReplica,
 			prometheus.CounterValue,
-			v.SendstoReplicaPersec,
+			&v.SendstoReplicaPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AvailReplicaSendstoTransport,
 			prometheus.CounterValue,
-			v.SendstoTransportPersec,
+			&v.SendstoTransportPersec,
 			sqlInstance, replicaName,
 		)
 	}
@@ -2431,21 +2485,21 @@ func (c *MSSQLCollector) collectAvailabilityReplica(ctx *ScrapeContext, ch chan<
 // Win32_PerfRawData_MSSQLSERVER_SQLServerBufferManager docs:
 // - https://docs.microsoft.com/en-us/sql/relational-databases/performance-monitor/sql-server-buffer-manager-object
 type mssqlBufferManager struct {
-	BackgroundwriterpagesPersec   float64 `perflib:"Background writer pages/sec"`
+	BackgroundwriterpagesPersec   float64 `perflib:"Background writer pages/sec,optional"`
 	Buffercachehitratio           float64 `perflib:"Buffer cache hit ratio"`
-	Buffercachehitratio_Base      float64 `perflib:"Buffer cache hit ratio base_Base"`
+	Buffercachehitratio_Base      float64 `perflib:"Buffer cache hit ratio,base"`
 	CheckpointpagesPersec         float64 `perflib:"Checkpoint pages/sec"`
 	Databasepages                 float64 `perflib:"Database pages"`
-	Extensionallocatedpages       float64 `perflib:"Extension allocated pages"`
-	Extensionfreepages            float64 `perflib:"Extension free pages"`
-	Extensioninuseaspercentage    float64 `perflib:"Extension in use as percentage"`
-	ExtensionoutstandingIOcounter float64 `perflib:"Extension outstanding IO counter"`
-	ExtensionpageevictionsPersec  float64 `perflib:"Extension page evictions/sec"`
-	ExtensionpagereadsPersec      float64 `perflib:"Extension page reads/sec"`
-	Extensionpageunreferencedtime float64 `perflib:"Extension page unreferenced time"`
-	ExtensionpagewritesPersec     float64 `perflib:"Extension page writes/sec"`
+	Extensionallocatedpages       float64 `perflib:"Extension allocated pages,optional"`
+	Extensionfreepages            float64 `perflib:"Extension free pages,optional"`
+	Extensioninuseaspercentage    float64 `perflib:"Extension in use as percentage,optional"`
+	ExtensionoutstandingIOcounter float64 `perflib:"Extension outstanding IO counter,optional"`
+	ExtensionpageevictionsPersec  float64 `perflib:"Extension page evictions/sec,optional"`
+	ExtensionpagereadsPersec      float64 `perflib:"Extension page reads/sec,optional"`
+	Extensionpageunreferencedtime float64 `perflib:"Extension page unreferenced time,optional"`
+	ExtensionpagewritesPersec     float64 `perflib:"Extension page writes/sec,optional"`
 	FreeliststallsPersec          float64 `perflib:"Free list stalls/sec"`
-	IntegralControllerSlope       float64 `perflib:"Integral Controller Slope"`
+	IntegralControllerSlope       float64 `perflib:"Integral Controller Slope,optional"`
 	LazywritesPersec              float64 `perflib:"Lazy writes/sec"`
 	Pagelifeexpectancy            float64 `perflib:"Page life expectancy"`
 	PagelookupsPersec             float64 `perflib:"Page lookups/sec"`
@@ -2458,171 +2512,196 @@ type mssqlBufferManager struct {
 
 func (c *MSSQLCollector) collectBufferManager(ctx *ScrapeContext, ch chan<- prometheus.Metric, sqlInstance string) (*prometheus.Desc, error) {
 	var dst []mssqlBufferManager
-	log.Debugf("mssql_bufman collector iterating sql instance %s.", sqlInstance)
+	log.With("child", "mssql_bufman", "mssql_instance", sqlInstance).Debug("Collecting sql instance")
 
-	if err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "bufman")], &dst); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "bufman")], &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	for _, v := range dst {
-		ch <- prometheus.MustNewConstMetric(
+	for i := range dst {
+		v := &dst[i]
+		present.emit(
+			ch,
 			c.BufManBackgroundwriterpages,
 			prometheus.CounterValue,
-			v.BackgroundwriterpagesPersec,
+			&v.BackgroundwriterpagesPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManBuffercachehits,
 			prometheus.GaugeValue,
-			v.Buffercachehitratio,
+			&v.Buffercachehitratio,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManBuffercachelookups,
 			prometheus.GaugeValue,
-			v.Buffercachehitratio_Base,
+			&v.Buffercachehitratio_Base,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManCheckpointpages,
 			prometheus.CounterValue,
-			v.CheckpointpagesPersec,
+			&v.CheckpointpagesPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManDatabasepages,
 			prometheus.GaugeValue,
-			v.Databasepages,
+			&v.Databasepages,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManExtensionallocatedpages,
 			prometheus.GaugeValue,
-			v.Extensionallocatedpages,
+			&v.Extensionallocatedpages,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManExtensionfreepages,
 			prometheus.GaugeValue,
-			v.Extensionfreepages,
+			&v.Extensionfreepages,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManExtensioninuseaspercentage,
 			prometheus.GaugeValue,
-			v.Extensioninuseaspercentage,
+			&v.Extensioninuseaspercentage,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManExtensionoutstandingIOcounter,
 			prometheus.GaugeValue,
-			v.ExtensionoutstandingIOcounter,
+			&v.ExtensionoutstandingIOcounter,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManExtensionpageevictions,
 			prometheus.CounterValue,
-			v.ExtensionpageevictionsPersec,
+			&v.ExtensionpageevictionsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManExtensionpagereads,
 			prometheus.CounterValue,
-			v.ExtensionpagereadsPersec,
+			&v.ExtensionpagereadsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManExtensionpageunreferencedtime,
 			prometheus.GaugeValue,
-			v.Extensionpageunreferencedtime,
+			&v.Extensionpageunreferencedtime,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManExtensionpagewrites,
 			prometheus.CounterValue,
-			v.ExtensionpagewritesPersec,
+			&v.ExtensionpagewritesPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManFreeliststalls,
 			prometheus.CounterValue,
-			v.FreeliststallsPersec,
+			&v.FreeliststallsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManIntegralControllerSlope,
 			prometheus.GaugeValue,
-			v.IntegralControllerSlope,
+			&v.IntegralControllerSlope,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManLazywrites,
 			prometheus.CounterValue,
-			v.LazywritesPersec,
+			&v.LazywritesPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManPagelifeexpectancy,
 			prometheus.GaugeValue,
-			v.Pagelifeexpectancy,
+			&v.Pagelifeexpectancy,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManPagelookups,
 			prometheus.CounterValue,
-			v.PagelookupsPersec,
+			&v.PagelookupsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManPagereads,
 			prometheus.CounterValue,
-			v.PagereadsPersec,
+			&v.PagereadsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManPagewrites,
 			prometheus.CounterValue,
-			v.PagewritesPersec,
+			&v.PagewritesPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManReadaheadpages,
 			prometheus.CounterValue,
-			v.ReadaheadpagesPersec,
+			&v.ReadaheadpagesPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManReadaheadtime,
 			prometheus.CounterValue,
-			v.ReadaheadtimePersec,
+			&v.ReadaheadtimePersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BufManTargetpages,
 			prometheus.GaugeValue,
-			v.Targetpages,
+			&v.Targetpages,
 			sqlInstance,
 		)
 	}
@@ -2637,22 +2716,22 @@ type mssqlDatabaseReplica struct {
 	DatabaseFlowControlDelay        float64 `perflib:"Database Flow Control Delay"`
 	DatabaseFlowControlsPersec      float64 `perflib:"Database Flow Controls/sec"`
 	FileBytesReceivedPersec         float64 `perflib:"File Bytes Received/sec"`
-	GroupCommitsPerSec              float64 `perflib:"Group Commits/Sec"`
-	GroupCommitTime                 float64 `perflib:"Group Commit Time"`
-	LogApplyPendingQueue            float64 `perflib:"Log Apply Pending Queue"`
-	LogApplyReadyQueue              float64 `perflib:"Log Apply Ready Queue"`
-	LogBytesCompressedPersec        float64 `perflib:"Log Bytes Compressed/sec"`
-	LogBytesDecompressedPersec      float64 `perflib:"Log Bytes Decompressed/sec"`
+	GroupCommitsPerSec              float64 `perflib:"Group Commits/Sec,optional"`
+	GroupCommitTime                 float64 `perflib:"Group Commit Time,optional"`
+	LogApplyPendingQueue            float64 `perflib:"Log Apply Pending Queue,optional"`
+	LogApplyReadyQueue              float64 `perflib:"Log Apply Ready Queue,optional"`
+	LogBytesCompressedPersec        float64 `perflib:"Log Bytes Compressed/sec,optional"`
+	LogBytesDecompressedPersec      float64 `perflib:"Log Bytes Decompressed/sec,optional"`
 	LogBytesReceivedPersec          float64 `perflib:"Log Bytes Received/sec"`
-	LogCompressionCachehitsPersec   float64 `perflib:"Log Compression Cache hits/sec"`
-	LogCompressionCachemissesPersec float64 `perflib:"Log Compression Cache misses/sec"`
-	LogCompressionsPersec           float64 `perflib:"Log Compressions/sec"`
-	LogDecompressionsPersec         float64 `perflib:"Log Decompressions/sec"`
+	LogCompressionCachehitsPersec   float64 `perflib:"Log Compression Cache hits/sec,optional"`
+	LogCompressionCachemissesPersec float64 `perflib:"Log Compression Cache misses/sec,optional"`
+	LogCompressionsPersec           float64 `perflib:"Log Compressions/sec,optional"`
+	LogDecompressionsPersec         float64 `perflib:"Log Decompressions/sec,optional"`
 	Logremainingforundo             float64 `perflib:"Log remaining for undo"`
 	LogSendQueue                    float64 `perflib:"Log Send Queue"`
 	MirroredWriteTransactionsPersec float64 `perflib:"Mirrored Write Transactions/sec"`
 	RecoveryQueue                   float64 `perflib:"Recovery Queue"`
-	RedoblockedPersec               float64 `perflib:"Redo blocked/sec"`
+	RedoblockedPersec               float64 `perflib:"Redo blocked/sec,optional"`
 	RedoBytesRemaining              float64 `perflib:"Redo Bytes Remaining"`
 	RedoneBytesPersec               float64 `perflib:"Redone Bytes/sec"`
 	RedonesPersec                   float64 `perflib:"Redones/sec"`
@@ -2662,185 +2741,212 @@ type mssqlDatabaseReplica struct {
 
 func (c *MSSQLCollector) collectDatabaseReplica(ctx *ScrapeContext, ch chan<- prometheus.Metric, sqlInstance string) (*prometheus.Desc, error) {
 	var dst []mssqlDatabaseReplica
-	log.Debugf("mssql_dbreplica collector iterating sql instance %s.", sqlInstance)
+	log.With("child", "mssql_dbreplica", "mssql_instance", sqlInstance).Debug("Collecting sql instance")
 
-	if err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "dbreplica")], &dst); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "dbreplica")], &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	for _, v := range dst {
+	for i := range dst {
+		v := &dst[i]
 		if strings.ToLower(v.Name) == "_total" {
 			continue
 		}
 		replicaName := v.Name
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaDatabaseFlowControlDelay,
 			prometheus.GaugeValue,
-			v.DatabaseFlowControlDelay,
+			&v.DatabaseFlowControlDelay,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaDatabaseFlowControls,
 			prometheus.CounterValue,
-			v.DatabaseFlowControlsPersec,
+			&v.DatabaseFlowControlsPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaFileBytesReceived,
 			prometheus.CounterValue,
-			v.FileBytesReceivedPersec,
+			&v.FileBytesReceivedPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaGroupCommits,
 			prometheus.CounterValue,
-			v.GroupCommitsPerSec,
+			&v.GroupCommitsPerSec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaGroupCommitTime,
 			prometheus.GaugeValue,
-			v.GroupCommitTime,
+			&v.GroupCommitTime,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaLogApplyPendingQueue,
 			prometheus.GaugeValue,
-			v.LogApplyPendingQueue,
+			&v.LogApplyPendingQueue,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaLogApplyReadyQueue,
 			prometheus.GaugeValue,
-			v.LogApplyReadyQueue,
+			&v.LogApplyReadyQueue,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaLogBytesCompressed,
 			prometheus.CounterValue,
-			v.LogBytesCompressedPersec,
+			&v.LogBytesCompressedPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaLogBytesDecompressed,
 			prometheus.CounterValue,
-			v.LogBytesDecompressedPersec,
+			&v.LogBytesDecompressedPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaLogBytesReceived,
 			prometheus.CounterValue,
-			v.LogBytesReceivedPersec,
+			&v.LogBytesReceivedPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaLogCompressionCachehits,
 			prometheus.CounterValue,
-			v.LogCompressionCachehitsPersec,
+			&v.LogCompressionCachehitsPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaLogCompressionCachemisses,
 			prometheus.CounterValue,
-			v.LogCompressionCachemissesPersec,
+			&v.LogCompressionCachemissesPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaLogCompressions,
 			prometheus.CounterValue,
-			v.LogCompressionsPersec,
+			&v.LogCompressionsPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaLogDecompressions,
 			prometheus.CounterValue,
-			v.LogDecompressionsPersec,
+			&v.LogDecompressionsPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaLogremainingforundo,
 			prometheus.GaugeValue,
-			v.Logremainingforundo,
+			&v.Logremainingforundo,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaLogSendQueue,
 			prometheus.GaugeValue,
-			v.LogSendQueue,
+			&v.LogSendQueue,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaMirroredWriteTransactions,
 			prometheus.CounterValue,
-			v.MirroredWriteTransactionsPersec,
+			&v.MirroredWriteTransactionsPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaRecoveryQueue,
 			prometheus.GaugeValue,
-			v.RecoveryQueue,
+			&v.RecoveryQueue,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaRedoblocked,
 			prometheus.CounterValue,
-			v.RedoblockedPersec,
+			&v.RedoblockedPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaRedoBytesRemaining,
 			prometheus.GaugeValue,
-			v.RedoBytesRemaining,
+			&v.RedoBytesRemaining,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaRedoneBytes,
 			prometheus.CounterValue,
-			v.RedoneBytesPersec,
+			&v.RedoneBytesPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaRedones,
 			prometheus.CounterValue,
-			v.RedonesPersec,
+			&v.RedonesPersec,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DBReplicaTotalLogrequiringundo,
 			prometheus.GaugeValue,
-			v.TotalLogrequiringundo,
+			&v.TotalLogrequiringundo,
 			sqlInstance, replicaName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.DBReplicaTransactionDelay,
-			prometheus.GaugeValue,
-			v.TransactionDelay/1000.0,
-			sqlInstance, replicaName,
-		)
+		if present.has(&v.TransactionDelay) {
+			ch <- prometheus.MustNewConstMetric(
+				c.DBReplicaTransactionDelay,
+				prometheus.GaugeValue,
+				v.TransactionDelay/1000.0,
+				sqlInstance, replicaName,
+			)
+		}
 	}
 	return nil, nil
 }
@@ -2849,405 +2955,464 @@ func (c *MSSQLCollector) collectDatabaseReplica(ctx *ScrapeContext, ch chan<- pr
 // - https://docs.microsoft.com/en-us/sql/relational-databases/performance-monitor/sql-server-databases-object?view=sql-server-2017
 type mssqlDatabases struct {
 	Name                             string
-	Activeparallelredothreads        float64 `perflib:"Active parallel redo threads"`
+	Activeparallelredothreads        float64 `perflib:"Active parallel redo threads,optional"`
 	ActiveTransactions               float64 `perflib:"Active Transactions"`
 	BackupPerRestoreThroughputPersec float64 `perflib:"Backup/Restore Throughput/sec"`
 	BulkCopyRowsPersec               float64 `perflib:"Bulk Copy Rows/sec"`
 	BulkCopyThroughputPersec         float64 `perflib:"Bulk Copy Throughput/sec"`
-	Committableentries               float64 `perflib:"Commit table entries"`
+	Committableentries               float64 `perflib:"Commit table entries,optional"`
 	DataFilesSizeKB                  float64 `perflib:"Data File(s) Size (KB)"`
 	DBCCLogicalScanBytesPersec       float64 `perflib:"DBCC Logical Scan Bytes/sec"`
-	GroupCommitTimePersec            float64 `perflib:"Group Commit Time/sec"`
+	GroupCommitTimePersec            float64 `perflib:"Group Commit Time/sec,optional"`
 	LogBytesFlushedPersec            float64 `perflib:"Log Bytes Flushed/sec"`
 	LogCacheHitRatio                 float64 `perflib:"Log Cache Hit Ratio"`
-	LogCacheHitRatio_Base            float64 `perflib:"Log Cache Hit Ratio Base_Base"`
+	LogCacheHitRatio_Base            float64 `perflib:"Log Cache Hit Ratio,base"`
 	LogCacheReadsPersec              float64 `perflib:"Log Cache Reads/sec"`
 	LogFilesSizeKB                   float64 `perflib:"Log File(s) Size (KB)"`
 	LogFilesUsedSizeKB               float64 `perflib:"Log File(s) Used Size (KB)"`
 	LogFlushesPersec                 float64 `perflib:"Log Flushes/sec"`
 	LogFlushWaitsPersec              float64 `perflib:"Log Flush Waits/sec"`
 	LogFlushWaitTime                 float64 `perflib:"Log Flush Wait Time"`
-	LogFlushWriteTimems              float64 `perflib:"Log Flush Write Time (ms)"`
+	LogFlushWriteTimems              float64 `perflib:"Log Flush Write Time (ms),optional"`
 	LogGrowths                       float64 `perflib:"Log Growths"`
-	LogPoolCacheMissesPersec         float64 `perflib:"Log Pool Cache Misses/sec"`
-	LogPoolDiskReadsPersec           float64 `perflib:"Log Pool Disk Reads/sec"`
-	LogPoolHashDeletesPersec         float64 `perflib:"Log Pool Hash Deletes/sec"`
-	LogPoolHashInsertsPersec         float64 `perflib:"Log Pool Hash Inserts/sec"`
-	LogPoolInvalidHashEntryPersec    float64 `perflib:"Log Pool Invalid Hash Entry/sec"`
-	LogPoolLogScanPushesPersec       float64 `perflib:"Log Pool Log Scan Pushes/sec"`
-	LogPoolLogWriterPushesPersec     float64 `perflib:"Log Pool LogWriter Pushes/sec"`
-	LogPoolPushEmptyFreePoolPersec   float64 `perflib:"Log Pool Push Empty FreePool/sec"`
-	LogPoolPushLowMemoryPersec       float64 `perflib:"Log Pool Push Low Memory/sec"`
-	LogPoolPushNoFreeBufferPersec    float64 `perflib:"Log Pool Push No Free Buffer/sec"`
-	LogPoolReqBehindTruncPersec      float64 `perflib:"Log Pool Req. Behind Trunc/sec"`
-	LogPoolRequestsOldVLFPersec      float64 `perflib:"Log Pool Requests Old VLF/sec"`
-	LogPoolRequestsPersec            float64 `perflib:"Log Pool Requests/sec"`
-	LogPoolTotalActiveLogSize        float64 `perflib:"Log Pool Total Active Log Size"`
-	LogPoolTotalSharedPoolSize       float64 `perflib:"Log Pool Total Shared Pool Size"`
+	LogPoolCacheMissesPersec         float64 `perflib:"Log Pool Cache Misses/sec,optional"`
+	LogPoolDiskReadsPersec           float64 `perflib:"Log Pool Disk Reads/sec,optional"`
+	LogPoolHashDeletesPersec         float64 `perflib:"Log Pool Hash Deletes/sec,optional"`
+	LogPoolHashInsertsPersec         float64 `perflib:"Log Pool Hash Inserts/sec,optional"`
+	LogPoolInvalidHashEntryPersec    float64 `perflib:"Log Pool Invalid Hash Entry/sec,optional"`
+	LogPoolLogScanPushesPersec       float64 `perflib:"Log Pool Log Scan Pushes/sec,optional"`
+	LogPoolLogWriterPushesPersec     float64 `perflib:"Log Pool LogWriter Pushes/sec,optional"`
+	LogPoolPushEmptyFreePoolPersec   float64 `perflib:"Log Pool Push Empty FreePool/sec,optional"`
+	LogPoolPushLowMemoryPersec       float64 `perflib:"Log Pool Push Low Memory/sec,optional"`
+	LogPoolPushNoFreeBufferPersec    float64 `perflib:"Log Pool Push No Free Buffer/sec,optional"`
+	LogPoolReqBehindTruncPersec      float64 `perflib:"Log Pool Req. Behind Trunc/sec,optional"`
+	LogPoolRequestsOldVLFPersec      float64 `perflib:"Log Pool Requests Old VLF/sec,optional"`
+	LogPoolRequestsPersec            float64 `perflib:"Log Pool Requests/sec,optional"`
+	LogPoolTotalActiveLogSize        float64 `perflib:"Log Pool Total Active Log Size,optional"`
+	LogPoolTotalSharedPoolSize       float64 `perflib:"Log Pool Total Shared Pool Size,optional"`
 	LogShrinks                       float64 `perflib:"Log Shrinks"`
 	LogTruncations                   float64 `perflib:"Log Truncations"`
 	PercentLogUsed                   float64 `perflib:"Percent Log Used"`
 	ReplPendingXacts                 float64 `perflib:"Repl. Pending Xacts"`
 	ReplTransRate                    float64 `perflib:"Repl. Trans. Rate"`
 	ShrinkDataMovementBytesPersec    float64 `perflib:"Shrink Data Movement Bytes/sec"`
-	TrackedtransactionsPersec        float64 `perflib:"Tracked transactions/sec"`
+	TrackedtransactionsPersec        float64 `perflib:"Tracked transactions/sec,optional"`
 	TransactionsPersec               float64 `perflib:"Transactions/sec"`
-	WriteTransactionsPersec          float64 `perflib:"Write Transactions/sec"`
-	XTPControllerDLCLatencyPerFetch  float64 `perflib:"XTP Controller DLC Latency/Fetch"`
-	XTPControllerDLCPeakLatency      float64 `perflib:"XTP Controller DLC Peak Latency"`
-	XTPControllerLogProcessedPersec  float64 `perflib:"XTP Controller Log Processed/sec"`
-	XTPMemoryUsedKB                  float64 `perflib:"XTP Memory Used (KB)"`
+	WriteTransactionsPersec          float64 `perflib:"Write Transactions/sec,optional"`
+	XTPControllerDLCLatencyPerFetch  float64 `perflib:"XTP Controller DLC Latency/Fetch,optional"`
+	XTPControllerDLCPeakLatency      float64 `perflib:"XTP Controller DLC Peak Latency,optional"`
+	XTPControllerLogProcessedPersec  float64 `perflib:"XTP Controller Log Processed/sec,optional"`
+	XTPMemoryUsedKB                  float64 `perflib:"XTP Memory Used (KB),optional"`
 }
 
 func (c *MSSQLCollector) collectDatabases(ctx *ScrapeContext, ch chan<- prometheus.Metric, sqlInstance string) (*prometheus.Desc, error) {
 	var dst []mssqlDatabases
-	log.Debugf("mssql_databases collector iterating sql instance %s.", sqlInstance)
+	log.With("child", "mssql_databases", "mssql_instance", sqlInstance).Debug("Collecting sql instance")
 
-	if err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "databases")], &dst); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "databases")], &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	for _, v := range dst {
+	for i := range dst {
+		v := &dst[i]
 		if strings.ToLower(v.Name) == "_total" {
 			continue
 		}
 		dbName := v.Name
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesActiveParallelredothreads,
 			prometheus.GaugeValue,
-			v.Activeparallelredothreads,
+			&v.Activeparallelredothreads,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesActiveTransactions,
 			prometheus.GaugeValue,
-			v.ActiveTransactions,
+			&v.ActiveTransactions,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesBackupPerRestoreThroughput,
 			prometheus.CounterValue,
-			v.BackupPerRestoreThroughputPersec,
+			&v.BackupPerRestoreThroughputPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesBulkCopyRows,
 			prometheus.CounterValue,
-			v.BulkCopyRowsPersec,
+			&v.BulkCopyRowsPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.DatabasesBulkCopyThroughput,
-			prometheus.CounterValue,
-			v.BulkCopyThroughputPersec*1024,
-			sqlInstance, dbName,
-		)
+		if present.has(&v.BulkCopyThroughputPersec) {
+			ch <- prometheus.MustNewConstMetric(
+				c.DatabasesBulkCopyThroughput,
+				prometheus.CounterValue,
+				v.BulkCopyThroughputPersec*1024,
+				sqlInstance, dbName,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesCommittableentries,
 			prometheus.GaugeValue,
-			v.Committableentries,
+			&v.Committableentries,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.DatabasesDataFilesSizeKB,
-			prometheus.GaugeValue,
-			v.DataFilesSizeKB*1024,
-			sqlInstance, dbName,
-		)
+		if present.has(&v.DataFilesSizeKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.DatabasesDataFilesSizeKB,
+				prometheus.GaugeValue,
+				v.DataFilesSizeKB*1024,
+				sqlInstance, dbName,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesDBCCLogicalScanBytes,
 			prometheus.CounterValue,
-			v.DBCCLogicalScanBytesPersec,
+			&v.DBCCLogicalScanBytesPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.DatabasesGroupCommitTime,
-			prometheus.CounterValue,
-			v.GroupCommitTimePersec/1000000.0,
-			sqlInstance, dbName,
-		)
+		if present.has(&v.GroupCommitTimePersec) {
+			ch <- prometheus.MustNewConstMetric(
+				c.DatabasesGroupCommitTime,
+				prometheus.CounterValue,
+				v.GroupCommitTimePersec/1000000.0,
+				sqlInstance, dbName,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogBytesFlushed,
 			prometheus.CounterValue,
-			v.LogBytesFlushedPersec,
+			&v.LogBytesFlushedPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogCacheHits,
 			prometheus.GaugeValue,
-			v.LogCacheHitRatio,
+			&v.LogCacheHitRatio,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogCacheLookups,
 			prometheus.GaugeValue,
-			v.LogCacheHitRatio_Base,
+			&v.LogCacheHitRatio_Base,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogCacheReads,
 			prometheus.CounterValue,
-			v.LogCacheReadsPersec,
+			&v.LogCacheReadsPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.DatabasesLogFilesSizeKB,
-			prometheus.GaugeValue,
-			v.LogFilesSizeKB*1024,
-			sqlInstance, dbName,
-		)
+		if present.has(&v.LogFilesSizeKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.DatabasesLogFilesSizeKB,
+				prometheus.GaugeValue,
+				v.LogFilesSizeKB*1024,
+				sqlInstance, dbName,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
-			c.DatabasesLogFilesUsedSizeKB,
-			prometheus.GaugeValue,
-			v.LogFilesUsedSizeKB*1024,
-			sqlInstance, dbName,
-		)
+		if present.has(&v.LogFilesUsedSizeKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.DatabasesLogFilesUsedSizeKB,
+				prometheus.GaugeValue,
+				v.LogFilesUsedSizeKB*1024,
+				sqlInstance, dbName,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogFlushes,
 			prometheus.CounterValue,
-			v.LogFlushesPersec,
+			&v.LogFlushesPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogFlushWaits,
 			prometheus.CounterValue,
-			v.LogFlushWaitsPersec,
+			&v.LogFlushWaitsPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.DatabasesLogFlushWaitTime,
-			prometheus.GaugeValue,
-			v.LogFlushWaitTime/1000.0,
-			sqlInstance, dbName,
-		)
+		if present.has(&v.LogFlushWaitTime) {
+			ch <- prometheus.MustNewConstMetric(
+				c.DatabasesLogFlushWaitTime,
+				prometheus.GaugeValue,
+				v.LogFlushWaitTime/1000.0,
+				sqlInstance, dbName,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
-			c.DatabasesLogFlushWriteTimems,
-			prometheus.GaugeValue,
-			v.LogFlushWriteTimems/1000.0,
-			sqlInstance, dbName,
-		)
+		if present.has(&v.LogFlushWriteTimems) {
+			ch <- prometheus.MustNewConstMetric(
+				c.DatabasesLogFlushWriteTimems,
+				prometheus.GaugeValue,
+				v.LogFlushWriteTimems/1000.0,
+				sqlInstance, dbName,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogGrowths,
 			prometheus.GaugeValue,
-			v.LogGrowths,
+			&v.LogGrowths,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogPoolCacheMisses,
 			prometheus.CounterValue,
-			v.LogPoolCacheMissesPersec,
+			&v.LogPoolCacheMissesPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogPoolDiskReads,
 			prometheus.CounterValue,
-			v.LogPoolDiskReadsPersec,
+			&v.LogPoolDiskReadsPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogPoolHashDeletes,
 			prometheus.CounterValue,
-			v.LogPoolHashDeletesPersec,
+			&v.LogPoolHashDeletesPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogPoolHashInserts,
 			prometheus.CounterValue,
-			v.LogPoolHashInsertsPersec,
+			&v.LogPoolHashInsertsPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogPoolInvalidHashEntry,
 			prometheus.CounterValue,
-			v.LogPoolInvalidHashEntryPersec,
+			&v.LogPoolInvalidHashEntryPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogPoolLogScanPushes,
 			prometheus.CounterValue,
-			v.LogPoolLogScanPushesPersec,
+			&v.LogPoolLogScanPushesPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogPoolLogWriterPushes,
 			prometheus.CounterValue,
-			v.LogPoolLogWriterPushesPersec,
+			&v.LogPoolLogWriterPushesPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogPoolPushEmptyFreePool,
 			prometheus.CounterValue,
-			v.LogPoolPushEmptyFreePoolPersec,
+			&v.LogPoolPushEmptyFreePoolPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogPoolPushLowMemory,
 			prometheus.CounterValue,
-			v.LogPoolPushLowMemoryPersec,
+			&v.LogPoolPushLowMemoryPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogPoolPushNoFreeBuffer,
 			prometheus.CounterValue,
-			v.LogPoolPushNoFreeBufferPersec,
+			&v.LogPoolPushNoFreeBufferPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogPoolReqBehindTrunc,
 			prometheus.CounterValue,
-			v.LogPoolReqBehindTruncPersec,
+			&v.LogPoolReqBehindTruncPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogPoolRequestsOldVLF,
 			prometheus.CounterValue,
-			v.LogPoolRequestsOldVLFPersec,
+			&v.LogPoolRequestsOldVLFPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogPoolRequests,
 			prometheus.CounterValue,
-			v.LogPoolRequestsPersec,
+			&v.LogPoolRequestsPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogPoolTotalActiveLogSize,
 			prometheus.GaugeValue,
-			v.LogPoolTotalActiveLogSize,
+			&v.LogPoolTotalActiveLogSize,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogPoolTotalSharedPoolSize,
 			prometheus.GaugeValue,
-			v.LogPoolTotalSharedPoolSize,
+			&v.LogPoolTotalSharedPoolSize,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogShrinks,
 			prometheus.GaugeValue,
-			v.LogShrinks,
+			&v.LogShrinks,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesLogTruncations,
 			prometheus.GaugeValue,
-			v.LogTruncations,
+			&v.LogTruncations,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesPercentLogUsed,
 			prometheus.GaugeValue,
-			v.PercentLogUsed,
+			&v.PercentLogUsed,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesReplPendingXacts,
 			prometheus.GaugeValue,
-			v.ReplPendingXacts,
+			&v.ReplPendingXacts,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesReplTransRate,
 			prometheus.CounterValue,
-			v.ReplTransRate,
+			&v.ReplTransRate,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesShrinkDataMovementBytes,
 			prometheus.CounterValue,
-			v.ShrinkDataMovementBytesPersec,
+			&v.ShrinkDataMovementBytesPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesTrackedtransactions,
 			prometheus.CounterValue,
-			v.TrackedtransactionsPersec,
+			&v.TrackedtransactionsPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesTransactions,
 			prometheus.CounterValue,
-			v.TransactionsPersec,
+			&v.TransactionsPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesWriteTransactions,
 			prometheus.CounterValue,
-			v.WriteTransactionsPersec,
+			&v.WriteTransactionsPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesXTPControllerDLCLatencyPerFetch,
 			prometheus.GaugeValue,
-			v.XTPControllerDLCLatencyPerFetch,
+			&v.XTPControllerDLCLatencyPerFetch,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.DatabasesXTPControllerDLCPeakLatency,
-			prometheus.GaugeValue,
-			v.XTPControllerDLCPeakLatency*1000000.0,
-			sqlInstance, dbName,
-		)
+		if present.has(&v.XTPControllerDLCPeakLatency) {
+			ch <- prometheus.MustNewConstMetric(
+				c.DatabasesXTPControllerDLCPeakLatency,
+				prometheus.GaugeValue,
+				v.XTPControllerDLCPeakLatency*1000000.0,
+				sqlInstance, dbName,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DatabasesXTPControllerLogProcessed,
 			prometheus.CounterValue,
-			v.XTPControllerLogProcessedPersec,
+			&v.XTPControllerLogProcessedPersec,
 			sqlInstance, dbName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.DatabasesXTPMemoryUsedKB,
-			prometheus.GaugeValue,
-			v.XTPMemoryUsedKB*1024,
-			sqlInstance, dbName,
-		)
+		if present.has(&v.XTPMemoryUsedKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.DatabasesXTPMemoryUsedKB,
+				prometheus.GaugeValue,
+				v.XTPMemoryUsedKB*1024,
+				sqlInstance, dbName,
+			)
+		}
 	}
 	return nil, nil
 }
@@ -3283,178 +3448,205 @@ type mssqlGeneralStatistics struct {
 
 func (c *MSSQLCollector) collectGeneralStatistics(ctx *ScrapeContext, ch chan<- prometheus.Metric, sqlInstance string) (*prometheus.Desc, error) {
 	var dst []mssqlGeneralStatistics
-	log.Debugf("mssql_genstats collector iterating sql instance %s.", sqlInstance)
+	log.With("child", "mssql_genstats", "mssql_instance", sqlInstance).Debug("Collecting sql instance")
+
+	present, err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "genstats")], &dst)
 
-	if err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "genstats")], &dst); err != nil {
+	if err != nil {
 		return nil, err
 	}
 
-	for _, v := range dst {
-		ch <- prometheus.MustNewConstMetric(
+	for i := range dst {
+		v := &dst[i]
+		present.emit(
+			ch,
 			c.GenStatsActiveTempTables,
 			prometheus.GaugeValue,
-			v.ActiveTempTables,
+			&v.ActiveTempTables,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsConnectionReset,
 			prometheus.CounterValue,
-			v.ConnectionResetPersec,
+			&v.ConnectionResetPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsEventNotificationsDelayedDrop,
 			prometheus.GaugeValue,
-			v.EventNotificationsDelayedDrop,
+			&v.EventNotificationsDelayedDrop,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsHTTPAuthenticatedRequests,
 			prometheus.GaugeValue,
-			v.HTTPAuthenticatedRequests,
+			&v.HTTPAuthenticatedRequests,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsLogicalConnections,
 			prometheus.GaugeValue,
-			v.LogicalConnections,
+			&v.LogicalConnections,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsLogins,
 			prometheus.CounterValue,
-			v.LoginsPersec,
+			&v.LoginsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsLogouts,
 			prometheus.CounterValue,
-			v.LogoutsPersec,
+			&v.LogoutsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsMarsDeadlocks,
 			prometheus.GaugeValue,
-			v.MarsDeadlocks,
+			&v.MarsDeadlocks,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsNonatomicyieldrate,
 			prometheus.CounterValue,
-			v.Nonatomicyieldrate,
+			&v.Nonatomicyieldrate,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsProcessesblocked,
 			prometheus.GaugeValue,
-			v.Processesblocked,
+			&v.Processesblocked,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsSOAPEmptyRequests,
 			prometheus.GaugeValue,
-			v.SOAPEmptyRequests,
+			&v.SOAPEmptyRequests,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsSOAPMethodInvocations,
 			prometheus.GaugeValue,
-			v.SOAPMethodInvocations,
+			&v.SOAPMethodInvocations,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsSOAPSessionInitiateRequests,
 			prometheus.GaugeValue,
-			v.SOAPSessionInitiateRequests,
+			&v.SOAPSessionInitiateRequests,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsSOAPSessionTerminateRequests,
 			prometheus.GaugeValue,
-			v.SOAPSessionTerminateRequests,
+			&v.SOAPSessionTerminateRequests,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsSOAPSQLRequests,
 			prometheus.GaugeValue,
-			v.SOAPSQLRequests,
+			&v.SOAPSQLRequests,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsSOAPWSDLRequests,
 			prometheus.GaugeValue,
-			v.SOAPWSDLRequests,
+			&v.SOAPWSDLRequests,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsSQLTraceIOProviderLockWaits,
 			prometheus.GaugeValue,
-			v.SQLTraceIOProviderLockWaits,
+			&v.SQLTraceIOProviderLockWaits,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsTempdbrecoveryunitid,
 			prometheus.GaugeValue,
-			v.Tempdbrecoveryunitid,
+			&v.Tempdbrecoveryunitid,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsTempdbrowsetid,
 			prometheus.GaugeValue,
-			v.Tempdbrowsetid,
+			&v.Tempdbrowsetid,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsTempTablesCreationRate,
 			prometheus.CounterValue,
-			v.TempTablesCreationRate,
+			&v.TempTablesCreationRate,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsTempTablesForDestruction,
 			prometheus.GaugeValue,
-			v.TempTablesForDestruction,
+			&v.TempTablesForDestruction,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsTraceEventNotificationQueue,
 			prometheus.GaugeValue,
-			v.TraceEventNotificationQueue,
+			&v.TraceEventNotificationQueue,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsTransactions,
 			prometheus.GaugeValue,
-			v.Transactions,
+			&v.Transactions,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GenStatsUserConnections,
 			prometheus.GaugeValue,
-			v.UserConnections,
+			&v.UserConnections,
 			sqlInstance,
 		)
 	}
@@ -3467,7 +3659,7 @@ func (c *MSSQLCollector) collectGeneralStatistics(ctx *ScrapeContext, ch chan<-
 type mssqlLocks struct {
 	Name                       string
 	AverageWaitTimems          float64 `perflib:"Average Wait Time (ms)"`
-	AverageWaitTimems_Base     float64 `perflib:"Average Wait Time Base_Base"`
+	AverageWaitTimems_Base     float64 `perflib:"Average Wait Time (ms),base"`
 	LockRequestsPersec         float64 `perflib:"Lock Requests/sec"`
 	LockTimeoutsPersec         float64 `perflib:"Lock Timeouts/sec"`
 	LockTimeoutstimeout0Persec float64 `perflib:"Lock Timeouts (timeout > 0)/sec"`
@@ -3478,71 +3670,85 @@ type mssqlLocks struct {
 
 func (c *MSSQLCollector) collectLocks(ctx *ScrapeContext, ch chan<- prometheus.Metric, sqlInstance string) (*prometheus.Desc, error) {
 	var dst []mssqlLocks
-	log.Debugf("mssql_locks collector iterating sql instance %s.", sqlInstance)
+	log.With("child", "mssql_locks", "mssql_instance", sqlInstance).Debug("Collecting sql instance")
+
+	present, err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "locks")], &dst)
 
-	if err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "locks")], &dst); err != nil {
+	if err != nil {
 		return nil, err
 	}
 
-	for _, v := range dst {
+	for i := range dst {
+		v := &dst[i]
 		if strings.ToLower(v.Name) == "_total" {
 			continue
 		}
 		lockResourceName := v.Name
 
-		ch <- prometheus.MustNewConstMetric(
-			c.LocksWaitTime,
-			prometheus.GaugeValue,
-			v.AverageWaitTimems/1000.0,
-			sqlInstance, lockResourceName,
-		)
+		if present.has(&v.AverageWaitTimems) {
+			ch <- prometheus.MustNewConstMetric(
+				c.LocksWaitTime,
+				prometheus.GaugeValue,
+				v.AverageWaitTimems/1000.0,
+				sqlInstance, lockResourceName,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
-			c.LocksCount,
-			prometheus.GaugeValue,
-			v.AverageWaitTimems_Base/1000.0,
-			sqlInstance, lockResourceName,
-		)
+		if present.has(&v.AverageWaitTimems_Base) {
+			ch <- prometheus.MustNewConstMetric(
+				c.LocksCount,
+				prometheus.GaugeValue,
+				v.AverageWaitTimems_Base/1000.0,
+				sqlInstance, lockResourceName,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.LocksLockRequests,
 			prometheus.CounterValue,
-			v.LockRequestsPersec,
+			&v.LockRequestsPersec,
 			sqlInstance, lockResourceName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.LocksLockTimeouts,
 			prometheus.CounterValue,
-			v.LockTimeoutsPersec,
+			&v.LockTimeoutsPersec,
 			sqlInstance, lockResourceName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.LocksLockTimeoutstimeout0,
 			prometheus.CounterValue,
-			v.LockTimeoutstimeout0Persec,
+			&v.LockTimeoutstimeout0Persec,
 			sqlInstance, lockResourceName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.LocksLockWaits,
 			prometheus.CounterValue,
-			v.LockWaitsPersec,
+			&v.LockWaitsPersec,
 			sqlInstance, lockResourceName,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.LocksLockWaitTimems,
-			prometheus.GaugeValue,
-			v.LockWaitTimems/1000.0,
-			sqlInstance, lockResourceName,
-		)
+		if present.has(&v.LockWaitTimems) {
+			ch <- prometheus.MustNewConstMetric(
+				c.LocksLockWaitTimems,
+				prometheus.GaugeValue,
+				v.LockWaitTimems/1000.0,
+				sqlInstance, lockResourceName,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.LocksNumberofDeadlocks,
 			prometheus.CounterValue,
-			v.NumberofDeadlocksPersec,
+			&v.NumberofDeadlocksPersec,
 			sqlInstance, lockResourceName,
 		)
 	}
@@ -3553,175 +3759,210 @@ func (c *MSSQLCollector) collectLocks(ctx *ScrapeContext, ch chan<- prometheus.M
 // - https://docs.microsoft.com/en-us/sql/relational-databases/performance-monitor/sql-server-memory-manager-object
 type mssqlMemoryManager struct {
 	ConnectionMemoryKB       float64 `perflib:"Connection Memory (KB)"`
-	DatabaseCacheMemoryKB    float64 `perflib:"Database Cache Memory (KB)"`
-	Externalbenefitofmemory  float64 `perflib:"External benefit of memory"`
-	FreeMemoryKB             float64 `perflib:"Free Memory (KB)"`
+	DatabaseCacheMemoryKB    float64 `perflib:"Database Cache Memory (KB),optional"`
+	Externalbenefitofmemory  float64 `perflib:"External benefit of memory,optional"`
+	FreeMemoryKB             float64 `perflib:"Free Memory (KB),optional"`
 	GrantedWorkspaceMemoryKB float64 `perflib:"Granted Workspace Memory (KB)"`
 	LockBlocks               float64 `perflib:"Lock Blocks"`
 	LockBlocksAllocated      float64 `perflib:"Lock Blocks Allocated"`
 	LockMemoryKB             float64 `perflib:"Lock Memory (KB)"`
 	LockOwnerBlocks          float64 `perflib:"Lock Owner Blocks"`
 	LockOwnerBlocksAllocated float64 `perflib:"Lock Owner Blocks Allocated"`
-	LogPoolMemoryKB          float64 `perflib:"Log Pool Memory (KB)"`
+	LogPoolMemoryKB          float64 `perflib:"Log Pool Memory (KB),optional"`
 	MaximumWorkspaceMemoryKB float64 `perflib:"Maximum Workspace Memory (KB)"`
 	MemoryGrantsOutstanding  float64 `perflib:"Memory Grants Outstanding"`
 	MemoryGrantsPending      float64 `perflib:"Memory Grants Pending"`
 	OptimizerMemoryKB        float64 `perflib:"Optimizer Memory (KB)"`
-	ReservedServerMemoryKB   float64 `perflib:"Reserved Server Memory (KB)"`
+	ReservedServerMemoryKB   float64 `perflib:"Reserved Server Memory (KB),optional"`
 	SQLCacheMemoryKB         float64 `perflib:"SQL Cache Memory (KB)"`
-	StolenServerMemoryKB     float64 `perflib:"Stolen Server Memory (KB)"`
+	StolenServerMemoryKB     float64 `perflib:"Stolen Server Memory (KB),optional"`
 	TargetServerMemoryKB     float64 `perflib:"Target Server Memory (KB)"`
 	TotalServerMemoryKB      float64 `perflib:"Total Server Memory (KB)"`
 }
 
 func (c *MSSQLCollector) collectMemoryManager(ctx *ScrapeContext, ch chan<- prometheus.Metric, sqlInstance string) (*prometheus.Desc, error) {
 	var dst []mssqlMemoryManager
-	log.Debugf("mssql_memmgr collector iterating sql instance %s.", sqlInstance)
+	log.With("child", "mssql_memmgr", "mssql_instance", sqlInstance).Debug("Collecting sql instance")
 
-	if err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "memmgr")], &dst); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "memmgr")], &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	for _, v := range dst {
-		ch <- prometheus.MustNewConstMetric(
-			c.MemMgrConnectionMemoryKB,
-			prometheus.GaugeValue,
-			v.ConnectionMemoryKB*1024,
-			sqlInstance,
-		)
+	for i := range dst {
+		v := &dst[i]
+		if present.has(&v.ConnectionMemoryKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.MemMgrConnectionMemoryKB,
+				prometheus.GaugeValue,
+				v.ConnectionMemoryKB*1024,
+				sqlInstance,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
-			c.MemMgrDatabaseCacheMemoryKB,
-			prometheus.GaugeValue,
-			v.DatabaseCacheMemoryKB*1024,
-			sqlInstance,
-		)
+		if present.has(&v.DatabaseCacheMemoryKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.MemMgrDatabaseCacheMemoryKB,
+				prometheus.GaugeValue,
+				v.DatabaseCacheMemoryKB*1024,
+				sqlInstance,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MemMgrExternalbenefitofmemory,
 			prometheus.GaugeValue,
-			v.Externalbenefitofmemory,
+			&v.Externalbenefitofmemory,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.MemMgrFreeMemoryKB,
-			prometheus.GaugeValue,
-			v.FreeMemoryKB*1024,
-			sqlInstance,
-		)
+		if present.has(&v.FreeMemoryKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.MemMgrFreeMemoryKB,
+				prometheus.GaugeValue,
+				v.FreeMemoryKB*1024,
+				sqlInstance,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
-			c.MemMgrGrantedWorkspaceMemoryKB,
-			prometheus.GaugeValue,
-			v.GrantedWorkspaceMemoryKB*1024,
-			sqlInstance,
-		)
+		if present.has(&v.GrantedWorkspaceMemoryKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.MemMgrGrantedWorkspaceMemoryKB,
+				prometheus.GaugeValue,
+				v.GrantedWorkspaceMemoryKB*1024,
+				sqlInstance,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MemMgrLockBlocks,
 			prometheus.GaugeValue,
-			v.LockBlocks,
+			&v.LockBlocks,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MemMgrLockBlocksAllocated,
 			prometheus.GaugeValue,
-			v.LockBlocksAllocated,
+			&v.LockBlocksAllocated,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.MemMgrLockMemoryKB,
-			prometheus.GaugeValue,
-			v.LockMemoryKB*1024,
-			sqlInstance,
-		)
+		if present.has(&v.LockMemoryKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.MemMgrLockMemoryKB,
+				prometheus.GaugeValue,
+				v.LockMemoryKB*1024,
+				sqlInstance,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MemMgrLockOwnerBlocks,
 			prometheus.GaugeValue,
-			v.LockOwnerBlocks,
+			&v.LockOwnerBlocks,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MemMgrLockOwnerBlocksAllocated,
 			prometheus.GaugeValue,
-			v.LockOwnerBlocksAllocated,
+			&v.LockOwnerBlocksAllocated,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.MemMgrLogPoolMemoryKB,
-			prometheus.GaugeValue,
-			v.LogPoolMemoryKB*1024,
-			sqlInstance,
-		)
+		if present.has(&v.LogPoolMemoryKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.MemMgrLogPoolMemoryKB,
+				prometheus.GaugeValue,
+				v.LogPoolMemoryKB*1024,
+				sqlInstance,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
-			c.MemMgrMaximumWorkspaceMemoryKB,
-			prometheus.GaugeValue,
-			v.MaximumWorkspaceMemoryKB*1024,
-			sqlInstance,
-		)
+		if present.has(&v.MaximumWorkspaceMemoryKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.MemMgrMaximumWorkspaceMemoryKB,
+				prometheus.GaugeValue,
+				v.MaximumWorkspaceMemoryKB*1024,
+				sqlInstance,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MemMgrMemoryGrantsOutstanding,
 			prometheus.GaugeValue,
-			v.MemoryGrantsOutstanding,
+			&v.MemoryGrantsOutstanding,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MemMgrMemoryGrantsPending,
 			prometheus.GaugeValue,
-			v.MemoryGrantsPending,
+			&v.MemoryGrantsPending,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.MemMgrOptimizerMemoryKB,
-			prometheus.GaugeValue,
-			v.OptimizerMemoryKB*1024,
-			sqlInstance,
-		)
+		if present.has(&v.OptimizerMemoryKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.MemMgrOptimizerMemoryKB,
+				prometheus.GaugeValue,
+				v.OptimizerMemoryKB*1024,
+				sqlInstance,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
-			c.MemMgrReservedServerMemoryKB,
-			prometheus.GaugeValue,
-			v.ReservedServerMemoryKB*1024,
-			sqlInstance,
-		)
+		if present.has(&v.ReservedServerMemoryKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.MemMgrReservedServerMemoryKB,
+				prometheus.GaugeValue,
+				v.ReservedServerMemoryKB*1024,
+				sqlInstance,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
-			c.MemMgrSQLCacheMemoryKB,
-			prometheus.GaugeValue,
-			v.SQLCacheMemoryKB*1024,
-			sqlInstance,
-		)
+		if present.has(&v.SQLCacheMemoryKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.MemMgrSQLCacheMemoryKB,
+				prometheus.GaugeValue,
+				v.SQLCacheMemoryKB*1024,
+				sqlInstance,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
-			c.MemMgrStolenServerMemoryKB,
-			prometheus.GaugeValue,
-			v.StolenServerMemoryKB*1024,
-			sqlInstance,
-		)
+		if present.has(&v.StolenServerMemoryKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.MemMgrStolenServerMemoryKB,
+				prometheus.GaugeValue,
+				v.StolenServerMemoryKB*1024,
+				sqlInstance,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
-			c.MemMgrTargetServerMemoryKB,
-			prometheus.GaugeValue,
-			v.TargetServerMemoryKB*1024,
-			sqlInstance,
-		)
+		if present.has(&v.TargetServerMemoryKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.MemMgrTargetServerMemoryKB,
+				prometheus.GaugeValue,
+				v.TargetServerMemoryKB*1024,
+				sqlInstance,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
-			c.MemMgrTotalServerMemoryKB,
-			prometheus.GaugeValue,
-			v.TotalServerMemoryKB*1024,
-			sqlInstance,
-		)
+		if present.has(&v.TotalServerMemoryKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.MemMgrTotalServerMemoryKB,
+				prometheus.GaugeValue,
+				v.TotalServerMemoryKB*1024,
+				sqlInstance,
+			)
+		}
 	}
 
 	return nil, nil
@@ -3745,87 +3986,101 @@ type mssqlSQLStatistics struct {
 
 func (c *MSSQLCollector) collectSQLStats(ctx *ScrapeContext, ch chan<- prometheus.Metric, sqlInstance string) (*prometheus.Desc, error) {
 	var dst []mssqlSQLStatistics
-	log.Debugf("mssql_sqlstats collector iterating sql instance %s.", sqlInstance)
+	log.With("child", "mssql_sqlstats", "mssql_instance", sqlInstance).Debug("Collecting sql instance")
+
+	present, err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "sqlstats")], &dst)
 
-	if err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "sqlstats")], &dst); err != nil {
+	if err != nil {
 		return nil, err
 	}
 
-	for _, v := range dst {
-		ch <- prometheus.MustNewConstMetric(
+	for i := range dst {
+		v := &dst[i]
+		present.emit(
+			ch,
 			c.SQLStatsAutoParamAttempts,
 			prometheus.CounterValue,
-			v.AutoParamAttemptsPersec,
+			&v.AutoParamAttemptsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.SQLStatsBatchRequests,
 			prometheus.CounterValue,
-			v.BatchRequestsPersec,
+			&v.BatchRequestsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.SQLStatsFailedAutoParams,
 			prometheus.CounterValue,
-			v.FailedAutoParamsPersec,
+			&v.FailedAutoParamsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.SQLStatsForcedParameterizations,
 			prometheus.CounterValue,
-			v.ForcedParameterizationsPersec,
+			&v.ForcedParameterizationsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.SQLStatsGuidedplanexecutions,
 			prometheus.CounterValue,
-			v.GuidedplanexecutionsPersec,
+			&v.GuidedplanexecutionsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.SQLStatsMisguidedplanexecutions,
 			prometheus.CounterValue,
-			v.MisguidedplanexecutionsPersec,
+			&v.MisguidedplanexecutionsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.SQLStatsSafeAutoParams,
 			prometheus.CounterValue,
-			v.SafeAutoParamsPersec,
+			&v.SafeAutoParamsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.SQLStatsSQLAttentionrate,
 			prometheus.CounterValue,
-			v.SQLAttentionrate,
+			&v.SQLAttentionrate,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.SQLStatsSQLCompilations,
 			prometheus.CounterValue,
-			v.SQLCompilationsPersec,
+			&v.SQLCompilationsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.SQLStatsSQLReCompilations,
 			prometheus.CounterValue,
-			v.SQLReCompilationsPersec,
+			&v.SQLReCompilationsPersec,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.SQLStatsUnsafeAutoParams,
 			prometheus.CounterValue,
-			v.UnsafeAutoParamsPersec,
+			&v.UnsafeAutoParamsPersec,
 			sqlInstance,
 		)
 	}
@@ -3853,96 +4108,111 @@ type mssqlWaitStatistics struct {
 
 func (c *MSSQLCollector) collectWaitStats(ctx *ScrapeContext, ch chan<- prometheus.Metric, sqlInstance string) (*prometheus.Desc, error) {
 	var dst []mssqlWaitStatistics
-	log.Debugf("mssql_waitstats collector iterating sql instance %s.", sqlInstance)
+	log.With("child", "mssql_waitstats", "mssql_instance", sqlInstance).Debug("Collecting sql instance")
 
-	if err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "waitstats")], &dst); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "waitstats")], &dst)
+
+	if err != nil {
 		return nil, err
 	}
 
-	for _, v := range dst {
+	for i := range dst {
+		v := &dst[i]
 		item := v.Name
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WaitStatsLockWaits,
 			prometheus.CounterValue,
-			v.WaitStatsLockWaits,
+			&v.WaitStatsLockWaits,
 			sqlInstance, item,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WaitStatsMemoryGrantQueueWaits,
 			prometheus.CounterValue,
-			v.WaitStatsMemoryGrantQueueWaits,
+			&v.WaitStatsMemoryGrantQueueWaits,
 			sqlInstance, item,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WaitStatsThreadSafeMemoryObjectsWaits,
 			prometheus.CounterValue,
-			v.WaitStatsThreadSafeMemoryObjectsWaits,
+			&v.WaitStatsThreadSafeMemoryObjectsWaits,
 			sqlInstance, item,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WaitStatsLogWriteWaits,
 			prometheus.CounterValue,
-			v.WaitStatsLogWriteWaits,
+			&v.WaitStatsLogWriteWaits,
 			sqlInstance, item,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WaitStatsLogBufferWaits,
 			prometheus.CounterValue,
-			v.WaitStatsLogBufferWaits,
+			&v.WaitStatsLogBufferWaits,
 			sqlInstance, item,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WaitStatsNetworkIOWaits,
 			prometheus.CounterValue,
-			v.WaitStatsNetworkIOWaits,
+			&v.WaitStatsNetworkIOWaits,
 			sqlInstance, item,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WaitStatsPageIOLatchWaits,
 			prometheus.CounterValue,
-			v.WaitStatsPageIOLatchWaits,
+			&v.WaitStatsPageIOLatchWaits,
 			sqlInstance, item,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WaitStatsPageLatchWaits,
 			prometheus.CounterValue,
-			v.WaitStatsPageLatchWaits,
+			&v.WaitStatsPageLatchWaits,
 			sqlInstance, item,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WaitStatsNonpageLatchWaits,
 			prometheus.CounterValue,
-			v.WaitStatsNonpageLatchWaits,
+			&v.WaitStatsNonpageLatchWaits,
 			sqlInstance, item,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WaitStatsWaitForTheWorkerWaits,
 			prometheus.CounterValue,
-			v.WaitStatsWaitForTheWorkerWaits,
+			&v.WaitStatsWaitForTheWorkerWaits,
 			sqlInstance, item,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WaitStatsWorkspaceSynchronizationWaits,
 			prometheus.CounterValue,
-			v.WaitStatsWorkspaceSynchronizationWaits,
+			&v.WaitStatsWorkspaceSynchronizationWaits,
 			sqlInstance, item,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WaitStatsTransactionOwnershipWaits,
 			prometheus.CounterValue,
-			v.WaitStatsTransactionOwnershipWaits,
+			&v.WaitStatsTransactionOwnershipWaits,
 			sqlInstance, item,
 		)
 	}
@@ -3959,22 +4229,26 @@ type mssqlSQLErrors struct {
 // - https://docs.microsoft.com/en-us/sql/relational-databases/performance-monitor/sql-server-sql-errors-object
 func (c *MSSQLCollector) collectSQLErrors(ctx *ScrapeContext, ch chan<- prometheus.Metric, sqlInstance string) (*prometheus.Desc, error) {
 	var dst []mssqlSQLErrors
-	log.Debugf("mssql_sqlerrors collector iterating sql instance %s.", sqlInstance)
+	log.With("child", "mssql_sqlerrors", "mssql_instance", sqlInstance).Debug("Collecting sql instance")
 
-	if err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "sqlerrors")], &dst); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "sqlerrors")], &dst)
+
+	if err != nil {
 		return nil, err
 	}
 
-	for _, v := range dst {
+	for i := range dst {
+		v := &dst[i]
 		if strings.ToLower(v.Name) == "_total" {
 			continue
 		}
 		resource := v.Name
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.SQLErrorsTotal,
 			prometheus.CounterValue,
-			v.ErrorsPersec,
+			&v.ErrorsPersec,
 			sqlInstance, resource,
 		)
 	}
@@ -4002,101 +4276,121 @@ type mssqlTransactions struct {
 // - https://docs.microsoft.com/en-us/sql/relational-databases/performance-monitor/sql-server-transactions-object
 func (c *MSSQLCollector) collectTransactions(ctx *ScrapeContext, ch chan<- prometheus.Metric, sqlInstance string) (*prometheus.Desc, error) {
 	var dst []mssqlTransactions
-	log.Debugf("mssql_transactions collector iterating sql instance %s.", sqlInstance)
+	log.With("child", "mssql_transactions", "mssql_instance", sqlInstance).Debug("Collecting sql instance")
+
+	present, err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "transactions")], &dst)
 
-	if err := unmarshalObject(ctx.perfObjects[mssqlGetPerfObjectName(sqlInstance, "transactions")], &dst); err != nil {
+	if err != nil {
 		return nil, err
 	}
 
-	for _, v := range dst {
-		ch <- prometheus.MustNewConstMetric(
-			c.TransactionsTempDbFreeSpaceBytes,
-			prometheus.GaugeValue,
-			v.FreeSpaceintempdbKB*1024,
-			sqlInstance,
-		)
+	for i := range dst {
+		v := &dst[i]
+		if present.has(&v.FreeSpaceintempdbKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.TransactionsTempDbFreeSpaceBytes,
+				prometheus.GaugeValue,
+				v.FreeSpaceintempdbKB*1024,
+				sqlInstance,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TransactionsLongestTransactionRunningSeconds,
 			prometheus.GaugeValue,
-			v.LongestTransactionRunningTime,
+			&v.LongestTransactionRunningTime,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TransactionsNonSnapshotVersionActiveTotal,
 			prometheus.CounterValue,
-			v.NonSnapshotVersionTransactions,
+			&v.NonSnapshotVersionTransactions,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TransactionsSnapshotActiveTotal,
 			prometheus.CounterValue,
-			v.SnapshotTransactions,
+			&v.SnapshotTransactions,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TransactionsActive,
 			prometheus.GaugeValue,
-			v.Transactions,
+			&v.Transactions,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TransactionsUpdateConflictsTotal,
 			prometheus.CounterValue,
-			v.Updateconflictratio,
+			&v.Updateconflictratio,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TransactionsUpdateSnapshotActiveTotal,
 			prometheus.CounterValue,
-			v.UpdateSnapshotTransactions,
+			&v.UpdateSnapshotTransactions,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.TransactionsVersionCleanupRateBytes,
-			prometheus.GaugeValue,
-			v.VersionCleanuprateKBPers*1024,
-			sqlInstance,
-		)
+		if present.has(&v.VersionCleanuprateKBPers) {
+			ch <- prometheus.MustNewConstMetric(
+				c.TransactionsVersionCleanupRateBytes,
+				prometheus.GaugeValue,
+				v.VersionCleanuprateKBPers*1024,
+				sqlInstance,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
-			c.TransactionsVersionGenerationRateBytes,
-			prometheus.GaugeValue,
-			v.VersionGenerationrateKBPers*1024,
-			sqlInstance,
-		)
+		if present.has(&v.VersionGenerationrateKBPers) {
+			ch <- prometheus.MustNewConstMetric(
+				c.TransactionsVersionGenerationRateBytes,
+				prometheus.GaugeValue,
+				v.VersionGenerationrateKBPers*1024,
+				sqlInstance,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
-			c.TransactionsVersionStoreSizeBytes,
-			prometheus.GaugeValue,
-			v.VersionStoreSizeKB*1024,
-			sqlInstance,
-		)
+		if present.has(&v.VersionStoreSizeKB) {
+			ch <- prometheus.MustNewConstMetric(
+				c.TransactionsVersionStoreSizeBytes,
+				prometheus.GaugeValue,
+				v.VersionStoreSizeKB*1024,
+				sqlInstance,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TransactionsVersionStoreUnits,
 			prometheus.CounterValue,
-			v.VersionStoreunitcount,
+			&v.VersionStoreunitcount,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TransactionsVersionStoreCreationUnits,
 			prometheus.CounterValue,
-			v.VersionStoreunitcreation,
+			&v.VersionStoreunitcreation,
 			sqlInstance,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TransactionsVersionStoreTruncationUnits,
 			prometheus.CounterValue,
-			v.VersionStoreunittruncation,
+			&v.VersionStoreunittruncation,
 			sqlInstance,
 		)
 	}
@@ -4,6 +4,10 @@ import (
 	"testing"
 )
 
+func TestMSSQLCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "mssql", NewMSSQLCollector)
+}
+
 func BenchmarkMSSQLCollector(b *testing.B) {
 	benchmarkCollector(b, "mssql", NewMSSQLCollector)
 }
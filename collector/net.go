@@ -3,12 +3,9 @@
 package collector
 
 import (
-	"fmt"
 	"regexp"
 
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
-	"gopkg.in/alecthomas/kingpin.v2"
 )
 
 func init() {
@@ -16,14 +13,8 @@ func init() {
 }
 
 var (
-	nicWhitelist = kingpin.Flag(
-		"collector.net.nic-whitelist",
-		"Regexp of NIC:s to whitelist. NIC name must both match whitelist and not match blacklist to be included.",
-	).Default(".+").String()
-	nicBlacklist = kingpin.Flag(
-		"collector.net.nic-blacklist",
-		"Regexp of NIC:s to blacklist. NIC name must both match whitelist and not match blacklist to be included.",
-	).Default("").String()
+	nicFilterFlags = registerFilterFlags("collector.net.nic-", "NICs", "nic").
+			withDeprecatedFlags("collector.net.nic-whitelist", "collector.net.nic-blacklist")
 	nicNameToUnderscore = regexp.MustCompile("[^a-zA-Z0-9]")
 )
 
@@ -42,14 +33,18 @@ type NetworkCollector struct {
 	PacketsSentTotal         *prometheus.Desc
 	CurrentBandwidth         *prometheus.Desc
 
-	nicWhitelistPattern *regexp.Regexp
-	nicBlacklistPattern *regexp.Regexp
+	nicFilter *instanceFilter
 }
 
 // NewNetworkCollector ...
 func NewNetworkCollector() (Collector, error) {
 	const subsystem = "net"
 
+	nicFilter, err := nicFilterFlags.build()
+	if err != nil {
+		return nil, err
+	}
+
 	return &NetworkCollector{
 		BytesReceivedTotal: prometheus.NewDesc(
 			prometheus.BuildFQName(Namespace, subsystem, "bytes_received_total"),
@@ -124,16 +119,14 @@ func NewNetworkCollector() (Collector, error) {
 			nil,
 		),
 
-		nicWhitelistPattern: regexp.MustCompile(fmt.Sprintf("^(?:%s)$", *nicWhitelist)),
-		nicBlacklistPattern: regexp.MustCompile(fmt.Sprintf("^(?:%s)$", *nicBlacklist)),
+		nicFilter: nicFilter,
 	}, nil
 }
 
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *NetworkCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ctx, ch); err != nil {
-		log.Error("failed collecting net metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
@@ -166,13 +159,15 @@ type networkInterface struct {
 func (c *NetworkCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []networkInterface
 
-	if err := unmarshalObject(ctx.perfObjects["Network Interface"], &dst); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["Network Interface"], &dst)
+
+	if err != nil {
 		return nil, err
 	}
 
-	for _, nic := range dst {
-		if c.nicBlacklistPattern.MatchString(nic.Name) ||
-			!c.nicWhitelistPattern.MatchString(nic.Name) {
+	for i := range dst {
+		nic := &dst[i]
+		if !c.nicFilter.matches(nic.Name) {
 			continue
 		}
 
@@ -182,78 +177,91 @@ func (c *NetworkCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metr
 		}
 
 		// Counters
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BytesReceivedTotal,
 			prometheus.CounterValue,
-			nic.BytesReceivedPerSec,
+			&nic.BytesReceivedPerSec,
 			name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BytesSentTotal,
 			prometheus.CounterValue,
-			nic.BytesSentPerSec,
+			&nic.BytesSentPerSec,
 			name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BytesTotal,
 			prometheus.CounterValue,
-			nic.BytesTotalPerSec,
+			&nic.BytesTotalPerSec,
 			name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PacketsOutboundDiscarded,
 			prometheus.CounterValue,
-			nic.PacketsOutboundDiscarded,
+			&nic.PacketsOutboundDiscarded,
 			name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PacketsOutboundErrors,
 			prometheus.CounterValue,
-			nic.PacketsOutboundErrors,
+			&nic.PacketsOutboundErrors,
 			name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PacketsTotal,
 			prometheus.CounterValue,
-			nic.PacketsPerSec,
+			&nic.PacketsPerSec,
 			name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PacketsReceivedDiscarded,
 			prometheus.CounterValue,
-			nic.PacketsReceivedDiscarded,
+			&nic.PacketsReceivedDiscarded,
 			name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PacketsReceivedErrors,
 			prometheus.CounterValue,
-			nic.PacketsReceivedErrors,
+			&nic.PacketsReceivedErrors,
 			name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PacketsReceivedTotal,
 			prometheus.CounterValue,
-			nic.PacketsReceivedPerSec,
+			&nic.PacketsReceivedPerSec,
 			name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PacketsReceivedUnknown,
 			prometheus.CounterValue,
-			nic.PacketsReceivedUnknown,
+			&nic.PacketsReceivedUnknown,
 			name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PacketsSentTotal,
 			prometheus.CounterValue,
-			nic.PacketsSentPerSec,
-			name,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.CurrentBandwidth,
-			prometheus.GaugeValue,
-			nic.CurrentBandwidth/8,
+			&nic.PacketsSentPerSec,
 			name,
 		)
+		if present.has(&nic.CurrentBandwidth) {
+			ch <- prometheus.MustNewConstMetric(
+				c.CurrentBandwidth,
+				prometheus.GaugeValue,
+				nic.CurrentBandwidth/8,
+				name,
+			)
+		}
 	}
 	return nil, nil
 }
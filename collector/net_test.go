@@ -18,9 +18,10 @@ func TestNetworkToInstanceName(t *testing.T) {
 	}
 }
 
+func TestNetCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "net", NewNetworkCollector)
+}
+
 func BenchmarkNetCollector(b *testing.B) {
-	// Whitelist is not set in testing context (kingpin flags not parsed), causing the collector to skip all interfaces.
-	localNicWhitelist := ".+"
-	nicWhitelist = &localNicWhitelist
 	benchmarkCollector(b, "net", NewNetworkCollector)
 }
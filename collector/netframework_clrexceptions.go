@@ -3,16 +3,14 @@
 package collector
 
 import (
-	"github.com/StackExchange/wmi"
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 func init() {
-	registerCollector("netframework_clrexceptions", NewNETFramework_NETCLRExceptionsCollector)
+	registerCollector("netframework_clrexceptions", NewNETFramework_NETCLRExceptionsCollector, ".NET CLR Exceptions")
 }
 
-// A NETFramework_NETCLRExceptionsCollector is a Prometheus collector for WMI Win32_PerfRawData_NETFramework_NETCLRExceptions metrics
+// A NETFramework_NETCLRExceptionsCollector is a Prometheus collector for Perflib .NET CLR Exceptions metrics
 type NETFramework_NETCLRExceptionsCollector struct {
 	NumberofExcepsThrown *prometheus.Desc
 	NumberofFilters      *prometheus.Desc
@@ -54,61 +52,64 @@ func NewNETFramework_NETCLRExceptionsCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *NETFramework_NETCLRExceptionsCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting win32_perfrawdata_netframework_netclrexceptions metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
 }
 
-type Win32_PerfRawData_NETFramework_NETCLRExceptions struct {
+type netframeworkCLRExceptions struct {
 	Name string
 
-	NumberofExcepsThrown       uint32
-	NumberofExcepsThrownPersec uint32
-	NumberofFiltersPersec      uint32
-	NumberofFinallysPersec     uint32
-	ThrowToCatchDepthPersec    uint32
+	NumberofExcepsThrown    float64 `perflib:"# of Exceps Thrown"`
+	NumberofFiltersPersec   float64 `perflib:"# of Filters / sec"`
+	NumberofFinallysPersec  float64 `perflib:"# of Finallys / sec"`
+	ThrowToCatchDepthPersec float64 `perflib:"Throw To Catch Depth / sec"`
 }
 
-func (c *NETFramework_NETCLRExceptionsCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
-	var dst []Win32_PerfRawData_NETFramework_NETCLRExceptions
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+func (c *NETFramework_NETCLRExceptionsCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []netframeworkCLRExceptions
+	present, err := unmarshalObject(ctx.perfObjects[".NET CLR Exceptions"], &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	for _, process := range dst {
+	for i := range dst {
+		process := &dst[i]
 
 		if process.Name == "_Global_" {
 			continue
 		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NumberofExcepsThrown,
 			prometheus.CounterValue,
-			float64(process.NumberofExcepsThrown),
+			&process.NumberofExcepsThrown,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NumberofFilters,
 			prometheus.CounterValue,
-			float64(process.NumberofFiltersPersec),
+			&process.NumberofFiltersPersec,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NumberofFinallys,
 			prometheus.CounterValue,
-			float64(process.NumberofFinallysPersec),
+			&process.NumberofFinallysPersec,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ThrowToCatchDepth,
 			prometheus.CounterValue,
-			float64(process.ThrowToCatchDepthPersec),
+			&process.ThrowToCatchDepthPersec,
 			process.Name,
 		)
 	}
@@ -2,9 +2,79 @@ package collector
 
 import (
 	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+func TestNetFrameworkNETCLRExceptionsCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "netframework_clrexceptions", NewNETFramework_NETCLRExceptionsCollector)
+}
+
+func TestNetFrameworkNETCLRExceptionsCollectorParity(t *testing.T) {
+	testCollectorParity(t, "netframework_clrexceptions", NewNETFramework_NETCLRExceptionsCollector, func(c Collector, ch chan<- prometheus.Metric) error {
+		_, err := c.(*NETFramework_NETCLRExceptionsCollector).collectWMI(ch)
+		return err
+	})
+}
+
 func BenchmarkNetFrameworkNETCLRExceptionsCollector(b *testing.B) {
-	// No context name required as collector source is WMI
-	benchmarkCollector(b, "", NewNETFramework_NETCLRExceptionsCollector)
+	benchmarkCollector(b, "netframework_clrexceptions", NewNETFramework_NETCLRExceptionsCollector)
+}
+
+// The WMI implementation the collector was moved from, kept to check that
+// the perflib implementation exposes the same metrics.
+
+type Win32_PerfRawData_NETFramework_NETCLRExceptions struct {
+	Name string
+
+	NumberofExcepsThrown       uint32
+	NumberofExcepsThrownPersec uint32
+	NumberofFiltersPersec      uint32
+	NumberofFinallysPersec     uint32
+	ThrowToCatchDepthPersec    uint32
+}
+
+func (c *NETFramework_NETCLRExceptionsCollector) collectWMI(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []Win32_PerfRawData_NETFramework_NETCLRExceptions
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
+		return nil, err
+	}
+
+	for _, process := range dst {
+
+		if process.Name == "_Global_" {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.NumberofExcepsThrown,
+			prometheus.CounterValue,
+			float64(process.NumberofExcepsThrown),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.NumberofFilters,
+			prometheus.CounterValue,
+			float64(process.NumberofFiltersPersec),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.NumberofFinallys,
+			prometheus.CounterValue,
+			float64(process.NumberofFinallysPersec),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.ThrowToCatchDepth,
+			prometheus.CounterValue,
+			float64(process.ThrowToCatchDepthPersec),
+			process.Name,
+		)
+	}
+
+	return nil, nil
 }
@@ -3,16 +3,14 @@
 package collector
 
 import (
-	"github.com/StackExchange/wmi"
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 func init() {
-	registerCollector("netframework_clrinterop", NewNETFramework_NETCLRInteropCollector)
+	registerCollector("netframework_clrinterop", NewNETFramework_NETCLRInteropCollector, ".NET CLR Interop")
 }
 
-// A NETFramework_NETCLRInteropCollector is a Prometheus collector for WMI Win32_PerfRawData_NETFramework_NETCLRInterop metrics
+// A NETFramework_NETCLRInteropCollector is a Prometheus collector for Perflib .NET CLR Interop metrics
 type NETFramework_NETCLRInteropCollector struct {
 	NumberofCCWs        *prometheus.Desc
 	Numberofmarshalling *prometheus.Desc
@@ -47,54 +45,55 @@ func NewNETFramework_NETCLRInteropCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *NETFramework_NETCLRInteropCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting win32_perfrawdata_netframework_netclrinterop metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
 }
 
-type Win32_PerfRawData_NETFramework_NETCLRInterop struct {
+type netframeworkCLRInterop struct {
 	Name string
 
-	NumberofCCWs             uint32
-	Numberofmarshalling      uint32
-	NumberofStubs            uint32
-	NumberofTLBexportsPersec uint32
-	NumberofTLBimportsPersec uint32
+	NumberofCCWs        float64 `perflib:"# of CCWs"`
+	NumberofStubs       float64 `perflib:"# of Stubs"`
+	Numberofmarshalling float64 `perflib:"# of marshalling"`
 }
 
-func (c *NETFramework_NETCLRInteropCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
-	var dst []Win32_PerfRawData_NETFramework_NETCLRInterop
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+func (c *NETFramework_NETCLRInteropCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []netframeworkCLRInterop
+	present, err := unmarshalObject(ctx.perfObjects[".NET CLR Interop"], &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	for _, process := range dst {
+	for i := range dst {
+		process := &dst[i]
 
 		if process.Name == "_Global_" {
 			continue
 		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NumberofCCWs,
 			prometheus.CounterValue,
-			float64(process.NumberofCCWs),
+			&process.NumberofCCWs,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.Numberofmarshalling,
 			prometheus.CounterValue,
-			float64(process.Numberofmarshalling),
+			&process.Numberofmarshalling,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NumberofStubs,
 			prometheus.CounterValue,
-			float64(process.NumberofStubs),
+			&process.NumberofStubs,
 			process.Name,
 		)
 	}
@@ -2,9 +2,72 @@ package collector
 
 import (
 	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+func TestNETFrameworkNETCLRInteropCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "netframework_clrinterop", NewNETFramework_NETCLRInteropCollector)
+}
+
+func TestNETFrameworkNETCLRInteropCollectorParity(t *testing.T) {
+	testCollectorParity(t, "netframework_clrinterop", NewNETFramework_NETCLRInteropCollector, func(c Collector, ch chan<- prometheus.Metric) error {
+		_, err := c.(*NETFramework_NETCLRInteropCollector).collectWMI(ch)
+		return err
+	})
+}
+
 func BenchmarkNETFrameworkNETCLRInteropCollector(b *testing.B) {
-	// No context name required as collector source is WMI
-	benchmarkCollector(b, "", NewNETFramework_NETCLRInteropCollector)
+	benchmarkCollector(b, "netframework_clrinterop", NewNETFramework_NETCLRInteropCollector)
+}
+
+// The WMI implementation the collector was moved from, kept to check that
+// the perflib implementation exposes the same metrics.
+
+type Win32_PerfRawData_NETFramework_NETCLRInterop struct {
+	Name string
+
+	NumberofCCWs             uint32
+	Numberofmarshalling      uint32
+	NumberofStubs            uint32
+	NumberofTLBexportsPersec uint32
+	NumberofTLBimportsPersec uint32
+}
+
+func (c *NETFramework_NETCLRInteropCollector) collectWMI(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []Win32_PerfRawData_NETFramework_NETCLRInterop
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
+		return nil, err
+	}
+
+	for _, process := range dst {
+
+		if process.Name == "_Global_" {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.NumberofCCWs,
+			prometheus.CounterValue,
+			float64(process.NumberofCCWs),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Numberofmarshalling,
+			prometheus.CounterValue,
+			float64(process.Numberofmarshalling),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.NumberofStubs,
+			prometheus.CounterValue,
+			float64(process.NumberofStubs),
+			process.Name,
+		)
+	}
+
+	return nil, nil
 }
@@ -3,16 +3,14 @@
 package collector
 
 import (
-	"github.com/StackExchange/wmi"
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 func init() {
-	registerCollector("netframework_clrjit", NewNETFramework_NETCLRJitCollector)
+	registerCollector("netframework_clrjit", NewNETFramework_NETCLRJitCollector, ".NET CLR Jit")
 }
 
-// A NETFramework_NETCLRJitCollector is a Prometheus collector for WMI Win32_PerfRawData_NETFramework_NETCLRJit metrics
+// A NETFramework_NETCLRJitCollector is a Prometheus collector for Perflib .NET CLR Jit metrics
 type NETFramework_NETCLRJitCollector struct {
 	NumberofMethodsJitted      *prometheus.Desc
 	TimeinJit                  *prometheus.Desc
@@ -54,63 +52,66 @@ func NewNETFramework_NETCLRJitCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *NETFramework_NETCLRJitCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting win32_perfrawdata_netframework_netclrjit metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
 }
 
-type Win32_PerfRawData_NETFramework_NETCLRJit struct {
+type netframeworkCLRJit struct {
 	Name string
 
-	Frequency_PerfTime         uint32
-	ILBytesJittedPersec        uint32
-	NumberofILBytesJitted      uint32
-	NumberofMethodsJitted      uint32
-	PercentTimeinJit           uint32
-	StandardJitFailures        uint32
-	TotalNumberofILBytesJitted uint32
+	NumberofMethodsJitted      float64 `perflib:"# of Methods Jitted"`
+	TotalNumberofILBytesJitted float64 `perflib:"Total # of IL Bytes Jitted"`
+	StandardJitFailures        float64 `perflib:"Standard Jit Failures"`
+	PercentTimeinJit           float64 `perflib:"% Time in Jit"`
 }
 
-func (c *NETFramework_NETCLRJitCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
-	var dst []Win32_PerfRawData_NETFramework_NETCLRJit
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+func (c *NETFramework_NETCLRJitCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []netframeworkCLRJit
+	obj := ctx.perfObjects[".NET CLR Jit"]
+	present, err := unmarshalObject(obj, &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	for _, process := range dst {
+	for i := range dst {
+		process := &dst[i]
 
 		if process.Name == "_Global_" {
 			continue
 		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NumberofMethodsJitted,
 			prometheus.CounterValue,
-			float64(process.NumberofMethodsJitted),
+			&process.NumberofMethodsJitted,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.TimeinJit,
-			prometheus.GaugeValue,
-			float64(process.PercentTimeinJit)/float64(process.Frequency_PerfTime),
-			process.Name,
-		)
+		if present.has(&process.PercentTimeinJit) {
+			ch <- prometheus.MustNewConstMetric(
+				c.TimeinJit,
+				prometheus.GaugeValue,
+				process.PercentTimeinJit/float64(obj.Frequency),
+				process.Name,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.StandardJitFailures,
 			prometheus.GaugeValue,
-			float64(process.StandardJitFailures),
+			&process.StandardJitFailures,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TotalNumberofILBytesJitted,
 			prometheus.CounterValue,
-			float64(process.TotalNumberofILBytesJitted),
+			&process.TotalNumberofILBytesJitted,
 			process.Name,
 		)
 	}
@@ -2,9 +2,81 @@ package collector
 
 import (
 	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+func TestNETFrameworkNETCLRJitCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "netframework_clrjit", NewNETFramework_NETCLRJitCollector)
+}
+
+func TestNETFrameworkNETCLRJitCollectorParity(t *testing.T) {
+	testCollectorParity(t, "netframework_clrjit", NewNETFramework_NETCLRJitCollector, func(c Collector, ch chan<- prometheus.Metric) error {
+		_, err := c.(*NETFramework_NETCLRJitCollector).collectWMI(ch)
+		return err
+	})
+}
+
 func BenchmarkNETFrameworkNETCLRJitCollector(b *testing.B) {
-	// No context name required as collector source is WMI
-	benchmarkCollector(b, "", NewNETFramework_NETCLRJitCollector)
+	benchmarkCollector(b, "netframework_clrjit", NewNETFramework_NETCLRJitCollector)
+}
+
+// The WMI implementation the collector was moved from, kept to check that
+// the perflib implementation exposes the same metrics.
+
+type Win32_PerfRawData_NETFramework_NETCLRJit struct {
+	Name string
+
+	Frequency_PerfTime         uint32
+	ILBytesJittedPersec        uint32
+	NumberofILBytesJitted      uint32
+	NumberofMethodsJitted      uint32
+	PercentTimeinJit           uint32
+	StandardJitFailures        uint32
+	TotalNumberofILBytesJitted uint32
+}
+
+func (c *NETFramework_NETCLRJitCollector) collectWMI(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []Win32_PerfRawData_NETFramework_NETCLRJit
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
+		return nil, err
+	}
+
+	for _, process := range dst {
+
+		if process.Name == "_Global_" {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.NumberofMethodsJitted,
+			prometheus.CounterValue,
+			float64(process.NumberofMethodsJitted),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.TimeinJit,
+			prometheus.GaugeValue,
+			float64(process.PercentTimeinJit)/float64(process.Frequency_PerfTime),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.StandardJitFailures,
+			prometheus.GaugeValue,
+			float64(process.StandardJitFailures),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.TotalNumberofILBytesJitted,
+			prometheus.CounterValue,
+			float64(process.TotalNumberofILBytesJitted),
+			process.Name,
+		)
+	}
+
+	return nil, nil
 }
@@ -3,16 +3,14 @@
 package collector
 
 import (
-	"github.com/StackExchange/wmi"
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 func init() {
-	registerCollector("netframework_clrloading", NewNETFramework_NETCLRLoadingCollector)
+	registerCollector("netframework_clrloading", NewNETFramework_NETCLRLoadingCollector, ".NET CLR Loading")
 }
 
-// A NETFramework_NETCLRLoadingCollector is a Prometheus collector for WMI Win32_PerfRawData_NETFramework_NETCLRLoading metrics
+// A NETFramework_NETCLRLoadingCollector is a Prometheus collector for Perflib .NET CLR Loading metrics
 type NETFramework_NETCLRLoadingCollector struct {
 	BytesinLoaderHeap         *prometheus.Desc
 	Currentappdomains         *prometheus.Desc
@@ -89,107 +87,109 @@ func NewNETFramework_NETCLRLoadingCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *NETFramework_NETCLRLoadingCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting win32_perfrawdata_netframework_netclrloading metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
 }
 
-type Win32_PerfRawData_NETFramework_NETCLRLoading struct {
+type netframeworkCLRLoading struct {
 	Name string
 
-	AssemblySearchLength      uint32
-	BytesinLoaderHeap         uint64
-	Currentappdomains         uint32
-	CurrentAssemblies         uint32
-	CurrentClassesLoaded      uint32
-	PercentTimeLoading        uint64
-	Rateofappdomains          uint32
-	Rateofappdomainsunloaded  uint32
-	RateofAssemblies          uint32
-	RateofClassesLoaded       uint32
-	RateofLoadFailures        uint32
-	TotalAppdomains           uint32
-	Totalappdomainsunloaded   uint32
-	TotalAssemblies           uint32
-	TotalClassesLoaded        uint32
-	TotalNumberofLoadFailures uint32
+	CurrentClassesLoaded      float64 `perflib:"Current Classes Loaded"`
+	TotalClassesLoaded        float64 `perflib:"Total Classes Loaded"`
+	Currentappdomains         float64 `perflib:"Current appdomains"`
+	TotalAppdomains           float64 `perflib:"Total Appdomains"`
+	CurrentAssemblies         float64 `perflib:"Current Assemblies"`
+	TotalAssemblies           float64 `perflib:"Total Assemblies"`
+	TotalNumberofLoadFailures float64 `perflib:"Total # of Load Failures"`
+	BytesinLoaderHeap         float64 `perflib:"Bytes in Loader Heap"`
+	Totalappdomainsunloaded   float64 `perflib:"Total appdomains unloaded"`
 }
 
-func (c *NETFramework_NETCLRLoadingCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
-	var dst []Win32_PerfRawData_NETFramework_NETCLRLoading
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+func (c *NETFramework_NETCLRLoadingCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []netframeworkCLRLoading
+	present, err := unmarshalObject(ctx.perfObjects[".NET CLR Loading"], &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	for _, process := range dst {
+	for i := range dst {
+		process := &dst[i]
 
 		if process.Name == "_Global_" {
 			continue
 		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BytesinLoaderHeap,
 			prometheus.GaugeValue,
-			float64(process.BytesinLoaderHeap),
+			&process.BytesinLoaderHeap,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.Currentappdomains,
 			prometheus.GaugeValue,
-			float64(process.Currentappdomains),
+			&process.Currentappdomains,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.CurrentAssemblies,
 			prometheus.GaugeValue,
-			float64(process.CurrentAssemblies),
+			&process.CurrentAssemblies,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.CurrentClassesLoaded,
 			prometheus.GaugeValue,
-			float64(process.CurrentClassesLoaded),
+			&process.CurrentClassesLoaded,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TotalAppdomains,
 			prometheus.CounterValue,
-			float64(process.TotalAppdomains),
+			&process.TotalAppdomains,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.Totalappdomainsunloaded,
 			prometheus.CounterValue,
-			float64(process.Totalappdomainsunloaded),
+			&process.Totalappdomainsunloaded,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TotalAssemblies,
 			prometheus.CounterValue,
-			float64(process.TotalAssemblies),
+			&process.TotalAssemblies,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TotalClassesLoaded,
 			prometheus.CounterValue,
-			float64(process.TotalClassesLoaded),
+			&process.TotalClassesLoaded,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TotalNumberofLoadFailures,
 			prometheus.CounterValue,
-			float64(process.TotalNumberofLoadFailures),
+			&process.TotalNumberofLoadFailures,
 			process.Name,
 		)
 	}
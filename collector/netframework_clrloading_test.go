@@ -2,9 +2,125 @@ package collector
 
 import (
 	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+func TestNETFrameworkNETCLRLoadingCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "netframework_clrloading", NewNETFramework_NETCLRLoadingCollector)
+}
+
+func TestNETFrameworkNETCLRLoadingCollectorParity(t *testing.T) {
+	testCollectorParity(t, "netframework_clrloading", NewNETFramework_NETCLRLoadingCollector, func(c Collector, ch chan<- prometheus.Metric) error {
+		_, err := c.(*NETFramework_NETCLRLoadingCollector).collectWMI(ch)
+		return err
+	})
+}
+
 func BenchmarkNETFrameworkNETCLRLoadingCollector(b *testing.B) {
-	// No context name required as collector source is WMI
-	benchmarkCollector(b, "", NewNETFramework_NETCLRLoadingCollector)
+	benchmarkCollector(b, "netframework_clrloading", NewNETFramework_NETCLRLoadingCollector)
+}
+
+// The WMI implementation the collector was moved from, kept to check that
+// the perflib implementation exposes the same metrics.
+
+type Win32_PerfRawData_NETFramework_NETCLRLoading struct {
+	Name string
+
+	AssemblySearchLength      uint32
+	BytesinLoaderHeap         uint64
+	Currentappdomains         uint32
+	CurrentAssemblies         uint32
+	CurrentClassesLoaded      uint32
+	PercentTimeLoading        uint64
+	Rateofappdomains          uint32
+	Rateofappdomainsunloaded  uint32
+	RateofAssemblies          uint32
+	RateofClassesLoaded       uint32
+	RateofLoadFailures        uint32
+	TotalAppdomains           uint32
+	Totalappdomainsunloaded   uint32
+	TotalAssemblies           uint32
+	TotalClassesLoaded        uint32
+	TotalNumberofLoadFailures uint32
+}
+
+func (c *NETFramework_NETCLRLoadingCollector) collectWMI(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []Win32_PerfRawData_NETFramework_NETCLRLoading
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
+		return nil, err
+	}
+
+	for _, process := range dst {
+
+		if process.Name == "_Global_" {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.BytesinLoaderHeap,
+			prometheus.GaugeValue,
+			float64(process.BytesinLoaderHeap),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Currentappdomains,
+			prometheus.GaugeValue,
+			float64(process.Currentappdomains),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.CurrentAssemblies,
+			prometheus.GaugeValue,
+			float64(process.CurrentAssemblies),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.CurrentClassesLoaded,
+			prometheus.GaugeValue,
+			float64(process.CurrentClassesLoaded),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.TotalAppdomains,
+			prometheus.CounterValue,
+			float64(process.TotalAppdomains),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Totalappdomainsunloaded,
+			prometheus.CounterValue,
+			float64(process.Totalappdomainsunloaded),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.TotalAssemblies,
+			prometheus.CounterValue,
+			float64(process.TotalAssemblies),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.TotalClassesLoaded,
+			prometheus.CounterValue,
+			float64(process.TotalClassesLoaded),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.TotalNumberofLoadFailures,
+			prometheus.CounterValue,
+			float64(process.TotalNumberofLoadFailures),
+			process.Name,
+		)
+	}
+
+	return nil, nil
 }
@@ -3,16 +3,14 @@
 package collector
 
 import (
-	"github.com/StackExchange/wmi"
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 func init() {
-	registerCollector("netframework_clrlocksandthreads", NewNETFramework_NETCLRLocksAndThreadsCollector)
+	registerCollector("netframework_clrlocksandthreads", NewNETFramework_NETCLRLocksAndThreadsCollector, ".NET CLR LocksAndThreads")
 }
 
-// A NETFramework_NETCLRLocksAndThreadsCollector is a Prometheus collector for WMI Win32_PerfRawData_NETFramework_NETCLRLocksAndThreads metrics
+// A NETFramework_NETCLRLocksAndThreadsCollector is a Prometheus collector for Perflib .NET CLR LocksAndThreads metrics
 type NETFramework_NETCLRLocksAndThreadsCollector struct {
 	CurrentQueueLength               *prometheus.Desc
 	NumberofcurrentlogicalThreads    *prometheus.Desc
@@ -75,87 +73,91 @@ func NewNETFramework_NETCLRLocksAndThreadsCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *NETFramework_NETCLRLocksAndThreadsCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting win32_perfrawdata_netframework_netclrlocksandthreads metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
 }
 
-type Win32_PerfRawData_NETFramework_NETCLRLocksAndThreads struct {
+type netframeworkCLRLocksAndThreads struct {
 	Name string
 
-	ContentionRatePersec             uint32
-	CurrentQueueLength               uint32
-	NumberofcurrentlogicalThreads    uint32
-	NumberofcurrentphysicalThreads   uint32
-	Numberofcurrentrecognizedthreads uint32
-	Numberoftotalrecognizedthreads   uint32
-	QueueLengthPeak                  uint32
-	QueueLengthPersec                uint32
-	RateOfRecognizedThreadsPersec    uint32
-	TotalNumberofContentions         uint32
+	TotalNumberofContentions         float64 `perflib:"Total # of Contentions"`
+	CurrentQueueLength               float64 `perflib:"Current Queue Length"`
+	QueueLengthPeak                  float64 `perflib:"Queue Length Peak"`
+	NumberofcurrentlogicalThreads    float64 `perflib:"# of current logical Threads"`
+	NumberofcurrentphysicalThreads   float64 `perflib:"# of current physical Threads"`
+	Numberofcurrentrecognizedthreads float64 `perflib:"# of current recognized threads"`
+	Numberoftotalrecognizedthreads   float64 `perflib:"# of total recognized threads"`
 }
 
-func (c *NETFramework_NETCLRLocksAndThreadsCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
-	var dst []Win32_PerfRawData_NETFramework_NETCLRLocksAndThreads
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+func (c *NETFramework_NETCLRLocksAndThreadsCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []netframeworkCLRLocksAndThreads
+	present, err := unmarshalObject(ctx.perfObjects[".NET CLR LocksAndThreads"], &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	for _, process := range dst {
+	for i := range dst {
+		process := &dst[i]
 
 		if process.Name == "_Global_" {
 			continue
 		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.CurrentQueueLength,
 			prometheus.GaugeValue,
-			float64(process.CurrentQueueLength),
+			&process.CurrentQueueLength,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NumberofcurrentlogicalThreads,
 			prometheus.GaugeValue,
-			float64(process.NumberofcurrentlogicalThreads),
+			&process.NumberofcurrentlogicalThreads,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NumberofcurrentphysicalThreads,
 			prometheus.GaugeValue,
-			float64(process.NumberofcurrentphysicalThreads),
+			&process.NumberofcurrentphysicalThreads,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.Numberofcurrentrecognizedthreads,
 			prometheus.GaugeValue,
-			float64(process.Numberofcurrentrecognizedthreads),
+			&process.Numberofcurrentrecognizedthreads,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.Numberoftotalrecognizedthreads,
 			prometheus.CounterValue,
-			float64(process.Numberoftotalrecognizedthreads),
+			&process.Numberoftotalrecognizedthreads,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.QueueLengthPeak,
 			prometheus.CounterValue,
-			float64(process.QueueLengthPeak),
+			&process.QueueLengthPeak,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TotalNumberofContentions,
 			prometheus.CounterValue,
-			float64(process.TotalNumberofContentions),
+			&process.TotalNumberofContentions,
 			process.Name,
 		)
 	}
@@ -2,9 +2,105 @@ package collector
 
 import (
 	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+func TestNETFrameworkNETCLRLocksAndThreadsCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "netframework_clrlocksandthreads", NewNETFramework_NETCLRLocksAndThreadsCollector)
+}
+
+func TestNETFrameworkNETCLRLocksAndThreadsCollectorParity(t *testing.T) {
+	testCollectorParity(t, "netframework_clrlocksandthreads", NewNETFramework_NETCLRLocksAndThreadsCollector, func(c Collector, ch chan<- prometheus.Metric) error {
+		_, err := c.(*NETFramework_NETCLRLocksAndThreadsCollector).collectWMI(ch)
+		return err
+	})
+}
+
 func BenchmarkNETFrameworkNETCLRLocksAndThreadsCollector(b *testing.B) {
-	// No context name required as collector source is WMI
-	benchmarkCollector(b, "", NewNETFramework_NETCLRLocksAndThreadsCollector)
+	benchmarkCollector(b, "netframework_clrlocksandthreads", NewNETFramework_NETCLRLocksAndThreadsCollector)
+}
+
+// The WMI implementation the collector was moved from, kept to check that
+// the perflib implementation exposes the same metrics.
+
+type Win32_PerfRawData_NETFramework_NETCLRLocksAndThreads struct {
+	Name string
+
+	ContentionRatePersec             uint32
+	CurrentQueueLength               uint32
+	NumberofcurrentlogicalThreads    uint32
+	NumberofcurrentphysicalThreads   uint32
+	Numberofcurrentrecognizedthreads uint32
+	Numberoftotalrecognizedthreads   uint32
+	QueueLengthPeak                  uint32
+	QueueLengthPersec                uint32
+	RateOfRecognizedThreadsPersec    uint32
+	TotalNumberofContentions         uint32
+}
+
+func (c *NETFramework_NETCLRLocksAndThreadsCollector) collectWMI(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []Win32_PerfRawData_NETFramework_NETCLRLocksAndThreads
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
+		return nil, err
+	}
+
+	for _, process := range dst {
+
+		if process.Name == "_Global_" {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.CurrentQueueLength,
+			prometheus.GaugeValue,
+			float64(process.CurrentQueueLength),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.NumberofcurrentlogicalThreads,
+			prometheus.GaugeValue,
+			float64(process.NumberofcurrentlogicalThreads),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.NumberofcurrentphysicalThreads,
+			prometheus.GaugeValue,
+			float64(process.NumberofcurrentphysicalThreads),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Numberofcurrentrecognizedthreads,
+			prometheus.GaugeValue,
+			float64(process.Numberofcurrentrecognizedthreads),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Numberoftotalrecognizedthreads,
+			prometheus.CounterValue,
+			float64(process.Numberoftotalrecognizedthreads),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.QueueLengthPeak,
+			prometheus.CounterValue,
+			float64(process.QueueLengthPeak),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.TotalNumberofContentions,
+			prometheus.CounterValue,
+			float64(process.TotalNumberofContentions),
+			process.Name,
+		)
+	}
+
+	return nil, nil
 }
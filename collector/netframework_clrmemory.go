@@ -3,16 +3,14 @@
 package collector
 
 import (
-	"github.com/StackExchange/wmi"
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 func init() {
-	registerCollector("netframework_clrmemory", NewNETFramework_NETCLRMemoryCollector)
+	registerCollector("netframework_clrmemory", NewNETFramework_NETCLRMemoryCollector, ".NET CLR Memory")
 }
 
-// A NETFramework_NETCLRMemoryCollector is a Prometheus collector for WMI Win32_PerfRawData_NETFramework_NETCLRMemory metrics
+// A NETFramework_NETCLRMemoryCollector is a Prometheus collector for Perflib .NET CLR Memory metrics
 type NETFramework_NETCLRMemoryCollector struct {
 	AllocatedBytes                     *prometheus.Desc
 	FinalizationSurvivors              *prometheus.Desc
@@ -113,189 +111,203 @@ func NewNETFramework_NETCLRMemoryCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *NETFramework_NETCLRMemoryCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting win32_perfrawdata_netframework_netclrmemory metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
 }
 
-type Win32_PerfRawData_NETFramework_NETCLRMemory struct {
+type netframeworkCLRMemory struct {
 	Name string
 
-	AllocatedBytesPersec               uint64
-	FinalizationSurvivors              uint64
-	Frequency_PerfTime                 uint64
-	Gen0heapsize                       uint64
-	Gen0PromotedBytesPerSec            uint64
-	Gen1heapsize                       uint64
-	Gen1PromotedBytesPerSec            uint64
-	Gen2heapsize                       uint64
-	LargeObjectHeapsize                uint64
-	NumberBytesinallHeaps              uint64
-	NumberGCHandles                    uint64
-	NumberGen0Collections              uint64
-	NumberGen1Collections              uint64
-	NumberGen2Collections              uint64
-	NumberInducedGC                    uint64
-	NumberofPinnedObjects              uint64
-	NumberofSinkBlocksinuse            uint64
-	NumberTotalcommittedBytes          uint64
-	NumberTotalreservedBytes           uint64
-	PercentTimeinGC                    uint32
-	ProcessID                          uint64
-	PromotedFinalizationMemoryfromGen0 uint64
-	PromotedMemoryfromGen0             uint64
-	PromotedMemoryfromGen1             uint64
+	NumberGen0Collections     float64 `perflib:"# Gen 0 Collections"`
+	NumberGen1Collections     float64 `perflib:"# Gen 1 Collections"`
+	NumberGen2Collections     float64 `perflib:"# Gen 2 Collections"`
+	Gen0PromotedBytesPerSec   float64 `perflib:"Gen 0 Promoted Bytes/Sec"`
+	Gen1PromotedBytesPerSec   float64 `perflib:"Gen 1 Promoted Bytes/Sec"`
+	Gen0heapsize              float64 `perflib:"Gen 0 heap size"`
+	Gen1heapsize              float64 `perflib:"Gen 1 heap size"`
+	Gen2heapsize              float64 `perflib:"Gen 2 heap size"`
+	LargeObjectHeapsize       float64 `perflib:"Large Object Heap size"`
+	FinalizationSurvivors     float64 `perflib:"Finalization Survivors"`
+	NumberGCHandles           float64 `perflib:"# GC Handles"`
+	AllocatedBytesPersec      float64 `perflib:"Allocated Bytes/sec"`
+	NumberInducedGC           float64 `perflib:"# Induced GC"`
+	PercentTimeinGC           float64 `perflib:"% Time in GC"`
+	NumberTotalcommittedBytes float64 `perflib:"# Total committed Bytes"`
+	NumberTotalreservedBytes  float64 `perflib:"# Total reserved Bytes"`
+	NumberofPinnedObjects     float64 `perflib:"# of Pinned Objects"`
+	NumberofSinkBlocksinuse   float64 `perflib:"# of Sink Blocks in use"`
 }
 
-func (c *NETFramework_NETCLRMemoryCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
-	var dst []Win32_PerfRawData_NETFramework_NETCLRMemory
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+func (c *NETFramework_NETCLRMemoryCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []netframeworkCLRMemory
+	obj := ctx.perfObjects[".NET CLR Memory"]
+	present, err := unmarshalObject(obj, &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	for _, process := range dst {
+	for i := range dst {
+		process := &dst[i]
 
 		if process.Name == "_Global_" {
 			continue
 		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.AllocatedBytes,
 			prometheus.CounterValue,
-			float64(process.AllocatedBytesPersec),
+			&process.AllocatedBytesPersec,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FinalizationSurvivors,
 			prometheus.GaugeValue,
-			float64(process.FinalizationSurvivors),
+			&process.FinalizationSurvivors,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.HeapSize,
 			prometheus.GaugeValue,
-			float64(process.Gen0heapsize),
+			&process.Gen0heapsize,
 			process.Name,
 			"Gen0",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PromotedBytes,
 			prometheus.GaugeValue,
-			float64(process.Gen0PromotedBytesPerSec),
+			&process.Gen0PromotedBytesPerSec,
 			process.Name,
 			"Gen0",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.HeapSize,
 			prometheus.GaugeValue,
-			float64(process.Gen1heapsize),
+			&process.Gen1heapsize,
 			process.Name,
 			"Gen1",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PromotedBytes,
 			prometheus.GaugeValue,
-			float64(process.Gen1PromotedBytesPerSec),
+			&process.Gen1PromotedBytesPerSec,
 			process.Name,
 			"Gen1",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.HeapSize,
 			prometheus.GaugeValue,
-			float64(process.Gen2heapsize),
+			&process.Gen2heapsize,
 			process.Name,
 			"Gen2",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.HeapSize,
 			prometheus.GaugeValue,
-			float64(process.LargeObjectHeapsize),
+			&process.LargeObjectHeapsize,
 			process.Name,
 			"LOH",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NumberGCHandles,
 			prometheus.GaugeValue,
-			float64(process.NumberGCHandles),
+			&process.NumberGCHandles,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NumberCollections,
 			prometheus.CounterValue,
-			float64(process.NumberGen0Collections),
+			&process.NumberGen0Collections,
 			process.Name,
 			"Gen0",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NumberCollections,
 			prometheus.CounterValue,
-			float64(process.NumberGen1Collections),
+			&process.NumberGen1Collections,
 			process.Name,
 			"Gen1",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NumberCollections,
 			prometheus.CounterValue,
-			float64(process.NumberGen2Collections),
+			&process.NumberGen2Collections,
 			process.Name,
 			"Gen2",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NumberInducedGC,
 			prometheus.CounterValue,
-			float64(process.NumberInducedGC),
+			&process.NumberInducedGC,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NumberofPinnedObjects,
 			prometheus.GaugeValue,
-			float64(process.NumberofPinnedObjects),
+			&process.NumberofPinnedObjects,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NumberofSinkBlocksinuse,
 			prometheus.GaugeValue,
-			float64(process.NumberofSinkBlocksinuse),
+			&process.NumberofSinkBlocksinuse,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NumberTotalCommittedBytes,
 			prometheus.GaugeValue,
-			float64(process.NumberTotalcommittedBytes),
+			&process.NumberTotalcommittedBytes,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NumberTotalreservedBytes,
 			prometheus.GaugeValue,
-			float64(process.NumberTotalreservedBytes),
+			&process.NumberTotalreservedBytes,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.TimeinGC,
-			prometheus.GaugeValue,
-			float64(process.PercentTimeinGC)/float64(process.Frequency_PerfTime),
-			process.Name,
-		)
+		if present.has(&process.PercentTimeinGC) {
+			ch <- prometheus.MustNewConstMetric(
+				c.TimeinGC,
+				prometheus.GaugeValue,
+				process.PercentTimeinGC/float64(obj.Frequency),
+				process.Name,
+			)
+		}
 	}
 
 	return nil, nil
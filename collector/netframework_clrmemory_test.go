@@ -2,9 +2,205 @@ package collector
 
 import (
 	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+func TestNETFrameworkNETCLRMemoryCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "netframework_clrmemory", NewNETFramework_NETCLRMemoryCollector)
+}
+
+func TestNETFrameworkNETCLRMemoryCollectorParity(t *testing.T) {
+	testCollectorParity(t, "netframework_clrmemory", NewNETFramework_NETCLRMemoryCollector, func(c Collector, ch chan<- prometheus.Metric) error {
+		_, err := c.(*NETFramework_NETCLRMemoryCollector).collectWMI(ch)
+		return err
+	})
+}
+
 func BenchmarkNETFrameworkNETCLRMemoryCollector(b *testing.B) {
-	// No context name required as collector source is WMI
-	benchmarkCollector(b, "", NewNETFramework_NETCLRMemoryCollector)
+	benchmarkCollector(b, "netframework_clrmemory", NewNETFramework_NETCLRMemoryCollector)
+}
+
+// The WMI implementation the collector was moved from, kept to check that
+// the perflib implementation exposes the same metrics.
+
+type Win32_PerfRawData_NETFramework_NETCLRMemory struct {
+	Name string
+
+	AllocatedBytesPersec               uint64
+	FinalizationSurvivors              uint64
+	Frequency_PerfTime                 uint64
+	Gen0heapsize                       uint64
+	Gen0PromotedBytesPerSec            uint64
+	Gen1heapsize                       uint64
+	Gen1PromotedBytesPerSec            uint64
+	Gen2heapsize                       uint64
+	LargeObjectHeapsize                uint64
+	NumberBytesinallHeaps              uint64
+	NumberGCHandles                    uint64
+	NumberGen0Collections              uint64
+	NumberGen1Collections              uint64
+	NumberGen2Collections              uint64
+	NumberInducedGC                    uint64
+	NumberofPinnedObjects              uint64
+	NumberofSinkBlocksinuse            uint64
+	NumberTotalcommittedBytes          uint64
+	NumberTotalreservedBytes           uint64
+	PercentTimeinGC                    uint32
+	ProcessID                          uint64
+	PromotedFinalizationMemoryfromGen0 uint64
+	PromotedMemoryfromGen0             uint64
+	PromotedMemoryfromGen1             uint64
+}
+
+func (c *NETFramework_NETCLRMemoryCollector) collectWMI(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []Win32_PerfRawData_NETFramework_NETCLRMemory
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
+		return nil, err
+	}
+
+	for _, process := range dst {
+
+		if process.Name == "_Global_" {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.AllocatedBytes,
+			prometheus.CounterValue,
+			float64(process.AllocatedBytesPersec),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.FinalizationSurvivors,
+			prometheus.GaugeValue,
+			float64(process.FinalizationSurvivors),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.HeapSize,
+			prometheus.GaugeValue,
+			float64(process.Gen0heapsize),
+			process.Name,
+			"Gen0",
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.PromotedBytes,
+			prometheus.GaugeValue,
+			float64(process.Gen0PromotedBytesPerSec),
+			process.Name,
+			"Gen0",
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.HeapSize,
+			prometheus.GaugeValue,
+			float64(process.Gen1heapsize),
+			process.Name,
+			"Gen1",
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.PromotedBytes,
+			prometheus.GaugeValue,
+			float64(process.Gen1PromotedBytesPerSec),
+			process.Name,
+			"Gen1",
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.HeapSize,
+			prometheus.GaugeValue,
+			float64(process.Gen2heapsize),
+			process.Name,
+			"Gen2",
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.HeapSize,
+			prometheus.GaugeValue,
+			float64(process.LargeObjectHeapsize),
+			process.Name,
+			"LOH",
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.NumberGCHandles,
+			prometheus.GaugeValue,
+			float64(process.NumberGCHandles),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.NumberCollections,
+			prometheus.CounterValue,
+			float64(process.NumberGen0Collections),
+			process.Name,
+			"Gen0",
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.NumberCollections,
+			prometheus.CounterValue,
+			float64(process.NumberGen1Collections),
+			process.Name,
+			"Gen1",
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.NumberCollections,
+			prometheus.CounterValue,
+			float64(process.NumberGen2Collections),
+			process.Name,
+			"Gen2",
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.NumberInducedGC,
+			prometheus.CounterValue,
+			float64(process.NumberInducedGC),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.NumberofPinnedObjects,
+			prometheus.GaugeValue,
+			float64(process.NumberofPinnedObjects),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.NumberofSinkBlocksinuse,
+			prometheus.GaugeValue,
+			float64(process.NumberofSinkBlocksinuse),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.NumberTotalCommittedBytes,
+			prometheus.GaugeValue,
+			float64(process.NumberTotalcommittedBytes),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.NumberTotalreservedBytes,
+			prometheus.GaugeValue,
+			float64(process.NumberTotalreservedBytes),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.TimeinGC,
+			prometheus.GaugeValue,
+			float64(process.PercentTimeinGC)/float64(process.Frequency_PerfTime),
+			process.Name,
+		)
+	}
+
+	return nil, nil
 }
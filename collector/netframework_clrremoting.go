@@ -3,16 +3,14 @@
 package collector
 
 import (
-	"github.com/StackExchange/wmi"
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 func init() {
-	registerCollector("netframework_clrremoting", NewNETFramework_NETCLRRemotingCollector)
+	registerCollector("netframework_clrremoting", NewNETFramework_NETCLRRemotingCollector, ".NET CLR Remoting")
 }
 
-// A NETFramework_NETCLRRemotingCollector is a Prometheus collector for WMI Win32_PerfRawData_NETFramework_NETCLRRemoting metrics
+// A NETFramework_NETCLRRemotingCollector is a Prometheus collector for Perflib .NET CLR Remoting metrics
 type NETFramework_NETCLRRemotingCollector struct {
 	Channels                  *prometheus.Desc
 	ContextBoundClassesLoaded *prometheus.Desc
@@ -68,77 +66,82 @@ func NewNETFramework_NETCLRRemotingCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *NETFramework_NETCLRRemotingCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting win32_perfrawdata_netframework_netclrremoting metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
 }
 
-type Win32_PerfRawData_NETFramework_NETCLRRemoting struct {
+type netframeworkCLRRemoting struct {
 	Name string
 
-	Channels                       uint32
-	ContextBoundClassesLoaded      uint32
-	ContextBoundObjectsAllocPersec uint32
-	ContextProxies                 uint32
-	Contexts                       uint32
-	RemoteCallsPersec              uint32
-	TotalRemoteCalls               uint32
+	TotalRemoteCalls               float64 `perflib:"Total Remote Calls"`
+	Channels                       float64 `perflib:"Channels"`
+	ContextProxies                 float64 `perflib:"Context Proxies"`
+	ContextBoundClassesLoaded      float64 `perflib:"Context-Bound Classes Loaded"`
+	ContextBoundObjectsAllocPersec float64 `perflib:"Context-Bound Objects Alloc / sec"`
+	Contexts                       float64 `perflib:"Contexts"`
 }
 
-func (c *NETFramework_NETCLRRemotingCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
-	var dst []Win32_PerfRawData_NETFramework_NETCLRRemoting
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+func (c *NETFramework_NETCLRRemotingCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []netframeworkCLRRemoting
+	present, err := unmarshalObject(ctx.perfObjects[".NET CLR Remoting"], &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	for _, process := range dst {
+	for i := range dst {
+		process := &dst[i]
 
 		if process.Name == "_Global_" {
 			continue
 		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.Channels,
 			prometheus.CounterValue,
-			float64(process.Channels),
+			&process.Channels,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ContextBoundClassesLoaded,
 			prometheus.GaugeValue,
-			float64(process.ContextBoundClassesLoaded),
+			&process.ContextBoundClassesLoaded,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ContextBoundObjects,
 			prometheus.CounterValue,
-			float64(process.ContextBoundObjectsAllocPersec),
+			&process.ContextBoundObjectsAllocPersec,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ContextProxies,
 			prometheus.CounterValue,
-			float64(process.ContextProxies),
+			&process.ContextProxies,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.Contexts,
 			prometheus.GaugeValue,
-			float64(process.Contexts),
+			&process.Contexts,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TotalRemoteCalls,
 			prometheus.CounterValue,
-			float64(process.TotalRemoteCalls),
+			&process.TotalRemoteCalls,
 			process.Name,
 		)
 	}
@@ -2,9 +2,95 @@ package collector
 
 import (
 	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+func TestNETFrameworkNETCLRRemotingCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "netframework_clrremoting", NewNETFramework_NETCLRRemotingCollector)
+}
+
+func TestNETFrameworkNETCLRRemotingCollectorParity(t *testing.T) {
+	testCollectorParity(t, "netframework_clrremoting", NewNETFramework_NETCLRRemotingCollector, func(c Collector, ch chan<- prometheus.Metric) error {
+		_, err := c.(*NETFramework_NETCLRRemotingCollector).collectWMI(ch)
+		return err
+	})
+}
+
 func BenchmarkNETFrameworkNETCLRRemotingCollector(b *testing.B) {
-	// No context name required as collector source is WMI
-	benchmarkCollector(b, "", NewNETFramework_NETCLRRemotingCollector)
+	benchmarkCollector(b, "netframework_clrremoting", NewNETFramework_NETCLRRemotingCollector)
+}
+
+// The WMI implementation the collector was moved from, kept to check that
+// the perflib implementation exposes the same metrics.
+
+type Win32_PerfRawData_NETFramework_NETCLRRemoting struct {
+	Name string
+
+	Channels                       uint32
+	ContextBoundClassesLoaded      uint32
+	ContextBoundObjectsAllocPersec uint32
+	ContextProxies                 uint32
+	Contexts                       uint32
+	RemoteCallsPersec              uint32
+	TotalRemoteCalls               uint32
+}
+
+func (c *NETFramework_NETCLRRemotingCollector) collectWMI(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []Win32_PerfRawData_NETFramework_NETCLRRemoting
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
+		return nil, err
+	}
+
+	for _, process := range dst {
+
+		if process.Name == "_Global_" {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Channels,
+			prometheus.CounterValue,
+			float64(process.Channels),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.ContextBoundClassesLoaded,
+			prometheus.GaugeValue,
+			float64(process.ContextBoundClassesLoaded),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.ContextBoundObjects,
+			prometheus.CounterValue,
+			float64(process.ContextBoundObjectsAllocPersec),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.ContextProxies,
+			prometheus.CounterValue,
+			float64(process.ContextProxies),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Contexts,
+			prometheus.GaugeValue,
+			float64(process.Contexts),
+			process.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.TotalRemoteCalls,
+			prometheus.CounterValue,
+			float64(process.TotalRemoteCalls),
+			process.Name,
+		)
+	}
+
+	return nil, nil
 }
@@ -3,16 +3,14 @@
 package collector
 
 import (
-	"github.com/StackExchange/wmi"
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 func init() {
-	registerCollector("netframework_clrsecurity", NewNETFramework_NETCLRSecurityCollector)
+	registerCollector("netframework_clrsecurity", NewNETFramework_NETCLRSecurityCollector, ".NET CLR Security")
 }
 
-// A NETFramework_NETCLRSecurityCollector is a Prometheus collector for WMI Win32_PerfRawData_NETFramework_NETCLRSecurity metrics
+// A NETFramework_NETCLRSecurityCollector is a Prometheus collector for Perflib .NET CLR Security metrics
 type NETFramework_NETCLRSecurityCollector struct {
 	NumberLinkTimeChecks *prometheus.Desc
 	TimeinRTchecks       *prometheus.Desc
@@ -54,62 +52,66 @@ func NewNETFramework_NETCLRSecurityCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *NETFramework_NETCLRSecurityCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting win32_perfrawdata_netframework_netclrsecurity metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
 }
 
-type Win32_PerfRawData_NETFramework_NETCLRSecurity struct {
+type netframeworkCLRSecurity struct {
 	Name string
 
-	Frequency_PerfTime           uint32
-	NumberLinkTimeChecks         uint32
-	PercentTimeinRTchecks        uint32
-	PercentTimeSigAuthenticating uint64
-	StackWalkDepth               uint32
-	TotalRuntimeChecks           uint32
+	TotalRuntimeChecks    float64 `perflib:"Total Runtime Checks"`
+	NumberLinkTimeChecks  float64 `perflib:"# Link Time Checks"`
+	PercentTimeinRTchecks float64 `perflib:"% Time in RT checks"`
+	StackWalkDepth        float64 `perflib:"Stack Walk Depth"`
 }
 
-func (c *NETFramework_NETCLRSecurityCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
-	var dst []Win32_PerfRawData_NETFramework_NETCLRSecurity
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+func (c *NETFramework_NETCLRSecurityCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []netframeworkCLRSecurity
+	obj := ctx.perfObjects[".NET CLR Security"]
+	present, err := unmarshalObject(obj, &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	for _, process := range dst {
+	for i := range dst {
+		process := &dst[i]
 
 		if process.Name == "_Global_" {
 			continue
 		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NumberLinkTimeChecks,
 			prometheus.CounterValue,
-			float64(process.NumberLinkTimeChecks),
+			&process.NumberLinkTimeChecks,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.TimeinRTchecks,
-			prometheus.GaugeValue,
-			float64(process.PercentTimeinRTchecks)/float64(process.Frequency_PerfTime),
-			process.Name,
-		)
+		if present.has(&process.PercentTimeinRTchecks) {
+			ch <- prometheus.MustNewConstMetric(
+				c.TimeinRTchecks,
+				prometheus.GaugeValue,
+				process.PercentTimeinRTchecks/float64(obj.Frequency),
+				process.Name,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.StackWalkDepth,
 			prometheus.GaugeValue,
-			float64(process.StackWalkDepth),
+			&process.StackWalkDepth,
 			process.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TotalRuntimeChecks,
 			prometheus.CounterValue,
-			float64(process.TotalRuntimeChecks),
+			&process.TotalRuntimeChecks,
 			process.Name,
 		)
 	}
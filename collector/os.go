@@ -11,7 +11,6 @@ import (
 	"github.com/prometheus-community/windows_exporter/headers/netapi32"
 	"github.com/prometheus-community/windows_exporter/headers/psapi"
 	"github.com/prometheus-community/windows_exporter/headers/sysinfoapi"
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sys/windows/registry"
 )
@@ -132,8 +131,7 @@ func NewOSCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *OSCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ctx, ch); err != nil {
-		log.Error("failed collecting os metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
@@ -217,16 +215,20 @@ func (c *OSCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (
 	}
 
 	var pfc = make([]pagingFileCounter, 0)
-	if err := unmarshalObject(ctx.perfObjects["Paging File"], &pfc); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["Paging File"], &pfc)
+	if err != nil {
 		return nil, err
 	}
 
 	// Get current page file usage.
 	var pfbRaw float64 = 0
-	for _, pageFile := range pfc {
+	hasUsage := true
+	for i := range pfc {
+		pageFile := &pfc[i]
 		if strings.Contains(strings.ToLower(pageFile.Name), "_total") {
 			continue
 		}
+		hasUsage = hasUsage && present.has(&pageFile.Usage)
 		pfbRaw += pageFile.Usage
 	}
 
@@ -260,11 +262,13 @@ func (c *OSCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (
 		timezoneName,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
-		c.PagingFreeBytes,
-		prometheus.GaugeValue,
-		pfb,
-	)
+	if hasUsage {
+		ch <- prometheus.MustNewConstMetric(
+			c.PagingFreeBytes,
+			prometheus.GaugeValue,
+			pfb,
+		)
+	}
 
 	ch <- prometheus.MustNewConstMetric(
 		c.VirtualMemoryFreeBytes,
@@ -1,19 +1,201 @@
+//go:generate go run ../tools/perflib-unmarshalers
+
 package collector
 
 import (
+	"encoding/binary"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf16"
 
 	perflibCollector "github.com/leoluk/perflib_exporter/collector"
 	"github.com/leoluk/perflib_exporter/perflib"
 	"github.com/prometheus-community/windows_exporter/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows/registry"
+)
+
+// Predefined registry keys holding the perflib name tables in English and in
+// the language of the system. They are not defined by the registry package.
+const (
+	performanceText    = registry.Key(0x80000050) // HKEY_PERFORMANCE_TEXT
+	performanceNLSText = registry.Key(0x80000060) // HKEY_PERFORMANCE_NLSTEXT
+)
+
+var (
+	perflibNames = loadPerflibNames()
+
+	// perfObjectNames maps the indexes of the perflib objects the collectors
+	// depend on to the English names they were registered with, as the names
+	// perflib reports can be missing or localized.
+	perfObjectNames = make(map[uint32]string)
 )
 
-var nametable = perflib.QueryNameTable("Counter 009") // Reads the names in English TODO: validate that the English names are always present
+// nameTable maps the indexes of perflib objects and counters to their names.
+type nameTable struct {
+	byIndex map[uint32]string
+	byName  map[string]uint32
+}
+
+// parseNameTable parses a perflib name table, a REG_MULTI_SZ of alternating
+// indexes and names.
+func parseNameTable(data []byte) (*nameTable, error) {
+	if len(data)%2 != 0 {
+		return nil, fmt.Errorf("invalid name table length %d", len(data))
+	}
+	u := make([]uint16, len(data)/2)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(data[2*i:])
+	}
+	fields := strings.Split(string(utf16.Decode(u)), "\x00")
+	for len(fields) > 0 && fields[len(fields)-1] == "" {
+		fields = fields[:len(fields)-1]
+	}
+	if len(fields)%2 != 0 {
+		return nil, fmt.Errorf("name table has no name for index %q", fields[len(fields)-1])
+	}
+
+	t := &nameTable{
+		byIndex: make(map[uint32]string, len(fields)/2),
+		byName:  make(map[string]uint32, len(fields)/2),
+	}
+	for i := 0; i < len(fields); i += 2 {
+		index, err := strconv.ParseUint(fields[i], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index %q in name table", fields[i])
+		}
+		name := fields[i+1]
+		t.byIndex[uint32(index)] = name
+		// Names are not unique. The lowest index belongs to the object
+		// registered first, which is the built-in one.
+		if existing, ok := t.byName[name]; !ok || uint32(index) < existing {
+			t.byName[name] = uint32(index)
+		}
+	}
+	return t, nil
+}
+
+// queryNameTable reads a name table from one of the performance registry keys.
+func queryNameTable(key registry.Key, name string) (*nameTable, error) {
+	// The size of the values of HKEY_PERFORMANCE_DATA is not reported, so
+	// the buffer is grown until the value fits.
+	buf := make([]byte, 64*1024)
+	for {
+		n, _, err := key.GetValue(name, buf)
+		if err == registry.ErrShortBuffer {
+			buf = make([]byte, 2*len(buf))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return parseNameTable(buf[:n])
+	}
+}
+
+// perflibNameTables resolves the English names of perflib objects and
+// counters, which the collectors depend on, to their indexes and back.
+type perflibNameTables struct {
+	// english is the "Counter 009" table of HKEY_PERFORMANCE_DATA, which
+	// perflib reads the names it reports from.
+	english *nameTable
+	// text is the English table of HKEY_PERFORMANCE_TEXT, which also holds
+	// the names of objects not registered in the "Counter 009" table on some
+	// localized installations.
+	text *nameTable
+	// local is the name table in the language of the system, of
+	// HKEY_PERFORMANCE_NLSTEXT. Some applications only register their
+	// objects in that language, with English names.
+	local *nameTable
+}
+
+func loadPerflibNames() perflibNameTables {
+	var (
+		names perflibNameTables
+		err   error
+	)
+	if names.english, err = queryNameTable(registry.PERFORMANCE_DATA, "Counter 009"); err != nil {
+		log.WithError(err).Warn("Failed to read English perflib name table of HKEY_PERFORMANCE_DATA")
+	}
+	if names.text, err = queryNameTable(performanceText, "Counter"); err != nil {
+		log.WithError(err).Warn("Failed to read English perflib name table of HKEY_PERFORMANCE_TEXT")
+	}
+	if names.local, err = queryNameTable(performanceNLSText, "Counter"); err != nil {
+		log.WithError(err).Debug("Failed to read perflib name table of the system language")
+	}
+	return names
+}
+
+// loaded returns true if any name table could be read.
+func (n perflibNameTables) loaded() bool {
+	return n.english != nil || n.text != nil || n.local != nil
+}
+
+// lookupIndex returns the index of the perflib object or counter with the
+// given English name. The name table of the system language is only
+// searched for objects and counters without an English name, which were
+// registered in that language only, as the localized name of another object
+// or counter may equal the English name.
+func (n perflibNameTables) lookupIndex(name string) (uint32, bool) {
+	for _, t := range []*nameTable{n.english, n.text} {
+		if t == nil {
+			continue
+		}
+		if index, ok := t.byName[name]; ok {
+			return index, true
+		}
+	}
+	if n.local == nil {
+		return 0, false
+	}
+	index, ok := n.local.byName[name]
+	if !ok {
+		return 0, false
+	}
+	if _, english := n.englishName(index); english {
+		return 0, false
+	}
+	return index, true
+}
+
+// lookupName returns the name of the perflib object or counter with the given
+// index, preferring the English name.
+func (n perflibNameTables) lookupName(index uint32) (string, bool) {
+	if name, ok := n.englishName(index); ok {
+		return name, true
+	}
+	if n.local != nil {
+		if name, ok := n.local.byIndex[index]; ok {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func (n perflibNameTables) englishName(index uint32) (string, bool) {
+	for _, t := range []*nameTable{n.english, n.text} {
+		if t == nil {
+			continue
+		}
+		if name, ok := t.byIndex[index]; ok {
+			return name, true
+		}
+	}
+	return "", false
+}
 
-func MapCounterToIndex(name string) string {
-	return strconv.Itoa(int(nametable.LookupIndex(name)))
+// counterName returns the name of the counter def, looked up by its index.
+// perflib names counters from the "Counter 009" table only, and leaves the
+// name of counters missing there empty.
+func (n perflibNameTables) counterName(def *perflib.PerfCounterDef) string {
+	if name, ok := n.lookupName(uint32(def.NameIndex)); ok {
+		return name
+	}
+	return def.Name
 }
 
 func getPerflibSnapshot(objNames string) (map[string]*perflib.PerfObject, error) {
@@ -21,25 +203,155 @@ func getPerflibSnapshot(objNames string) (map[string]*perflib.PerfObject, error)
 	if err != nil {
 		return nil, err
 	}
+	return indexPerfObjects(objects, perfObjectNames), nil
+}
 
-	indexed := make(map[string]*perflib.PerfObject)
+// indexPerfObjects maps perflib objects by name. Objects are named by the
+// names in names, looked up by their index, if they are found there.
+func indexPerfObjects(objects []*perflib.PerfObject, names map[uint32]string) map[string]*perflib.PerfObject {
+	indexed := make(map[string]*perflib.PerfObject, len(objects))
 	for _, obj := range objects {
-		indexed[obj.Name] = obj
+		name := obj.Name
+		if n, ok := names[uint32(obj.NameIndex)]; ok {
+			name = n
+		}
+		indexed[name] = obj
+	}
+	return indexed
+}
+
+// unmarshalObject sets the tagged float64 fields of the structs in the slice
+// pointed to by vs from the counters of the instances of obj. Fields are
+// tagged with the name of a counter, optionally followed by options:
+//
+//	Value   float64 `perflib:"Avg. Disk sec/Read"`
+//	Base    float64 `perflib:"Avg. Disk sec/Read,base"`
+//	Average float64 `perflib:"Avg. Disk sec/Read,ratio"`
+//	Limit   float64 `perflib:"% Performance Limit,optional"`
+//
+// Options follow a comma without a space, so counter names may contain ", ".
+//
+// The instance name is copied to a Name field. The identity of the instance
+// is copied to the optional fields:
+//
+//	InstanceName string // Instance name without the "#<n>" suffix
+//	ProcessID    int    // ID Process counter, e.g. of threads, or -1
+//
+// Together they identify instances sharing a name, like processes, and
+// relate instances to their process, like threads.
+//
+// Without an option, the field is set to the value of the counter, converted
+// to seconds for timers. The base option selects the base counter following
+// a fraction (PERF_RAW_FRACTION, PERF_LARGE_RAW_FRACTION), average
+// (PERF_AVERAGE_TIMER, PERF_AVERAGE_BULK) or PERF_COUNTER_MULTI_TIMER
+// counter, and the ratio option divides the value of the counter by its base.
+// As the raw values are accumulated since the counters were created, the
+// ratio of an average counter is the average over that period.
+//
+// Fields of counters which are not defined by obj are left at zero, and are
+// absent from the returned perflibPresence, whose emit method skips their
+// metrics. The missing counters are reported, unless they are tagged
+// optional because they only exist on some Windows versions. If a counter
+// tagged required is missing, an error is returned instead.
+//
+// Structs declared in the collector package are unmarshalled by functions
+// generated with `go generate ./collector/`, which look up the counters by
+// position instead of by name. Other structs fall back to reflection.
+func unmarshalObject(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+	unmarshal, ok := perflibUnmarshalers[reflect.TypeOf(vs)]
+	if !ok {
+		unmarshal = unmarshalObjectReflect
+	}
+	present, err := unmarshal(obj, vs)
+	if err != nil {
+		if obj == nil {
+			return present, err
+		}
+		return present, &perflibError{object: perfObjectKey(obj), err: err}
+	}
+	return present, nil
+}
+
+// perflibPresence tells which fields of the structs unmarshalled by
+// unmarshalObject were set from a counter.
+type perflibPresence struct {
+	// absent holds the fields whose counter is missing, which are only few
+	// if any.
+	absent map[*float64]bool
+}
+
+func (p *perflibPresence) setAbsent(field *float64) {
+	if p.absent == nil {
+		p.absent = make(map[*float64]bool)
+	}
+	p.absent[field] = true
+}
+
+// has returns true if all fields were set from a counter. The fields must be
+// pointers into the slice passed to unmarshalObject, not into a copy of its
+// elements.
+func (p perflibPresence) has(fields ...*float64) bool {
+	for _, f := range fields {
+		if p.absent[f] {
+			return false
+		}
 	}
-	return indexed, nil
+	return true
 }
 
-func unmarshalObject(obj *perflib.PerfObject, vs interface{}) error {
+// emit sends a metric with the value of field, unless its counter is
+// missing, so that no metrics are emitted from zero values. Metrics whose
+// value is computed from fields are sent if has returns true for them.
+func (p perflibPresence) emit(ch chan<- prometheus.Metric, desc *prometheus.Desc, valueType prometheus.ValueType, field *float64, labelValues ...string) {
+	if p.has(field) {
+		ch <- prometheus.MustNewConstMetric(desc, valueType, *field, labelValues...)
+	}
+}
+
+// perflibError is the error of unmarshalling a perflib object, logged with
+// the object.
+type perflibError struct {
+	object string
+	err    error
+}
+
+func (e *perflibError) Error() string { return e.err.Error() }
+func (e *perflibError) Unwrap() error { return e.err }
+
+// LogFields returns the fields log.WithError adds to the log entry.
+func (e *perflibError) LogFields() []interface{} {
+	return []interface{}{"perf_object", e.object}
+}
+
+// perflibUnmarshaler unmarshals a perflib object into a pointer to a slice of
+// a specific struct type.
+type perflibUnmarshaler func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error)
+
+// perflibUnmarshalers holds the generated unmarshalers, keyed by the type of
+// the pointer to slice they unmarshal into. It is set in
+// perflib_unmarshalers.go, generated by tools/perflib-unmarshalers.
+var perflibUnmarshalers map[reflect.Type]perflibUnmarshaler
+
+func unmarshalObjectReflect(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+	var present perflibPresence
 	if obj == nil {
-		return fmt.Errorf("counter not found")
+		return present, fmt.Errorf("counter not found")
 	}
 	rv := reflect.ValueOf(vs)
 	if rv.Kind() != reflect.Ptr || rv.IsNil() {
-		return fmt.Errorf("%v is nil or not a pointer to slice", reflect.TypeOf(vs))
+		return present, fmt.Errorf("%v is nil or not a pointer to slice", reflect.TypeOf(vs))
 	}
 	ev := rv.Elem()
 	if ev.Kind() != reflect.Slice {
-		return fmt.Errorf("%v is not slice", reflect.TypeOf(vs))
+		return present, fmt.Errorf("%v is not slice", reflect.TypeOf(vs))
+	}
+
+	fields, err := perflibFields(ev.Type().Elem())
+	if err != nil {
+		return present, err
+	}
+	if _, err := checkCounters(obj, ev.Type().Elem(), fields); err != nil {
+		return present, err
 	}
 
 	// Ensure sufficient length
@@ -50,58 +362,368 @@ func unmarshalObject(obj *perflib.PerfObject, vs interface{}) error {
 
 	for idx, instance := range obj.Instances {
 		target := ev.Index(idx)
-		rt := target.Type()
 
 		counters := make(map[string]*perflib.PerfCounter, len(instance.Counters))
-		for _, ctr := range instance.Counters {
+		bases := make(map[string]*perflib.PerfCounter)
+		for i, ctr := range instance.Counters {
+			name := perflibNames.counterName(ctr.Def)
 			if ctr.Def.IsBaseValue && !ctr.Def.IsNanosecondCounter {
-				counters[ctr.Def.Name+"_Base"] = ctr
-			} else {
-				counters[ctr.Def.Name] = ctr
-			}
-		}
-
-		for i := 0; i < target.NumField(); i++ {
-			f := rt.Field(i)
-			tag := f.Tag.Get("perflib")
-			if tag == "" {
+				counters[name+"_Base"] = ctr
 				continue
 			}
+			counters[name] = ctr
+			// The base counter of a counter immediately follows it.
+			if i+1 < len(instance.Counters) && isBaseCounter(instance.Counters[i+1].Def.CounterType) {
+				bases[name] = instance.Counters[i+1]
+			}
+		}
 
-			ctr, found := counters[tag]
+		for _, f := range fields {
+			ctr, found := counters[f.counter]
 			if !found {
-				log.Debugf("missing counter %q, have %v", tag, counterMapKeys(counters))
+				present.setAbsent(target.Field(f.index).Addr().Interface().(*float64))
 				continue
 			}
-			if !target.Field(i).CanSet() {
-				return fmt.Errorf("tagged field %v cannot be written to", f.Name)
-			}
-			if fieldType := target.Field(i).Type(); fieldType != reflect.TypeOf((*float64)(nil)).Elem() {
-				return fmt.Errorf("tagged field %v has wrong type %v, must be float64", f.Name, fieldType)
+			switch f.value {
+			case "":
+				target.Field(f.index).SetFloat(counterValue(obj, ctr))
+			case "base", "ratio":
+				base, found := bases[f.counter]
+				if !found {
+					present.setAbsent(target.Field(f.index).Addr().Interface().(*float64))
+					continue
+				}
+				if f.value == "base" {
+					target.Field(f.index).SetFloat(float64(base.Value))
+				} else if base.Value != 0 {
+					target.Field(f.index).SetFloat(counterValue(obj, ctr) / float64(base.Value))
+				}
 			}
+		}
 
-			switch ctr.Def.CounterType {
-			case perflibCollector.PERF_ELAPSED_TIME:
-				target.Field(i).SetFloat(float64(ctr.Value-windowsEpoch) / float64(obj.Frequency))
-			case perflibCollector.PERF_100NSEC_TIMER, perflibCollector.PERF_PRECISION_100NS_TIMER:
-				target.Field(i).SetFloat(float64(ctr.Value) * ticksToSecondsScaleFactor)
+		if instance.Name != "" && target.FieldByName("Name").CanSet() {
+			target.FieldByName("Name").SetString(instance.Name)
+		}
+		setInstanceIdentity(target, instanceIdentity(instance))
+	}
+
+	return present, nil
+}
+
+// perflibField is a struct field tagged with a perflib counter.
+type perflibField struct {
+	index   int
+	counter string
+	// value is "" for the value of the counter, "base" or "ratio".
+	value    string
+	required bool
+	optional bool
+}
+
+// source returns the tag of the field without presence options, which
+// identifies the counter it is read from.
+func (f perflibField) source() string {
+	if f.value == "" {
+		return f.counter
+	}
+	return f.counter + "," + f.value
+}
+
+// perflibFields returns the fields of the struct type t tagged with perflib
+// counters.
+func perflibFields(t reflect.Type) ([]perflibField, error) {
+	var fields []perflibField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("perflib")
+		if tag == "" {
+			continue
+		}
+		if sf.PkgPath != "" {
+			return nil, fmt.Errorf("tagged field %v cannot be written to", sf.Name)
+		}
+		if sf.Type != reflect.TypeOf((*float64)(nil)).Elem() {
+			return nil, fmt.Errorf("tagged field %v has wrong type %v, must be float64", sf.Name, sf.Type)
+		}
+
+		counter, options := splitPerflibTag(tag)
+		f := perflibField{index: i, counter: counter}
+		for _, option := range options {
+			switch option {
+			case "base", "ratio":
+				f.value = option
+			case "required":
+				f.required = true
+			case "optional":
+				f.optional = true
 			default:
-				target.Field(i).SetFloat(float64(ctr.Value))
+				return nil, fmt.Errorf("tagged field %v has unknown option %q", sf.Name, option)
 			}
 		}
+		if f.required && f.optional {
+			return nil, fmt.Errorf("tagged field %v cannot be both required and optional", sf.Name)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
 
-		if instance.Name != "" && target.FieldByName("Name").CanSet() {
-			target.FieldByName("Name").SetString(instance.Name)
+// splitPerflibTag splits a perflib tag into the counter name and the options.
+// Options are separated by commas, while a comma followed by a space is part
+// of the counter name, e.g. in "DRA Inbound Bytes Compressed (Between Sites,
+// After Compression)/sec".
+func splitPerflibTag(tag string) (string, []string) {
+	parts := strings.Split(tag, ",")
+	counter := parts[0]
+	i := 1
+	for ; i < len(parts) && strings.HasPrefix(parts[i], " "); i++ {
+		counter += "," + parts[i]
+	}
+	return counter, parts[i:]
+}
+
+// perflibLayout holds the positions of the counters read into the fields of
+// a struct in the counters of the instances of an object, computed once per
+// object. Instances have one counter per counter definition of the object,
+// in the same order. Missing counters have the position -1.
+type perflibLayout struct {
+	counters []int
+	bases    []int
+}
+
+// checkCounters returns the layout of obj for fields, and records the
+// counters read into the fields of t which obj does not define. An error is
+// returned if any of them is required.
+func checkCounters(obj *perflib.PerfObject, t reflect.Type, fields []perflibField) (perflibLayout, error) {
+	defs := obj.CounterDefs
+	if len(defs) == 0 && len(obj.Instances) > 0 {
+		for _, ctr := range obj.Instances[0].Counters {
+			defs = append(defs, ctr.Def)
+		}
+	}
+	positions := make(map[string]int, len(defs))
+	bases := make(map[string]int)
+	for i, def := range defs {
+		name := perflibNames.counterName(def)
+		if def.IsBaseValue && !def.IsNanosecondCounter {
+			positions[name+"_Base"] = i
+			continue
+		}
+		positions[name] = i
+		// The base counter of a counter immediately follows it.
+		if i+1 < len(defs) && isBaseCounter(defs[i+1].CounterType) {
+			bases[name] = i + 1
+		}
+	}
+
+	layout := perflibLayout{
+		counters: make([]int, len(fields)),
+		bases:    make([]int, len(fields)),
+	}
+	var missing, unresolved, missingRequired []string
+	for k, f := range fields {
+		layout.counters[k], layout.bases[k] = -1, -1
+		if i, ok := positions[f.counter]; ok {
+			layout.counters[k] = i
+		}
+		if i, ok := bases[f.counter]; ok {
+			layout.bases[k] = i
+		}
+
+		found := layout.counters[k] >= 0
+		if f.value != "" {
+			found = layout.bases[k] >= 0
+		}
+		if found || f.optional || find(missing, f.source()) || find(unresolved, f.source()) {
+			continue
+		}
+		if perflibNames.loaded() && !perflibNames.resolves(f.counter) {
+			unresolved = append(unresolved, f.source())
+		} else {
+			missing = append(missing, f.source())
+		}
+		if f.required {
+			missingRequired = append(missingRequired, f.source())
 		}
 	}
 
+	object := perfObjectKey(obj)
+	recordMissingCounters(object, t, missing, unresolved)
+	if len(missingRequired) > 0 {
+		return layout, fmt.Errorf("required counters of perflib object %s not found: %s", object, strings.Join(missingRequired, ", "))
+	}
+	return layout, nil
+}
+
+// counter returns the counter read into the k-th field, or nil if missing.
+func (l perflibLayout) counter(instance *perflib.PerfInstance, k int) *perflib.PerfCounter {
+	if i := l.counters[k]; i >= 0 && i < len(instance.Counters) {
+		return instance.Counters[i]
+	}
 	return nil
 }
 
-func counterMapKeys(m map[string]*perflib.PerfCounter) []string {
-	keys := make([]string, 0, len(m))
-	for k := range m {
-		keys = append(keys, k)
+// base returns the base counter of the counter read into the k-th field, or
+// nil if missing.
+func (l perflibLayout) base(instance *perflib.PerfInstance, k int) *perflib.PerfCounter {
+	if i := l.bases[k]; i >= 0 && i < len(instance.Counters) {
+		return instance.Counters[i]
+	}
+	return nil
+}
+
+// resolves returns true if the counter with the given English name, or the
+// base counter "<name>_Base", is found in the name tables.
+func (n perflibNameTables) resolves(counter string) bool {
+	_, ok := n.lookupIndex(strings.TrimSuffix(counter, "_Base"))
+	return ok
+}
+
+var (
+	missingCountersMtx sync.Mutex
+	// missingCounters and unresolvedCounters hold the counters not found
+	// when unmarshalling perflib objects, by object and struct type. The
+	// unresolved counters are not found in the perflib name tables either.
+	missingCounters    = make(map[string]map[reflect.Type][]string)
+	unresolvedCounters = make(map[string]map[reflect.Type][]string)
+)
+
+func recordMissingCounters(object string, t reflect.Type, missing []string, unresolved []string) {
+	missingCountersMtx.Lock()
+	defer missingCountersMtx.Unlock()
+
+	for _, r := range []struct {
+		counters []string
+		byObject map[string]map[reflect.Type][]string
+		msg      string
+	}{
+		{missing, missingCounters, "Perflib counter not found, its metrics are missing"},
+		{unresolved, unresolvedCounters, "Perflib counter not found in the perflib name tables, its metrics are missing"},
+	} {
+		byType, ok := r.byObject[object]
+		if !ok {
+			byType = make(map[reflect.Type][]string)
+			r.byObject[object] = byType
+		}
+		for _, counter := range r.counters {
+			if !find(byType[t], counter) {
+				log.With("perf_object", object, "counter", counter).Warn(r.msg)
+			}
+		}
+		byType[t] = r.counters
+	}
+}
+
+// MissingPerfCounters returns the counters of the perflib objects the
+// collector depends on which were not found in the last scrape, by object.
+func MissingPerfCounters(collector string) map[string][]string {
+	return collectorCounters(collector, missingCounters)
+}
+
+// UnresolvedPerfCounters returns the counters of the perflib objects the
+// collector depends on which were not found in the perflib name tables in
+// the last scrape, by object.
+func UnresolvedPerfCounters(collector string) map[string][]string {
+	return collectorCounters(collector, unresolvedCounters)
+}
+
+func collectorCounters(collector string, byObject map[string]map[reflect.Type][]string) map[string][]string {
+	missingCountersMtx.Lock()
+	defer missingCountersMtx.Unlock()
+
+	counters := make(map[string][]string)
+	for _, object := range perfObjectsByCollector[collector] {
+		for _, cs := range byObject[object] {
+			for _, counter := range cs {
+				if !find(counters[object], counter) {
+					counters[object] = append(counters[object], counter)
+				}
+			}
+		}
+		sort.Strings(counters[object])
+	}
+	return counters
+}
+
+// perfObjectKey returns the name obj is known by to the collectors.
+func perfObjectKey(obj *perflib.PerfObject) string {
+	if name, ok := perfObjectNames[uint32(obj.NameIndex)]; ok {
+		return name
+	}
+	return obj.Name
+}
+
+// processIDCounter is the counter holding the ID of the process an instance
+// belongs to, e.g. of Process, Thread and the .NET CLR objects.
+const processIDCounter = "ID Process"
+
+// perfInstanceIdentity identifies a perflib instance beyond its name, whose
+// "#<n>" suffix telling apart instances sharing a name changes between
+// scrapes.
+type perfInstanceIdentity struct {
+	// Name is the instance name without the "#<n>" suffix.
+	Name string
+	// ProcessID is the value of the ID Process counter of the instance, or
+	// -1 if the object has none.
+	ProcessID int
+}
+
+// instanceIdentity returns the identity of instance, from its name and its
+// ID Process counter.
+func instanceIdentity(instance *perflib.PerfInstance) perfInstanceIdentity {
+	id := perfInstanceIdentity{Name: trimInstanceIndex(instance.Name), ProcessID: -1}
+	for _, ctr := range instance.Counters {
+		if perflibNames.counterName(ctr.Def) == processIDCounter {
+			id.ProcessID = int(ctr.Value)
+			break
+		}
+	}
+	return id
+}
+
+func setInstanceIdentity(target reflect.Value, id perfInstanceIdentity) {
+	if f := target.FieldByName("InstanceName"); f.CanSet() && f.Kind() == reflect.String {
+		f.SetString(id.Name)
+	}
+	if f := target.FieldByName("ProcessID"); f.CanSet() && f.Kind() == reflect.Int {
+		f.SetInt(int64(id.ProcessID))
+	}
+}
+
+// trimInstanceIndex removes the "#<n>" suffix which disambiguates instances
+// sharing a name, e.g. "svchost#1". The suffix depends on the order of the
+// instances and changes between scrapes, so it must not be part of labels.
+// Use an identifier reported by the object, like the process ID, instead.
+func trimInstanceIndex(name string) string {
+	if i := strings.LastIndex(name, "#"); i > 0 {
+		if _, err := strconv.Atoi(name[i+1:]); err == nil {
+			return name[:i]
+		}
+	}
+	return name
+}
+
+// counterValue returns the value of ctr, converted to seconds for timers.
+func counterValue(obj *perflib.PerfObject, ctr *perflib.PerfCounter) float64 {
+	switch ctr.Def.CounterType {
+	case perflibCollector.PERF_ELAPSED_TIME:
+		return float64(ctr.Value-windowsEpoch) / float64(obj.Frequency)
+	case perflibCollector.PERF_100NSEC_TIMER, perflibCollector.PERF_PRECISION_100NS_TIMER:
+		return float64(ctr.Value) * ticksToSecondsScaleFactor
+	case perflibCollector.PERF_AVERAGE_TIMER, perflibCollector.PERF_COUNTER_MULTI_TIMER:
+		// Measured in ticks of the performance counter of the object.
+		if obj.Frequency > 0 {
+			return float64(ctr.Value) / float64(obj.Frequency)
+		}
+	}
+	return float64(ctr.Value)
+}
+
+func isBaseCounter(counterType uint32) bool {
+	switch counterType {
+	case perflibCollector.PERF_RAW_BASE, perflibCollector.PERF_LARGE_RAW_BASE,
+		perflibCollector.PERF_AVERAGE_BASE, perflibCollector.PERF_COUNTER_MULTI_BASE:
+		return true
 	}
-	return keys
+	return false
 }
@@ -0,0 +1,210 @@
+package collector
+
+import (
+	"fmt"
+	"strconv"
+
+	perflibCollector "github.com/leoluk/perflib_exporter/collector"
+	"github.com/leoluk/perflib_exporter/perflib"
+)
+
+// PerfDump is the format of recorded perflib objects, as printed by
+// `windows_exporter perflib --format=json`. The collector test fixtures use
+// the same format, so recorded dumps can be used as fixtures.
+type PerfDump struct {
+	Perflib []PerfObjectDump `json:"perflib"`
+}
+
+// PerfObjectDump is a recorded perflib object.
+type PerfObjectDump struct {
+	Name      string             `json:"name"`
+	Index     uint               `json:"index,omitempty"`
+	Frequency int64              `json:"frequency"`
+	Counters  []PerfCounterDump  `json:"counters"`
+	Instances []PerfInstanceDump `json:"instances"`
+}
+
+// PerfCounterDump is a recorded perflib counter definition.
+type PerfCounterDump struct {
+	Name  string `json:"name"`
+	Index uint   `json:"index,omitempty"`
+	// Type is the name of the counter type, e.g. "PERF_COUNTER_BULK_COUNT".
+	Type string `json:"type"`
+	// BaseOf is the name of the counter a base counter belongs to.
+	BaseOf string `json:"base_of,omitempty"`
+}
+
+// PerfInstanceDump is a recorded instance of a perflib object.
+type PerfInstanceDump struct {
+	Name string `json:"name"`
+	// Values holds one raw value per entry in the object's counter list.
+	Values []int64 `json:"values"`
+}
+
+var perfCounterTypes = map[string]uint32{
+	"PERF_COUNTER_RAWCOUNT_HEX":           perflibCollector.PERF_COUNTER_RAWCOUNT_HEX,
+	"PERF_COUNTER_LARGE_RAWCOUNT_HEX":     perflibCollector.PERF_COUNTER_LARGE_RAWCOUNT_HEX,
+	"PERF_COUNTER_TEXT":                   perflibCollector.PERF_COUNTER_TEXT,
+	"PERF_COUNTER_RAWCOUNT":               perflibCollector.PERF_COUNTER_RAWCOUNT,
+	"PERF_COUNTER_LARGE_RAWCOUNT":         perflibCollector.PERF_COUNTER_LARGE_RAWCOUNT,
+	"PERF_DOUBLE_RAW":                     perflibCollector.PERF_DOUBLE_RAW,
+	"PERF_COUNTER_DELTA":                  perflibCollector.PERF_COUNTER_DELTA,
+	"PERF_COUNTER_LARGE_DELTA":            perflibCollector.PERF_COUNTER_LARGE_DELTA,
+	"PERF_SAMPLE_COUNTER":                 perflibCollector.PERF_SAMPLE_COUNTER,
+	"PERF_COUNTER_QUEUELEN_TYPE":          perflibCollector.PERF_COUNTER_QUEUELEN_TYPE,
+	"PERF_COUNTER_LARGE_QUEUELEN_TYPE":    perflibCollector.PERF_COUNTER_LARGE_QUEUELEN_TYPE,
+	"PERF_COUNTER_100NS_QUEUELEN_TYPE":    perflibCollector.PERF_COUNTER_100NS_QUEUELEN_TYPE,
+	"PERF_COUNTER_OBJ_TIME_QUEUELEN_TYPE": perflibCollector.PERF_COUNTER_OBJ_TIME_QUEUELEN_TYPE,
+	"PERF_COUNTER_COUNTER":                perflibCollector.PERF_COUNTER_COUNTER,
+	"PERF_COUNTER_BULK_COUNT":             perflibCollector.PERF_COUNTER_BULK_COUNT,
+	"PERF_RAW_FRACTION":                   perflibCollector.PERF_RAW_FRACTION,
+	"PERF_LARGE_RAW_FRACTION":             perflibCollector.PERF_LARGE_RAW_FRACTION,
+	"PERF_COUNTER_TIMER":                  perflibCollector.PERF_COUNTER_TIMER,
+	"PERF_PRECISION_SYSTEM_TIMER":         perflibCollector.PERF_PRECISION_SYSTEM_TIMER,
+	"PERF_100NSEC_TIMER":                  perflibCollector.PERF_100NSEC_TIMER,
+	"PERF_PRECISION_100NS_TIMER":          perflibCollector.PERF_PRECISION_100NS_TIMER,
+	"PERF_OBJ_TIME_TIMER":                 perflibCollector.PERF_OBJ_TIME_TIMER,
+	"PERF_PRECISION_OBJECT_TIMER":         perflibCollector.PERF_PRECISION_OBJECT_TIMER,
+	"PERF_SAMPLE_FRACTION":                perflibCollector.PERF_SAMPLE_FRACTION,
+	"PERF_COUNTER_TIMER_INV":              perflibCollector.PERF_COUNTER_TIMER_INV,
+	"PERF_100NSEC_TIMER_INV":              perflibCollector.PERF_100NSEC_TIMER_INV,
+	"PERF_COUNTER_MULTI_TIMER":            perflibCollector.PERF_COUNTER_MULTI_TIMER,
+	"PERF_100NSEC_MULTI_TIMER":            perflibCollector.PERF_100NSEC_MULTI_TIMER,
+	"PERF_COUNTER_MULTI_TIMER_INV":        perflibCollector.PERF_COUNTER_MULTI_TIMER_INV,
+	"PERF_100NSEC_MULTI_TIMER_INV":        perflibCollector.PERF_100NSEC_MULTI_TIMER_INV,
+	"PERF_AVERAGE_TIMER":                  perflibCollector.PERF_AVERAGE_TIMER,
+	"PERF_ELAPSED_TIME":                   perflibCollector.PERF_ELAPSED_TIME,
+	"PERF_COUNTER_NODATA":                 perflibCollector.PERF_COUNTER_NODATA,
+	"PERF_AVERAGE_BULK":                   perflibCollector.PERF_AVERAGE_BULK,
+	"PERF_SAMPLE_BASE":                    perflibCollector.PERF_SAMPLE_BASE,
+	"PERF_AVERAGE_BASE":                   perflibCollector.PERF_AVERAGE_BASE,
+	"PERF_RAW_BASE":                       perflibCollector.PERF_RAW_BASE,
+	"PERF_PRECISION_TIMESTAMP":            perflibCollector.PERF_PRECISION_TIMESTAMP,
+	"PERF_LARGE_RAW_BASE":                 perflibCollector.PERF_LARGE_RAW_BASE,
+	"PERF_COUNTER_MULTI_BASE":             perflibCollector.PERF_COUNTER_MULTI_BASE,
+	"PERF_COUNTER_HISTOGRAM_TYPE":         perflibCollector.PERF_COUNTER_HISTOGRAM_TYPE,
+}
+
+var perfCounterTypeNames = func() map[uint32]string {
+	names := make(map[uint32]string, len(perfCounterTypes))
+	for name, t := range perfCounterTypes {
+		names[t] = name
+	}
+	return names
+}()
+
+func perfCounterTypeName(counterType uint32) string {
+	if name, ok := perfCounterTypeNames[counterType]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%08x", counterType)
+}
+
+func parsePerfCounterType(name string) (uint32, bool) {
+	if t, ok := perfCounterTypes[name]; ok {
+		return t, true
+	}
+	// Types without a name are dumped as hex numbers.
+	t, err := strconv.ParseUint(name, 0, 32)
+	return uint32(t), err == nil
+}
+
+// QueryPerfDump records the perflib objects selected by query, either
+// "Global" for all objects or a space-separated list of object indexes.
+func QueryPerfDump(query string) (*PerfDump, error) {
+	objects, err := perflib.QueryPerformanceData(query)
+	if err != nil {
+		return nil, err
+	}
+	perflib.SortObjects(objects)
+
+	dump := &PerfDump{Perflib: make([]PerfObjectDump, 0, len(objects))}
+	for _, obj := range objects {
+		dump.Perflib = append(dump.Perflib, dumpPerfObject(obj))
+	}
+	return dump, nil
+}
+
+// LookupPerfObjectIndex returns the index of the perflib object with the given
+// English name.
+func LookupPerfObjectIndex(name string) (uint32, bool) {
+	return perflibNames.lookupIndex(name)
+}
+
+func dumpPerfObject(obj *perflib.PerfObject) PerfObjectDump {
+	d := PerfObjectDump{
+		Name:      obj.Name,
+		Index:     obj.NameIndex,
+		Frequency: obj.Frequency,
+		Counters:  make([]PerfCounterDump, 0, len(obj.CounterDefs)),
+		Instances: make([]PerfInstanceDump, 0, len(obj.Instances)),
+	}
+	for _, def := range obj.CounterDefs {
+		d.Counters = append(d.Counters, PerfCounterDump{
+			Name:  perflibNames.counterName(def),
+			Index: def.NameIndex,
+			Type:  perfCounterTypeName(def.CounterType),
+		})
+	}
+	d.LinkBaseCounters()
+	for _, instance := range obj.Instances {
+		values := make([]int64, 0, len(instance.Counters))
+		for _, ctr := range instance.Counters {
+			values = append(values, ctr.Value)
+		}
+		d.Instances = append(d.Instances, PerfInstanceDump{Name: instance.Name, Values: values})
+	}
+	return d
+}
+
+// LinkBaseCounters sets BaseOf of the base counters, which may be missing in
+// recordings.
+func (o *PerfObjectDump) LinkBaseCounters() {
+	for i := 1; i < len(o.Counters); i++ {
+		// The base counter of a counter immediately follows it.
+		if t, ok := parsePerfCounterType(o.Counters[i].Type); ok && isBaseCounter(t) && o.Counters[i].BaseOf == "" {
+			o.Counters[i].BaseOf = o.Counters[i-1].Name
+		}
+	}
+}
+
+// PerfObject builds a perflib.PerfObject from the recording the same way
+// perflib.QueryPerformanceData does when parsing the raw performance data
+// block.
+func (o PerfObjectDump) PerfObject() (*perflib.PerfObject, error) {
+	obj := &perflib.PerfObject{
+		Name:        o.Name,
+		NameIndex:   o.Index,
+		Frequency:   o.Frequency,
+		CounterDefs: make([]*perflib.PerfCounterDef, 0, len(o.Counters)),
+		Instances:   make([]*perflib.PerfInstance, 0, len(o.Instances)),
+	}
+	for _, c := range o.Counters {
+		counterType, ok := parsePerfCounterType(c.Type)
+		if !ok {
+			return nil, fmt.Errorf("object %q: unknown type %q for counter %q", o.Name, c.Type, c.Name)
+		}
+		obj.CounterDefs = append(obj.CounterDefs, &perflib.PerfCounterDef{
+			Name:                c.Name,
+			NameIndex:           c.Index,
+			CounterType:         counterType,
+			IsCounter:           counterType&0x400 == 0x400,
+			IsBaseValue:         counterType&0x00030000 == 0x00030000,
+			IsNanosecondCounter: counterType&0x00100000 == 0x00100000,
+		})
+	}
+	for _, i := range o.Instances {
+		if len(i.Values) != len(obj.CounterDefs) {
+			return nil, fmt.Errorf("object %q: instance %q has %d values, expected %d", o.Name, i.Name, len(i.Values), len(obj.CounterDefs))
+		}
+		instance := &perflib.PerfInstance{
+			Name:     i.Name,
+			Counters: make([]*perflib.PerfCounter, 0, len(i.Values)),
+		}
+		for idx, v := range i.Values {
+			instance.Counters = append(instance.Counters, &perflib.PerfCounter{Value: v, Def: obj.CounterDefs[idx]})
+		}
+		obj.Instances = append(obj.Instances, instance)
+	}
+	return obj, nil
+}
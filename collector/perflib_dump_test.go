@@ -0,0 +1,49 @@
+package collector
+
+import (
+	"reflect"
+	"testing"
+
+	perflibCollector "github.com/leoluk/perflib_exporter/collector"
+	"github.com/leoluk/perflib_exporter/perflib"
+)
+
+func TestPerfObjectDump(t *testing.T) {
+	obj := perfObjectWithBase(1000, perflibCollector.PERF_AVERAGE_TIMER, perflibCollector.PERF_AVERAGE_BASE, 5000, 10)
+	obj.Name, obj.NameIndex = "Test", 42
+	obj.Instances[0].Name = "instance"
+	// Counter types without a name.
+	obj.CounterDefs = append(obj.CounterDefs, &perflib.PerfCounterDef{Name: "Unknown", CounterType: 0x12345678})
+	obj.Instances[0].Counters = append(obj.Instances[0].Counters, &perflib.PerfCounter{Def: obj.CounterDefs[2], Value: 1})
+
+	dump := dumpPerfObject(obj)
+	expected := PerfObjectDump{
+		Name:      "Test",
+		Index:     42,
+		Frequency: 1000,
+		Counters: []PerfCounterDump{
+			{Name: "Something", Type: "PERF_AVERAGE_TIMER"},
+			{Name: "Something Base", Type: "PERF_AVERAGE_BASE", BaseOf: "Something"},
+			{Name: "Unknown", Type: "0x12345678"},
+		},
+		Instances: []PerfInstanceDump{{Name: "instance", Values: []int64{5000, 10, 1}}},
+	}
+	if !reflect.DeepEqual(dump, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, dump)
+	}
+
+	replayed, err := dump.PerfObject()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output []fraction
+	if _, err := unmarshalObject(replayed, &output); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []fraction{{Value: 5, Base: 10, Ratio: 0.5}}; !reflect.DeepEqual(output, expected) {
+		t.Errorf("expected %+v, got %+v", expected, output)
+	}
+	if got := replayed.CounterDefs[2].CounterType; got != 0x12345678 {
+		t.Errorf("expected counter type 0x12345678, got %#x", got)
+	}
+}
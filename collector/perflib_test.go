@@ -1,11 +1,14 @@
 package collector
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"reflect"
 	"testing"
 
 	perflibCollector "github.com/leoluk/perflib_exporter/collector"
 	"github.com/leoluk/perflib_exporter/perflib"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type simple struct {
@@ -109,7 +112,7 @@ func TestUnmarshalPerflib(t *testing.T) {
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			output := make([]simple, 0)
-			err := unmarshalObject(c.obj, &output)
+			_, err := unmarshalObject(c.obj, &output)
 			if err != nil && !c.expectError {
 				t.Errorf("Did not expect error, got %q", err)
 			}
@@ -123,3 +126,441 @@ func TestUnmarshalPerflib(t *testing.T) {
 		})
 	}
 }
+
+type fraction struct {
+	Value float64 `perflib:"Something"`
+	Base  float64 `perflib:"Something,base"`
+	Ratio float64 `perflib:"Something,ratio"`
+}
+
+func perfObjectWithBase(frequency int64, counterType, baseType uint32, value, base int64) *perflib.PerfObject {
+	counterDef := &perflib.PerfCounterDef{Name: "Something", CounterType: counterType}
+	// Base counters usually have a name of their own, or the name of the
+	// counter they belong to.
+	baseDef := &perflib.PerfCounterDef{Name: "Something Base", CounterType: baseType, IsBaseValue: true}
+	return &perflib.PerfObject{
+		Frequency:   frequency,
+		CounterDefs: []*perflib.PerfCounterDef{counterDef, baseDef},
+		Instances: []*perflib.PerfInstance{
+			{
+				Counters: []*perflib.PerfCounter{
+					{Def: counterDef, Value: value},
+					{Def: baseDef, Value: base},
+				},
+			},
+		},
+	}
+}
+
+func TestUnmarshalPerflibBaseCounters(t *testing.T) {
+	cases := []struct {
+		name string
+		obj  *perflib.PerfObject
+
+		expectedOutput []fraction
+	}{
+		{
+			name:           "raw fraction",
+			obj:            perfObjectWithBase(0, perflibCollector.PERF_RAW_FRACTION, perflibCollector.PERF_RAW_BASE, 25, 100),
+			expectedOutput: []fraction{{Value: 25, Base: 100, Ratio: 0.25}},
+		},
+		{
+			name:           "large raw fraction",
+			obj:            perfObjectWithBase(0, perflibCollector.PERF_LARGE_RAW_FRACTION, perflibCollector.PERF_LARGE_RAW_BASE, 3, 4),
+			expectedOutput: []fraction{{Value: 3, Base: 4, Ratio: 0.75}},
+		},
+		{
+			name:           "average timer",
+			obj:            perfObjectWithBase(1000, perflibCollector.PERF_AVERAGE_TIMER, perflibCollector.PERF_AVERAGE_BASE, 5000, 10),
+			expectedOutput: []fraction{{Value: 5, Base: 10, Ratio: 0.5}},
+		},
+		{
+			name:           "average bulk",
+			obj:            perfObjectWithBase(1000, perflibCollector.PERF_AVERAGE_BULK, perflibCollector.PERF_AVERAGE_BASE, 300, 4),
+			expectedOutput: []fraction{{Value: 300, Base: 4, Ratio: 75}},
+		},
+		{
+			name:           "multi timer",
+			obj:            perfObjectWithBase(100, perflibCollector.PERF_COUNTER_MULTI_TIMER, perflibCollector.PERF_COUNTER_MULTI_BASE, 800, 2),
+			expectedOutput: []fraction{{Value: 8, Base: 2, Ratio: 4}},
+		},
+		{
+			name:           "zero base",
+			obj:            perfObjectWithBase(0, perflibCollector.PERF_RAW_FRACTION, perflibCollector.PERF_RAW_BASE, 0, 0),
+			expectedOutput: []fraction{{}},
+		},
+		{
+			name: "missing base",
+			obj: &perflib.PerfObject{
+				Instances: []*perflib.PerfInstance{
+					{
+						Counters: []*perflib.PerfCounter{
+							{
+								Def: &perflib.PerfCounterDef{
+									Name:        "Something",
+									CounterType: perflibCollector.PERF_COUNTER_RAWCOUNT,
+								},
+								Value: 7,
+							},
+						},
+					},
+				},
+			},
+			expectedOutput: []fraction{{Value: 7}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			output := make([]fraction, 0)
+			if _, err := unmarshalObject(c.obj, &output); err != nil {
+				t.Fatalf("Did not expect error, got %q", err)
+			}
+			if !reflect.DeepEqual(output, c.expectedOutput) {
+				t.Errorf("Output mismatch, expected %+v, got %+v", c.expectedOutput, output)
+			}
+		})
+	}
+}
+
+func TestUnmarshalPerflibUnknownOption(t *testing.T) {
+	var output []struct {
+		Value float64 `perflib:"Something,average"`
+	}
+	obj := perfObjectWithBase(0, perflibCollector.PERF_RAW_FRACTION, perflibCollector.PERF_RAW_BASE, 1, 2)
+	if _, err := unmarshalObject(obj, &output); err == nil {
+		t.Errorf("Expected an error, but got ok")
+	}
+}
+
+func TestSplitPerflibTag(t *testing.T) {
+	for tag, expected := range map[string]struct {
+		counter string
+		options []string
+	}{
+		"Something":                        {"Something", []string{}},
+		"Something,base":                   {"Something", []string{"base"}},
+		"Some, Thing/sec":                  {"Some, Thing/sec", []string{}},
+		"Some, Thing, Else,ratio,optional": {"Some, Thing, Else", []string{"ratio", "optional"}},
+	} {
+		counter, options := splitPerflibTag(tag)
+		if counter != expected.counter || !reflect.DeepEqual(options, expected.options) {
+			t.Errorf("%q: expected %q %q, got %q %q", tag, expected.counter, expected.options, counter, options)
+		}
+	}
+}
+
+type partial struct {
+	Value    float64 `perflib:"Something"`
+	Missing  float64 `perflib:"Something Missing"`
+	Optional float64 `perflib:"Something Optional,optional"`
+	Ratio    float64 `perflib:"Something Base,ratio"`
+}
+
+func TestUnmarshalPerflibMissingCounters(t *testing.T) {
+	defer func(objects map[string][]string, missing map[string]map[reflect.Type][]string) {
+		perfObjectsByCollector, missingCounters = objects, missing
+	}(perfObjectsByCollector, missingCounters)
+	perfObjectsByCollector = map[string][]string{"test": {"Test"}}
+	missingCounters = make(map[string]map[reflect.Type][]string)
+
+	obj := perfObjectWithBase(0, perflibCollector.PERF_RAW_FRACTION, perflibCollector.PERF_RAW_BASE, 1, 2)
+	obj.Name = "Test"
+	var output []partial
+	if _, err := unmarshalObject(obj, &output); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []partial{{Value: 1}}; !reflect.DeepEqual(output, expected) {
+		t.Errorf("expected %+v, got %+v", expected, output)
+	}
+	expected := map[string][]string{"Test": {"Something Base,ratio", "Something Missing"}}
+	if missing := MissingPerfCounters("test"); !reflect.DeepEqual(missing, expected) {
+		t.Errorf("expected missing counters %v, got %v", expected, missing)
+	}
+	if missing := MissingPerfCounters("other"); len(missing) != 0 {
+		t.Errorf("expected no missing counters for other collector, got %v", missing)
+	}
+
+	// Counters appearing later are no longer reported.
+	def := &perflib.PerfCounterDef{Name: "Something Missing", CounterType: perflibCollector.PERF_COUNTER_RAWCOUNT}
+	obj.CounterDefs = append(obj.CounterDefs, def)
+	obj.Instances[0].Counters = append(obj.Instances[0].Counters, &perflib.PerfCounter{Def: def, Value: 3})
+	if _, err := unmarshalObject(obj, &output); err != nil {
+		t.Fatal(err)
+	}
+	expected = map[string][]string{"Test": {"Something Base,ratio"}}
+	if missing := MissingPerfCounters("test"); !reflect.DeepEqual(missing, expected) {
+		t.Errorf("expected missing counters %v, got %v", expected, missing)
+	}
+}
+
+func TestUnmarshalPerflibRequiredCounter(t *testing.T) {
+	defer func(missing map[string]map[reflect.Type][]string) {
+		missingCounters = missing
+	}(missingCounters)
+	missingCounters = make(map[string]map[reflect.Type][]string)
+
+	var output []struct {
+		Value   float64 `perflib:"Something"`
+		Missing float64 `perflib:"Something Missing,required"`
+	}
+	obj := perfObjectWithBase(0, perflibCollector.PERF_RAW_FRACTION, perflibCollector.PERF_RAW_BASE, 1, 2)
+	if _, err := unmarshalObject(obj, &output); err == nil {
+		t.Errorf("Expected an error, but got ok")
+	}
+	if len(output) != 0 {
+		t.Errorf("Expected no output, got %+v", output)
+	}
+}
+
+func TestUnmarshalPerflibPresence(t *testing.T) {
+	defer func(missing map[string]map[reflect.Type][]string) {
+		missingCounters = missing
+	}(missingCounters)
+	missingCounters = make(map[string]map[reflect.Type][]string)
+
+	var output []struct {
+		Value   float64 `perflib:"Something"`
+		Missing float64 `perflib:"Something Missing"`
+	}
+	obj := perfObjectWithBase(0, perflibCollector.PERF_RAW_FRACTION, perflibCollector.PERF_RAW_BASE, 1, 2)
+	present, err := unmarshalObject(obj, &output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !present.has(&output[0].Value) {
+		t.Errorf("expected field of counter Something to be present")
+	}
+	if present.has(&output[0].Missing) || present.has(&output[0].Value, &output[0].Missing) {
+		t.Errorf("expected field of counter Something Missing to be absent")
+	}
+
+	desc := prometheus.NewDesc("test", "", nil, nil)
+	ch := make(chan prometheus.Metric, 2)
+	present.emit(ch, desc, prometheus.GaugeValue, &output[0].Value)
+	present.emit(ch, desc, prometheus.GaugeValue, &output[0].Missing)
+	close(ch)
+	if n := len(ch); n != 1 {
+		t.Errorf("expected 1 metric, got %d", n)
+	}
+}
+
+func loadNameTable(t *testing.T, name string) *nameTable {
+	t.Helper()
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "nametable", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, err := parseNameTable(data)
+	if err != nil {
+		t.Fatalf("failed to parse %s: %v", name, err)
+	}
+	return table
+}
+
+func TestParseNameTable(t *testing.T) {
+	english := loadNameTable(t, "counter_009.bin")
+	if got := english.byIndex[236]; got != "LogicalDisk" {
+		t.Errorf("expected index 236 to be LogicalDisk, got %q", got)
+	}
+	if got := english.byName["Processor"]; got != 238 {
+		t.Errorf("expected Processor to have index 238, got %d", got)
+	}
+	// "% Processor Time" is registered twice, the lowest index wins.
+	if got := english.byName["% Processor Time"]; got != 6 {
+		t.Errorf("expected %% Processor Time to have index 6, got %d", got)
+	}
+
+	japanese := loadNameTable(t, "counter_011.bin")
+	if got := japanese.byIndex[238]; got != "プロセッサ" {
+		t.Errorf("expected index 238 to be プロセッサ, got %q", got)
+	}
+
+	for _, data := range [][]byte{
+		{'1', 0},               // odd length
+		{'x', 0, 0, 0, 'a', 0}, // invalid index
+		{'1', 0, 0, 0},         // index without name
+	} {
+		if _, err := parseNameTable(data); err == nil {
+			t.Errorf("expected an error parsing %v, but got ok", data)
+		}
+	}
+}
+
+func TestPerflibNameTablesLookupIndex(t *testing.T) {
+	names := perflibNameTables{
+		english: loadNameTable(t, "counter_009.bin"),
+		local:   loadNameTable(t, "counter_007.bin"),
+	}
+	cases := []struct {
+		name     string
+		index    uint32
+		resolved bool
+	}{
+		{"Processor", 238, true},
+		{"LogicalDisk", 236, true},
+		// Only registered in the system language.
+		{"SQLServer:Buffer Manager", 10500, true},
+		{"MSExchange ADAccess Processes", 0, false},
+	}
+	for _, c := range cases {
+		index, ok := names.lookupIndex(c.name)
+		if ok != c.resolved || index != c.index {
+			t.Errorf("%s: expected (%d, %v), got (%d, %v)", c.name, c.index, c.resolved, index, ok)
+		}
+	}
+
+	// Without an English name table, names are only resolved in the system
+	// language.
+	if _, ok := (perflibNameTables{local: names.local}).lookupIndex("Processor"); ok {
+		t.Errorf("expected Processor not to be resolved without an English name table")
+	}
+
+	// The localized name of an object with an English name is not taken
+	// for the English name of another object.
+	names = perflibNameTables{
+		english: testNameTable(map[uint32]string{5000: "Paging File"}),
+		text:    testNameTable(map[uint32]string{5002: "Processor Information"}),
+		local:   testNameTable(map[uint32]string{5000: "Processor", 5002: "Page File", 6000: "Cache"}),
+	}
+	for name, expected := range map[string]uint32{"Paging File": 5000, "Processor Information": 5002, "Cache": 6000} {
+		if index, ok := names.lookupIndex(name); !ok || index != expected {
+			t.Errorf("%s: expected (%d, true), got (%d, %v)", name, expected, index, ok)
+		}
+	}
+	for _, name := range []string{"Processor", "Page File"} {
+		if index, ok := names.lookupIndex(name); ok {
+			t.Errorf("%s: expected not to be resolved, got %d", name, index)
+		}
+	}
+	if name, ok := names.lookupName(6000); !ok || name != "Cache" {
+		t.Errorf("expected index 6000 to be named Cache, got (%q, %v)", name, ok)
+	}
+}
+
+func testNameTable(names map[uint32]string) *nameTable {
+	t := &nameTable{byIndex: names, byName: make(map[string]uint32, len(names))}
+	for index, name := range names {
+		t.byName[name] = index
+	}
+	return t
+}
+
+func TestUnmarshalPerflibUnresolvedCounters(t *testing.T) {
+	defer func(names perflibNameTables, objects map[string][]string, missing, unresolved map[string]map[reflect.Type][]string) {
+		perflibNames, perfObjectsByCollector, missingCounters, unresolvedCounters = names, objects, missing, unresolved
+	}(perflibNames, perfObjectsByCollector, missingCounters, unresolvedCounters)
+	perflibNames = perflibNameTables{
+		english: testNameTable(map[uint32]string{10: "Something", 12: "Something Missing"}),
+		local:   testNameTable(map[uint32]string{20: "Something Local"}),
+	}
+	perfObjectsByCollector = map[string][]string{"test": {"Test"}}
+	missingCounters = make(map[string]map[reflect.Type][]string)
+	unresolvedCounters = make(map[string]map[reflect.Type][]string)
+
+	// perflib only names counters from the "Counter 009" table.
+	def := &perflib.PerfCounterDef{Name: "Something", NameIndex: 10, CounterType: perflibCollector.PERF_COUNTER_RAWCOUNT}
+	localDef := &perflib.PerfCounterDef{NameIndex: 20, CounterType: perflibCollector.PERF_COUNTER_RAWCOUNT}
+	obj := &perflib.PerfObject{
+		Name:        "Test",
+		CounterDefs: []*perflib.PerfCounterDef{def, localDef},
+		Instances: []*perflib.PerfInstance{
+			{Counters: []*perflib.PerfCounter{{Def: def, Value: 1}, {Def: localDef, Value: 2}}},
+		},
+	}
+	var output []struct {
+		Value      float64 `perflib:"Something"`
+		Local      float64 `perflib:"Something Local"`
+		Missing    float64 `perflib:"Something Missing"`
+		Unresolved float64 `perflib:"Something Unresolved"`
+	}
+	if _, err := unmarshalObject(obj, &output); err != nil {
+		t.Fatal(err)
+	}
+	if len(output) != 1 || output[0].Value != 1 || output[0].Local != 2 {
+		t.Errorf("expected values 1 and 2, got %+v", output)
+	}
+	if expected, missing := map[string][]string{"Test": {"Something Missing"}}, MissingPerfCounters("test"); !reflect.DeepEqual(missing, expected) {
+		t.Errorf("expected missing counters %v, got %v", expected, missing)
+	}
+	if expected, unresolved := map[string][]string{"Test": {"Something Unresolved"}}, UnresolvedPerfCounters("test"); !reflect.DeepEqual(unresolved, expected) {
+		t.Errorf("expected unresolved counters %v, got %v", expected, unresolved)
+	}
+}
+
+func TestIndexPerfObjects(t *testing.T) {
+	objects := []*perflib.PerfObject{
+		{Name: "Prozessor", NameIndex: 238},
+		{Name: "", NameIndex: 236},
+		{Name: "System", NameIndex: 2},
+	}
+	indexed := indexPerfObjects(objects, map[uint32]string{238: "Processor", 236: "LogicalDisk"})
+	for name, obj := range map[string]*perflib.PerfObject{
+		"Processor":   objects[0],
+		"LogicalDisk": objects[1],
+		"System":      objects[2],
+	} {
+		if indexed[name] != obj {
+			t.Errorf("expected %s to be indexed as %+v, got %+v", name, obj, indexed[name])
+		}
+	}
+}
+
+type thread struct {
+	Name         string
+	InstanceName string
+	ProcessID    int
+	IDThread     float64 `perflib:"ID Thread"`
+}
+
+func TestUnmarshalPerflibInstanceIdentity(t *testing.T) {
+	idThread := &perflib.PerfCounterDef{Name: "ID Thread", CounterType: perflibCollector.PERF_COUNTER_RAWCOUNT}
+	idProcess := &perflib.PerfCounterDef{Name: "ID Process", CounterType: perflibCollector.PERF_COUNTER_RAWCOUNT}
+	threads := &perflib.PerfObject{
+		Name:        "Thread",
+		CounterDefs: []*perflib.PerfCounterDef{idThread, idProcess},
+		Instances: []*perflib.PerfInstance{
+			{Name: "svchost/0", Counters: []*perflib.PerfCounter{{Def: idThread, Value: 120}, {Def: idProcess, Value: 4}}},
+			{Name: "svchost/0#1", Counters: []*perflib.PerfCounter{{Def: idThread, Value: 244}, {Def: idProcess, Value: 8}}},
+		},
+	}
+	var output []thread
+	if _, err := unmarshalObject(threads, &output); err != nil {
+		t.Fatal(err)
+	}
+	expected := []thread{
+		{Name: "svchost/0", InstanceName: "svchost/0", ProcessID: 4, IDThread: 120},
+		{Name: "svchost/0#1", InstanceName: "svchost/0", ProcessID: 8, IDThread: 244},
+	}
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Output mismatch, expected %+v, got %+v", expected, output)
+	}
+
+	// Objects without an ID Process counter have no process ID.
+	var processors []thread
+	if _, err := unmarshalObject(&perflib.PerfObject{
+		Name:        "Processor",
+		CounterDefs: []*perflib.PerfCounterDef{idThread},
+		Instances:   []*perflib.PerfInstance{{Name: "0", Counters: []*perflib.PerfCounter{{Def: idThread, Value: 1}}}},
+	}, &processors); err != nil {
+		t.Fatal(err)
+	}
+	if len(processors) != 1 || processors[0].ProcessID != -1 || processors[0].InstanceName != "0" {
+		t.Errorf("expected no process ID, got %+v", processors)
+	}
+}
+
+func TestTrimInstanceIndex(t *testing.T) {
+	for name, expected := range map[string]string{
+		"svchost":    "svchost",
+		"svchost#1":  "svchost",
+		"svchost#12": "svchost",
+		"C#":         "C#",
+		"#1":         "#1",
+		"a#b":        "a#b",
+		"a#1#2":      "a#1",
+	} {
+		if got := trimInstanceIndex(name); got != expected {
+			t.Errorf("%s: expected %q, got %q", name, expected, got)
+		}
+	}
+}
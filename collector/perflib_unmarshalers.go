@@ -0,0 +1,6671 @@
+// Code generated by tools/perflib-unmarshalers; DO NOT EDIT.
+
+// +build windows
+
+package collector
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/leoluk/perflib_exporter/perflib"
+)
+
+func init() {
+	perflibUnmarshalers = map[reflect.Type]perflibUnmarshaler{
+		reflect.TypeOf((*[]PerflibDFSRConnection)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibDFSRConnection(obj, vs.(*[]PerflibDFSRConnection))
+		},
+		reflect.TypeOf((*[]PerflibDFSRFolder)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibDFSRFolder(obj, vs.(*[]PerflibDFSRFolder))
+		},
+		reflect.TypeOf((*[]PerflibDFSRVolume)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibDFSRVolume(obj, vs.(*[]PerflibDFSRVolume))
+		},
+		reflect.TypeOf((*[]PerflibSMTPServer)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibSMTPServer(obj, vs.(*[]PerflibSMTPServer))
+		},
+		reflect.TypeOf((*[]dhcpPerf)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalDhcpPerf(obj, vs.(*[]dhcpPerf))
+		},
+		reflect.TypeOf((*[]directoryServices)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalDirectoryServices(obj, vs.(*[]directoryServices))
+		},
+		reflect.TypeOf((*[]dnsPerflib)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalDnsPerflib(obj, vs.(*[]dnsPerflib))
+		},
+		reflect.TypeOf((*[]logicalDisk)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalLogicalDisk(obj, vs.(*[]logicalDisk))
+		},
+		reflect.TypeOf((*[]memory)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalMemory(obj, vs.(*[]memory))
+		},
+		reflect.TypeOf((*[]msmqQueue)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalMsmqQueue(obj, vs.(*[]msmqQueue))
+		},
+		reflect.TypeOf((*[]mssqlAccessMethods)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalMssqlAccessMethods(obj, vs.(*[]mssqlAccessMethods))
+		},
+		reflect.TypeOf((*[]mssqlAvailabilityReplica)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalMssqlAvailabilityReplica(obj, vs.(*[]mssqlAvailabilityReplica))
+		},
+		reflect.TypeOf((*[]mssqlBufferManager)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalMssqlBufferManager(obj, vs.(*[]mssqlBufferManager))
+		},
+		reflect.TypeOf((*[]mssqlDatabaseReplica)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalMssqlDatabaseReplica(obj, vs.(*[]mssqlDatabaseReplica))
+		},
+		reflect.TypeOf((*[]mssqlDatabases)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalMssqlDatabases(obj, vs.(*[]mssqlDatabases))
+		},
+		reflect.TypeOf((*[]mssqlGeneralStatistics)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalMssqlGeneralStatistics(obj, vs.(*[]mssqlGeneralStatistics))
+		},
+		reflect.TypeOf((*[]mssqlLocks)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalMssqlLocks(obj, vs.(*[]mssqlLocks))
+		},
+		reflect.TypeOf((*[]mssqlMemoryManager)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalMssqlMemoryManager(obj, vs.(*[]mssqlMemoryManager))
+		},
+		reflect.TypeOf((*[]mssqlSQLErrors)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalMssqlSQLErrors(obj, vs.(*[]mssqlSQLErrors))
+		},
+		reflect.TypeOf((*[]mssqlSQLStatistics)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalMssqlSQLStatistics(obj, vs.(*[]mssqlSQLStatistics))
+		},
+		reflect.TypeOf((*[]mssqlTransactions)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalMssqlTransactions(obj, vs.(*[]mssqlTransactions))
+		},
+		reflect.TypeOf((*[]mssqlWaitStatistics)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalMssqlWaitStatistics(obj, vs.(*[]mssqlWaitStatistics))
+		},
+		reflect.TypeOf((*[]netframeworkCLRExceptions)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalNetframeworkCLRExceptions(obj, vs.(*[]netframeworkCLRExceptions))
+		},
+		reflect.TypeOf((*[]netframeworkCLRInterop)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalNetframeworkCLRInterop(obj, vs.(*[]netframeworkCLRInterop))
+		},
+		reflect.TypeOf((*[]netframeworkCLRJit)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalNetframeworkCLRJit(obj, vs.(*[]netframeworkCLRJit))
+		},
+		reflect.TypeOf((*[]netframeworkCLRLoading)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalNetframeworkCLRLoading(obj, vs.(*[]netframeworkCLRLoading))
+		},
+		reflect.TypeOf((*[]netframeworkCLRLocksAndThreads)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalNetframeworkCLRLocksAndThreads(obj, vs.(*[]netframeworkCLRLocksAndThreads))
+		},
+		reflect.TypeOf((*[]netframeworkCLRMemory)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalNetframeworkCLRMemory(obj, vs.(*[]netframeworkCLRMemory))
+		},
+		reflect.TypeOf((*[]netframeworkCLRRemoting)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalNetframeworkCLRRemoting(obj, vs.(*[]netframeworkCLRRemoting))
+		},
+		reflect.TypeOf((*[]netframeworkCLRSecurity)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalNetframeworkCLRSecurity(obj, vs.(*[]netframeworkCLRSecurity))
+		},
+		reflect.TypeOf((*[]networkInterface)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalNetworkInterface(obj, vs.(*[]networkInterface))
+		},
+		reflect.TypeOf((*[]pagingFileCounter)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPagingFileCounter(obj, vs.(*[]pagingFileCounter))
+		},
+		reflect.TypeOf((*[]perflibADAccessProcesses)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibADAccessProcesses(obj, vs.(*[]perflibADAccessProcesses))
+		},
+		reflect.TypeOf((*[]perflibADFS)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibADFS(obj, vs.(*[]perflibADFS))
+		},
+		reflect.TypeOf((*[]perflibActiveSync)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibActiveSync(obj, vs.(*[]perflibActiveSync))
+		},
+		reflect.TypeOf((*[]perflibAutodiscover)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibAutodiscover(obj, vs.(*[]perflibAutodiscover))
+		},
+		reflect.TypeOf((*[]perflibAvailabilityService)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibAvailabilityService(obj, vs.(*[]perflibAvailabilityService))
+		},
+		reflect.TypeOf((*[]perflibCache)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibCache(obj, vs.(*[]perflibCache))
+		},
+		reflect.TypeOf((*[]perflibHTTPProxy)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibHTTPProxy(obj, vs.(*[]perflibHTTPProxy))
+		},
+		reflect.TypeOf((*[]perflibOWA)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibOWA(obj, vs.(*[]perflibOWA))
+		},
+		reflect.TypeOf((*[]perflibProcess)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibProcess(obj, vs.(*[]perflibProcess))
+		},
+		reflect.TypeOf((*[]perflibProcessor)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibProcessor(obj, vs.(*[]perflibProcessor))
+		},
+		reflect.TypeOf((*[]perflibProcessorInformation)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibProcessorInformation(obj, vs.(*[]perflibProcessorInformation))
+		},
+		reflect.TypeOf((*[]perflibRPCClientAccess)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibRPCClientAccess(obj, vs.(*[]perflibRPCClientAccess))
+		},
+		reflect.TypeOf((*[]perflibRemoteDesktopConnectionBrokerCounterset)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibRemoteDesktopConnectionBrokerCounterset(obj, vs.(*[]perflibRemoteDesktopConnectionBrokerCounterset))
+		},
+		reflect.TypeOf((*[]perflibRemoteFxGraphics)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibRemoteFxGraphics(obj, vs.(*[]perflibRemoteFxGraphics))
+		},
+		reflect.TypeOf((*[]perflibRemoteFxNetwork)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibRemoteFxNetwork(obj, vs.(*[]perflibRemoteFxNetwork))
+		},
+		reflect.TypeOf((*[]perflibTerminalServices)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibTerminalServices(obj, vs.(*[]perflibTerminalServices))
+		},
+		reflect.TypeOf((*[]perflibTerminalServicesSession)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibTerminalServicesSession(obj, vs.(*[]perflibTerminalServicesSession))
+		},
+		reflect.TypeOf((*[]perflibTransportQueues)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibTransportQueues(obj, vs.(*[]perflibTransportQueues))
+		},
+		reflect.TypeOf((*[]perflibWorkloadManagementWorkloads)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalPerflibWorkloadManagementWorkloads(obj, vs.(*[]perflibWorkloadManagementWorkloads))
+		},
+		reflect.TypeOf((*[]system)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalSystem(obj, vs.(*[]system))
+		},
+		reflect.TypeOf((*[]tcp)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalTcp(obj, vs.(*[]tcp))
+		},
+		reflect.TypeOf((*[]vmwareCPU)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalVmwareCPU(obj, vs.(*[]vmwareCPU))
+		},
+		reflect.TypeOf((*[]vmwareMem)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalVmwareMem(obj, vs.(*[]vmwareMem))
+		},
+		reflect.TypeOf((*[]windowsTime)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {
+			return unmarshalWindowsTime(obj, vs.(*[]windowsTime))
+		},
+	}
+}
+
+var perflibFieldsOfPerflibDFSRConnection = []perflibField{
+	{index: 1, counter: "Bandwidth Savings Using DFS Replication"},
+	{index: 2, counter: "Total Bytes Received"},
+	{index: 3, counter: "Compressed Size of Files Received"},
+	{index: 4, counter: "Total Files Received"},
+	{index: 5, counter: "RDC Bytes Received"},
+	{index: 6, counter: "RDC Compressed Size of Files Received"},
+	{index: 7, counter: "RDC Number of Files Received"},
+	{index: 8, counter: "RDC Size of Files Received"},
+	{index: 9, counter: "Size of Files Received"},
+}
+
+func unmarshalPerflibDFSRConnection(obj *perflib.PerfObject, vs *[]PerflibDFSRConnection) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*PerflibDFSRConnection)(nil)).Elem(), perflibFieldsOfPerflibDFSRConnection)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]PerflibDFSRConnection, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.BandwidthSavingsUsingDFSReplicationTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BandwidthSavingsUsingDFSReplicationTotal)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.BytesReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BytesReceivedTotal)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.CompressedSizeOfFilesReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CompressedSizeOfFilesReceivedTotal)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.FilesReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FilesReceivedTotal)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.RDCBytesReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RDCBytesReceivedTotal)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.RDCCompressedSizeOfFilesReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RDCCompressedSizeOfFilesReceivedTotal)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.RDCNumberofFilesReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RDCNumberofFilesReceivedTotal)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.RDCSizeOfFilesReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RDCSizeOfFilesReceivedTotal)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.SizeOfFilesReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SizeOfFilesReceivedTotal)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibDFSRFolder = []perflibField{
+	{index: 1, counter: "Bandwidth Savings Using DFS Replication"},
+	{index: 2, counter: "Compressed Size of Files Received"},
+	{index: 3, counter: "Conflict Bytes Cleaned Up"},
+	{index: 4, counter: "Conflict Bytes Generated"},
+	{index: 5, counter: "Conflict Files Cleaned Up"},
+	{index: 6, counter: "Conflict Files Generated"},
+	{index: 7, counter: "Conflict Folder Cleanups Completed"},
+	{index: 8, counter: "Conflict Space In Use"},
+	{index: 9, counter: "Deleted Space In Use"},
+	{index: 10, counter: "Deleted Bytes Cleaned Up"},
+	{index: 11, counter: "Deleted Bytes Generated"},
+	{index: 12, counter: "Deleted Files Cleaned Up"},
+	{index: 13, counter: "Deleted Files Generated"},
+	{index: 14, counter: "File Installs Retried"},
+	{index: 15, counter: "File Installs Succeeded"},
+	{index: 16, counter: "Total Files Received"},
+	{index: 17, counter: "RDC Bytes Received"},
+	{index: 18, counter: "RDC Compressed Size of Files Received"},
+	{index: 19, counter: "RDC Number of Files Received"},
+	{index: 20, counter: "RDC Size of Files Received"},
+	{index: 21, counter: "Size of Files Received"},
+	{index: 22, counter: "Staging Space In Use"},
+	{index: 23, counter: "Staging Bytes Cleaned Up"},
+	{index: 24, counter: "Staging Bytes Generated"},
+	{index: 25, counter: "Staging Files Cleaned Up"},
+	{index: 26, counter: "Staging Files Generated"},
+	{index: 27, counter: "Updates Dropped"},
+}
+
+func unmarshalPerflibDFSRFolder(obj *perflib.PerfObject, vs *[]PerflibDFSRFolder) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*PerflibDFSRFolder)(nil)).Elem(), perflibFieldsOfPerflibDFSRFolder)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]PerflibDFSRFolder, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.BandwidthSavingsUsingDFSReplicationTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BandwidthSavingsUsingDFSReplicationTotal)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.CompressedSizeOfFilesReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CompressedSizeOfFilesReceivedTotal)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.ConflictBytesCleanedupTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ConflictBytesCleanedupTotal)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.ConflictBytesGeneratedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ConflictBytesGeneratedTotal)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.ConflictFilesCleanedUpTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ConflictFilesCleanedUpTotal)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.ConflictFilesGeneratedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ConflictFilesGeneratedTotal)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.ConflictFolderCleanupsCompletedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ConflictFolderCleanupsCompletedTotal)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.ConflictSpaceInUse = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ConflictSpaceInUse)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.DeletedSpaceInUse = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DeletedSpaceInUse)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.DeletedBytesCleanedUpTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DeletedBytesCleanedUpTotal)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.DeletedBytesGeneratedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DeletedBytesGeneratedTotal)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.DeletedFilesCleanedUpTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DeletedFilesCleanedUpTotal)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.DeletedFilesGeneratedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DeletedFilesGeneratedTotal)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.FileInstallsRetriedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FileInstallsRetriedTotal)
+		}
+		if c := layout.counter(instance, 14); c != nil {
+			v.FileInstallsSucceededTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FileInstallsSucceededTotal)
+		}
+		if c := layout.counter(instance, 15); c != nil {
+			v.FilesReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FilesReceivedTotal)
+		}
+		if c := layout.counter(instance, 16); c != nil {
+			v.RDCBytesReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RDCBytesReceivedTotal)
+		}
+		if c := layout.counter(instance, 17); c != nil {
+			v.RDCCompressedSizeOfFilesReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RDCCompressedSizeOfFilesReceivedTotal)
+		}
+		if c := layout.counter(instance, 18); c != nil {
+			v.RDCNumberofFilesReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RDCNumberofFilesReceivedTotal)
+		}
+		if c := layout.counter(instance, 19); c != nil {
+			v.RDCSizeOfFilesReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RDCSizeOfFilesReceivedTotal)
+		}
+		if c := layout.counter(instance, 20); c != nil {
+			v.SizeOfFilesReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SizeOfFilesReceivedTotal)
+		}
+		if c := layout.counter(instance, 21); c != nil {
+			v.StagingSpaceInUse = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.StagingSpaceInUse)
+		}
+		if c := layout.counter(instance, 22); c != nil {
+			v.StagingBytesCleanedUpTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.StagingBytesCleanedUpTotal)
+		}
+		if c := layout.counter(instance, 23); c != nil {
+			v.StagingBytesGeneratedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.StagingBytesGeneratedTotal)
+		}
+		if c := layout.counter(instance, 24); c != nil {
+			v.StagingFilesCleanedUpTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.StagingFilesCleanedUpTotal)
+		}
+		if c := layout.counter(instance, 25); c != nil {
+			v.StagingFilesGeneratedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.StagingFilesGeneratedTotal)
+		}
+		if c := layout.counter(instance, 26); c != nil {
+			v.UpdatesDroppedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.UpdatesDroppedTotal)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibDFSRVolume = []perflibField{
+	{index: 1, counter: "Database Commits"},
+	{index: 2, counter: "Database Lookups"},
+	{index: 3, counter: "USN Journal Records Read"},
+	{index: 4, counter: "USN Journal Records Accepted"},
+	{index: 5, counter: "USN Journal Records Unread Percentage"},
+}
+
+func unmarshalPerflibDFSRVolume(obj *perflib.PerfObject, vs *[]PerflibDFSRVolume) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*PerflibDFSRVolume)(nil)).Elem(), perflibFieldsOfPerflibDFSRVolume)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]PerflibDFSRVolume, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.DatabaseCommitsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DatabaseCommitsTotal)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.DatabaseLookupsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DatabaseLookupsTotal)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.USNJournalRecordsReadTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.USNJournalRecordsReadTotal)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.USNJournalRecordsAcceptedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.USNJournalRecordsAcceptedTotal)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.USNJournalUnreadPercentage = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.USNJournalUnreadPercentage)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibSMTPServer = []perflibField{
+	{index: 1, counter: "Badmailed Messages (Bad Pickup File)"},
+	{index: 2, counter: "Badmailed Messages (General Failure)"},
+	{index: 3, counter: "Badmailed Messages (Hop Count Exceeded)"},
+	{index: 4, counter: "Badmailed Messages (NDR of DSN)"},
+	{index: 5, counter: "Badmailed Messages (No Recipients)"},
+	{index: 6, counter: "Badmailed Messages (Triggered via Event)"},
+	{index: 7, counter: "Bytes Sent Total"},
+	{index: 8, counter: "Bytes Received Total"},
+	{index: 9, counter: "Categorizer Queue Length"},
+	{index: 10, counter: "Total Connection Errors"},
+	{index: 11, counter: "Current Messages in Local Delivery"},
+	{index: 12, counter: "Directory Drops Total"},
+	{index: 13, counter: "DNS Queries Total"},
+	{index: 14, counter: "Total DSN Failures"},
+	{index: 15, counter: "ETRN Messages Total"},
+	{index: 16, counter: "Inbound Connections Current"},
+	{index: 17, counter: "Inbound Connections Total"},
+	{index: 18, counter: "Local Queue Length"},
+	{index: 19, counter: "Local Retry Queue Length"},
+	{index: 20, counter: "Number of MailFiles Open"},
+	{index: 21, counter: "Message Bytes Received Total"},
+	{index: 22, counter: "Message Bytes Sent Total"},
+	{index: 23, counter: "Message Delivery Retries"},
+	{index: 24, counter: "Message Send Retries"},
+	{index: 25, counter: "Messages Currently Undeliverable"},
+	{index: 26, counter: "Messages Delivered Total"},
+	{index: 27, counter: "Messages Pending Routing"},
+	{index: 28, counter: "Messages Received Total"},
+	{index: 29, counter: "Messages Refused for Address Objects"},
+	{index: 30, counter: "Messages Refused for Mail Objects"},
+	{index: 31, counter: "Messages Refused for Size"},
+	{index: 32, counter: "Messages Sent Total"},
+	{index: 33, counter: "Total messages submitted"},
+	{index: 34, counter: "NDRs Generated"},
+	{index: 35, counter: "Outbound Connections Current"},
+	{index: 36, counter: "Outbound Connections Refused"},
+	{index: 37, counter: "Outbound Connections Total"},
+	{index: 38, counter: "Number of QueueFiles Open"},
+	{index: 39, counter: "Pickup Directory Messages Retrieved Total"},
+	{index: 40, counter: "Remote Queue Length"},
+	{index: 41, counter: "Remote Retry Queue Length"},
+	{index: 42, counter: "Routing Table Lookups Total"},
+}
+
+func unmarshalPerflibSMTPServer(obj *perflib.PerfObject, vs *[]PerflibSMTPServer) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*PerflibSMTPServer)(nil)).Elem(), perflibFieldsOfPerflibSMTPServer)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]PerflibSMTPServer, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.BadmailedMessagesBadPickupFileTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BadmailedMessagesBadPickupFileTotal)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.BadmailedMessagesGeneralFailureTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BadmailedMessagesGeneralFailureTotal)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.BadmailedMessagesHopCountExceededTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BadmailedMessagesHopCountExceededTotal)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.BadmailedMessagesNDROfDSNTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BadmailedMessagesNDROfDSNTotal)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.BadmailedMessagesNoRecipientsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BadmailedMessagesNoRecipientsTotal)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.BadmailedMessagesTriggeredViaEventTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BadmailedMessagesTriggeredViaEventTotal)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.BytesSentTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BytesSentTotal)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.BytesReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BytesReceivedTotal)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.CategorizerQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CategorizerQueueLength)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.ConnectionErrorsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ConnectionErrorsTotal)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.CurrentMessagesInLocalDelivery = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CurrentMessagesInLocalDelivery)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.DirectoryDropsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DirectoryDropsTotal)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.DNSQueriesTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DNSQueriesTotal)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.DSNFailuresTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSNFailuresTotal)
+		}
+		if c := layout.counter(instance, 14); c != nil {
+			v.ETRNMessagesTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ETRNMessagesTotal)
+		}
+		if c := layout.counter(instance, 15); c != nil {
+			v.InboundConnectionsCurrent = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.InboundConnectionsCurrent)
+		}
+		if c := layout.counter(instance, 16); c != nil {
+			v.InboundConnectionsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.InboundConnectionsTotal)
+		}
+		if c := layout.counter(instance, 17); c != nil {
+			v.LocalQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LocalQueueLength)
+		}
+		if c := layout.counter(instance, 18); c != nil {
+			v.LocalRetryQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LocalRetryQueueLength)
+		}
+		if c := layout.counter(instance, 19); c != nil {
+			v.MailFilesOpen = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MailFilesOpen)
+		}
+		if c := layout.counter(instance, 20); c != nil {
+			v.MessageBytesReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MessageBytesReceivedTotal)
+		}
+		if c := layout.counter(instance, 21); c != nil {
+			v.MessageBytesSentTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MessageBytesSentTotal)
+		}
+		if c := layout.counter(instance, 22); c != nil {
+			v.MessageDeliveryRetriesTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MessageDeliveryRetriesTotal)
+		}
+		if c := layout.counter(instance, 23); c != nil {
+			v.MessageSendRetriesTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MessageSendRetriesTotal)
+		}
+		if c := layout.counter(instance, 24); c != nil {
+			v.MessagesCurrentlyUndeliverable = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MessagesCurrentlyUndeliverable)
+		}
+		if c := layout.counter(instance, 25); c != nil {
+			v.MessagesDeliveredTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MessagesDeliveredTotal)
+		}
+		if c := layout.counter(instance, 26); c != nil {
+			v.MessagesPendingRouting = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MessagesPendingRouting)
+		}
+		if c := layout.counter(instance, 27); c != nil {
+			v.MessagesReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MessagesReceivedTotal)
+		}
+		if c := layout.counter(instance, 28); c != nil {
+			v.MessagesRefusedForAddressObjectsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MessagesRefusedForAddressObjectsTotal)
+		}
+		if c := layout.counter(instance, 29); c != nil {
+			v.MessagesRefusedForMailObjectsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MessagesRefusedForMailObjectsTotal)
+		}
+		if c := layout.counter(instance, 30); c != nil {
+			v.MessagesRefusedForSizeTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MessagesRefusedForSizeTotal)
+		}
+		if c := layout.counter(instance, 31); c != nil {
+			v.MessagesSentTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MessagesSentTotal)
+		}
+		if c := layout.counter(instance, 32); c != nil {
+			v.MessagesSubmittedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MessagesSubmittedTotal)
+		}
+		if c := layout.counter(instance, 33); c != nil {
+			v.NDRsGeneratedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NDRsGeneratedTotal)
+		}
+		if c := layout.counter(instance, 34); c != nil {
+			v.OutboundConnectionsCurrent = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.OutboundConnectionsCurrent)
+		}
+		if c := layout.counter(instance, 35); c != nil {
+			v.OutboundConnectionsRefusedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.OutboundConnectionsRefusedTotal)
+		}
+		if c := layout.counter(instance, 36); c != nil {
+			v.OutboundConnectionsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.OutboundConnectionsTotal)
+		}
+		if c := layout.counter(instance, 37); c != nil {
+			v.QueueFilesOpen = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.QueueFilesOpen)
+		}
+		if c := layout.counter(instance, 38); c != nil {
+			v.PickupDirectoryMessagesRetrievedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PickupDirectoryMessagesRetrievedTotal)
+		}
+		if c := layout.counter(instance, 39); c != nil {
+			v.RemoteQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RemoteQueueLength)
+		}
+		if c := layout.counter(instance, 40); c != nil {
+			v.RemoteRetryQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RemoteRetryQueueLength)
+		}
+		if c := layout.counter(instance, 41); c != nil {
+			v.RoutingTableLookupsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RoutingTableLookupsTotal)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfDhcpPerf = []perflibField{
+	{index: 0, counter: "Packets Received/sec"},
+	{index: 1, counter: "Duplicates Dropped/sec"},
+	{index: 2, counter: "Packets Expired/sec"},
+	{index: 3, counter: "Active Queue Length"},
+	{index: 4, counter: "Conflict Check Queue Length"},
+	{index: 5, counter: "Discovers/sec"},
+	{index: 6, counter: "Offers/sec"},
+	{index: 7, counter: "Requests/sec"},
+	{index: 8, counter: "Informs/sec"},
+	{index: 9, counter: "Acks/sec"},
+	{index: 10, counter: "Nacks/sec"},
+	{index: 11, counter: "Declines/sec"},
+	{index: 12, counter: "Releases/sec"},
+	{index: 13, counter: "Denied due to match."},
+	{index: 14, counter: "Denied due to match."},
+	{index: 15, counter: "Offer Queue Length"},
+	{index: 16, counter: "Failover: BndUpd sent/sec.", optional: true},
+	{index: 17, counter: "Failover: BndUpd received/sec.", optional: true},
+	{index: 18, counter: "Failover: BndAck sent/sec.", optional: true},
+	{index: 19, counter: "Failover: BndAck received/sec.", optional: true},
+	{index: 20, counter: "Failover: BndUpd pending in outbound queue.", optional: true},
+	{index: 21, counter: "Failover: Transitions to COMMUNICATION-INTERRUPTED state.", optional: true},
+	{index: 22, counter: "Failover: Transitions to PARTNER-DOWN state.", optional: true},
+	{index: 23, counter: "Failover: Transitions to RECOVER state.", optional: true},
+	{index: 24, counter: "Failover: BndUpd Dropped.", optional: true},
+}
+
+func unmarshalDhcpPerf(obj *perflib.PerfObject, vs *[]dhcpPerf) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*dhcpPerf)(nil)).Elem(), perflibFieldsOfDhcpPerf)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]dhcpPerf, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.PacketsReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PacketsReceivedTotal)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.DuplicatesDroppedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DuplicatesDroppedTotal)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.PacketsExpiredTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PacketsExpiredTotal)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.ActiveQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ActiveQueueLength)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.ConflictCheckQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ConflictCheckQueueLength)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.DiscoversTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DiscoversTotal)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.OffersTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.OffersTotal)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.RequestsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RequestsTotal)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.InformsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.InformsTotal)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.AcksTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AcksTotal)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.NacksTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NacksTotal)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.DeclinesTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DeclinesTotal)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.ReleasesTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ReleasesTotal)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.DeniedDueToMatch = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DeniedDueToMatch)
+		}
+		if c := layout.counter(instance, 14); c != nil {
+			v.DeniedDueToNonMatch = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DeniedDueToNonMatch)
+		}
+		if c := layout.counter(instance, 15); c != nil {
+			v.OfferQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.OfferQueueLength)
+		}
+		if c := layout.counter(instance, 16); c != nil {
+			v.FailoverBndupdSentTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FailoverBndupdSentTotal)
+		}
+		if c := layout.counter(instance, 17); c != nil {
+			v.FailoverBndupdReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FailoverBndupdReceivedTotal)
+		}
+		if c := layout.counter(instance, 18); c != nil {
+			v.FailoverBndackSentTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FailoverBndackSentTotal)
+		}
+		if c := layout.counter(instance, 19); c != nil {
+			v.FailoverBndackReceivedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FailoverBndackReceivedTotal)
+		}
+		if c := layout.counter(instance, 20); c != nil {
+			v.FailoverBndupdPendingOutboundQueue = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FailoverBndupdPendingOutboundQueue)
+		}
+		if c := layout.counter(instance, 21); c != nil {
+			v.FailoverTransitionsCommunicationinterruptedState = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FailoverTransitionsCommunicationinterruptedState)
+		}
+		if c := layout.counter(instance, 22); c != nil {
+			v.FailoverTransitionsPartnerdownState = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FailoverTransitionsPartnerdownState)
+		}
+		if c := layout.counter(instance, 23); c != nil {
+			v.FailoverTransitionsRecoverState = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FailoverTransitionsRecoverState)
+		}
+		if c := layout.counter(instance, 24); c != nil {
+			v.FailoverBndupdDropped = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FailoverBndupdDropped)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfDirectoryServices = []perflibField{
+	{index: 1, counter: "AB ANR/sec"},
+	{index: 2, counter: "AB Browses/sec"},
+	{index: 3, counter: "AB Client Sessions"},
+	{index: 4, counter: "AB Matches/sec"},
+	{index: 5, counter: "AB Property Reads/sec"},
+	{index: 6, counter: "AB Proxy Lookups/sec"},
+	{index: 7, counter: "AB Searches/sec"},
+	{index: 8, counter: "Approximate highest DNT"},
+	{index: 9, counter: "ATQ Estimated Queue Delay"},
+	{index: 10, counter: "ATQ Outstanding Queued Requests"},
+	{index: 11, counter: "ATQ Request Latency"},
+	{index: 12, counter: "ATQ Threads LDAP"},
+	{index: 13, counter: "ATQ Threads Other"},
+	{index: 14, counter: "Base searches/sec"},
+	{index: 15, counter: "Database adds/sec"},
+	{index: 16, counter: "Database deletes/sec"},
+	{index: 17, counter: "Database modifys/sec"},
+	{index: 18, counter: "Database recycles/sec"},
+	{index: 19, counter: "Digest Binds/sec"},
+	{index: 20, counter: "DRA Highest USN Committed (High part)"},
+	{index: 21, counter: "DRA Highest USN Committed (Low part)"},
+	{index: 22, counter: "DRA Highest USN Issued (High part)"},
+	{index: 23, counter: "DRA Highest USN Issued (Low part)"},
+	{index: 24, counter: "DRA Inbound Bytes Compressed (Between Sites, After Compression)/sec"},
+	{index: 25, counter: "DRA Inbound Bytes Not Compressed (Within Site)/sec"},
+	{index: 26, counter: "DRA Inbound Full Sync Objects Remaining"},
+	{index: 27, counter: "DRA Inbound Link Value Updates Remaining in Packet"},
+	{index: 28, counter: "DRA Inbound Objects Applied/sec"},
+	{index: 29, counter: "DRA Inbound Objects Filtered/sec"},
+	{index: 30, counter: "DRA Inbound Properties Applied/sec"},
+	{index: 31, counter: "DRA Inbound Properties Filtered/sec"},
+	{index: 32, counter: "DRA Outbound Bytes Compressed (Between Sites, After Compression)/sec"},
+	{index: 33, counter: "DRA Outbound Bytes Not Compressed (Within Site)/sec"},
+	{index: 34, counter: "DRA Pending Replication Operations"},
+	{index: 35, counter: "DRA Pending Replication Synchronizations"},
+	{index: 36, counter: "DRA Sync Failures on Schema Mismatch"},
+	{index: 37, counter: "DRA Sync Requests Made"},
+	{index: 38, counter: "DRA Sync Requests Successful"},
+	{index: 39, counter: "DS Client Binds/sec"},
+	{index: 40, counter: "DS Client Name Translations/sec"},
+	{index: 41, counter: "DS Monitor List Size"},
+	{index: 42, counter: "DS Name Cache hit rate"},
+	{index: 43, counter: "DS Name Cache hit rate", value: "base"},
+	{index: 44, counter: "DS Notify Queue Size"},
+	{index: 45, counter: "DS % Reads from DRA"},
+	{index: 46, counter: "DS % Reads from KCC"},
+	{index: 47, counter: "DS % Reads from LSA"},
+	{index: 48, counter: "DS % Reads from NSPI"},
+	{index: 49, counter: "DS % Reads from NTDSAPI"},
+	{index: 50, counter: "DS % Reads from SAM"},
+	{index: 51, counter: "DS % Reads Other"},
+	{index: 52, counter: "DS % Searches from DRA"},
+	{index: 53, counter: "DS % Searches from KCC"},
+	{index: 54, counter: "DS % Searches from LDAP"},
+	{index: 55, counter: "DS % Searches from LSA"},
+	{index: 56, counter: "DS % Searches from NSPI"},
+	{index: 57, counter: "DS % Searches from NTDSAPI"},
+	{index: 58, counter: "DS % Searches from SAM"},
+	{index: 59, counter: "DS % Searches Other"},
+	{index: 60, counter: "DS % Writes from DRA"},
+	{index: 61, counter: "DS % Writes from KCC"},
+	{index: 62, counter: "DS % Writes from LDAP"},
+	{index: 63, counter: "DS % Writes from LSA"},
+	{index: 64, counter: "DS % Writes from NSPI"},
+	{index: 65, counter: "DS % Writes from NTDSAPI"},
+	{index: 66, counter: "DS % Writes from SAM"},
+	{index: 67, counter: "DS % Writes Other"},
+	{index: 68, counter: "DS Search sub-operations/sec"},
+	{index: 69, counter: "DS Security Descriptor Propagations Events"},
+	{index: 70, counter: "DS Security Descriptor Propagator Average Exclusion Time"},
+	{index: 71, counter: "DS Security Descriptor Propagator Runtime Queue"},
+	{index: 72, counter: "DS Security Descriptor sub-operations/sec"},
+	{index: 73, counter: "DS Server Binds/sec"},
+	{index: 74, counter: "DS Server Name Translations/sec"},
+	{index: 75, counter: "DS Threads in Use"},
+	{index: 76, counter: "External Binds/sec"},
+	{index: 77, counter: "Fast Binds/sec"},
+	{index: 78, counter: "LDAP Active Threads"},
+	{index: 79, counter: "LDAP Bind Time"},
+	{index: 80, counter: "LDAP Closed Connections/sec"},
+	{index: 81, counter: "LDAP New Connections/sec"},
+	{index: 82, counter: "LDAP New SSL Connections/sec"},
+	{index: 83, counter: "LDAP Searches/sec"},
+	{index: 84, counter: "LDAP Successful Binds/sec"},
+	{index: 85, counter: "LDAP UDP operations/sec"},
+	{index: 86, counter: "LDAP Writes/sec"},
+	{index: 87, counter: "Link Values Cleaned/sec"},
+	{index: 88, counter: "Negotiated Binds/sec"},
+	{index: 89, counter: "NTLM Binds/sec"},
+	{index: 90, counter: "Onelevel searches/sec"},
+	{index: 91, counter: "Phantoms Cleaned/sec"},
+	{index: 92, counter: "Phantoms Visited/sec"},
+	{index: 93, counter: "SAM Account Group Evaluation Latency"},
+	{index: 94, counter: "SAM Display Information Queries/sec"},
+	{index: 95, counter: "SAM Domain Local Group Membership Evaluations/sec"},
+	{index: 96, counter: "SAM Enumerations/sec"},
+	{index: 97, counter: "SAM GC Evaluations/sec"},
+	{index: 98, counter: "SAM Global Group Membership Evaluations/sec"},
+	{index: 99, counter: "SAM Machine Creation Attempts/sec"},
+	{index: 100, counter: "SAM Membership Changes/sec"},
+	{index: 101, counter: "SAM Non-Transitive Membership Evaluations/sec"},
+	{index: 102, counter: "SAM Password Changes/sec"},
+	{index: 103, counter: "SAM Resource Group Evaluation Latency"},
+	{index: 104, counter: "SAM Successful Computer Creations/sec: Includes all requests"},
+	{index: 105, counter: "SAM Successful User Creations/sec"},
+	{index: 106, counter: "SAM Transitive Membership Evaluations/sec"},
+	{index: 107, counter: "SAM Universal Group Membership Evaluations/sec"},
+	{index: 108, counter: "SAM User Creation Attempts/sec"},
+	{index: 109, counter: "Simple Binds/sec"},
+	{index: 110, counter: "Subtree searches/sec"},
+	{index: 111, counter: "Tombstones Garbage Collected/sec"},
+	{index: 112, counter: "Tombstones Visited/sec"},
+}
+
+func unmarshalDirectoryServices(obj *perflib.PerfObject, vs *[]directoryServices) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*directoryServices)(nil)).Elem(), perflibFieldsOfDirectoryServices)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]directoryServices, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.ABANRPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ABANRPersec)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.ABBrowsesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ABBrowsesPersec)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.ABClientSessions = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ABClientSessions)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.ABMatchesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ABMatchesPersec)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.ABPropertyReadsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ABPropertyReadsPersec)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.ABProxyLookupsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ABProxyLookupsPersec)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.ABSearchesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ABSearchesPersec)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.ApproximatehighestDNT = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ApproximatehighestDNT)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.ATQEstimatedQueueDelay = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ATQEstimatedQueueDelay)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.ATQOutstandingQueuedRequests = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ATQOutstandingQueuedRequests)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.ATQRequestLatency = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ATQRequestLatency)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.ATQThreadsLDAP = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ATQThreadsLDAP)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.ATQThreadsOther = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ATQThreadsOther)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.BasesearchesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BasesearchesPersec)
+		}
+		if c := layout.counter(instance, 14); c != nil {
+			v.DatabaseaddsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DatabaseaddsPersec)
+		}
+		if c := layout.counter(instance, 15); c != nil {
+			v.DatabasedeletesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DatabasedeletesPersec)
+		}
+		if c := layout.counter(instance, 16); c != nil {
+			v.DatabasemodifysPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DatabasemodifysPersec)
+		}
+		if c := layout.counter(instance, 17); c != nil {
+			v.DatabaserecyclesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DatabaserecyclesPersec)
+		}
+		if c := layout.counter(instance, 18); c != nil {
+			v.DigestBindsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DigestBindsPersec)
+		}
+		if c := layout.counter(instance, 19); c != nil {
+			v.DRAHighestUSNCommittedHighpart = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRAHighestUSNCommittedHighpart)
+		}
+		if c := layout.counter(instance, 20); c != nil {
+			v.DRAHighestUSNCommittedLowpart = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRAHighestUSNCommittedLowpart)
+		}
+		if c := layout.counter(instance, 21); c != nil {
+			v.DRAHighestUSNIssuedHighpart = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRAHighestUSNIssuedHighpart)
+		}
+		if c := layout.counter(instance, 22); c != nil {
+			v.DRAHighestUSNIssuedLowpart = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRAHighestUSNIssuedLowpart)
+		}
+		if c := layout.counter(instance, 23); c != nil {
+			v.DRAInboundBytesCompressedBetweenSitesAfterCompressionPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRAInboundBytesCompressedBetweenSitesAfterCompressionPersec)
+		}
+		if c := layout.counter(instance, 24); c != nil {
+			v.DRAInboundBytesNotCompressedWithinSitePersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRAInboundBytesNotCompressedWithinSitePersec)
+		}
+		if c := layout.counter(instance, 25); c != nil {
+			v.DRAInboundFullSyncObjectsRemaining = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRAInboundFullSyncObjectsRemaining)
+		}
+		if c := layout.counter(instance, 26); c != nil {
+			v.DRAInboundLinkValueUpdatesRemaininginPacket = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRAInboundLinkValueUpdatesRemaininginPacket)
+		}
+		if c := layout.counter(instance, 27); c != nil {
+			v.DRAInboundObjectsAppliedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRAInboundObjectsAppliedPersec)
+		}
+		if c := layout.counter(instance, 28); c != nil {
+			v.DRAInboundObjectsFilteredPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRAInboundObjectsFilteredPersec)
+		}
+		if c := layout.counter(instance, 29); c != nil {
+			v.DRAInboundPropertiesAppliedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRAInboundPropertiesAppliedPersec)
+		}
+		if c := layout.counter(instance, 30); c != nil {
+			v.DRAInboundPropertiesFilteredPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRAInboundPropertiesFilteredPersec)
+		}
+		if c := layout.counter(instance, 31); c != nil {
+			v.DRAOutboundBytesCompressedBetweenSitesAfterCompressionPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRAOutboundBytesCompressedBetweenSitesAfterCompressionPersec)
+		}
+		if c := layout.counter(instance, 32); c != nil {
+			v.DRAOutboundBytesNotCompressedWithinSitePersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRAOutboundBytesNotCompressedWithinSitePersec)
+		}
+		if c := layout.counter(instance, 33); c != nil {
+			v.DRAPendingReplicationOperations = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRAPendingReplicationOperations)
+		}
+		if c := layout.counter(instance, 34); c != nil {
+			v.DRAPendingReplicationSynchronizations = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRAPendingReplicationSynchronizations)
+		}
+		if c := layout.counter(instance, 35); c != nil {
+			v.DRASyncFailuresonSchemaMismatch = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRASyncFailuresonSchemaMismatch)
+		}
+		if c := layout.counter(instance, 36); c != nil {
+			v.DRASyncRequestsMade = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRASyncRequestsMade)
+		}
+		if c := layout.counter(instance, 37); c != nil {
+			v.DRASyncRequestsSuccessful = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DRASyncRequestsSuccessful)
+		}
+		if c := layout.counter(instance, 38); c != nil {
+			v.DSClientBindsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSClientBindsPersec)
+		}
+		if c := layout.counter(instance, 39); c != nil {
+			v.DSClientNameTranslationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSClientNameTranslationsPersec)
+		}
+		if c := layout.counter(instance, 40); c != nil {
+			v.DSMonitorListSize = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSMonitorListSize)
+		}
+		if c := layout.counter(instance, 41); c != nil {
+			v.DSNameCachehitrate = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSNameCachehitrate)
+		}
+		if c, b := layout.counter(instance, 42), layout.base(instance, 42); c != nil && b != nil {
+			v.DSNameCachehitrateBase = float64(b.Value)
+		} else {
+			present.setAbsent(&v.DSNameCachehitrateBase)
+		}
+		if c := layout.counter(instance, 43); c != nil {
+			v.DSNotifyQueueSize = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSNotifyQueueSize)
+		}
+		if c := layout.counter(instance, 44); c != nil {
+			v.DSPercentReadsfromDRA = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentReadsfromDRA)
+		}
+		if c := layout.counter(instance, 45); c != nil {
+			v.DSPercentReadsfromKCC = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentReadsfromKCC)
+		}
+		if c := layout.counter(instance, 46); c != nil {
+			v.DSPercentReadsfromLSA = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentReadsfromLSA)
+		}
+		if c := layout.counter(instance, 47); c != nil {
+			v.DSPercentReadsfromNSPI = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentReadsfromNSPI)
+		}
+		if c := layout.counter(instance, 48); c != nil {
+			v.DSPercentReadsfromNTDSAPI = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentReadsfromNTDSAPI)
+		}
+		if c := layout.counter(instance, 49); c != nil {
+			v.DSPercentReadsfromSAM = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentReadsfromSAM)
+		}
+		if c := layout.counter(instance, 50); c != nil {
+			v.DSPercentReadsOther = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentReadsOther)
+		}
+		if c := layout.counter(instance, 51); c != nil {
+			v.DSPercentSearchesfromDRA = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentSearchesfromDRA)
+		}
+		if c := layout.counter(instance, 52); c != nil {
+			v.DSPercentSearchesfromKCC = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentSearchesfromKCC)
+		}
+		if c := layout.counter(instance, 53); c != nil {
+			v.DSPercentSearchesfromLDAP = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentSearchesfromLDAP)
+		}
+		if c := layout.counter(instance, 54); c != nil {
+			v.DSPercentSearchesfromLSA = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentSearchesfromLSA)
+		}
+		if c := layout.counter(instance, 55); c != nil {
+			v.DSPercentSearchesfromNSPI = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentSearchesfromNSPI)
+		}
+		if c := layout.counter(instance, 56); c != nil {
+			v.DSPercentSearchesfromNTDSAPI = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentSearchesfromNTDSAPI)
+		}
+		if c := layout.counter(instance, 57); c != nil {
+			v.DSPercentSearchesfromSAM = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentSearchesfromSAM)
+		}
+		if c := layout.counter(instance, 58); c != nil {
+			v.DSPercentSearchesOther = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentSearchesOther)
+		}
+		if c := layout.counter(instance, 59); c != nil {
+			v.DSPercentWritesfromDRA = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentWritesfromDRA)
+		}
+		if c := layout.counter(instance, 60); c != nil {
+			v.DSPercentWritesfromKCC = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentWritesfromKCC)
+		}
+		if c := layout.counter(instance, 61); c != nil {
+			v.DSPercentWritesfromLDAP = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentWritesfromLDAP)
+		}
+		if c := layout.counter(instance, 62); c != nil {
+			v.DSPercentWritesfromLSA = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentWritesfromLSA)
+		}
+		if c := layout.counter(instance, 63); c != nil {
+			v.DSPercentWritesfromNSPI = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentWritesfromNSPI)
+		}
+		if c := layout.counter(instance, 64); c != nil {
+			v.DSPercentWritesfromNTDSAPI = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentWritesfromNTDSAPI)
+		}
+		if c := layout.counter(instance, 65); c != nil {
+			v.DSPercentWritesfromSAM = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentWritesfromSAM)
+		}
+		if c := layout.counter(instance, 66); c != nil {
+			v.DSPercentWritesOther = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSPercentWritesOther)
+		}
+		if c := layout.counter(instance, 67); c != nil {
+			v.DSSearchsuboperationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSSearchsuboperationsPersec)
+		}
+		if c := layout.counter(instance, 68); c != nil {
+			v.DSSecurityDescriptorPropagationsEvents = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSSecurityDescriptorPropagationsEvents)
+		}
+		if c := layout.counter(instance, 69); c != nil {
+			v.DSSecurityDescriptorPropagatorAverageExclusionTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSSecurityDescriptorPropagatorAverageExclusionTime)
+		}
+		if c := layout.counter(instance, 70); c != nil {
+			v.DSSecurityDescriptorPropagatorRuntimeQueue = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSSecurityDescriptorPropagatorRuntimeQueue)
+		}
+		if c := layout.counter(instance, 71); c != nil {
+			v.DSSecurityDescriptorsuboperationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSSecurityDescriptorsuboperationsPersec)
+		}
+		if c := layout.counter(instance, 72); c != nil {
+			v.DSServerBindsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSServerBindsPersec)
+		}
+		if c := layout.counter(instance, 73); c != nil {
+			v.DSServerNameTranslationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSServerNameTranslationsPersec)
+		}
+		if c := layout.counter(instance, 74); c != nil {
+			v.DSThreadsinUse = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DSThreadsinUse)
+		}
+		if c := layout.counter(instance, 75); c != nil {
+			v.ExternalBindsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ExternalBindsPersec)
+		}
+		if c := layout.counter(instance, 76); c != nil {
+			v.FastBindsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FastBindsPersec)
+		}
+		if c := layout.counter(instance, 77); c != nil {
+			v.LDAPActiveThreads = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LDAPActiveThreads)
+		}
+		if c := layout.counter(instance, 78); c != nil {
+			v.LDAPBindTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LDAPBindTime)
+		}
+		if c := layout.counter(instance, 79); c != nil {
+			v.LDAPClosedConnectionsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LDAPClosedConnectionsPersec)
+		}
+		if c := layout.counter(instance, 80); c != nil {
+			v.LDAPNewConnectionsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LDAPNewConnectionsPersec)
+		}
+		if c := layout.counter(instance, 81); c != nil {
+			v.LDAPNewSSLConnectionsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LDAPNewSSLConnectionsPersec)
+		}
+		if c := layout.counter(instance, 82); c != nil {
+			v.LDAPSearchesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LDAPSearchesPersec)
+		}
+		if c := layout.counter(instance, 83); c != nil {
+			v.LDAPSuccessfulBindsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LDAPSuccessfulBindsPersec)
+		}
+		if c := layout.counter(instance, 84); c != nil {
+			v.LDAPUDPoperationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LDAPUDPoperationsPersec)
+		}
+		if c := layout.counter(instance, 85); c != nil {
+			v.LDAPWritesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LDAPWritesPersec)
+		}
+		if c := layout.counter(instance, 86); c != nil {
+			v.LinkValuesCleanedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LinkValuesCleanedPersec)
+		}
+		if c := layout.counter(instance, 87); c != nil {
+			v.NegotiatedBindsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NegotiatedBindsPersec)
+		}
+		if c := layout.counter(instance, 88); c != nil {
+			v.NTLMBindsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NTLMBindsPersec)
+		}
+		if c := layout.counter(instance, 89); c != nil {
+			v.OnelevelsearchesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.OnelevelsearchesPersec)
+		}
+		if c := layout.counter(instance, 90); c != nil {
+			v.PhantomsCleanedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PhantomsCleanedPersec)
+		}
+		if c := layout.counter(instance, 91); c != nil {
+			v.PhantomsVisitedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PhantomsVisitedPersec)
+		}
+		if c := layout.counter(instance, 92); c != nil {
+			v.SAMAccountGroupEvaluationLatency = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SAMAccountGroupEvaluationLatency)
+		}
+		if c := layout.counter(instance, 93); c != nil {
+			v.SAMDisplayInformationQueriesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SAMDisplayInformationQueriesPersec)
+		}
+		if c := layout.counter(instance, 94); c != nil {
+			v.SAMDomainLocalGroupMembershipEvaluationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SAMDomainLocalGroupMembershipEvaluationsPersec)
+		}
+		if c := layout.counter(instance, 95); c != nil {
+			v.SAMEnumerationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SAMEnumerationsPersec)
+		}
+		if c := layout.counter(instance, 96); c != nil {
+			v.SAMGCEvaluationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SAMGCEvaluationsPersec)
+		}
+		if c := layout.counter(instance, 97); c != nil {
+			v.SAMGlobalGroupMembershipEvaluationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SAMGlobalGroupMembershipEvaluationsPersec)
+		}
+		if c := layout.counter(instance, 98); c != nil {
+			v.SAMMachineCreationAttemptsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SAMMachineCreationAttemptsPersec)
+		}
+		if c := layout.counter(instance, 99); c != nil {
+			v.SAMMembershipChangesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SAMMembershipChangesPersec)
+		}
+		if c := layout.counter(instance, 100); c != nil {
+			v.SAMNonTransitiveMembershipEvaluationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SAMNonTransitiveMembershipEvaluationsPersec)
+		}
+		if c := layout.counter(instance, 101); c != nil {
+			v.SAMPasswordChangesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SAMPasswordChangesPersec)
+		}
+		if c := layout.counter(instance, 102); c != nil {
+			v.SAMResourceGroupEvaluationLatency = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SAMResourceGroupEvaluationLatency)
+		}
+		if c := layout.counter(instance, 103); c != nil {
+			v.SAMSuccessfulComputerCreationsPersecIncludesallrequests = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SAMSuccessfulComputerCreationsPersecIncludesallrequests)
+		}
+		if c := layout.counter(instance, 104); c != nil {
+			v.SAMSuccessfulUserCreationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SAMSuccessfulUserCreationsPersec)
+		}
+		if c := layout.counter(instance, 105); c != nil {
+			v.SAMTransitiveMembershipEvaluationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SAMTransitiveMembershipEvaluationsPersec)
+		}
+		if c := layout.counter(instance, 106); c != nil {
+			v.SAMUniversalGroupMembershipEvaluationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SAMUniversalGroupMembershipEvaluationsPersec)
+		}
+		if c := layout.counter(instance, 107); c != nil {
+			v.SAMUserCreationAttemptsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SAMUserCreationAttemptsPersec)
+		}
+		if c := layout.counter(instance, 108); c != nil {
+			v.SimpleBindsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SimpleBindsPersec)
+		}
+		if c := layout.counter(instance, 109); c != nil {
+			v.SubtreesearchesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SubtreesearchesPersec)
+		}
+		if c := layout.counter(instance, 110); c != nil {
+			v.TombstonesGarbageCollectedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TombstonesGarbageCollectedPersec)
+		}
+		if c := layout.counter(instance, 111); c != nil {
+			v.TombstonesVisitedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TombstonesVisitedPersec)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfDnsPerflib = []perflibField{
+	{index: 0, counter: "AXFR Request Received"},
+	{index: 1, counter: "AXFR Request Sent"},
+	{index: 2, counter: "AXFR Response Received"},
+	{index: 3, counter: "AXFR Success Received"},
+	{index: 4, counter: "AXFR Success Sent"},
+	{index: 5, counter: "Caching Memory"},
+	{index: 6, counter: "Database Node Memory"},
+	{index: 7, counter: "Dynamic Update NoOperation"},
+	{index: 8, counter: "Dynamic Update Queued"},
+	{index: 9, counter: "Dynamic Update Rejected"},
+	{index: 10, counter: "Dynamic Update TimeOuts"},
+	{index: 11, counter: "Dynamic Update Written to Database"},
+	{index: 12, counter: "IXFR Request Received"},
+	{index: 13, counter: "IXFR Request Sent"},
+	{index: 14, counter: "IXFR Response Received"},
+	{index: 15, counter: "IXFR Success Sent"},
+	{index: 16, counter: "IXFR TCP Success Received"},
+	{index: 17, counter: "Nbstat Memory"},
+	{index: 18, counter: "Notify Received"},
+	{index: 19, counter: "Notify Sent"},
+	{index: 20, counter: "Record Flow Memory"},
+	{index: 21, counter: "Recursive Queries"},
+	{index: 22, counter: "Recursive Query Failure"},
+	{index: 23, counter: "Recursive Send TimeOuts"},
+	{index: 24, counter: "Secure Update Failure"},
+	{index: 25, counter: "Secure Update Received"},
+	{index: 26, counter: "TCP Message Memory"},
+	{index: 27, counter: "TCP Query Received"},
+	{index: 28, counter: "TCP Response Sent"},
+	{index: 29, counter: "UDP Message Memory"},
+	{index: 30, counter: "UDP Query Received"},
+	{index: 31, counter: "UDP Response Sent"},
+	{index: 32, counter: "Unmatched Responses Received"},
+	{index: 33, counter: "WINS Lookup Received"},
+	{index: 34, counter: "WINS Response Sent"},
+	{index: 35, counter: "WINS Reverse Lookup Received"},
+	{index: 36, counter: "WINS Reverse Response Sent"},
+	{index: 37, counter: "Zone Transfer Failure"},
+	{index: 38, counter: "Zone Transfer SOA Request Sent"},
+}
+
+func unmarshalDnsPerflib(obj *perflib.PerfObject, vs *[]dnsPerflib) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*dnsPerflib)(nil)).Elem(), perflibFieldsOfDnsPerflib)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]dnsPerflib, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.AXFRRequestReceived = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AXFRRequestReceived)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.AXFRRequestSent = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AXFRRequestSent)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.AXFRResponseReceived = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AXFRResponseReceived)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.AXFRSuccessReceived = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AXFRSuccessReceived)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.AXFRSuccessSent = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AXFRSuccessSent)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.CachingMemory = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CachingMemory)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.DatabaseNodeMemory = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DatabaseNodeMemory)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.DynamicUpdateNoOperation = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DynamicUpdateNoOperation)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.DynamicUpdateQueued = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DynamicUpdateQueued)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.DynamicUpdateRejected = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DynamicUpdateRejected)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.DynamicUpdateTimeOuts = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DynamicUpdateTimeOuts)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.DynamicUpdateWrittentoDatabase = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DynamicUpdateWrittentoDatabase)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.IXFRRequestReceived = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IXFRRequestReceived)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.IXFRRequestSent = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IXFRRequestSent)
+		}
+		if c := layout.counter(instance, 14); c != nil {
+			v.IXFRResponseReceived = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IXFRResponseReceived)
+		}
+		if c := layout.counter(instance, 15); c != nil {
+			v.IXFRSuccessSent = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IXFRSuccessSent)
+		}
+		if c := layout.counter(instance, 16); c != nil {
+			v.IXFRTCPSuccessReceived = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IXFRTCPSuccessReceived)
+		}
+		if c := layout.counter(instance, 17); c != nil {
+			v.NbstatMemory = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NbstatMemory)
+		}
+		if c := layout.counter(instance, 18); c != nil {
+			v.NotifyReceived = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NotifyReceived)
+		}
+		if c := layout.counter(instance, 19); c != nil {
+			v.NotifySent = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NotifySent)
+		}
+		if c := layout.counter(instance, 20); c != nil {
+			v.RecordFlowMemory = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RecordFlowMemory)
+		}
+		if c := layout.counter(instance, 21); c != nil {
+			v.RecursiveQueries = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RecursiveQueries)
+		}
+		if c := layout.counter(instance, 22); c != nil {
+			v.RecursiveQueryFailure = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RecursiveQueryFailure)
+		}
+		if c := layout.counter(instance, 23); c != nil {
+			v.RecursiveSendTimeOuts = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RecursiveSendTimeOuts)
+		}
+		if c := layout.counter(instance, 24); c != nil {
+			v.SecureUpdateFailure = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SecureUpdateFailure)
+		}
+		if c := layout.counter(instance, 25); c != nil {
+			v.SecureUpdateReceived = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SecureUpdateReceived)
+		}
+		if c := layout.counter(instance, 26); c != nil {
+			v.TCPMessageMemory = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TCPMessageMemory)
+		}
+		if c := layout.counter(instance, 27); c != nil {
+			v.TCPQueryReceived = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TCPQueryReceived)
+		}
+		if c := layout.counter(instance, 28); c != nil {
+			v.TCPResponseSent = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TCPResponseSent)
+		}
+		if c := layout.counter(instance, 29); c != nil {
+			v.UDPMessageMemory = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.UDPMessageMemory)
+		}
+		if c := layout.counter(instance, 30); c != nil {
+			v.UDPQueryReceived = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.UDPQueryReceived)
+		}
+		if c := layout.counter(instance, 31); c != nil {
+			v.UDPResponseSent = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.UDPResponseSent)
+		}
+		if c := layout.counter(instance, 32); c != nil {
+			v.UnmatchedResponsesReceived = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.UnmatchedResponsesReceived)
+		}
+		if c := layout.counter(instance, 33); c != nil {
+			v.WINSLookupReceived = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WINSLookupReceived)
+		}
+		if c := layout.counter(instance, 34); c != nil {
+			v.WINSResponseSent = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WINSResponseSent)
+		}
+		if c := layout.counter(instance, 35); c != nil {
+			v.WINSReverseLookupReceived = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WINSReverseLookupReceived)
+		}
+		if c := layout.counter(instance, 36); c != nil {
+			v.WINSReverseResponseSent = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WINSReverseResponseSent)
+		}
+		if c := layout.counter(instance, 37); c != nil {
+			v.ZoneTransferFailure = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ZoneTransferFailure)
+		}
+		if c := layout.counter(instance, 38); c != nil {
+			v.ZoneTransferSOARequestSent = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ZoneTransferSOARequestSent)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfLogicalDisk = []perflibField{
+	{index: 1, counter: "Current Disk Queue Length"},
+	{index: 2, counter: "Disk Read Bytes/sec"},
+	{index: 3, counter: "Disk Reads/sec"},
+	{index: 4, counter: "Disk Write Bytes/sec"},
+	{index: 5, counter: "Disk Writes/sec"},
+	{index: 6, counter: "% Disk Read Time"},
+	{index: 7, counter: "% Disk Write Time"},
+	{index: 8, counter: "% Free Space"},
+	{index: 9, counter: "% Free Space", value: "base"},
+	{index: 10, counter: "% Idle Time"},
+	{index: 11, counter: "Split IO/Sec"},
+	{index: 12, counter: "Avg. Disk sec/Read"},
+	{index: 13, counter: "Avg. Disk sec/Write"},
+	{index: 14, counter: "Avg. Disk sec/Transfer"},
+}
+
+func unmarshalLogicalDisk(obj *perflib.PerfObject, vs *[]logicalDisk) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*logicalDisk)(nil)).Elem(), perflibFieldsOfLogicalDisk)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]logicalDisk, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.CurrentDiskQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CurrentDiskQueueLength)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.DiskReadBytesPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DiskReadBytesPerSec)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.DiskReadsPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DiskReadsPerSec)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.DiskWriteBytesPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DiskWriteBytesPerSec)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.DiskWritesPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DiskWritesPerSec)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.PercentDiskReadTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentDiskReadTime)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.PercentDiskWriteTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentDiskWriteTime)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.PercentFreeSpace = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentFreeSpace)
+		}
+		if c, b := layout.counter(instance, 8), layout.base(instance, 8); c != nil && b != nil {
+			v.PercentFreeSpace_Base = float64(b.Value)
+		} else {
+			present.setAbsent(&v.PercentFreeSpace_Base)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.PercentIdleTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentIdleTime)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.SplitIOPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SplitIOPerSec)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.AvgDiskSecPerRead = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AvgDiskSecPerRead)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.AvgDiskSecPerWrite = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AvgDiskSecPerWrite)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.AvgDiskSecPerTransfer = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AvgDiskSecPerTransfer)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfMemory = []perflibField{
+	{index: 0, counter: "Available Bytes"},
+	{index: 1, counter: "Available KBytes"},
+	{index: 2, counter: "Available MBytes"},
+	{index: 3, counter: "Cache Bytes"},
+	{index: 4, counter: "Cache Bytes Peak"},
+	{index: 5, counter: "Cache Faults/sec"},
+	{index: 6, counter: "Commit Limit"},
+	{index: 7, counter: "Committed Bytes"},
+	{index: 8, counter: "Demand Zero Faults/sec"},
+	{index: 9, counter: "Free & Zero Page List Bytes"},
+	{index: 10, counter: "Free System Page Table Entries"},
+	{index: 11, counter: "Modified Page List Bytes"},
+	{index: 12, counter: "Page Faults/sec"},
+	{index: 13, counter: "Page Reads/sec"},
+	{index: 14, counter: "Pages Input/sec"},
+	{index: 15, counter: "Pages Output/sec"},
+	{index: 16, counter: "Pages/sec"},
+	{index: 17, counter: "Page Writes/sec"},
+	{index: 18, counter: "Pool Nonpaged Allocs"},
+	{index: 19, counter: "Pool Nonpaged Bytes"},
+	{index: 20, counter: "Pool Paged Allocs"},
+	{index: 21, counter: "Pool Paged Bytes"},
+	{index: 22, counter: "Pool Paged Resident Bytes"},
+	{index: 23, counter: "Standby Cache Core Bytes"},
+	{index: 24, counter: "Standby Cache Normal Priority Bytes"},
+	{index: 25, counter: "Standby Cache Reserve Bytes"},
+	{index: 26, counter: "System Cache Resident Bytes"},
+	{index: 27, counter: "System Code Resident Bytes"},
+	{index: 28, counter: "System Code Total Bytes"},
+	{index: 29, counter: "System Driver Resident Bytes"},
+	{index: 30, counter: "System Driver Total Bytes"},
+	{index: 31, counter: "Transition Faults/sec"},
+	{index: 32, counter: "Transition Pages RePurposed/sec"},
+	{index: 33, counter: "Write Copies/sec"},
+}
+
+func unmarshalMemory(obj *perflib.PerfObject, vs *[]memory) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*memory)(nil)).Elem(), perflibFieldsOfMemory)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]memory, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.AvailableBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AvailableBytes)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.AvailableKBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AvailableKBytes)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.AvailableMBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AvailableMBytes)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.CacheBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CacheBytes)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.CacheBytesPeak = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CacheBytesPeak)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.CacheFaultsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CacheFaultsPersec)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.CommitLimit = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CommitLimit)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.CommittedBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CommittedBytes)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.DemandZeroFaultsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DemandZeroFaultsPersec)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.FreeAndZeroPageListBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FreeAndZeroPageListBytes)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.FreeSystemPageTableEntries = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FreeSystemPageTableEntries)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.ModifiedPageListBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ModifiedPageListBytes)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.PageFaultsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PageFaultsPersec)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.PageReadsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PageReadsPersec)
+		}
+		if c := layout.counter(instance, 14); c != nil {
+			v.PagesInputPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PagesInputPersec)
+		}
+		if c := layout.counter(instance, 15); c != nil {
+			v.PagesOutputPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PagesOutputPersec)
+		}
+		if c := layout.counter(instance, 16); c != nil {
+			v.PagesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PagesPersec)
+		}
+		if c := layout.counter(instance, 17); c != nil {
+			v.PageWritesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PageWritesPersec)
+		}
+		if c := layout.counter(instance, 18); c != nil {
+			v.PoolNonpagedAllocs = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PoolNonpagedAllocs)
+		}
+		if c := layout.counter(instance, 19); c != nil {
+			v.PoolNonpagedBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PoolNonpagedBytes)
+		}
+		if c := layout.counter(instance, 20); c != nil {
+			v.PoolPagedAllocs = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PoolPagedAllocs)
+		}
+		if c := layout.counter(instance, 21); c != nil {
+			v.PoolPagedBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PoolPagedBytes)
+		}
+		if c := layout.counter(instance, 22); c != nil {
+			v.PoolPagedResidentBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PoolPagedResidentBytes)
+		}
+		if c := layout.counter(instance, 23); c != nil {
+			v.StandbyCacheCoreBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.StandbyCacheCoreBytes)
+		}
+		if c := layout.counter(instance, 24); c != nil {
+			v.StandbyCacheNormalPriorityBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.StandbyCacheNormalPriorityBytes)
+		}
+		if c := layout.counter(instance, 25); c != nil {
+			v.StandbyCacheReserveBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.StandbyCacheReserveBytes)
+		}
+		if c := layout.counter(instance, 26); c != nil {
+			v.SystemCacheResidentBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SystemCacheResidentBytes)
+		}
+		if c := layout.counter(instance, 27); c != nil {
+			v.SystemCodeResidentBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SystemCodeResidentBytes)
+		}
+		if c := layout.counter(instance, 28); c != nil {
+			v.SystemCodeTotalBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SystemCodeTotalBytes)
+		}
+		if c := layout.counter(instance, 29); c != nil {
+			v.SystemDriverResidentBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SystemDriverResidentBytes)
+		}
+		if c := layout.counter(instance, 30); c != nil {
+			v.SystemDriverTotalBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SystemDriverTotalBytes)
+		}
+		if c := layout.counter(instance, 31); c != nil {
+			v.TransitionFaultsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TransitionFaultsPersec)
+		}
+		if c := layout.counter(instance, 32); c != nil {
+			v.TransitionPagesRePurposedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TransitionPagesRePurposedPersec)
+		}
+		if c := layout.counter(instance, 33); c != nil {
+			v.WriteCopiesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WriteCopiesPersec)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfMsmqQueue = []perflibField{
+	{index: 1, counter: "Bytes in Journal Queue"},
+	{index: 2, counter: "Bytes in Queue"},
+	{index: 3, counter: "Messages in Journal Queue"},
+	{index: 4, counter: "Messages in Queue"},
+}
+
+func unmarshalMsmqQueue(obj *perflib.PerfObject, vs *[]msmqQueue) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*msmqQueue)(nil)).Elem(), perflibFieldsOfMsmqQueue)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]msmqQueue, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.BytesinJournalQueue = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BytesinJournalQueue)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.BytesinQueue = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BytesinQueue)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.MessagesinJournalQueue = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MessagesinJournalQueue)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.MessagesinQueue = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MessagesinQueue)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfMssqlAccessMethods = []perflibField{
+	{index: 0, counter: "AU cleanup batches/sec"},
+	{index: 1, counter: "AU cleanups/sec"},
+	{index: 2, counter: "By-reference Lob Create Count", optional: true},
+	{index: 3, counter: "By-reference Lob Use Count", optional: true},
+	{index: 4, counter: "Count Lob Readahead", optional: true},
+	{index: 5, counter: "Count Pull In Row", optional: true},
+	{index: 6, counter: "Count Push Off Row", optional: true},
+	{index: 7, counter: "Deferred dropped AUs"},
+	{index: 8, counter: "Deferred Dropped rowsets"},
+	{index: 9, counter: "Dropped rowset cleanups/sec"},
+	{index: 10, counter: "Dropped rowsets skipped/sec"},
+	{index: 11, counter: "Extent Deallocations/sec"},
+	{index: 12, counter: "Extents Allocated/sec"},
+	{index: 13, counter: "Failed AU cleanup batches/sec"},
+	{index: 14, counter: "Failed leaf page cookie"},
+	{index: 15, counter: "Failed tree page cookie"},
+	{index: 16, counter: "Forwarded Records/sec"},
+	{index: 17, counter: "FreeSpace Page Fetches/sec"},
+	{index: 18, counter: "FreeSpace Scans/sec"},
+	{index: 19, counter: "Full Scans/sec"},
+	{index: 20, counter: "Index Searches/sec"},
+	{index: 21, counter: "InSysXact waits/sec", optional: true},
+	{index: 22, counter: "LobHandle Create Count"},
+	{index: 23, counter: "LobHandle Destroy Count"},
+	{index: 24, counter: "LobSS Provider Create Count"},
+	{index: 25, counter: "LobSS Provider Destroy Count"},
+	{index: 26, counter: "LobSS Provider Truncation Count"},
+	{index: 27, counter: "Mixed page allocations/sec"},
+	{index: 28, counter: "Page compression attempts/sec"},
+	{index: 29, counter: "Page Deallocations/sec"},
+	{index: 30, counter: "Pages Allocated/sec"},
+	{index: 31, counter: "Pages compressed/sec"},
+	{index: 32, counter: "Page Splits/sec"},
+	{index: 33, counter: "Probe Scans/sec"},
+	{index: 34, counter: "Range Scans/sec"},
+	{index: 35, counter: "Scan Point Revalidations/sec"},
+	{index: 36, counter: "Skipped Ghosted Records/sec"},
+	{index: 37, counter: "Table Lock Escalations/sec"},
+	{index: 38, counter: "Used leaf page cookie"},
+	{index: 39, counter: "Used tree page cookie"},
+	{index: 40, counter: "Workfiles Created/sec"},
+	{index: 41, counter: "Worktables Created/sec"},
+	{index: 42, counter: "Worktables From Cache Ratio"},
+	{index: 43, counter: "Worktables From Cache Ratio", value: "base"},
+}
+
+func unmarshalMssqlAccessMethods(obj *perflib.PerfObject, vs *[]mssqlAccessMethods) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*mssqlAccessMethods)(nil)).Elem(), perflibFieldsOfMssqlAccessMethods)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]mssqlAccessMethods, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.AUcleanupbatchesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AUcleanupbatchesPersec)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.AUcleanupsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AUcleanupsPersec)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.ByreferenceLobCreateCount = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ByreferenceLobCreateCount)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.ByreferenceLobUseCount = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ByreferenceLobUseCount)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.CountLobReadahead = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CountLobReadahead)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.CountPullInRow = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CountPullInRow)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.CountPushOffRow = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CountPushOffRow)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.DeferreddroppedAUs = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DeferreddroppedAUs)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.DeferredDroppedrowsets = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DeferredDroppedrowsets)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.DroppedrowsetcleanupsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DroppedrowsetcleanupsPersec)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.DroppedrowsetsskippedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DroppedrowsetsskippedPersec)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.ExtentDeallocationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ExtentDeallocationsPersec)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.ExtentsAllocatedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ExtentsAllocatedPersec)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.FailedAUcleanupbatchesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FailedAUcleanupbatchesPersec)
+		}
+		if c := layout.counter(instance, 14); c != nil {
+			v.Failedleafpagecookie = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Failedleafpagecookie)
+		}
+		if c := layout.counter(instance, 15); c != nil {
+			v.Failedtreepagecookie = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Failedtreepagecookie)
+		}
+		if c := layout.counter(instance, 16); c != nil {
+			v.ForwardedRecordsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ForwardedRecordsPersec)
+		}
+		if c := layout.counter(instance, 17); c != nil {
+			v.FreeSpacePageFetchesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FreeSpacePageFetchesPersec)
+		}
+		if c := layout.counter(instance, 18); c != nil {
+			v.FreeSpaceScansPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FreeSpaceScansPersec)
+		}
+		if c := layout.counter(instance, 19); c != nil {
+			v.FullScansPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FullScansPersec)
+		}
+		if c := layout.counter(instance, 20); c != nil {
+			v.IndexSearchesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IndexSearchesPersec)
+		}
+		if c := layout.counter(instance, 21); c != nil {
+			v.InSysXactwaitsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.InSysXactwaitsPersec)
+		}
+		if c := layout.counter(instance, 22); c != nil {
+			v.LobHandleCreateCount = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LobHandleCreateCount)
+		}
+		if c := layout.counter(instance, 23); c != nil {
+			v.LobHandleDestroyCount = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LobHandleDestroyCount)
+		}
+		if c := layout.counter(instance, 24); c != nil {
+			v.LobSSProviderCreateCount = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LobSSProviderCreateCount)
+		}
+		if c := layout.counter(instance, 25); c != nil {
+			v.LobSSProviderDestroyCount = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LobSSProviderDestroyCount)
+		}
+		if c := layout.counter(instance, 26); c != nil {
+			v.LobSSProviderTruncationCount = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LobSSProviderTruncationCount)
+		}
+		if c := layout.counter(instance, 27); c != nil {
+			v.MixedpageallocationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MixedpageallocationsPersec)
+		}
+		if c := layout.counter(instance, 28); c != nil {
+			v.PagecompressionattemptsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PagecompressionattemptsPersec)
+		}
+		if c := layout.counter(instance, 29); c != nil {
+			v.PageDeallocationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PageDeallocationsPersec)
+		}
+		if c := layout.counter(instance, 30); c != nil {
+			v.PagesAllocatedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PagesAllocatedPersec)
+		}
+		if c := layout.counter(instance, 31); c != nil {
+			v.PagescompressedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PagescompressedPersec)
+		}
+		if c := layout.counter(instance, 32); c != nil {
+			v.PageSplitsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PageSplitsPersec)
+		}
+		if c := layout.counter(instance, 33); c != nil {
+			v.ProbeScansPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ProbeScansPersec)
+		}
+		if c := layout.counter(instance, 34); c != nil {
+			v.RangeScansPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RangeScansPersec)
+		}
+		if c := layout.counter(instance, 35); c != nil {
+			v.ScanPointRevalidationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ScanPointRevalidationsPersec)
+		}
+		if c := layout.counter(instance, 36); c != nil {
+			v.SkippedGhostedRecordsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SkippedGhostedRecordsPersec)
+		}
+		if c := layout.counter(instance, 37); c != nil {
+			v.TableLockEscalationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TableLockEscalationsPersec)
+		}
+		if c := layout.counter(instance, 38); c != nil {
+			v.Usedleafpagecookie = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Usedleafpagecookie)
+		}
+		if c := layout.counter(instance, 39); c != nil {
+			v.Usedtreepagecookie = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Usedtreepagecookie)
+		}
+		if c := layout.counter(instance, 40); c != nil {
+			v.WorkfilesCreatedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WorkfilesCreatedPersec)
+		}
+		if c := layout.counter(instance, 41); c != nil {
+			v.WorktablesCreatedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WorktablesCreatedPersec)
+		}
+		if c := layout.counter(instance, 42); c != nil {
+			v.WorktablesFromCacheRatio = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WorktablesFromCacheRatio)
+		}
+		if c, b := layout.counter(instance, 43), layout.base(instance, 43); c != nil && b != nil {
+			v.WorktablesFromCacheRatio_Base = float64(b.Value)
+		} else {
+			present.setAbsent(&v.WorktablesFromCacheRatio_Base)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfMssqlAvailabilityReplica = []perflibField{
+	{index: 1, counter: "Bytes Received from Replica/sec"},
+	{index: 2, counter: "Bytes Sent to Replica/sec"},
+	{index: 3, counter: "Bytes Sent to Transport/sec"},
+	{index: 4, counter: "Flow Control/sec"},
+	{index: 5, counter: "Flow Control Time (ms/sec)"},
+	{index: 6, counter: "Receives from Replica/sec"},
+	{index: 7, counter: "Resent Messages/sec"},
+	{index: 8, counter: "Sends to Replica/sec"},
+	{index: 9, counter: "Sends to Transport/sec"},
+}
+
+func unmarshalMssqlAvailabilityReplica(obj *perflib.PerfObject, vs *[]mssqlAvailabilityReplica) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*mssqlAvailabilityReplica)(nil)).Elem(), perflibFieldsOfMssqlAvailabilityReplica)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]mssqlAvailabilityReplica, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.BytesReceivedfromReplicaPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BytesReceivedfromReplicaPersec)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.BytesSenttoReplicaPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BytesSenttoReplicaPersec)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.BytesSenttoTransportPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BytesSenttoTransportPersec)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.FlowControlPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FlowControlPersec)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.FlowControlTimemsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FlowControlTimemsPersec)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.ReceivesfromReplicaPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ReceivesfromReplicaPersec)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.ResentMessagesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ResentMessagesPersec)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.SendstoReplicaPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SendstoReplicaPersec)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.SendstoTransportPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SendstoTransportPersec)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfMssqlBufferManager = []perflibField{
+	{index: 0, counter: "Background writer pages/sec", optional: true},
+	{index: 1, counter: "Buffer cache hit ratio"},
+	{index: 2, counter: "Buffer cache hit ratio", value: "base"},
+	{index: 3, counter: "Checkpoint pages/sec"},
+	{index: 4, counter: "Database pages"},
+	{index: 5, counter: "Extension allocated pages", optional: true},
+	{index: 6, counter: "Extension free pages", optional: true},
+	{index: 7, counter: "Extension in use as percentage", optional: true},
+	{index: 8, counter: "Extension outstanding IO counter", optional: true},
+	{index: 9, counter: "Extension page evictions/sec", optional: true},
+	{index: 10, counter: "Extension page reads/sec", optional: true},
+	{index: 11, counter: "Extension page unreferenced time", optional: true},
+	{index: 12, counter: "Extension page writes/sec", optional: true},
+	{index: 13, counter: "Free list stalls/sec"},
+	{index: 14, counter: "Integral Controller Slope", optional: true},
+	{index: 15, counter: "Lazy writes/sec"},
+	{index: 16, counter: "Page life expectancy"},
+	{index: 17, counter: "Page lookups/sec"},
+	{index: 18, counter: "Page reads/sec"},
+	{index: 19, counter: "Page writes/sec"},
+	{index: 20, counter: "Readahead pages/sec"},
+	{index: 21, counter: "Readahead time/sec"},
+	{index: 22, counter: "Target pages"},
+}
+
+func unmarshalMssqlBufferManager(obj *perflib.PerfObject, vs *[]mssqlBufferManager) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*mssqlBufferManager)(nil)).Elem(), perflibFieldsOfMssqlBufferManager)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]mssqlBufferManager, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.BackgroundwriterpagesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BackgroundwriterpagesPersec)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.Buffercachehitratio = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Buffercachehitratio)
+		}
+		if c, b := layout.counter(instance, 2), layout.base(instance, 2); c != nil && b != nil {
+			v.Buffercachehitratio_Base = float64(b.Value)
+		} else {
+			present.setAbsent(&v.Buffercachehitratio_Base)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.CheckpointpagesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CheckpointpagesPersec)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.Databasepages = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Databasepages)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.Extensionallocatedpages = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Extensionallocatedpages)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.Extensionfreepages = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Extensionfreepages)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.Extensioninuseaspercentage = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Extensioninuseaspercentage)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.ExtensionoutstandingIOcounter = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ExtensionoutstandingIOcounter)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.ExtensionpageevictionsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ExtensionpageevictionsPersec)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.ExtensionpagereadsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ExtensionpagereadsPersec)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.Extensionpageunreferencedtime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Extensionpageunreferencedtime)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.ExtensionpagewritesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ExtensionpagewritesPersec)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.FreeliststallsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FreeliststallsPersec)
+		}
+		if c := layout.counter(instance, 14); c != nil {
+			v.IntegralControllerSlope = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IntegralControllerSlope)
+		}
+		if c := layout.counter(instance, 15); c != nil {
+			v.LazywritesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LazywritesPersec)
+		}
+		if c := layout.counter(instance, 16); c != nil {
+			v.Pagelifeexpectancy = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Pagelifeexpectancy)
+		}
+		if c := layout.counter(instance, 17); c != nil {
+			v.PagelookupsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PagelookupsPersec)
+		}
+		if c := layout.counter(instance, 18); c != nil {
+			v.PagereadsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PagereadsPersec)
+		}
+		if c := layout.counter(instance, 19); c != nil {
+			v.PagewritesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PagewritesPersec)
+		}
+		if c := layout.counter(instance, 20); c != nil {
+			v.ReadaheadpagesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ReadaheadpagesPersec)
+		}
+		if c := layout.counter(instance, 21); c != nil {
+			v.ReadaheadtimePersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ReadaheadtimePersec)
+		}
+		if c := layout.counter(instance, 22); c != nil {
+			v.Targetpages = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Targetpages)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfMssqlDatabaseReplica = []perflibField{
+	{index: 1, counter: "Database Flow Control Delay"},
+	{index: 2, counter: "Database Flow Controls/sec"},
+	{index: 3, counter: "File Bytes Received/sec"},
+	{index: 4, counter: "Group Commits/Sec", optional: true},
+	{index: 5, counter: "Group Commit Time", optional: true},
+	{index: 6, counter: "Log Apply Pending Queue", optional: true},
+	{index: 7, counter: "Log Apply Ready Queue", optional: true},
+	{index: 8, counter: "Log Bytes Compressed/sec", optional: true},
+	{index: 9, counter: "Log Bytes Decompressed/sec", optional: true},
+	{index: 10, counter: "Log Bytes Received/sec"},
+	{index: 11, counter: "Log Compression Cache hits/sec", optional: true},
+	{index: 12, counter: "Log Compression Cache misses/sec", optional: true},
+	{index: 13, counter: "Log Compressions/sec", optional: true},
+	{index: 14, counter: "Log Decompressions/sec", optional: true},
+	{index: 15, counter: "Log remaining for undo"},
+	{index: 16, counter: "Log Send Queue"},
+	{index: 17, counter: "Mirrored Write Transactions/sec"},
+	{index: 18, counter: "Recovery Queue"},
+	{index: 19, counter: "Redo blocked/sec", optional: true},
+	{index: 20, counter: "Redo Bytes Remaining"},
+	{index: 21, counter: "Redone Bytes/sec"},
+	{index: 22, counter: "Redones/sec"},
+	{index: 23, counter: "Total Log requiring undo"},
+	{index: 24, counter: "Transaction Delay"},
+}
+
+func unmarshalMssqlDatabaseReplica(obj *perflib.PerfObject, vs *[]mssqlDatabaseReplica) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*mssqlDatabaseReplica)(nil)).Elem(), perflibFieldsOfMssqlDatabaseReplica)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]mssqlDatabaseReplica, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.DatabaseFlowControlDelay = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DatabaseFlowControlDelay)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.DatabaseFlowControlsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DatabaseFlowControlsPersec)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.FileBytesReceivedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FileBytesReceivedPersec)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.GroupCommitsPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.GroupCommitsPerSec)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.GroupCommitTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.GroupCommitTime)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.LogApplyPendingQueue = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogApplyPendingQueue)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.LogApplyReadyQueue = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogApplyReadyQueue)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.LogBytesCompressedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogBytesCompressedPersec)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.LogBytesDecompressedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogBytesDecompressedPersec)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.LogBytesReceivedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogBytesReceivedPersec)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.LogCompressionCachehitsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogCompressionCachehitsPersec)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.LogCompressionCachemissesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogCompressionCachemissesPersec)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.LogCompressionsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogCompressionsPersec)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.LogDecompressionsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogDecompressionsPersec)
+		}
+		if c := layout.counter(instance, 14); c != nil {
+			v.Logremainingforundo = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Logremainingforundo)
+		}
+		if c := layout.counter(instance, 15); c != nil {
+			v.LogSendQueue = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogSendQueue)
+		}
+		if c := layout.counter(instance, 16); c != nil {
+			v.MirroredWriteTransactionsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MirroredWriteTransactionsPersec)
+		}
+		if c := layout.counter(instance, 17); c != nil {
+			v.RecoveryQueue = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RecoveryQueue)
+		}
+		if c := layout.counter(instance, 18); c != nil {
+			v.RedoblockedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RedoblockedPersec)
+		}
+		if c := layout.counter(instance, 19); c != nil {
+			v.RedoBytesRemaining = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RedoBytesRemaining)
+		}
+		if c := layout.counter(instance, 20); c != nil {
+			v.RedoneBytesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RedoneBytesPersec)
+		}
+		if c := layout.counter(instance, 21); c != nil {
+			v.RedonesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RedonesPersec)
+		}
+		if c := layout.counter(instance, 22); c != nil {
+			v.TotalLogrequiringundo = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TotalLogrequiringundo)
+		}
+		if c := layout.counter(instance, 23); c != nil {
+			v.TransactionDelay = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TransactionDelay)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfMssqlDatabases = []perflibField{
+	{index: 1, counter: "Active parallel redo threads", optional: true},
+	{index: 2, counter: "Active Transactions"},
+	{index: 3, counter: "Backup/Restore Throughput/sec"},
+	{index: 4, counter: "Bulk Copy Rows/sec"},
+	{index: 5, counter: "Bulk Copy Throughput/sec"},
+	{index: 6, counter: "Commit table entries", optional: true},
+	{index: 7, counter: "Data File(s) Size (KB)"},
+	{index: 8, counter: "DBCC Logical Scan Bytes/sec"},
+	{index: 9, counter: "Group Commit Time/sec", optional: true},
+	{index: 10, counter: "Log Bytes Flushed/sec"},
+	{index: 11, counter: "Log Cache Hit Ratio"},
+	{index: 12, counter: "Log Cache Hit Ratio", value: "base"},
+	{index: 13, counter: "Log Cache Reads/sec"},
+	{index: 14, counter: "Log File(s) Size (KB)"},
+	{index: 15, counter: "Log File(s) Used Size (KB)"},
+	{index: 16, counter: "Log Flushes/sec"},
+	{index: 17, counter: "Log Flush Waits/sec"},
+	{index: 18, counter: "Log Flush Wait Time"},
+	{index: 19, counter: "Log Flush Write Time (ms)", optional: true},
+	{index: 20, counter: "Log Growths"},
+	{index: 21, counter: "Log Pool Cache Misses/sec", optional: true},
+	{index: 22, counter: "Log Pool Disk Reads/sec", optional: true},
+	{index: 23, counter: "Log Pool Hash Deletes/sec", optional: true},
+	{index: 24, counter: "Log Pool Hash Inserts/sec", optional: true},
+	{index: 25, counter: "Log Pool Invalid Hash Entry/sec", optional: true},
+	{index: 26, counter: "Log Pool Log Scan Pushes/sec", optional: true},
+	{index: 27, counter: "Log Pool LogWriter Pushes/sec", optional: true},
+	{index: 28, counter: "Log Pool Push Empty FreePool/sec", optional: true},
+	{index: 29, counter: "Log Pool Push Low Memory/sec", optional: true},
+	{index: 30, counter: "Log Pool Push No Free Buffer/sec", optional: true},
+	{index: 31, counter: "Log Pool Req. Behind Trunc/sec", optional: true},
+	{index: 32, counter: "Log Pool Requests Old VLF/sec", optional: true},
+	{index: 33, counter: "Log Pool Requests/sec", optional: true},
+	{index: 34, counter: "Log Pool Total Active Log Size", optional: true},
+	{index: 35, counter: "Log Pool Total Shared Pool Size", optional: true},
+	{index: 36, counter: "Log Shrinks"},
+	{index: 37, counter: "Log Truncations"},
+	{index: 38, counter: "Percent Log Used"},
+	{index: 39, counter: "Repl. Pending Xacts"},
+	{index: 40, counter: "Repl. Trans. Rate"},
+	{index: 41, counter: "Shrink Data Movement Bytes/sec"},
+	{index: 42, counter: "Tracked transactions/sec", optional: true},
+	{index: 43, counter: "Transactions/sec"},
+	{index: 44, counter: "Write Transactions/sec", optional: true},
+	{index: 45, counter: "XTP Controller DLC Latency/Fetch", optional: true},
+	{index: 46, counter: "XTP Controller DLC Peak Latency", optional: true},
+	{index: 47, counter: "XTP Controller Log Processed/sec", optional: true},
+	{index: 48, counter: "XTP Memory Used (KB)", optional: true},
+}
+
+func unmarshalMssqlDatabases(obj *perflib.PerfObject, vs *[]mssqlDatabases) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*mssqlDatabases)(nil)).Elem(), perflibFieldsOfMssqlDatabases)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]mssqlDatabases, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.Activeparallelredothreads = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Activeparallelredothreads)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.ActiveTransactions = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ActiveTransactions)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.BackupPerRestoreThroughputPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BackupPerRestoreThroughputPersec)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.BulkCopyRowsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BulkCopyRowsPersec)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.BulkCopyThroughputPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BulkCopyThroughputPersec)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.Committableentries = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Committableentries)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.DataFilesSizeKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DataFilesSizeKB)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.DBCCLogicalScanBytesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DBCCLogicalScanBytesPersec)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.GroupCommitTimePersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.GroupCommitTimePersec)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.LogBytesFlushedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogBytesFlushedPersec)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.LogCacheHitRatio = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogCacheHitRatio)
+		}
+		if c, b := layout.counter(instance, 11), layout.base(instance, 11); c != nil && b != nil {
+			v.LogCacheHitRatio_Base = float64(b.Value)
+		} else {
+			present.setAbsent(&v.LogCacheHitRatio_Base)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.LogCacheReadsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogCacheReadsPersec)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.LogFilesSizeKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogFilesSizeKB)
+		}
+		if c := layout.counter(instance, 14); c != nil {
+			v.LogFilesUsedSizeKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogFilesUsedSizeKB)
+		}
+		if c := layout.counter(instance, 15); c != nil {
+			v.LogFlushesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogFlushesPersec)
+		}
+		if c := layout.counter(instance, 16); c != nil {
+			v.LogFlushWaitsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogFlushWaitsPersec)
+		}
+		if c := layout.counter(instance, 17); c != nil {
+			v.LogFlushWaitTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogFlushWaitTime)
+		}
+		if c := layout.counter(instance, 18); c != nil {
+			v.LogFlushWriteTimems = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogFlushWriteTimems)
+		}
+		if c := layout.counter(instance, 19); c != nil {
+			v.LogGrowths = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogGrowths)
+		}
+		if c := layout.counter(instance, 20); c != nil {
+			v.LogPoolCacheMissesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogPoolCacheMissesPersec)
+		}
+		if c := layout.counter(instance, 21); c != nil {
+			v.LogPoolDiskReadsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogPoolDiskReadsPersec)
+		}
+		if c := layout.counter(instance, 22); c != nil {
+			v.LogPoolHashDeletesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogPoolHashDeletesPersec)
+		}
+		if c := layout.counter(instance, 23); c != nil {
+			v.LogPoolHashInsertsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogPoolHashInsertsPersec)
+		}
+		if c := layout.counter(instance, 24); c != nil {
+			v.LogPoolInvalidHashEntryPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogPoolInvalidHashEntryPersec)
+		}
+		if c := layout.counter(instance, 25); c != nil {
+			v.LogPoolLogScanPushesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogPoolLogScanPushesPersec)
+		}
+		if c := layout.counter(instance, 26); c != nil {
+			v.LogPoolLogWriterPushesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogPoolLogWriterPushesPersec)
+		}
+		if c := layout.counter(instance, 27); c != nil {
+			v.LogPoolPushEmptyFreePoolPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogPoolPushEmptyFreePoolPersec)
+		}
+		if c := layout.counter(instance, 28); c != nil {
+			v.LogPoolPushLowMemoryPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogPoolPushLowMemoryPersec)
+		}
+		if c := layout.counter(instance, 29); c != nil {
+			v.LogPoolPushNoFreeBufferPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogPoolPushNoFreeBufferPersec)
+		}
+		if c := layout.counter(instance, 30); c != nil {
+			v.LogPoolReqBehindTruncPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogPoolReqBehindTruncPersec)
+		}
+		if c := layout.counter(instance, 31); c != nil {
+			v.LogPoolRequestsOldVLFPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogPoolRequestsOldVLFPersec)
+		}
+		if c := layout.counter(instance, 32); c != nil {
+			v.LogPoolRequestsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogPoolRequestsPersec)
+		}
+		if c := layout.counter(instance, 33); c != nil {
+			v.LogPoolTotalActiveLogSize = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogPoolTotalActiveLogSize)
+		}
+		if c := layout.counter(instance, 34); c != nil {
+			v.LogPoolTotalSharedPoolSize = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogPoolTotalSharedPoolSize)
+		}
+		if c := layout.counter(instance, 35); c != nil {
+			v.LogShrinks = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogShrinks)
+		}
+		if c := layout.counter(instance, 36); c != nil {
+			v.LogTruncations = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogTruncations)
+		}
+		if c := layout.counter(instance, 37); c != nil {
+			v.PercentLogUsed = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentLogUsed)
+		}
+		if c := layout.counter(instance, 38); c != nil {
+			v.ReplPendingXacts = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ReplPendingXacts)
+		}
+		if c := layout.counter(instance, 39); c != nil {
+			v.ReplTransRate = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ReplTransRate)
+		}
+		if c := layout.counter(instance, 40); c != nil {
+			v.ShrinkDataMovementBytesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ShrinkDataMovementBytesPersec)
+		}
+		if c := layout.counter(instance, 41); c != nil {
+			v.TrackedtransactionsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TrackedtransactionsPersec)
+		}
+		if c := layout.counter(instance, 42); c != nil {
+			v.TransactionsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TransactionsPersec)
+		}
+		if c := layout.counter(instance, 43); c != nil {
+			v.WriteTransactionsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WriteTransactionsPersec)
+		}
+		if c := layout.counter(instance, 44); c != nil {
+			v.XTPControllerDLCLatencyPerFetch = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.XTPControllerDLCLatencyPerFetch)
+		}
+		if c := layout.counter(instance, 45); c != nil {
+			v.XTPControllerDLCPeakLatency = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.XTPControllerDLCPeakLatency)
+		}
+		if c := layout.counter(instance, 46); c != nil {
+			v.XTPControllerLogProcessedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.XTPControllerLogProcessedPersec)
+		}
+		if c := layout.counter(instance, 47); c != nil {
+			v.XTPMemoryUsedKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.XTPMemoryUsedKB)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfMssqlGeneralStatistics = []perflibField{
+	{index: 0, counter: "Active Temp Tables"},
+	{index: 1, counter: "Connection Reset/sec"},
+	{index: 2, counter: "Event Notifications Delayed Drop"},
+	{index: 3, counter: "HTTP Authenticated Requests"},
+	{index: 4, counter: "Logical Connections"},
+	{index: 5, counter: "Logins/sec"},
+	{index: 6, counter: "Logouts/sec"},
+	{index: 7, counter: "Mars Deadlocks"},
+	{index: 8, counter: "Non-atomic yield rate"},
+	{index: 9, counter: "Processes blocked"},
+	{index: 10, counter: "SOAP Empty Requests"},
+	{index: 11, counter: "SOAP Method Invocations"},
+	{index: 12, counter: "SOAP Session Initiate Requests"},
+	{index: 13, counter: "SOAP Session Terminate Requests"},
+	{index: 14, counter: "SOAP SQL Requests"},
+	{index: 15, counter: "SOAP WSDL Requests"},
+	{index: 16, counter: "SQL Trace IO Provider Lock Waits"},
+	{index: 17, counter: "Tempdb recovery unit id"},
+	{index: 18, counter: "Tempdb rowset id"},
+	{index: 19, counter: "Temp Tables Creation Rate"},
+	{index: 20, counter: "Temp Tables For Destruction"},
+	{index: 21, counter: "Trace Event Notification Queue"},
+	{index: 22, counter: "Transactions"},
+	{index: 23, counter: "User Connections"},
+}
+
+func unmarshalMssqlGeneralStatistics(obj *perflib.PerfObject, vs *[]mssqlGeneralStatistics) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*mssqlGeneralStatistics)(nil)).Elem(), perflibFieldsOfMssqlGeneralStatistics)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]mssqlGeneralStatistics, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.ActiveTempTables = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ActiveTempTables)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.ConnectionResetPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ConnectionResetPersec)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.EventNotificationsDelayedDrop = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.EventNotificationsDelayedDrop)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.HTTPAuthenticatedRequests = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.HTTPAuthenticatedRequests)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.LogicalConnections = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogicalConnections)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.LoginsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LoginsPersec)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.LogoutsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogoutsPersec)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.MarsDeadlocks = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MarsDeadlocks)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.Nonatomicyieldrate = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Nonatomicyieldrate)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.Processesblocked = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Processesblocked)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.SOAPEmptyRequests = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SOAPEmptyRequests)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.SOAPMethodInvocations = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SOAPMethodInvocations)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.SOAPSessionInitiateRequests = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SOAPSessionInitiateRequests)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.SOAPSessionTerminateRequests = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SOAPSessionTerminateRequests)
+		}
+		if c := layout.counter(instance, 14); c != nil {
+			v.SOAPSQLRequests = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SOAPSQLRequests)
+		}
+		if c := layout.counter(instance, 15); c != nil {
+			v.SOAPWSDLRequests = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SOAPWSDLRequests)
+		}
+		if c := layout.counter(instance, 16); c != nil {
+			v.SQLTraceIOProviderLockWaits = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SQLTraceIOProviderLockWaits)
+		}
+		if c := layout.counter(instance, 17); c != nil {
+			v.Tempdbrecoveryunitid = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Tempdbrecoveryunitid)
+		}
+		if c := layout.counter(instance, 18); c != nil {
+			v.Tempdbrowsetid = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Tempdbrowsetid)
+		}
+		if c := layout.counter(instance, 19); c != nil {
+			v.TempTablesCreationRate = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TempTablesCreationRate)
+		}
+		if c := layout.counter(instance, 20); c != nil {
+			v.TempTablesForDestruction = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TempTablesForDestruction)
+		}
+		if c := layout.counter(instance, 21); c != nil {
+			v.TraceEventNotificationQueue = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TraceEventNotificationQueue)
+		}
+		if c := layout.counter(instance, 22); c != nil {
+			v.Transactions = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Transactions)
+		}
+		if c := layout.counter(instance, 23); c != nil {
+			v.UserConnections = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.UserConnections)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfMssqlLocks = []perflibField{
+	{index: 1, counter: "Average Wait Time (ms)"},
+	{index: 2, counter: "Average Wait Time (ms)", value: "base"},
+	{index: 3, counter: "Lock Requests/sec"},
+	{index: 4, counter: "Lock Timeouts/sec"},
+	{index: 5, counter: "Lock Timeouts (timeout > 0)/sec"},
+	{index: 6, counter: "Lock Waits/sec"},
+	{index: 7, counter: "Lock Wait Time (ms)"},
+	{index: 8, counter: "Number of Deadlocks/sec"},
+}
+
+func unmarshalMssqlLocks(obj *perflib.PerfObject, vs *[]mssqlLocks) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*mssqlLocks)(nil)).Elem(), perflibFieldsOfMssqlLocks)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]mssqlLocks, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.AverageWaitTimems = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AverageWaitTimems)
+		}
+		if c, b := layout.counter(instance, 1), layout.base(instance, 1); c != nil && b != nil {
+			v.AverageWaitTimems_Base = float64(b.Value)
+		} else {
+			present.setAbsent(&v.AverageWaitTimems_Base)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.LockRequestsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LockRequestsPersec)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.LockTimeoutsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LockTimeoutsPersec)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.LockTimeoutstimeout0Persec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LockTimeoutstimeout0Persec)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.LockWaitsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LockWaitsPersec)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.LockWaitTimems = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LockWaitTimems)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.NumberofDeadlocksPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberofDeadlocksPersec)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfMssqlMemoryManager = []perflibField{
+	{index: 0, counter: "Connection Memory (KB)"},
+	{index: 1, counter: "Database Cache Memory (KB)", optional: true},
+	{index: 2, counter: "External benefit of memory", optional: true},
+	{index: 3, counter: "Free Memory (KB)", optional: true},
+	{index: 4, counter: "Granted Workspace Memory (KB)"},
+	{index: 5, counter: "Lock Blocks"},
+	{index: 6, counter: "Lock Blocks Allocated"},
+	{index: 7, counter: "Lock Memory (KB)"},
+	{index: 8, counter: "Lock Owner Blocks"},
+	{index: 9, counter: "Lock Owner Blocks Allocated"},
+	{index: 10, counter: "Log Pool Memory (KB)", optional: true},
+	{index: 11, counter: "Maximum Workspace Memory (KB)"},
+	{index: 12, counter: "Memory Grants Outstanding"},
+	{index: 13, counter: "Memory Grants Pending"},
+	{index: 14, counter: "Optimizer Memory (KB)"},
+	{index: 15, counter: "Reserved Server Memory (KB)", optional: true},
+	{index: 16, counter: "SQL Cache Memory (KB)"},
+	{index: 17, counter: "Stolen Server Memory (KB)", optional: true},
+	{index: 18, counter: "Target Server Memory (KB)"},
+	{index: 19, counter: "Total Server Memory (KB)"},
+}
+
+func unmarshalMssqlMemoryManager(obj *perflib.PerfObject, vs *[]mssqlMemoryManager) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*mssqlMemoryManager)(nil)).Elem(), perflibFieldsOfMssqlMemoryManager)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]mssqlMemoryManager, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.ConnectionMemoryKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ConnectionMemoryKB)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.DatabaseCacheMemoryKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DatabaseCacheMemoryKB)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.Externalbenefitofmemory = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Externalbenefitofmemory)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.FreeMemoryKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FreeMemoryKB)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.GrantedWorkspaceMemoryKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.GrantedWorkspaceMemoryKB)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.LockBlocks = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LockBlocks)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.LockBlocksAllocated = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LockBlocksAllocated)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.LockMemoryKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LockMemoryKB)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.LockOwnerBlocks = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LockOwnerBlocks)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.LockOwnerBlocksAllocated = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LockOwnerBlocksAllocated)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.LogPoolMemoryKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LogPoolMemoryKB)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.MaximumWorkspaceMemoryKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MaximumWorkspaceMemoryKB)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.MemoryGrantsOutstanding = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MemoryGrantsOutstanding)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.MemoryGrantsPending = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MemoryGrantsPending)
+		}
+		if c := layout.counter(instance, 14); c != nil {
+			v.OptimizerMemoryKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.OptimizerMemoryKB)
+		}
+		if c := layout.counter(instance, 15); c != nil {
+			v.ReservedServerMemoryKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ReservedServerMemoryKB)
+		}
+		if c := layout.counter(instance, 16); c != nil {
+			v.SQLCacheMemoryKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SQLCacheMemoryKB)
+		}
+		if c := layout.counter(instance, 17); c != nil {
+			v.StolenServerMemoryKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.StolenServerMemoryKB)
+		}
+		if c := layout.counter(instance, 18); c != nil {
+			v.TargetServerMemoryKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TargetServerMemoryKB)
+		}
+		if c := layout.counter(instance, 19); c != nil {
+			v.TotalServerMemoryKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TotalServerMemoryKB)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfMssqlSQLErrors = []perflibField{
+	{index: 1, counter: "Errors/sec"},
+}
+
+func unmarshalMssqlSQLErrors(obj *perflib.PerfObject, vs *[]mssqlSQLErrors) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*mssqlSQLErrors)(nil)).Elem(), perflibFieldsOfMssqlSQLErrors)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]mssqlSQLErrors, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.ErrorsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ErrorsPersec)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfMssqlSQLStatistics = []perflibField{
+	{index: 0, counter: "Auto-Param Attempts/sec"},
+	{index: 1, counter: "Batch Requests/sec"},
+	{index: 2, counter: "Failed Auto-Params/sec"},
+	{index: 3, counter: "Forced Parameterizations/sec"},
+	{index: 4, counter: "Guided plan executions/sec"},
+	{index: 5, counter: "Misguided plan executions/sec"},
+	{index: 6, counter: "Safe Auto-Params/sec"},
+	{index: 7, counter: "SQL Attention rate"},
+	{index: 8, counter: "SQL Compilations/sec"},
+	{index: 9, counter: "SQL Re-Compilations/sec"},
+	{index: 10, counter: "Unsafe Auto-Params/sec"},
+}
+
+func unmarshalMssqlSQLStatistics(obj *perflib.PerfObject, vs *[]mssqlSQLStatistics) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*mssqlSQLStatistics)(nil)).Elem(), perflibFieldsOfMssqlSQLStatistics)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]mssqlSQLStatistics, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.AutoParamAttemptsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AutoParamAttemptsPersec)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.BatchRequestsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BatchRequestsPersec)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.FailedAutoParamsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FailedAutoParamsPersec)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.ForcedParameterizationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ForcedParameterizationsPersec)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.GuidedplanexecutionsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.GuidedplanexecutionsPersec)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.MisguidedplanexecutionsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MisguidedplanexecutionsPersec)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.SafeAutoParamsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SafeAutoParamsPersec)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.SQLAttentionrate = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SQLAttentionrate)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.SQLCompilationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SQLCompilationsPersec)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.SQLReCompilationsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SQLReCompilationsPersec)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.UnsafeAutoParamsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.UnsafeAutoParamsPersec)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfMssqlTransactions = []perflibField{
+	{index: 0, counter: "Free Space in tempdb (KB)"},
+	{index: 1, counter: "Longest Transaction Running Time"},
+	{index: 2, counter: "NonSnapshot Version Transactions"},
+	{index: 3, counter: "Snapshot Transactions"},
+	{index: 4, counter: "Transactions"},
+	{index: 5, counter: "Update conflict ratio"},
+	{index: 6, counter: "Update Snapshot Transactions"},
+	{index: 7, counter: "Version Cleanup rate (KB/s)"},
+	{index: 8, counter: "Version Generation rate (KB/s)"},
+	{index: 9, counter: "Version Store Size (KB)"},
+	{index: 10, counter: "Version Store unit count"},
+	{index: 11, counter: "Version Store unit creation"},
+	{index: 12, counter: "Version Store unit truncation"},
+}
+
+func unmarshalMssqlTransactions(obj *perflib.PerfObject, vs *[]mssqlTransactions) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*mssqlTransactions)(nil)).Elem(), perflibFieldsOfMssqlTransactions)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]mssqlTransactions, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.FreeSpaceintempdbKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FreeSpaceintempdbKB)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.LongestTransactionRunningTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LongestTransactionRunningTime)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.NonSnapshotVersionTransactions = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NonSnapshotVersionTransactions)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.SnapshotTransactions = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SnapshotTransactions)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.Transactions = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Transactions)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.Updateconflictratio = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Updateconflictratio)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.UpdateSnapshotTransactions = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.UpdateSnapshotTransactions)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.VersionCleanuprateKBPers = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.VersionCleanuprateKBPers)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.VersionGenerationrateKBPers = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.VersionGenerationrateKBPers)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.VersionStoreSizeKB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.VersionStoreSizeKB)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.VersionStoreunitcount = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.VersionStoreunitcount)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.VersionStoreunitcreation = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.VersionStoreunitcreation)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.VersionStoreunittruncation = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.VersionStoreunittruncation)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfMssqlWaitStatistics = []perflibField{
+	{index: 1, counter: "Lock waits"},
+	{index: 2, counter: "Memory grant queue waits"},
+	{index: 3, counter: "Thread-safe memory objects waits"},
+	{index: 4, counter: "Log write waits"},
+	{index: 5, counter: "Log buffer waits"},
+	{index: 6, counter: "Network IO waits"},
+	{index: 7, counter: "Page IO latch waits"},
+	{index: 8, counter: "Page latch waits"},
+	{index: 9, counter: "Non-Page latch waits"},
+	{index: 10, counter: "Wait for the worker"},
+	{index: 11, counter: "Workspace synchronization waits"},
+	{index: 12, counter: "Transaction ownership waits"},
+}
+
+func unmarshalMssqlWaitStatistics(obj *perflib.PerfObject, vs *[]mssqlWaitStatistics) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*mssqlWaitStatistics)(nil)).Elem(), perflibFieldsOfMssqlWaitStatistics)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]mssqlWaitStatistics, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.WaitStatsLockWaits = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WaitStatsLockWaits)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.WaitStatsMemoryGrantQueueWaits = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WaitStatsMemoryGrantQueueWaits)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.WaitStatsThreadSafeMemoryObjectsWaits = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WaitStatsThreadSafeMemoryObjectsWaits)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.WaitStatsLogWriteWaits = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WaitStatsLogWriteWaits)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.WaitStatsLogBufferWaits = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WaitStatsLogBufferWaits)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.WaitStatsNetworkIOWaits = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WaitStatsNetworkIOWaits)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.WaitStatsPageIOLatchWaits = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WaitStatsPageIOLatchWaits)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.WaitStatsPageLatchWaits = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WaitStatsPageLatchWaits)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.WaitStatsNonpageLatchWaits = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WaitStatsNonpageLatchWaits)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.WaitStatsWaitForTheWorkerWaits = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WaitStatsWaitForTheWorkerWaits)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.WaitStatsWorkspaceSynchronizationWaits = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WaitStatsWorkspaceSynchronizationWaits)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.WaitStatsTransactionOwnershipWaits = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WaitStatsTransactionOwnershipWaits)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfNetframeworkCLRExceptions = []perflibField{
+	{index: 1, counter: "# of Exceps Thrown"},
+	{index: 2, counter: "# of Filters / sec"},
+	{index: 3, counter: "# of Finallys / sec"},
+	{index: 4, counter: "Throw To Catch Depth / sec"},
+}
+
+func unmarshalNetframeworkCLRExceptions(obj *perflib.PerfObject, vs *[]netframeworkCLRExceptions) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*netframeworkCLRExceptions)(nil)).Elem(), perflibFieldsOfNetframeworkCLRExceptions)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]netframeworkCLRExceptions, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.NumberofExcepsThrown = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberofExcepsThrown)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.NumberofFiltersPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberofFiltersPersec)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.NumberofFinallysPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberofFinallysPersec)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.ThrowToCatchDepthPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ThrowToCatchDepthPersec)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfNetframeworkCLRInterop = []perflibField{
+	{index: 1, counter: "# of CCWs"},
+	{index: 2, counter: "# of Stubs"},
+	{index: 3, counter: "# of marshalling"},
+}
+
+func unmarshalNetframeworkCLRInterop(obj *perflib.PerfObject, vs *[]netframeworkCLRInterop) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*netframeworkCLRInterop)(nil)).Elem(), perflibFieldsOfNetframeworkCLRInterop)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]netframeworkCLRInterop, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.NumberofCCWs = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberofCCWs)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.NumberofStubs = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberofStubs)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.Numberofmarshalling = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Numberofmarshalling)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfNetframeworkCLRJit = []perflibField{
+	{index: 1, counter: "# of Methods Jitted"},
+	{index: 2, counter: "Total # of IL Bytes Jitted"},
+	{index: 3, counter: "Standard Jit Failures"},
+	{index: 4, counter: "% Time in Jit"},
+}
+
+func unmarshalNetframeworkCLRJit(obj *perflib.PerfObject, vs *[]netframeworkCLRJit) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*netframeworkCLRJit)(nil)).Elem(), perflibFieldsOfNetframeworkCLRJit)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]netframeworkCLRJit, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.NumberofMethodsJitted = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberofMethodsJitted)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.TotalNumberofILBytesJitted = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TotalNumberofILBytesJitted)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.StandardJitFailures = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.StandardJitFailures)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.PercentTimeinJit = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentTimeinJit)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfNetframeworkCLRLoading = []perflibField{
+	{index: 1, counter: "Current Classes Loaded"},
+	{index: 2, counter: "Total Classes Loaded"},
+	{index: 3, counter: "Current appdomains"},
+	{index: 4, counter: "Total Appdomains"},
+	{index: 5, counter: "Current Assemblies"},
+	{index: 6, counter: "Total Assemblies"},
+	{index: 7, counter: "Total # of Load Failures"},
+	{index: 8, counter: "Bytes in Loader Heap"},
+	{index: 9, counter: "Total appdomains unloaded"},
+}
+
+func unmarshalNetframeworkCLRLoading(obj *perflib.PerfObject, vs *[]netframeworkCLRLoading) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*netframeworkCLRLoading)(nil)).Elem(), perflibFieldsOfNetframeworkCLRLoading)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]netframeworkCLRLoading, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.CurrentClassesLoaded = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CurrentClassesLoaded)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.TotalClassesLoaded = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TotalClassesLoaded)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.Currentappdomains = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Currentappdomains)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.TotalAppdomains = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TotalAppdomains)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.CurrentAssemblies = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CurrentAssemblies)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.TotalAssemblies = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TotalAssemblies)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.TotalNumberofLoadFailures = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TotalNumberofLoadFailures)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.BytesinLoaderHeap = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BytesinLoaderHeap)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.Totalappdomainsunloaded = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Totalappdomainsunloaded)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfNetframeworkCLRLocksAndThreads = []perflibField{
+	{index: 1, counter: "Total # of Contentions"},
+	{index: 2, counter: "Current Queue Length"},
+	{index: 3, counter: "Queue Length Peak"},
+	{index: 4, counter: "# of current logical Threads"},
+	{index: 5, counter: "# of current physical Threads"},
+	{index: 6, counter: "# of current recognized threads"},
+	{index: 7, counter: "# of total recognized threads"},
+}
+
+func unmarshalNetframeworkCLRLocksAndThreads(obj *perflib.PerfObject, vs *[]netframeworkCLRLocksAndThreads) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*netframeworkCLRLocksAndThreads)(nil)).Elem(), perflibFieldsOfNetframeworkCLRLocksAndThreads)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]netframeworkCLRLocksAndThreads, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.TotalNumberofContentions = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TotalNumberofContentions)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.CurrentQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CurrentQueueLength)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.QueueLengthPeak = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.QueueLengthPeak)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.NumberofcurrentlogicalThreads = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberofcurrentlogicalThreads)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.NumberofcurrentphysicalThreads = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberofcurrentphysicalThreads)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.Numberofcurrentrecognizedthreads = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Numberofcurrentrecognizedthreads)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.Numberoftotalrecognizedthreads = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Numberoftotalrecognizedthreads)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfNetframeworkCLRMemory = []perflibField{
+	{index: 1, counter: "# Gen 0 Collections"},
+	{index: 2, counter: "# Gen 1 Collections"},
+	{index: 3, counter: "# Gen 2 Collections"},
+	{index: 4, counter: "Gen 0 Promoted Bytes/Sec"},
+	{index: 5, counter: "Gen 1 Promoted Bytes/Sec"},
+	{index: 6, counter: "Gen 0 heap size"},
+	{index: 7, counter: "Gen 1 heap size"},
+	{index: 8, counter: "Gen 2 heap size"},
+	{index: 9, counter: "Large Object Heap size"},
+	{index: 10, counter: "Finalization Survivors"},
+	{index: 11, counter: "# GC Handles"},
+	{index: 12, counter: "Allocated Bytes/sec"},
+	{index: 13, counter: "# Induced GC"},
+	{index: 14, counter: "% Time in GC"},
+	{index: 15, counter: "# Total committed Bytes"},
+	{index: 16, counter: "# Total reserved Bytes"},
+	{index: 17, counter: "# of Pinned Objects"},
+	{index: 18, counter: "# of Sink Blocks in use"},
+}
+
+func unmarshalNetframeworkCLRMemory(obj *perflib.PerfObject, vs *[]netframeworkCLRMemory) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*netframeworkCLRMemory)(nil)).Elem(), perflibFieldsOfNetframeworkCLRMemory)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]netframeworkCLRMemory, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.NumberGen0Collections = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberGen0Collections)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.NumberGen1Collections = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberGen1Collections)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.NumberGen2Collections = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberGen2Collections)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.Gen0PromotedBytesPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Gen0PromotedBytesPerSec)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.Gen1PromotedBytesPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Gen1PromotedBytesPerSec)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.Gen0heapsize = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Gen0heapsize)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.Gen1heapsize = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Gen1heapsize)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.Gen2heapsize = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Gen2heapsize)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.LargeObjectHeapsize = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LargeObjectHeapsize)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.FinalizationSurvivors = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FinalizationSurvivors)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.NumberGCHandles = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberGCHandles)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.AllocatedBytesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AllocatedBytesPersec)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.NumberInducedGC = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberInducedGC)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.PercentTimeinGC = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentTimeinGC)
+		}
+		if c := layout.counter(instance, 14); c != nil {
+			v.NumberTotalcommittedBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberTotalcommittedBytes)
+		}
+		if c := layout.counter(instance, 15); c != nil {
+			v.NumberTotalreservedBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberTotalreservedBytes)
+		}
+		if c := layout.counter(instance, 16); c != nil {
+			v.NumberofPinnedObjects = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberofPinnedObjects)
+		}
+		if c := layout.counter(instance, 17); c != nil {
+			v.NumberofSinkBlocksinuse = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberofSinkBlocksinuse)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfNetframeworkCLRRemoting = []perflibField{
+	{index: 1, counter: "Total Remote Calls"},
+	{index: 2, counter: "Channels"},
+	{index: 3, counter: "Context Proxies"},
+	{index: 4, counter: "Context-Bound Classes Loaded"},
+	{index: 5, counter: "Context-Bound Objects Alloc / sec"},
+	{index: 6, counter: "Contexts"},
+}
+
+func unmarshalNetframeworkCLRRemoting(obj *perflib.PerfObject, vs *[]netframeworkCLRRemoting) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*netframeworkCLRRemoting)(nil)).Elem(), perflibFieldsOfNetframeworkCLRRemoting)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]netframeworkCLRRemoting, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.TotalRemoteCalls = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TotalRemoteCalls)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.Channels = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Channels)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.ContextProxies = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ContextProxies)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.ContextBoundClassesLoaded = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ContextBoundClassesLoaded)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.ContextBoundObjectsAllocPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ContextBoundObjectsAllocPersec)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.Contexts = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Contexts)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfNetframeworkCLRSecurity = []perflibField{
+	{index: 1, counter: "Total Runtime Checks"},
+	{index: 2, counter: "# Link Time Checks"},
+	{index: 3, counter: "% Time in RT checks"},
+	{index: 4, counter: "Stack Walk Depth"},
+}
+
+func unmarshalNetframeworkCLRSecurity(obj *perflib.PerfObject, vs *[]netframeworkCLRSecurity) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*netframeworkCLRSecurity)(nil)).Elem(), perflibFieldsOfNetframeworkCLRSecurity)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]netframeworkCLRSecurity, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.TotalRuntimeChecks = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TotalRuntimeChecks)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.NumberLinkTimeChecks = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NumberLinkTimeChecks)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.PercentTimeinRTchecks = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentTimeinRTchecks)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.StackWalkDepth = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.StackWalkDepth)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfNetworkInterface = []perflibField{
+	{index: 0, counter: "Bytes Received/sec"},
+	{index: 1, counter: "Bytes Sent/sec"},
+	{index: 2, counter: "Bytes Total/sec"},
+	{index: 4, counter: "Packets Outbound Discarded"},
+	{index: 5, counter: "Packets Outbound Errors"},
+	{index: 6, counter: "Packets/sec"},
+	{index: 7, counter: "Packets Received Discarded"},
+	{index: 8, counter: "Packets Received Errors"},
+	{index: 9, counter: "Packets Received/sec"},
+	{index: 10, counter: "Packets Received Unknown"},
+	{index: 11, counter: "Packets Sent/sec"},
+	{index: 12, counter: "Current Bandwidth"},
+}
+
+func unmarshalNetworkInterface(obj *perflib.PerfObject, vs *[]networkInterface) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*networkInterface)(nil)).Elem(), perflibFieldsOfNetworkInterface)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]networkInterface, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.BytesReceivedPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BytesReceivedPerSec)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.BytesSentPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BytesSentPerSec)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.BytesTotalPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BytesTotalPerSec)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.PacketsOutboundDiscarded = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PacketsOutboundDiscarded)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.PacketsOutboundErrors = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PacketsOutboundErrors)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.PacketsPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PacketsPerSec)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.PacketsReceivedDiscarded = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PacketsReceivedDiscarded)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.PacketsReceivedErrors = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PacketsReceivedErrors)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.PacketsReceivedPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PacketsReceivedPerSec)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.PacketsReceivedUnknown = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PacketsReceivedUnknown)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.PacketsSentPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PacketsSentPerSec)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.CurrentBandwidth = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CurrentBandwidth)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPagingFileCounter = []perflibField{
+	{index: 1, counter: "% Usage"},
+	{index: 2, counter: "% Usage Peak"},
+}
+
+func unmarshalPagingFileCounter(obj *perflib.PerfObject, vs *[]pagingFileCounter) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*pagingFileCounter)(nil)).Elem(), perflibFieldsOfPagingFileCounter)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]pagingFileCounter, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.Usage = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Usage)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.UsagePeak = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.UsagePeak)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibADAccessProcesses = []perflibField{
+	{index: 1, counter: "LDAP Read Time"},
+	{index: 2, counter: "LDAP Search Time"},
+	{index: 3, counter: "LDAP Write Time"},
+	{index: 4, counter: "LDAP Timeout Errors/sec"},
+	{index: 5, counter: "Long Running LDAP Operations/min"},
+}
+
+func unmarshalPerflibADAccessProcesses(obj *perflib.PerfObject, vs *[]perflibADAccessProcesses) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibADAccessProcesses)(nil)).Elem(), perflibFieldsOfPerflibADAccessProcesses)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibADAccessProcesses, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.LDAPReadTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LDAPReadTime)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.LDAPSearchTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LDAPSearchTime)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.LDAPWriteTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LDAPWriteTime)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.LDAPTimeoutErrorsPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LDAPTimeoutErrorsPerSec)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.LongRunningLDAPOperationsPerMin = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LongRunningLDAPOperationsPerMin)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibADFS = []perflibField{
+	{index: 0, counter: "AD login Connection Failures"},
+	{index: 1, counter: "Certificate Authentications", optional: true},
+	{index: 2, counter: "Device Authentications", optional: true},
+	{index: 3, counter: "Extranet Account Lockouts", optional: true},
+	{index: 4, counter: "Federated Authentications"},
+	{index: 5, counter: "Microsoft Passport Authentications", optional: true},
+	{index: 6, counter: "Passive Requests"},
+	{index: 7, counter: "Password Change Failed Requests", optional: true},
+	{index: 8, counter: "Password Change Successful Requests", optional: true},
+	{index: 9, counter: "Token Requests"},
+	{index: 10, counter: "Windows Integrated Authentications"},
+}
+
+func unmarshalPerflibADFS(obj *perflib.PerfObject, vs *[]perflibADFS) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibADFS)(nil)).Elem(), perflibFieldsOfPerflibADFS)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibADFS, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.AdLoginConnectionFailures = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AdLoginConnectionFailures)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.CertificateAuthentications = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CertificateAuthentications)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.DeviceAuthentications = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DeviceAuthentications)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.ExtranetAccountLockouts = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ExtranetAccountLockouts)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.FederatedAuthentications = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FederatedAuthentications)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.PassportAuthentications = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PassportAuthentications)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.PassiveRequests = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PassiveRequests)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.PasswordChangeFailed = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PasswordChangeFailed)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.PasswordChangeSucceeded = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PasswordChangeSucceeded)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.TokenRequests = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TokenRequests)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.WindowsIntegratedAuthentications = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WindowsIntegratedAuthentications)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibActiveSync = []perflibField{
+	{index: 0, counter: "Requests/sec"},
+	{index: 1, counter: "Ping Commands Pending"},
+	{index: 2, counter: "Sync Commands/sec"},
+}
+
+func unmarshalPerflibActiveSync(obj *perflib.PerfObject, vs *[]perflibActiveSync) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibActiveSync)(nil)).Elem(), perflibFieldsOfPerflibActiveSync)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibActiveSync, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.RequestsPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RequestsPerSec)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.PingCommandsPending = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PingCommandsPending)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.SyncCommandsPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SyncCommandsPerSec)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibAutodiscover = []perflibField{
+	{index: 0, counter: "Requests/sec"},
+}
+
+func unmarshalPerflibAutodiscover(obj *perflib.PerfObject, vs *[]perflibAutodiscover) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibAutodiscover)(nil)).Elem(), perflibFieldsOfPerflibAutodiscover)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibAutodiscover, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.RequestsPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RequestsPerSec)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibAvailabilityService = []perflibField{
+	{index: 0, counter: "Availability Requests (sec)"},
+}
+
+func unmarshalPerflibAvailabilityService(obj *perflib.PerfObject, vs *[]perflibAvailabilityService) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibAvailabilityService)(nil)).Elem(), perflibFieldsOfPerflibAvailabilityService)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibAvailabilityService, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.RequestsSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RequestsSec)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibCache = []perflibField{
+	{index: 0, counter: "Async Copy Reads/sec"},
+	{index: 1, counter: "Async Data Maps/sec"},
+	{index: 2, counter: "Async Fast Reads/sec"},
+	{index: 3, counter: "Async MDL Reads/sec"},
+	{index: 4, counter: "Async Pin Reads/sec"},
+	{index: 5, counter: "Copy Read Hits %"},
+	{index: 6, counter: "Copy Reads/sec"},
+	{index: 7, counter: "Data Flushes/sec"},
+	{index: 8, counter: "Data Flush Pages/sec"},
+	{index: 9, counter: "Data Map Hits %"},
+	{index: 10, counter: "Data Map Pins/sec"},
+	{index: 11, counter: "Data Maps/sec"},
+	{index: 12, counter: "Dirty Pages"},
+	{index: 13, counter: "Dirty Page Threshold"},
+	{index: 14, counter: "Fast Read Not Possibles/sec"},
+	{index: 15, counter: "Fast Read Resource Misses/sec"},
+	{index: 16, counter: "Fast Reads/sec"},
+	{index: 17, counter: "Lazy Write Flushes/sec"},
+	{index: 18, counter: "Lazy Write Pages/sec"},
+	{index: 19, counter: "MDL Read Hits %"},
+	{index: 20, counter: "MDL Reads/sec"},
+	{index: 21, counter: "Pin Read Hits %"},
+	{index: 22, counter: "Pin Reads/sec"},
+	{index: 23, counter: "Read Aheads/sec"},
+	{index: 24, counter: "Sync Copy Reads/sec"},
+	{index: 25, counter: "Sync Data Maps/sec"},
+	{index: 26, counter: "Sync Fast Reads/sec"},
+	{index: 27, counter: "Sync MDL Reads/sec"},
+	{index: 28, counter: "Sync Pin Reads/sec"},
+}
+
+func unmarshalPerflibCache(obj *perflib.PerfObject, vs *[]perflibCache) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibCache)(nil)).Elem(), perflibFieldsOfPerflibCache)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibCache, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.AsyncCopyReadsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AsyncCopyReadsTotal)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.AsyncDataMapsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AsyncDataMapsTotal)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.AsyncFastReadsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AsyncFastReadsTotal)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.AsyncMDLReadsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AsyncMDLReadsTotal)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.AsyncPinReadsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AsyncPinReadsTotal)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.CopyReadHitsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CopyReadHitsTotal)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.CopyReadsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CopyReadsTotal)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.DataFlushesTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DataFlushesTotal)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.DataFlushPagesTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DataFlushPagesTotal)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.DataMapHitsPercent = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DataMapHitsPercent)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.DataMapPinsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DataMapPinsTotal)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.DataMapsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DataMapsTotal)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.DirtyPages = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DirtyPages)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.DirtyPageThreshold = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DirtyPageThreshold)
+		}
+		if c := layout.counter(instance, 14); c != nil {
+			v.FastReadNotPossiblesTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FastReadNotPossiblesTotal)
+		}
+		if c := layout.counter(instance, 15); c != nil {
+			v.FastReadResourceMissesTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FastReadResourceMissesTotal)
+		}
+		if c := layout.counter(instance, 16); c != nil {
+			v.FastReadsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FastReadsTotal)
+		}
+		if c := layout.counter(instance, 17); c != nil {
+			v.LazyWriteFlushesTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LazyWriteFlushesTotal)
+		}
+		if c := layout.counter(instance, 18); c != nil {
+			v.LazyWritePagesTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.LazyWritePagesTotal)
+		}
+		if c := layout.counter(instance, 19); c != nil {
+			v.MDLReadHitsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MDLReadHitsTotal)
+		}
+		if c := layout.counter(instance, 20); c != nil {
+			v.MDLReadsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MDLReadsTotal)
+		}
+		if c := layout.counter(instance, 21); c != nil {
+			v.PinReadHitsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PinReadHitsTotal)
+		}
+		if c := layout.counter(instance, 22); c != nil {
+			v.PinReadsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PinReadsTotal)
+		}
+		if c := layout.counter(instance, 23); c != nil {
+			v.ReadAheadsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ReadAheadsTotal)
+		}
+		if c := layout.counter(instance, 24); c != nil {
+			v.SyncCopyReadsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SyncCopyReadsTotal)
+		}
+		if c := layout.counter(instance, 25); c != nil {
+			v.SyncDataMapsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SyncDataMapsTotal)
+		}
+		if c := layout.counter(instance, 26); c != nil {
+			v.SyncFastReadsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SyncFastReadsTotal)
+		}
+		if c := layout.counter(instance, 27); c != nil {
+			v.SyncMDLReadsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SyncMDLReadsTotal)
+		}
+		if c := layout.counter(instance, 28); c != nil {
+			v.SyncPinReadsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SyncPinReadsTotal)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibHTTPProxy = []perflibField{
+	{index: 1, counter: "MailboxServerLocator Average Latency (Moving Average)"},
+	{index: 2, counter: "Average Authentication Latency"},
+	{index: 3, counter: "Average ClientAccess Server Processing Latency"},
+	{index: 4, counter: "Mailbox Server Proxy Failure Rate"},
+	{index: 5, counter: "Outstanding Proxy Requests"},
+	{index: 6, counter: "Proxy Requests/Sec"},
+}
+
+func unmarshalPerflibHTTPProxy(obj *perflib.PerfObject, vs *[]perflibHTTPProxy) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibHTTPProxy)(nil)).Elem(), perflibFieldsOfPerflibHTTPProxy)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibHTTPProxy, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.MailboxServerLocatorAverageLatency = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MailboxServerLocatorAverageLatency)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.AverageAuthenticationLatency = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AverageAuthenticationLatency)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.AverageCASProcessingLatency = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AverageCASProcessingLatency)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.MailboxServerProxyFailureRate = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MailboxServerProxyFailureRate)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.OutstandingProxyRequests = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.OutstandingProxyRequests)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.ProxyRequestsPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ProxyRequestsPerSec)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibOWA = []perflibField{
+	{index: 0, counter: "Current Unique Users"},
+	{index: 1, counter: "Requests/sec"},
+}
+
+func unmarshalPerflibOWA(obj *perflib.PerfObject, vs *[]perflibOWA) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibOWA)(nil)).Elem(), perflibFieldsOfPerflibOWA)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibOWA, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.CurrentUniqueUsers = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CurrentUniqueUsers)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.RequestsPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RequestsPerSec)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibProcess = []perflibField{
+	{index: 1, counter: "% Processor Time"},
+	{index: 2, counter: "% Privileged Time"},
+	{index: 3, counter: "% User Time"},
+	{index: 4, counter: "Creating Process ID"},
+	{index: 5, counter: "Elapsed Time"},
+	{index: 6, counter: "Handle Count"},
+	{index: 7, counter: "ID Process"},
+	{index: 8, counter: "IO Data Bytes/sec"},
+	{index: 9, counter: "IO Data Operations/sec"},
+	{index: 10, counter: "IO Other Bytes/sec"},
+	{index: 11, counter: "IO Other Operations/sec"},
+	{index: 12, counter: "IO Read Bytes/sec"},
+	{index: 13, counter: "IO Read Operations/sec"},
+	{index: 14, counter: "IO Write Bytes/sec"},
+	{index: 15, counter: "IO Write Operations/sec"},
+	{index: 16, counter: "Page Faults/sec"},
+	{index: 17, counter: "Page File Bytes Peak"},
+	{index: 18, counter: "Page File Bytes"},
+	{index: 19, counter: "Pool Nonpaged Bytes"},
+	{index: 20, counter: "Pool Paged Bytes"},
+	{index: 21, counter: "Priority Base"},
+	{index: 22, counter: "Private Bytes"},
+	{index: 23, counter: "Thread Count"},
+	{index: 24, counter: "Virtual Bytes Peak"},
+	{index: 25, counter: "Virtual Bytes"},
+	{index: 26, counter: "Working Set - Private"},
+	{index: 27, counter: "Working Set Peak"},
+	{index: 28, counter: "Working Set"},
+}
+
+func unmarshalPerflibProcess(obj *perflib.PerfObject, vs *[]perflibProcess) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibProcess)(nil)).Elem(), perflibFieldsOfPerflibProcess)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibProcess, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.PercentProcessorTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentProcessorTime)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.PercentPrivilegedTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentPrivilegedTime)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.PercentUserTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentUserTime)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.CreatingProcessID = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CreatingProcessID)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.ElapsedTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ElapsedTime)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.HandleCount = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.HandleCount)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.IDProcess = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IDProcess)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.IODataBytesPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IODataBytesPerSec)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.IODataOperationsPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IODataOperationsPerSec)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.IOOtherBytesPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IOOtherBytesPerSec)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.IOOtherOperationsPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IOOtherOperationsPerSec)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.IOReadBytesPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IOReadBytesPerSec)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.IOReadOperationsPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IOReadOperationsPerSec)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.IOWriteBytesPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IOWriteBytesPerSec)
+		}
+		if c := layout.counter(instance, 14); c != nil {
+			v.IOWriteOperationsPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IOWriteOperationsPerSec)
+		}
+		if c := layout.counter(instance, 15); c != nil {
+			v.PageFaultsPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PageFaultsPerSec)
+		}
+		if c := layout.counter(instance, 16); c != nil {
+			v.PageFileBytesPeak = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PageFileBytesPeak)
+		}
+		if c := layout.counter(instance, 17); c != nil {
+			v.PageFileBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PageFileBytes)
+		}
+		if c := layout.counter(instance, 18); c != nil {
+			v.PoolNonpagedBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PoolNonpagedBytes)
+		}
+		if c := layout.counter(instance, 19); c != nil {
+			v.PoolPagedBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PoolPagedBytes)
+		}
+		if c := layout.counter(instance, 20); c != nil {
+			v.PriorityBase = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PriorityBase)
+		}
+		if c := layout.counter(instance, 21); c != nil {
+			v.PrivateBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PrivateBytes)
+		}
+		if c := layout.counter(instance, 22); c != nil {
+			v.ThreadCount = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ThreadCount)
+		}
+		if c := layout.counter(instance, 23); c != nil {
+			v.VirtualBytesPeak = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.VirtualBytesPeak)
+		}
+		if c := layout.counter(instance, 24); c != nil {
+			v.VirtualBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.VirtualBytes)
+		}
+		if c := layout.counter(instance, 25); c != nil {
+			v.WorkingSetPrivate = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WorkingSetPrivate)
+		}
+		if c := layout.counter(instance, 26); c != nil {
+			v.WorkingSetPeak = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WorkingSetPeak)
+		}
+		if c := layout.counter(instance, 27); c != nil {
+			v.WorkingSet = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WorkingSet)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibProcessor = []perflibField{
+	{index: 1, counter: "C1 Transitions/sec"},
+	{index: 2, counter: "C2 Transitions/sec"},
+	{index: 3, counter: "C3 Transitions/sec"},
+	{index: 4, counter: "DPC Rate"},
+	{index: 5, counter: "DPCs Queued/sec"},
+	{index: 6, counter: "Interrupts/sec"},
+	{index: 7, counter: "% C1 Time"},
+	{index: 8, counter: "% C2 Time"},
+	{index: 9, counter: "% C3 Time"},
+	{index: 10, counter: "% DPC Time"},
+	{index: 11, counter: "% Idle Time"},
+	{index: 12, counter: "% Interrupt Time"},
+	{index: 13, counter: "% Privileged Time"},
+	{index: 14, counter: "% Processor Time"},
+	{index: 15, counter: "% User Time"},
+}
+
+func unmarshalPerflibProcessor(obj *perflib.PerfObject, vs *[]perflibProcessor) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibProcessor)(nil)).Elem(), perflibFieldsOfPerflibProcessor)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibProcessor, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.C1Transitions = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.C1Transitions)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.C2Transitions = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.C2Transitions)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.C3Transitions = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.C3Transitions)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.DPCRate = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DPCRate)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.DPCsQueued = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DPCsQueued)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.Interrupts = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Interrupts)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.PercentC2Time = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentC2Time)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.PercentC3Time = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentC3Time)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.PercentC1Time = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentC1Time)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.PercentDPCTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentDPCTime)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.PercentIdleTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentIdleTime)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.PercentInterruptTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentInterruptTime)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.PercentPrivilegedTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentPrivilegedTime)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.PercentProcessorTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentProcessorTime)
+		}
+		if c := layout.counter(instance, 14); c != nil {
+			v.PercentUserTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentUserTime)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibProcessorInformation = []perflibField{
+	{index: 1, counter: "% C1 Time"},
+	{index: 2, counter: "% C2 Time"},
+	{index: 3, counter: "% C3 Time"},
+	{index: 4, counter: "C1 Transitions/sec"},
+	{index: 5, counter: "C2 Transitions/sec"},
+	{index: 6, counter: "C3 Transitions/sec"},
+	{index: 7, counter: "Clock Interrupts/sec"},
+	{index: 8, counter: "DPCs Queued/sec"},
+	{index: 9, counter: "% DPC Time"},
+	{index: 10, counter: "Idle Break Events/sec"},
+	{index: 11, counter: "% Idle Time"},
+	{index: 12, counter: "Interrupts/sec"},
+	{index: 13, counter: "% Interrupt Time"},
+	{index: 14, counter: "Parking Status"},
+	{index: 15, counter: "% Performance Limit"},
+	{index: 16, counter: "% Priority Time"},
+	{index: 17, counter: "% Privileged Time"},
+	{index: 18, counter: "% Privileged Utility", optional: true},
+	{index: 19, counter: "Processor Frequency"},
+	{index: 20, counter: "% Processor Performance", optional: true},
+	{index: 21, counter: "% Processor Time"},
+	{index: 22, counter: "% Processor Utility", optional: true},
+	{index: 23, counter: "% User Time"},
+}
+
+func unmarshalPerflibProcessorInformation(obj *perflib.PerfObject, vs *[]perflibProcessorInformation) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibProcessorInformation)(nil)).Elem(), perflibFieldsOfPerflibProcessorInformation)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibProcessorInformation, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.C1TimeSeconds = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.C1TimeSeconds)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.C2TimeSeconds = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.C2TimeSeconds)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.C3TimeSeconds = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.C3TimeSeconds)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.C1TransitionsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.C1TransitionsTotal)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.C2TransitionsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.C2TransitionsTotal)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.C3TransitionsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.C3TransitionsTotal)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.ClockInterruptsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ClockInterruptsTotal)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.DPCsQueuedTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DPCsQueuedTotal)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.DPCTimeSeconds = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.DPCTimeSeconds)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.IdleBreakEventsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IdleBreakEventsTotal)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.IdleTimeSeconds = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IdleTimeSeconds)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.InterruptsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.InterruptsTotal)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.InterruptTimeSeconds = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.InterruptTimeSeconds)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.ParkingStatus = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ParkingStatus)
+		}
+		if c := layout.counter(instance, 14); c != nil {
+			v.PerformanceLimitPercent = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PerformanceLimitPercent)
+		}
+		if c := layout.counter(instance, 15); c != nil {
+			v.PriorityTimeSeconds = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PriorityTimeSeconds)
+		}
+		if c := layout.counter(instance, 16); c != nil {
+			v.PrivilegedTimeSeconds = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PrivilegedTimeSeconds)
+		}
+		if c := layout.counter(instance, 17); c != nil {
+			v.PrivilegedUtilitySeconds = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PrivilegedUtilitySeconds)
+		}
+		if c := layout.counter(instance, 18); c != nil {
+			v.ProcessorFrequencyMHz = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ProcessorFrequencyMHz)
+		}
+		if c := layout.counter(instance, 19); c != nil {
+			v.ProcessorPerformance = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ProcessorPerformance)
+		}
+		if c := layout.counter(instance, 20); c != nil {
+			v.ProcessorTimeSeconds = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ProcessorTimeSeconds)
+		}
+		if c := layout.counter(instance, 21); c != nil {
+			v.ProcessorUtilityRate = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ProcessorUtilityRate)
+		}
+		if c := layout.counter(instance, 22); c != nil {
+			v.UserTimeSeconds = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.UserTimeSeconds)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibRPCClientAccess = []perflibField{
+	{index: 0, counter: "RPC Averaged Latency"},
+	{index: 1, counter: "RPC Requests"},
+	{index: 2, counter: "Active User Count"},
+	{index: 3, counter: "Connection Count"},
+	{index: 4, counter: "RPC Operations/sec"},
+	{index: 5, counter: "User Count"},
+}
+
+func unmarshalPerflibRPCClientAccess(obj *perflib.PerfObject, vs *[]perflibRPCClientAccess) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibRPCClientAccess)(nil)).Elem(), perflibFieldsOfPerflibRPCClientAccess)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibRPCClientAccess, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.RPCAveragedLatency = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RPCAveragedLatency)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.RPCRequests = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RPCRequests)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.ActiveUserCount = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ActiveUserCount)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.ConnectionCount = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ConnectionCount)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.RPCOperationsPerSec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RPCOperationsPerSec)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.UserCount = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.UserCount)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibRemoteDesktopConnectionBrokerCounterset = []perflibField{
+	{index: 0, counter: "Successful Connections"},
+	{index: 1, counter: "Pending Connections"},
+	{index: 2, counter: "Failed Connections"},
+}
+
+func unmarshalPerflibRemoteDesktopConnectionBrokerCounterset(obj *perflib.PerfObject, vs *[]perflibRemoteDesktopConnectionBrokerCounterset) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibRemoteDesktopConnectionBrokerCounterset)(nil)).Elem(), perflibFieldsOfPerflibRemoteDesktopConnectionBrokerCounterset)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibRemoteDesktopConnectionBrokerCounterset, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.SuccessfulConnections = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SuccessfulConnections)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.PendingConnections = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PendingConnections)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.FailedConnections = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FailedConnections)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibRemoteFxGraphics = []perflibField{
+	{index: 1, counter: "Average Encoding Time"},
+	{index: 2, counter: "Frame Quality"},
+	{index: 3, counter: "Frames Skipped/Second - Insufficient Server Resources"},
+	{index: 4, counter: "Frames Skipped/Second - Insufficient Network Resources"},
+	{index: 5, counter: "Frames Skipped/Second - Insufficient Client Resources"},
+	{index: 6, counter: "Graphics Compression ratio"},
+	{index: 7, counter: "Input Frames/Second"},
+	{index: 8, counter: "Output Frames/Second"},
+	{index: 9, counter: "Source Frames/Second"},
+}
+
+func unmarshalPerflibRemoteFxGraphics(obj *perflib.PerfObject, vs *[]perflibRemoteFxGraphics) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibRemoteFxGraphics)(nil)).Elem(), perflibFieldsOfPerflibRemoteFxGraphics)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibRemoteFxGraphics, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.AverageEncodingTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.AverageEncodingTime)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.FrameQuality = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FrameQuality)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.FramesSkippedPerSecondInsufficientClientResources = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FramesSkippedPerSecondInsufficientClientResources)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.FramesSkippedPerSecondInsufficientNetworkResources = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FramesSkippedPerSecondInsufficientNetworkResources)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.FramesSkippedPerSecondInsufficientServerResources = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.FramesSkippedPerSecondInsufficientServerResources)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.GraphicsCompressionratio = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.GraphicsCompressionratio)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.InputFramesPerSecond = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.InputFramesPerSecond)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.OutputFramesPerSecond = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.OutputFramesPerSecond)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.SourceFramesPerSecond = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SourceFramesPerSecond)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibRemoteFxNetwork = []perflibField{
+	{index: 1, counter: "Base TCP RTT"},
+	{index: 2, counter: "Base UDP RTT"},
+	{index: 3, counter: "Current TCP Bandwidth"},
+	{index: 4, counter: "Current TCP RTT"},
+	{index: 5, counter: "Current UDP Bandwidth"},
+	{index: 6, counter: "Current UDP RTT"},
+	{index: 7, counter: "Total Received Bytes"},
+	{index: 8, counter: "Total Sent Bytes"},
+	{index: 9, counter: "UDP Packets Received/sec"},
+	{index: 10, counter: "UDP Packets Sent/sec"},
+}
+
+func unmarshalPerflibRemoteFxNetwork(obj *perflib.PerfObject, vs *[]perflibRemoteFxNetwork) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibRemoteFxNetwork)(nil)).Elem(), perflibFieldsOfPerflibRemoteFxNetwork)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibRemoteFxNetwork, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.BaseTCPRTT = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BaseTCPRTT)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.BaseUDPRTT = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.BaseUDPRTT)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.CurrentTCPBandwidth = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CurrentTCPBandwidth)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.CurrentTCPRTT = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CurrentTCPRTT)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.CurrentUDPBandwidth = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CurrentUDPBandwidth)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.CurrentUDPRTT = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CurrentUDPRTT)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.TotalReceivedBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TotalReceivedBytes)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.TotalSentBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TotalSentBytes)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.UDPPacketsReceivedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.UDPPacketsReceivedPersec)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.UDPPacketsSentPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.UDPPacketsSentPersec)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibTerminalServices = []perflibField{
+	{index: 0, counter: "Active Sessions"},
+	{index: 1, counter: "Inactive Sessions"},
+	{index: 2, counter: "Total Sessions"},
+}
+
+func unmarshalPerflibTerminalServices(obj *perflib.PerfObject, vs *[]perflibTerminalServices) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibTerminalServices)(nil)).Elem(), perflibFieldsOfPerflibTerminalServices)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibTerminalServices, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.ActiveSessions = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ActiveSessions)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.InactiveSessions = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.InactiveSessions)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.TotalSessions = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.TotalSessions)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibTerminalServicesSession = []perflibField{
+	{index: 1, counter: "Handle Count"},
+	{index: 2, counter: "Page Faults/sec"},
+	{index: 3, counter: "Page File Bytes"},
+	{index: 4, counter: "Page File Bytes Peak"},
+	{index: 5, counter: "% Privileged Time"},
+	{index: 6, counter: "% Processor Time"},
+	{index: 7, counter: "% User Time"},
+	{index: 8, counter: "Pool Nonpaged Bytes"},
+	{index: 9, counter: "Pool Paged Bytes"},
+	{index: 10, counter: "Private Bytes"},
+	{index: 11, counter: "Thread Count"},
+	{index: 12, counter: "Virtual Bytes"},
+	{index: 13, counter: "Virtual Bytes Peak"},
+	{index: 14, counter: "Working Set"},
+	{index: 15, counter: "Working Set Peak"},
+}
+
+func unmarshalPerflibTerminalServicesSession(obj *perflib.PerfObject, vs *[]perflibTerminalServicesSession) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibTerminalServicesSession)(nil)).Elem(), perflibFieldsOfPerflibTerminalServicesSession)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibTerminalServicesSession, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.HandleCount = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.HandleCount)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.PageFaultsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PageFaultsPersec)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.PageFileBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PageFileBytes)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.PageFileBytesPeak = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PageFileBytesPeak)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.PercentPrivilegedTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentPrivilegedTime)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.PercentProcessorTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentProcessorTime)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.PercentUserTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PercentUserTime)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.PoolNonpagedBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PoolNonpagedBytes)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.PoolPagedBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PoolPagedBytes)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.PrivateBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PrivateBytes)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.ThreadCount = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ThreadCount)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.VirtualBytes = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.VirtualBytes)
+		}
+		if c := layout.counter(instance, 12); c != nil {
+			v.VirtualBytesPeak = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.VirtualBytesPeak)
+		}
+		if c := layout.counter(instance, 13); c != nil {
+			v.WorkingSet = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WorkingSet)
+		}
+		if c := layout.counter(instance, 14); c != nil {
+			v.WorkingSetPeak = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.WorkingSetPeak)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibTransportQueues = []perflibField{
+	{index: 1, counter: "External Active Remote Delivery Queue Length"},
+	{index: 2, counter: "Internal Active Remote Delivery Queue Length"},
+	{index: 3, counter: "Active Mailbox Delivery Queue Length"},
+	{index: 4, counter: "Retry Mailbox Delivery Queue Length"},
+	{index: 5, counter: "Unreachable Queue Length"},
+	{index: 6, counter: "External Largest Delivery Queue Length"},
+	{index: 7, counter: "Internal Largest Delivery Queue Length"},
+	{index: 8, counter: "Poison Queue Length"},
+}
+
+func unmarshalPerflibTransportQueues(obj *perflib.PerfObject, vs *[]perflibTransportQueues) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibTransportQueues)(nil)).Elem(), perflibFieldsOfPerflibTransportQueues)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibTransportQueues, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.ExternalActiveRemoteDeliveryQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ExternalActiveRemoteDeliveryQueueLength)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.InternalActiveRemoteDeliveryQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.InternalActiveRemoteDeliveryQueueLength)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.ActiveMailboxDeliveryQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ActiveMailboxDeliveryQueueLength)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.RetryMailboxDeliveryQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.RetryMailboxDeliveryQueueLength)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.UnreachableQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.UnreachableQueueLength)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.ExternalLargestDeliveryQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ExternalLargestDeliveryQueueLength)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.InternalLargestDeliveryQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.InternalLargestDeliveryQueueLength)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.PoisonQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.PoisonQueueLength)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfPerflibWorkloadManagementWorkloads = []perflibField{
+	{index: 1, counter: "ActiveTasks"},
+	{index: 2, counter: "CompletedTasks"},
+	{index: 3, counter: "QueuedTasks"},
+	{index: 4, counter: "YieldedTasks"},
+	{index: 5, counter: "Active"},
+}
+
+func unmarshalPerflibWorkloadManagementWorkloads(obj *perflib.PerfObject, vs *[]perflibWorkloadManagementWorkloads) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*perflibWorkloadManagementWorkloads)(nil)).Elem(), perflibFieldsOfPerflibWorkloadManagementWorkloads)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]perflibWorkloadManagementWorkloads, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.ActiveTasks = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ActiveTasks)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.CompletedTasks = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CompletedTasks)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.QueuedTasks = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.QueuedTasks)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.YieldedTasks = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.YieldedTasks)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.IsActive = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.IsActive)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfSystem = []perflibField{
+	{index: 0, counter: "Context Switches/sec"},
+	{index: 1, counter: "Exception Dispatches/sec"},
+	{index: 2, counter: "Processor Queue Length"},
+	{index: 3, counter: "System Calls/sec"},
+	{index: 4, counter: "System Up Time"},
+	{index: 5, counter: "Threads"},
+}
+
+func unmarshalSystem(obj *perflib.PerfObject, vs *[]system) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*system)(nil)).Elem(), perflibFieldsOfSystem)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]system, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.ContextSwitchesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ContextSwitchesPersec)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.ExceptionDispatchesPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ExceptionDispatchesPersec)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.ProcessorQueueLength = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ProcessorQueueLength)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.SystemCallsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SystemCallsPersec)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.SystemUpTime = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SystemUpTime)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.Threads = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.Threads)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfTcp = []perflibField{
+	{index: 0, counter: "Connection Failures"},
+	{index: 1, counter: "Connections Active"},
+	{index: 2, counter: "Connections Established"},
+	{index: 3, counter: "Connections Passive"},
+	{index: 4, counter: "Connections Reset"},
+	{index: 5, counter: "Segments/sec"},
+	{index: 6, counter: "Segments Received/sec"},
+	{index: 7, counter: "Segments Retransmitted/sec"},
+	{index: 8, counter: "Segments Sent/sec"},
+}
+
+func unmarshalTcp(obj *perflib.PerfObject, vs *[]tcp) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*tcp)(nil)).Elem(), perflibFieldsOfTcp)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]tcp, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.ConnectionFailures = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ConnectionFailures)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.ConnectionsActive = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ConnectionsActive)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.ConnectionsEstablished = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ConnectionsEstablished)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.ConnectionsPassive = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ConnectionsPassive)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.ConnectionsReset = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ConnectionsReset)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.SegmentsPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SegmentsPersec)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.SegmentsReceivedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SegmentsReceivedPersec)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.SegmentsRetransmittedPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SegmentsRetransmittedPersec)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.SegmentsSentPersec = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.SegmentsSentPersec)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfVmwareCPU = []perflibField{
+	{index: 1, counter: "Limit in MHz"},
+	{index: 2, counter: "Reservation in MHz"},
+	{index: 3, counter: "Shares"},
+	{index: 4, counter: "CPU stolen time"},
+	{index: 5, counter: "% Processor Time"},
+	{index: 6, counter: "Effective VM Speed in MHz"},
+	{index: 7, counter: "Host processor speed in MHz"},
+}
+
+func unmarshalVmwareCPU(obj *perflib.PerfObject, vs *[]vmwareCPU) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*vmwareCPU)(nil)).Elem(), perflibFieldsOfVmwareCPU)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]vmwareCPU, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.CpuLimitMHz = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CpuLimitMHz)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.CpuReservationMHz = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CpuReservationMHz)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.CpuShares = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CpuShares)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.CpuStolenSeconds = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CpuStolenSeconds)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.CpuTimeSeconds = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.CpuTimeSeconds)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.EffectiveVMSpeedMHz = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.EffectiveVMSpeedMHz)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.HostProcessorSpeedMHz = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.HostProcessorSpeedMHz)
+		}
+		if instance.Name != "" {
+			v.Name = instance.Name
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfVmwareMem = []perflibField{
+	{index: 0, counter: "Memory Active in MB"},
+	{index: 1, counter: "Memory Ballooned in MB"},
+	{index: 2, counter: "Memory Limit in MB"},
+	{index: 3, counter: "Memory Mapped in MB"},
+	{index: 4, counter: "Memory Overhead in MB"},
+	{index: 5, counter: "Memory Reservation in MB"},
+	{index: 6, counter: "Memory Shared in MB"},
+	{index: 7, counter: "Memory Shared Saved in MB"},
+	{index: 8, counter: "Memory Shares"},
+	{index: 9, counter: "Memory Swapped in MB"},
+	{index: 10, counter: "Memory Target Size"},
+	{index: 11, counter: "Memory Used in MB"},
+}
+
+func unmarshalVmwareMem(obj *perflib.PerfObject, vs *[]vmwareMem) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*vmwareMem)(nil)).Elem(), perflibFieldsOfVmwareMem)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]vmwareMem, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.MemActiveMB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MemActiveMB)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.MemBalloonedMB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MemBalloonedMB)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.MemLimitMB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MemLimitMB)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.MemMappedMB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MemMappedMB)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.MemOverheadMB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MemOverheadMB)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.MemReservationMB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MemReservationMB)
+		}
+		if c := layout.counter(instance, 6); c != nil {
+			v.MemSharedMB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MemSharedMB)
+		}
+		if c := layout.counter(instance, 7); c != nil {
+			v.MemSharedSavedMB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MemSharedSavedMB)
+		}
+		if c := layout.counter(instance, 8); c != nil {
+			v.MemShares = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MemShares)
+		}
+		if c := layout.counter(instance, 9); c != nil {
+			v.MemSwappedMB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MemSwappedMB)
+		}
+		if c := layout.counter(instance, 10); c != nil {
+			v.MemTargetSizeMB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MemTargetSizeMB)
+		}
+		if c := layout.counter(instance, 11); c != nil {
+			v.MemUsedMB = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.MemUsedMB)
+		}
+	}
+
+	return present, nil
+}
+
+var perflibFieldsOfWindowsTime = []perflibField{
+	{index: 0, counter: "Clock Frequency Adjustment (ppb)", optional: true},
+	{index: 1, counter: "Computed Time Offset"},
+	{index: 2, counter: "NTP Client Time Source Count"},
+	{index: 3, counter: "NTP Roundtrip Delay"},
+	{index: 4, counter: "NTP Server Incoming Requests"},
+	{index: 5, counter: "NTP Server Outgoing Responses"},
+}
+
+func unmarshalWindowsTime(obj *perflib.PerfObject, vs *[]windowsTime) (perflibPresence, error) {
+	var present perflibPresence
+	if obj == nil {
+		return present, fmt.Errorf("counter not found")
+	}
+	layout, err := checkCounters(obj, reflect.TypeOf((*windowsTime)(nil)).Elem(), perflibFieldsOfWindowsTime)
+	if err != nil {
+		return present, err
+	}
+
+	if cap(*vs) < len(obj.Instances) {
+		*vs = make([]windowsTime, len(obj.Instances))
+	}
+
+	for i, instance := range obj.Instances {
+		v := &(*vs)[i]
+		if c := layout.counter(instance, 0); c != nil {
+			v.ClockFrequencyAdjustmentPPBTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ClockFrequencyAdjustmentPPBTotal)
+		}
+		if c := layout.counter(instance, 1); c != nil {
+			v.ComputedTimeOffset = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.ComputedTimeOffset)
+		}
+		if c := layout.counter(instance, 2); c != nil {
+			v.NTPClientTimeSourceCount = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NTPClientTimeSourceCount)
+		}
+		if c := layout.counter(instance, 3); c != nil {
+			v.NTPRoundtripDelay = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NTPRoundtripDelay)
+		}
+		if c := layout.counter(instance, 4); c != nil {
+			v.NTPServerIncomingRequestsTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NTPServerIncomingRequestsTotal)
+		}
+		if c := layout.counter(instance, 5); c != nil {
+			v.NTPServerOutgoingResponsesTotal = counterValue(obj, c)
+		} else {
+			present.setAbsent(&v.NTPServerOutgoingResponsesTotal)
+		}
+	}
+
+	return present, nil
+}
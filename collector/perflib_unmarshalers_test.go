@@ -0,0 +1,133 @@
+package collector
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	perflibCollector "github.com/leoluk/perflib_exporter/collector"
+	"github.com/leoluk/perflib_exporter/perflib"
+)
+
+// perfObjectFor returns an object with the counters read into the struct
+// type t, and instances with distinct values. Every skip-th counter is left
+// out, to cover missing counters.
+func perfObjectFor(t reflect.Type, instances, skip int) *perflib.PerfObject {
+	fields, err := perflibFields(t)
+	if err != nil {
+		panic(err)
+	}
+
+	// Counters read with their base are followed by a base counter.
+	withBase := map[string]bool{}
+	for _, f := range fields {
+		if f.value != "" || strings.HasSuffix(f.counter, "_Base") {
+			withBase[strings.TrimSuffix(f.counter, "_Base")] = true
+		}
+	}
+
+	obj := &perflib.PerfObject{Name: "Test", Frequency: 1000}
+	defined := map[string]bool{}
+	for k, f := range fields {
+		name := strings.TrimSuffix(f.counter, "_Base")
+		if defined[name] || (skip > 0 && k%skip == 0) {
+			continue
+		}
+		defined[name] = true
+		if !withBase[name] {
+			obj.CounterDefs = append(obj.CounterDefs, &perflib.PerfCounterDef{Name: name, CounterType: perflibCollector.PERF_COUNTER_BULK_COUNT})
+			continue
+		}
+		obj.CounterDefs = append(obj.CounterDefs,
+			&perflib.PerfCounterDef{Name: name, CounterType: perflibCollector.PERF_AVERAGE_TIMER},
+			&perflib.PerfCounterDef{Name: name, CounterType: perflibCollector.PERF_AVERAGE_BASE, IsBaseValue: true},
+		)
+	}
+
+	for i := 0; i < instances; i++ {
+		instance := &perflib.PerfInstance{Name: fmt.Sprintf("instance#%d", i)}
+		for j, def := range obj.CounterDefs {
+			instance.Counters = append(instance.Counters, &perflib.PerfCounter{Def: def, Value: int64(i*1000 + j + 1)})
+		}
+		obj.Instances = append(obj.Instances, instance)
+	}
+	return obj
+}
+
+func TestPerflibUnmarshalersParity(t *testing.T) {
+	defer func(missing map[string]map[reflect.Type][]string) {
+		missingCounters = missing
+	}(missingCounters)
+	missingCounters = make(map[string]map[reflect.Type][]string)
+
+	if len(perflibUnmarshalers) == 0 {
+		t.Fatal("no generated unmarshalers, run go generate ./collector/")
+	}
+	for typ, unmarshal := range perflibUnmarshalers {
+		for _, skip := range []int{0, 3} {
+			obj := perfObjectFor(typ.Elem().Elem(), 3, skip)
+
+			expected := reflect.New(typ.Elem())
+			expectedPresent, expectedErr := unmarshalObjectReflect(obj, expected.Interface())
+			got := reflect.New(typ.Elem())
+			present, err := unmarshal(obj, got.Interface())
+
+			if (err == nil) != (expectedErr == nil) {
+				t.Errorf("%v (skip %d): expected error %v, got %v", typ, skip, expectedErr, err)
+			}
+			if !reflect.DeepEqual(got.Interface(), expected.Interface()) {
+				t.Errorf("%v (skip %d): expected %+v, got %+v", typ, skip, expected.Elem(), got.Elem())
+			}
+			if e, g := absentFields(expectedPresent, expected.Elem()), absentFields(present, got.Elem()); !reflect.DeepEqual(g, e) {
+				t.Errorf("%v (skip %d): expected absent fields %v, got %v", typ, skip, e, g)
+			}
+		}
+	}
+}
+
+// absentFields returns the fields of the elements of slice which are absent
+// from present, as "<index>.<field>".
+func absentFields(present perflibPresence, slice reflect.Value) []string {
+	var absent []string
+	for i := 0; i < slice.Len(); i++ {
+		elem := slice.Index(i)
+		for j := 0; j < elem.NumField(); j++ {
+			field := elem.Field(j)
+			if field.Kind() != reflect.Float64 {
+				continue
+			}
+			if !present.has(field.Addr().Interface().(*float64)) {
+				absent = append(absent, fmt.Sprintf("%d.%s", i, elem.Type().Field(j).Name))
+			}
+		}
+	}
+	return absent
+}
+
+func TestPerflibUnmarshalersNil(t *testing.T) {
+	var data []perflibProcess
+	if _, err := unmarshalObject(nil, &data); err == nil {
+		t.Errorf("Expected an error, but got ok")
+	}
+}
+
+func benchmarkUnmarshalObject(b *testing.B, unmarshal perflibUnmarshaler) {
+	obj := perfObjectFor(reflect.TypeOf(perflibProcess{}), 2000, 0)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		data := make([]perflibProcess, 0)
+		if _, err := unmarshal(obj, &data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalObjectReflect(b *testing.B) {
+	benchmarkUnmarshalObject(b, unmarshalObjectReflect)
+}
+
+func BenchmarkUnmarshalObjectGenerated(b *testing.B) {
+	benchmarkUnmarshalObject(b, unmarshalObject)
+}
@@ -3,31 +3,19 @@
 package collector
 
 import (
-	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
 
-	"github.com/StackExchange/wmi"
 	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
-	"gopkg.in/alecthomas/kingpin.v2"
 )
 
 func init() {
 	registerCollector("process", newProcessCollector, "Process")
 }
 
-var (
-	processWhitelist = kingpin.Flag(
-		"collector.process.whitelist",
-		"Regexp of processes to include. Process name must both match whitelist and not match blacklist to be included.",
-	).Default(".*").String()
-	processBlacklist = kingpin.Flag(
-		"collector.process.blacklist",
-		"Regexp of processes to exclude. Process name must both match whitelist and not match blacklist to be included.",
-	).Default("").String()
-)
+var processFilterFlags = registerFilterFlags("collector.process.", "processes", "process", "process_id", "creating_process_id").
+	withDeprecatedFlags("collector.process.whitelist", "collector.process.blacklist")
 
 type processCollector struct {
 	StartTime         *prometheus.Desc
@@ -46,16 +34,19 @@ type processCollector struct {
 	WorkingSetPeak    *prometheus.Desc
 	WorkingSet        *prometheus.Desc
 
-	processWhitelistPattern *regexp.Regexp
-	processBlacklistPattern *regexp.Regexp
+	processFilter *instanceFilter
 }
 
 // NewProcessCollector ...
 func newProcessCollector() (Collector, error) {
 	const subsystem = "process"
 
-	if *processWhitelist == ".*" && *processBlacklist == "" {
-		log.Warn("No filters specified for process collector. This will generate a very large number of metrics!")
+	processFilter, err := processFilterFlags.build()
+	if err != nil {
+		return nil, err
+	}
+	if processFilter.isEmpty() {
+		log.With("collector", "process").Warn("No filters specified for process collector. This will generate a very large number of metrics! Consider setting --collectors.max-series.")
 	}
 
 	return &processCollector{
@@ -149,8 +140,7 @@ func newProcessCollector() (Collector, error) {
 			[]string{"process", "process_id", "creating_process_id"},
 			nil,
 		),
-		processWhitelistPattern: regexp.MustCompile(fmt.Sprintf("^(?:%s)$", *processWhitelist)),
-		processBlacklistPattern: regexp.MustCompile(fmt.Sprintf("^(?:%s)$", *processBlacklist)),
+		processFilter: processFilter,
 	}, nil
 }
 
@@ -193,27 +183,31 @@ type WorkerProcess struct {
 
 func (c *processCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
 	data := make([]perflibProcess, 0)
-	err := unmarshalObject(ctx.perfObjects["Process"], &data)
+	present, err := unmarshalObject(ctx.perfObjects["Process"], &data)
 	if err != nil {
 		return err
 	}
 
 	var dst_wp []WorkerProcess
-	q_wp := queryAll(&dst_wp)
-	if err := wmi.QueryNamespace(q_wp, &dst_wp, "root\\WebAdministration"); err != nil {
-		log.Debugf("Could not query WebAdministration namespace for IIS worker processes: %v. Skipping", err)
+	q_wp := querySelect(&dst_wp, "")
+	if err := wmiQueryNamespace(q_wp, &dst_wp, "root\\WebAdministration"); err != nil {
+		log.With("query", "iis_worker_processes").WithError(err).Debug("Could not query WebAdministration namespace for IIS worker processes, skipping")
 	}
 
-	for _, process := range data {
-		if process.Name == "_Total" ||
-			c.processBlacklistPattern.MatchString(process.Name) ||
-			!c.processWhitelistPattern.MatchString(process.Name) {
+	for i := range data {
+		process := &data[i]
+		if process.Name == "_Total" {
 			continue
 		}
-		// Duplicate processes are suffixed # and an index number. Remove those.
-		processName := strings.Split(process.Name, "#")[0]
+		// Processes are told apart by their ID, not by the index suffix of
+		// duplicate names. The deprecated whitelist and blacklist still
+		// match the name with the suffix, as before.
+		processName := trimInstanceIndex(process.Name)
 		pid := strconv.FormatUint(uint64(process.IDProcess), 10)
 		cpid := strconv.FormatUint(uint64(process.CreatingProcessID), 10)
+		if !c.processFilter.matchesRaw(process.Name, processName, pid, cpid) {
+			continue
+		}
 
 		for _, wp := range dst_wp {
 			if wp.ProcessId == uint64(process.IDProcess) {
@@ -222,200 +216,221 @@ func (c *processCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metr
 			}
 		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.StartTime,
 			prometheus.GaugeValue,
-			process.ElapsedTime,
+			&process.ElapsedTime,
 			processName,
 			pid,
 			cpid,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.HandleCount,
 			prometheus.GaugeValue,
-			process.HandleCount,
+			&process.HandleCount,
 			processName,
 			pid,
 			cpid,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.CPUTimeTotal,
 			prometheus.CounterValue,
-			process.PercentPrivilegedTime,
+			&process.PercentPrivilegedTime,
 			processName,
 			pid,
 			cpid,
 			"privileged",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.CPUTimeTotal,
 			prometheus.CounterValue,
-			process.PercentUserTime,
+			&process.PercentUserTime,
 			processName,
 			pid,
 			cpid,
 			"user",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.IOBytesTotal,
 			prometheus.CounterValue,
-			process.IOOtherBytesPerSec,
+			&process.IOOtherBytesPerSec,
 			processName,
 			pid,
 			cpid,
 			"other",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.IOOperationsTotal,
 			prometheus.CounterValue,
-			process.IOOtherOperationsPerSec,
+			&process.IOOtherOperationsPerSec,
 			processName,
 			pid,
 			cpid,
 			"other",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.IOBytesTotal,
 			prometheus.CounterValue,
-			process.IOReadBytesPerSec,
+			&process.IOReadBytesPerSec,
 			processName,
 			pid,
 			cpid,
 			"read",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.IOOperationsTotal,
 			prometheus.CounterValue,
-			process.IOReadOperationsPerSec,
+			&process.IOReadOperationsPerSec,
 			processName,
 			pid,
 			cpid,
 			"read",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.IOBytesTotal,
 			prometheus.CounterValue,
-			process.IOWriteBytesPerSec,
+			&process.IOWriteBytesPerSec,
 			processName,
 			pid,
 			cpid,
 			"write",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.IOOperationsTotal,
 			prometheus.CounterValue,
-			process.IOWriteOperationsPerSec,
+			&process.IOWriteOperationsPerSec,
 			processName,
 			pid,
 			cpid,
 			"write",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PageFaultsTotal,
 			prometheus.CounterValue,
-			process.PageFaultsPerSec,
+			&process.PageFaultsPerSec,
 			processName,
 			pid,
 			cpid,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PageFileBytes,
 			prometheus.GaugeValue,
-			process.PageFileBytes,
+			&process.PageFileBytes,
 			processName,
 			pid,
 			cpid,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PoolBytes,
 			prometheus.GaugeValue,
-			process.PoolNonpagedBytes,
+			&process.PoolNonpagedBytes,
 			processName,
 			pid,
 			cpid,
 			"nonpaged",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PoolBytes,
 			prometheus.GaugeValue,
-			process.PoolPagedBytes,
+			&process.PoolPagedBytes,
 			processName,
 			pid,
 			cpid,
 			"paged",
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PriorityBase,
 			prometheus.GaugeValue,
-			process.PriorityBase,
+			&process.PriorityBase,
 			processName,
 			pid,
 			cpid,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PrivateBytes,
 			prometheus.GaugeValue,
-			process.PrivateBytes,
+			&process.PrivateBytes,
 			processName,
 			pid,
 			cpid,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ThreadCount,
 			prometheus.GaugeValue,
-			process.ThreadCount,
+			&process.ThreadCount,
 			processName,
 			pid,
 			cpid,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.VirtualBytes,
 			prometheus.GaugeValue,
-			process.VirtualBytes,
+			&process.VirtualBytes,
 			processName,
 			pid,
 			cpid,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WorkingSetPrivate,
 			prometheus.GaugeValue,
-			process.WorkingSetPrivate,
+			&process.WorkingSetPrivate,
 			processName,
 			pid,
 			cpid,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WorkingSetPeak,
 			prometheus.GaugeValue,
-			process.WorkingSetPeak,
+			&process.WorkingSetPeak,
 			processName,
 			pid,
 			cpid,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WorkingSet,
 			prometheus.GaugeValue,
-			process.WorkingSet,
+			&process.WorkingSet,
 			processName,
 			pid,
 			cpid,
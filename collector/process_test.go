@@ -1,14 +1,107 @@
 package collector
 
 import (
+	"errors"
+	"reflect"
+	"sort"
 	"testing"
+
+	perflibCollector "github.com/leoluk/perflib_exporter/collector"
+	"github.com/leoluk/perflib_exporter/perflib"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 )
 
-func BenchmarkProcessCollector(b *testing.B) {
-	// Whitelist is not set in testing context (kingpin flags not parsed), causing the collector to skip all processes.
-	localProcessWhitelist := ".+"
-	processWhitelist = &localProcessWhitelist
+func TestProcessCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "process", newProcessCollector)
+}
 
+func BenchmarkProcessCollector(b *testing.B) {
 	// No context name required as collector source is WMI
 	benchmarkCollector(b, "", newProcessCollector)
 }
+
+func TestProcessCollectorTrimsInstanceIndex(t *testing.T) {
+	idProcess := &perflib.PerfCounterDef{Name: "ID Process", CounterType: perflibCollector.PERF_COUNTER_RAWCOUNT}
+	elapsedTime := &perflib.PerfCounterDef{Name: "Elapsed Time", CounterType: perflibCollector.PERF_ELAPSED_TIME}
+	instance := func(name string, pid int64) *perflib.PerfInstance {
+		return &perflib.PerfInstance{Name: name, Counters: []*perflib.PerfCounter{{Def: idProcess, Value: pid}, {Def: elapsedTime}}}
+	}
+	processes := &perflib.PerfObject{
+		Name:        "Process",
+		CounterDefs: []*perflib.PerfCounterDef{idProcess, elapsedTime},
+		Instances: []*perflib.PerfInstance{
+			instance("firefox", 100),
+			instance("firefox#1", 101),
+			instance("firefox#2", 102),
+			instance("firefoxhelper", 103),
+			instance("chrome", 200),
+			instance("_Total", 0),
+		},
+	}
+
+	origQueryNamespace := wmiQueryNamespace
+	defer func() { wmiQueryNamespace = origQueryNamespace }()
+	wmiQueryNamespace = func(string, interface{}, string) error { return errors.New("not installed") }
+
+	labels := []string{"process", "process_id", "creating_process_id"}
+	for _, c := range []struct {
+		name              string
+		include, exclude  []string
+		whitelist         string
+		expectedProcesses []string
+	}{
+		{
+			name:              "include",
+			include:           []string{"firefox"},
+			exclude:           []string{"process_id=102"},
+			expectedProcesses: []string{"firefox 100", "firefox 101"},
+		},
+		{
+			// The deprecated flags match the name with the index suffix.
+			name:              "whitelist",
+			whitelist:         "firefox.+",
+			expectedProcesses: []string{"firefox 101", "firefox 102", "firefoxhelper 103"},
+		},
+	} {
+		flags := &filterFlags{labels: labels, include: &c.include, exclude: &c.exclude, deprecatedInclude: &c.whitelist}
+		filter, err := flags.build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		pc, err := newProcessCollector()
+		if err != nil {
+			t.Fatal(err)
+		}
+		collector := pc.(*processCollector)
+		collector.processFilter = filter
+
+		ch := make(chan prometheus.Metric)
+		go func() {
+			defer close(ch)
+			if err := collector.Collect(&ScrapeContext{perfObjects: map[string]*perflib.PerfObject{"Process": processes}}, ch); err != nil {
+				t.Error(err)
+			}
+		}()
+		var got []string
+		for m := range ch {
+			if m.Desc() != collector.StartTime {
+				continue
+			}
+			var pb dto.Metric
+			if err := m.Write(&pb); err != nil {
+				t.Fatal(err)
+			}
+			labels := map[string]string{}
+			for _, l := range pb.Label {
+				labels[l.GetName()] = l.GetValue()
+			}
+			got = append(got, labels["process"]+" "+labels["process_id"])
+		}
+		sort.Strings(got)
+
+		if !reflect.DeepEqual(got, c.expectedProcesses) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expectedProcesses, got)
+		}
+	}
+}
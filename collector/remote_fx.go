@@ -5,7 +5,6 @@ package collector
 import (
 	"strings"
 
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -156,13 +155,11 @@ func NewRemoteFx() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *RemoteFxCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collectRemoteFXNetworkCount(ctx, ch); err != nil {
-		log.Error("failed collecting terminal services session count metrics:", desc, err)
-		return err
+	if _, err := c.collectRemoteFXNetworkCount(ctx, ch); err != nil {
+		return withQuery("network", err)
 	}
-	if desc, err := c.collectRemoteFXGraphicsCounters(ctx, ch); err != nil {
-		log.Error("failed collecting terminal services session count metrics:", desc, err)
-		return err
+	if _, err := c.collectRemoteFXGraphicsCounters(ctx, ch); err != nil {
+		return withQuery("graphics", err)
 	}
 	return nil
 }
@@ -183,75 +180,92 @@ type perflibRemoteFxNetwork struct {
 
 func (c *RemoteFxCollector) collectRemoteFXNetworkCount(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	dst := make([]perflibRemoteFxNetwork, 0)
-	err := unmarshalObject(ctx.perfObjects["RemoteFX Network"], &dst)
+	present, err := unmarshalObject(ctx.perfObjects["RemoteFX Network"], &dst)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, d := range dst {
+	for i := range dst {
+		d := &dst[i]
 		// only connect metrics for remote named sessions
 		n := strings.ToLower(d.Name)
 		if n == "" || n == "services" || n == "console" {
 			continue
 		}
-		ch <- prometheus.MustNewConstMetric(
-			c.BaseTCPRTT,
-			prometheus.GaugeValue,
-			milliSecToSec(d.BaseTCPRTT),
-			d.Name,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.BaseUDPRTT,
-			prometheus.GaugeValue,
-			milliSecToSec(d.BaseUDPRTT),
-			d.Name,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.CurrentTCPBandwidth,
-			prometheus.GaugeValue,
-			(d.CurrentTCPBandwidth*1000)/8,
-			d.Name,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.CurrentTCPRTT,
-			prometheus.GaugeValue,
-			milliSecToSec(d.CurrentTCPRTT),
-			d.Name,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.CurrentUDPBandwidth,
-			prometheus.GaugeValue,
-			(d.CurrentUDPBandwidth*1000)/8,
-			d.Name,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.CurrentUDPRTT,
-			prometheus.GaugeValue,
-			milliSecToSec(d.CurrentUDPRTT),
-			d.Name,
-		)
-		ch <- prometheus.MustNewConstMetric(
+		if present.has(&d.BaseTCPRTT) {
+			ch <- prometheus.MustNewConstMetric(
+				c.BaseTCPRTT,
+				prometheus.GaugeValue,
+				milliSecToSec(d.BaseTCPRTT),
+				d.Name,
+			)
+		}
+		if present.has(&d.BaseUDPRTT) {
+			ch <- prometheus.MustNewConstMetric(
+				c.BaseUDPRTT,
+				prometheus.GaugeValue,
+				milliSecToSec(d.BaseUDPRTT),
+				d.Name,
+			)
+		}
+		if present.has(&d.CurrentTCPBandwidth) {
+			ch <- prometheus.MustNewConstMetric(
+				c.CurrentTCPBandwidth,
+				prometheus.GaugeValue,
+				(d.CurrentTCPBandwidth*1000)/8,
+				d.Name,
+			)
+		}
+		if present.has(&d.CurrentTCPRTT) {
+			ch <- prometheus.MustNewConstMetric(
+				c.CurrentTCPRTT,
+				prometheus.GaugeValue,
+				milliSecToSec(d.CurrentTCPRTT),
+				d.Name,
+			)
+		}
+		if present.has(&d.CurrentUDPBandwidth) {
+			ch <- prometheus.MustNewConstMetric(
+				c.CurrentUDPBandwidth,
+				prometheus.GaugeValue,
+				(d.CurrentUDPBandwidth*1000)/8,
+				d.Name,
+			)
+		}
+		if present.has(&d.CurrentUDPRTT) {
+			ch <- prometheus.MustNewConstMetric(
+				c.CurrentUDPRTT,
+				prometheus.GaugeValue,
+				milliSecToSec(d.CurrentUDPRTT),
+				d.Name,
+			)
+		}
+		present.emit(
+			ch,
 			c.TotalReceivedBytes,
 			prometheus.CounterValue,
-			d.TotalReceivedBytes,
+			&d.TotalReceivedBytes,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.TotalSentBytes,
 			prometheus.CounterValue,
-			d.TotalSentBytes,
+			&d.TotalSentBytes,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.UDPPacketsReceivedPersec,
 			prometheus.CounterValue,
-			d.UDPPacketsReceivedPersec,
+			&d.UDPPacketsReceivedPersec,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.UDPPacketsSentPersec,
 			prometheus.CounterValue,
-			d.UDPPacketsSentPersec,
+			&d.UDPPacketsSentPersec,
 			d.Name,
 		)
 	}
@@ -273,72 +287,83 @@ type perflibRemoteFxGraphics struct {
 
 func (c *RemoteFxCollector) collectRemoteFXGraphicsCounters(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	dst := make([]perflibRemoteFxGraphics, 0)
-	err := unmarshalObject(ctx.perfObjects["RemoteFX Graphics"], &dst)
+	present, err := unmarshalObject(ctx.perfObjects["RemoteFX Graphics"], &dst)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, d := range dst {
+	for i := range dst {
+		d := &dst[i]
 		// only connect metrics for remote named sessions
 		n := strings.ToLower(d.Name)
 		if n == "" || n == "services" || n == "console" {
 			continue
 		}
-		ch <- prometheus.MustNewConstMetric(
-			c.AverageEncodingTime,
-			prometheus.GaugeValue,
-			milliSecToSec(d.AverageEncodingTime),
-			d.Name,
-		)
-		ch <- prometheus.MustNewConstMetric(
+		if present.has(&d.AverageEncodingTime) {
+			ch <- prometheus.MustNewConstMetric(
+				c.AverageEncodingTime,
+				prometheus.GaugeValue,
+				milliSecToSec(d.AverageEncodingTime),
+				d.Name,
+			)
+		}
+		present.emit(
+			ch,
 			c.FrameQuality,
 			prometheus.GaugeValue,
-			d.FrameQuality,
+			&d.FrameQuality,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FramesSkippedPerSecondInsufficientResources,
 			prometheus.CounterValue,
-			d.FramesSkippedPerSecondInsufficientClientResources,
+			&d.FramesSkippedPerSecondInsufficientClientResources,
 			d.Name,
 			"client",
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FramesSkippedPerSecondInsufficientResources,
 			prometheus.CounterValue,
-			d.FramesSkippedPerSecondInsufficientNetworkResources,
+			&d.FramesSkippedPerSecondInsufficientNetworkResources,
 			d.Name,
 			"network",
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.FramesSkippedPerSecondInsufficientResources,
 			prometheus.CounterValue,
-			d.FramesSkippedPerSecondInsufficientServerResources,
+			&d.FramesSkippedPerSecondInsufficientServerResources,
 			d.Name,
 			"server",
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.GraphicsCompressionratio,
 			prometheus.GaugeValue,
-			d.GraphicsCompressionratio,
+			&d.GraphicsCompressionratio,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.InputFramesPerSecond,
 			prometheus.CounterValue,
-			d.InputFramesPerSecond,
+			&d.InputFramesPerSecond,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.OutputFramesPerSecond,
 			prometheus.CounterValue,
-			d.OutputFramesPerSecond,
+			&d.OutputFramesPerSecond,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.SourceFramesPerSecond,
 			prometheus.CounterValue,
-			d.SourceFramesPerSecond,
+			&d.SourceFramesPerSecond,
 			d.Name,
 		)
 	}
@@ -4,6 +4,10 @@ import (
 	"testing"
 )
 
+func TestRemoteFXCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "remote_fx", NewRemoteFx)
+}
+
 func BenchmarkRemoteFXCollector(b *testing.B) {
 	benchmarkCollector(b, "remote_fx", NewRemoteFx)
 }
@@ -5,8 +5,8 @@ package collector
 import (
 	"strconv"
 	"strings"
+	"time"
 
-	"github.com/StackExchange/wmi"
 	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/alecthomas/kingpin.v2"
@@ -21,6 +21,8 @@ var (
 		"collector.service.services-where",
 		"WQL 'where' clause to use in WMI metrics query. Limits the response to the services you specify and reduces the size of the response.",
 	).Default("").String()
+	serviceFilterFlags = registerFilterFlags("collector.service.", "services", "name", "display_name", "start_mode", "state", "status", "run_as")
+	serviceCacheTTL    = registerWMICacheFlag("service", time.Minute)
 )
 
 // A serviceCollector is a Prometheus collector for WMI Win32_Service metrics
@@ -30,15 +32,23 @@ type serviceCollector struct {
 	StartMode   *prometheus.Desc
 	Status      *prometheus.Desc
 
-	queryWhereClause string
+	queryWhere    wqlCondition
+	serviceFilter *instanceFilter
+	// Caches the configuration of the services, see win32ServiceConfig.
+	cache *wmiCache
 }
 
 // NewserviceCollector ...
 func NewserviceCollector() (Collector, error) {
 	const subsystem = "service"
 
-	if *serviceWhereClause == "" {
-		log.Warn("No where-clause specified for service collector. This will generate a very large number of metrics!")
+	serviceFilter, err := serviceFilterFlags.build()
+	if err != nil {
+		return nil, err
+	}
+
+	if *serviceWhereClause == "" && serviceFilter.isEmpty() {
+		log.With("collector", "service").Warn("No where-clause or filter specified for service collector. This will generate a very large number of metrics!")
 	}
 
 	return &serviceCollector{
@@ -66,15 +76,26 @@ func NewserviceCollector() (Collector, error) {
 			[]string{"name", "status"},
 			nil,
 		),
-		queryWhereClause: *serviceWhereClause,
+		queryWhere:    serviceQueryWhere(*serviceWhereClause, serviceFilter),
+		serviceFilter: serviceFilter,
+		cache:         newWMICache("service", *serviceCacheTTL),
 	}, nil
 }
 
+// serviceQueryWhere returns the condition of the Win32_Service query. If the
+// filter includes services by name only, the query is limited to them.
+func serviceQueryWhere(where string, filter *instanceFilter) wqlCondition {
+	cond := wqlRaw(where)
+	if names, ok := filter.includedValues(0); ok {
+		cond = wqlAnd(cond, wqlIn("Name", names...))
+	}
+	return cond
+}
+
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *serviceCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting service metrics:", desc, err)
+	if _, err := c.collect(ch); err != nil {
 		return err
 	}
 	return nil
@@ -92,6 +113,88 @@ type Win32_Service struct {
 	StartName   *string
 }
 
+// win32ServiceConfig holds the properties of Win32_Service which only change
+// when a service is reconfigured, and are cached.
+type win32ServiceConfig struct {
+	DisplayName string
+	Name        string
+	StartMode   string
+	StartName   *string
+}
+
+// win32ServiceStatus holds the properties of Win32_Service which change while
+// services are running, and are queried on every scrape.
+type win32ServiceStatus struct {
+	Name      string
+	ProcessId uint32
+	State     string
+	Status    string
+}
+
+// queryServices returns the services matching the where clause. If caching
+// is enabled, the configuration and status of the services are queried
+// separately, and the configuration is taken from the cache.
+func (c *serviceCollector) queryServices() ([]Win32_Service, error) {
+	var dst []Win32_Service
+	if c.cache.ttl <= 0 {
+		q := querySelect(&dst, c.queryWhere)
+		err := wmiQuery(q, &dst)
+		return dst, err
+	}
+
+	var status []win32ServiceStatus
+	q := querySelectForClass(&status, "Win32_Service", c.queryWhere)
+	if err := wmiQuery(q, &status); err != nil {
+		return nil, err
+	}
+
+	var config []win32ServiceConfig
+	q = querySelectForClass(&config, "Win32_Service", c.queryWhere)
+	configs, err := c.queryServiceConfig(q, &config)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range status {
+		if _, ok := configs[s.Name]; !ok {
+			// The service was installed after the configuration was cached.
+			c.cache.invalidate(q, "")
+			if configs, err = c.queryServiceConfig(q, &config); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	dst = make([]Win32_Service, 0, len(status))
+	for _, s := range status {
+		cfg, ok := configs[s.Name]
+		if !ok {
+			continue
+		}
+		dst = append(dst, Win32_Service{
+			DisplayName: cfg.DisplayName,
+			Name:        s.Name,
+			ProcessId:   s.ProcessId,
+			State:       s.State,
+			Status:      s.Status,
+			StartMode:   cfg.StartMode,
+			StartName:   cfg.StartName,
+		})
+	}
+	return dst, nil
+}
+
+func (c *serviceCollector) queryServiceConfig(q string, dst *[]win32ServiceConfig) (map[string]win32ServiceConfig, error) {
+	if err := c.cache.query(q, dst); err != nil {
+		return nil, err
+	}
+	configs := make(map[string]win32ServiceConfig, len(*dst))
+	for _, config := range *dst {
+		configs[config.Name] = config
+	}
+	return configs, nil
+}
+
 var (
 	allStates = []string{
 		"stopped",
@@ -127,9 +230,8 @@ var (
 )
 
 func (c *serviceCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
-	var dst []Win32_Service
-	q := queryAllWhere(&dst, c.queryWhereClause)
-	if err := wmi.Query(q, &dst); err != nil {
+	dst, err := c.queryServices()
+	if err != nil {
 		return nil, err
 	}
 	for _, service := range dst {
@@ -139,6 +241,17 @@ func (c *serviceCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Des
 		if service.StartName != nil {
 			runAs = *service.StartName
 		}
+
+		if !c.serviceFilter.matches(
+			strings.ToLower(service.Name),
+			service.DisplayName,
+			strings.ToLower(service.StartMode),
+			strings.ToLower(service.State),
+			strings.ToLower(service.Status),
+			runAs,
+		) {
+			continue
+		}
 		ch <- prometheus.MustNewConstMetric(
 			c.Information,
 			prometheus.GaugeValue,
@@ -2,8 +2,49 @@ package collector
 
 import (
 	"testing"
+	"time"
 )
 
+func TestServiceCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "service", NewserviceCollector)
+}
+
 func BenchmarkServiceCollector(b *testing.B) {
 	benchmarkCollector(b, "service", NewserviceCollector)
 }
+
+func TestServiceQueryServicesNewService(t *testing.T) {
+	origQuery, origEntries := wmiQuery, wmiCacheEntries
+	defer func() { wmiQuery, wmiCacheEntries = origQuery, origEntries }()
+	wmiCacheEntries = make(map[wmiCacheKey]*wmiCacheEntry)
+
+	installed := []string{"EventLog"}
+	wmiQuery = func(query string, dst interface{}, _ ...interface{}) error {
+		switch rows := dst.(type) {
+		case *[]win32ServiceStatus:
+			*rows = nil
+			for _, name := range installed {
+				*rows = append(*rows, win32ServiceStatus{Name: name, State: "Running"})
+			}
+		case *[]win32ServiceConfig:
+			*rows = nil
+			for _, name := range installed {
+				*rows = append(*rows, win32ServiceConfig{Name: name, StartMode: "Auto"})
+			}
+		}
+		return nil
+	}
+
+	c := &serviceCollector{cache: newWMICache("service", time.Minute)}
+	if _, err := c.queryServices(); err != nil {
+		t.Fatal(err)
+	}
+	installed = append(installed, "Spooler")
+	services, err := c.queryServices()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(services) != 2 || services[1].Name != "Spooler" || services[1].StartMode != "Auto" || services[1].State != "Running" {
+		t.Errorf("expected the new service to be included, got %+v", services)
+	}
+}
@@ -3,21 +3,16 @@
 package collector
 
 import (
-	"fmt"
 	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
-	"gopkg.in/alecthomas/kingpin.v2"
-	"regexp"
 )
 
 func init() {
 	registerCollector("smtp", NewSMTPCollector, "SMTP Server")
 }
 
-var (
-	serverWhitelist = kingpin.Flag("collector.smtp.server-whitelist", "Regexp of virtual servers to whitelist. Server name must both match whitelist and not match blacklist to be included.").Default(".+").String()
-	serverBlacklist = kingpin.Flag("collector.smtp.server-blacklist", "Regexp of virtual servers to blacklist. Server name must both match whitelist and not match blacklist to be included.").String()
-)
+var serverFilterFlags = registerFilterFlags("collector.smtp.server-", "virtual servers", "site").
+	withDeprecatedFlags("collector.smtp.server-whitelist", "collector.smtp.server-blacklist")
 
 type SMTPCollector struct {
 	BadmailedMessagesBadPickupFileTotal     *prometheus.Desc
@@ -63,14 +58,18 @@ type SMTPCollector struct {
 	RemoteRetryQueueLength                  *prometheus.Desc
 	RoutingTableLookupsTotal                *prometheus.Desc
 
-	serverWhitelistPattern *regexp.Regexp
-	serverBlacklistPattern *regexp.Regexp
+	serverFilter *instanceFilter
 }
 
 func NewSMTPCollector() (Collector, error) {
-	log.Info("smtp collector is in an experimental state! Metrics for this collector have not been tested.")
+	log.With("collector", "smtp").Info("smtp collector is in an experimental state! Metrics for this collector have not been tested.")
 	const subsystem = "smtp"
 
+	serverFilter, err := serverFilterFlags.build()
+	if err != nil {
+		return nil, err
+	}
+
 	return &SMTPCollector{
 		BadmailedMessagesBadPickupFileTotal: prometheus.NewDesc(
 			prometheus.BuildFQName(Namespace, subsystem, "badmailed_messages_bad_pickup_file_total"),
@@ -325,16 +324,14 @@ func NewSMTPCollector() (Collector, error) {
 			nil,
 		),
 
-		serverWhitelistPattern: regexp.MustCompile(fmt.Sprintf("^(?:%s)$", *serverWhitelist)),
-		serverBlacklistPattern: regexp.MustCompile(fmt.Sprintf("^(?:%s)$", *serverBlacklist)),
+		serverFilter: serverFilter,
 	}, nil
 }
 
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *SMTPCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ctx, ch); err != nil {
-		log.Error("failed collecting smtp metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
@@ -390,301 +387,342 @@ type PerflibSMTPServer struct {
 
 func (c *SMTPCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []PerflibSMTPServer
-	if err := unmarshalObject(ctx.perfObjects["SMTP Server"], &dst); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["SMTP Server"], &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	for _, server := range dst {
-		if server.Name == "_Total" ||
-			c.serverBlacklistPattern.MatchString(server.Name) ||
-			!c.serverWhitelistPattern.MatchString(server.Name) {
+	for i := range dst {
+		server := &dst[i]
+		if server.Name == "_Total" || !c.serverFilter.matches(server.Name) {
 			continue
 		}
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BadmailedMessagesBadPickupFileTotal,
 			prometheus.CounterValue,
-			server.BadmailedMessagesBadPickupFileTotal,
+			&server.BadmailedMessagesBadPickupFileTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BadmailedMessagesHopCountExceededTotal,
 			prometheus.CounterValue,
-			server.BadmailedMessagesHopCountExceededTotal,
+			&server.BadmailedMessagesHopCountExceededTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BadmailedMessagesNDROfDSNTotal,
 			prometheus.CounterValue,
-			server.BadmailedMessagesNDROfDSNTotal,
+			&server.BadmailedMessagesNDROfDSNTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BadmailedMessagesNoRecipientsTotal,
 			prometheus.CounterValue,
-			server.BadmailedMessagesNoRecipientsTotal,
+			&server.BadmailedMessagesNoRecipientsTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BadmailedMessagesTriggeredViaEventTotal,
 			prometheus.CounterValue,
-			server.BadmailedMessagesTriggeredViaEventTotal,
+			&server.BadmailedMessagesTriggeredViaEventTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BytesSentTotal,
 			prometheus.CounterValue,
-			server.BytesSentTotal,
+			&server.BytesSentTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.BytesReceivedTotal,
 			prometheus.CounterValue,
-			server.BytesReceivedTotal,
+			&server.BytesReceivedTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.CategorizerQueueLength,
 			prometheus.GaugeValue,
-			server.CategorizerQueueLength,
+			&server.CategorizerQueueLength,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ConnectionErrorsTotal,
 			prometheus.CounterValue,
-			server.ConnectionErrorsTotal,
+			&server.ConnectionErrorsTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.CurrentMessagesInLocalDelivery,
 			prometheus.GaugeValue,
-			server.CurrentMessagesInLocalDelivery,
+			&server.CurrentMessagesInLocalDelivery,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DirectoryDropsTotal,
 			prometheus.CounterValue,
-			server.DirectoryDropsTotal,
+			&server.DirectoryDropsTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DSNFailuresTotal,
 			prometheus.CounterValue,
-			server.DSNFailuresTotal,
+			&server.DSNFailuresTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.DNSQueriesTotal,
 			prometheus.CounterValue,
-			server.DNSQueriesTotal,
+			&server.DNSQueriesTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ETRNMessagesTotal,
 			prometheus.CounterValue,
-			server.ETRNMessagesTotal,
+			&server.ETRNMessagesTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.InboundConnectionsTotal,
 			prometheus.CounterValue,
-			server.InboundConnectionsTotal,
+			&server.InboundConnectionsTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.InboundConnectionsCurrent,
 			prometheus.GaugeValue,
-			server.InboundConnectionsCurrent,
+			&server.InboundConnectionsCurrent,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.LocalQueueLength,
 			prometheus.GaugeValue,
-			server.LocalQueueLength,
+			&server.LocalQueueLength,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.LocalRetryQueueLength,
 			prometheus.GaugeValue,
-			server.LocalRetryQueueLength,
+			&server.LocalRetryQueueLength,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MailFilesOpen,
 			prometheus.GaugeValue,
-			server.MailFilesOpen,
+			&server.MailFilesOpen,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MessageBytesReceivedTotal,
 			prometheus.CounterValue,
-			server.MessageBytesReceivedTotal,
+			&server.MessageBytesReceivedTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MessageBytesSentTotal,
 			prometheus.CounterValue,
-			server.MessageBytesSentTotal,
+			&server.MessageBytesSentTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MessageDeliveryRetriesTotal,
 			prometheus.CounterValue,
-			server.MessageDeliveryRetriesTotal,
+			&server.MessageDeliveryRetriesTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MessageSendRetriesTotal,
 			prometheus.CounterValue,
-			server.MessageSendRetriesTotal,
+			&server.MessageSendRetriesTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MessagesCurrentlyUndeliverable,
 			prometheus.GaugeValue,
-			server.MessagesCurrentlyUndeliverable,
+			&server.MessagesCurrentlyUndeliverable,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MessagesDeliveredTotal,
 			prometheus.CounterValue,
-			server.MessagesDeliveredTotal,
+			&server.MessagesDeliveredTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MessagesPendingRouting,
 			prometheus.GaugeValue,
-			server.MessagesPendingRouting,
+			&server.MessagesPendingRouting,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MessagesReceivedTotal,
 			prometheus.CounterValue,
-			server.MessagesReceivedTotal,
+			&server.MessagesReceivedTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MessagesRefusedForAddressObjectsTotal,
 			prometheus.CounterValue,
-			server.MessagesRefusedForAddressObjectsTotal,
+			&server.MessagesRefusedForAddressObjectsTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MessagesRefusedForMailObjectsTotal,
 			prometheus.CounterValue,
-			server.MessagesRefusedForMailObjectsTotal,
+			&server.MessagesRefusedForMailObjectsTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MessagesRefusedForSizeTotal,
 			prometheus.CounterValue,
-			server.MessagesRefusedForSizeTotal,
+			&server.MessagesRefusedForSizeTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MessagesSentTotal,
 			prometheus.CounterValue,
-			server.MessagesSentTotal,
+			&server.MessagesSentTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.MessagesSubmittedTotal,
 			prometheus.CounterValue,
-			server.MessagesSubmittedTotal,
+			&server.MessagesSubmittedTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.NDRsGeneratedTotal,
 			prometheus.CounterValue,
-			server.NDRsGeneratedTotal,
+			&server.NDRsGeneratedTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.OutboundConnectionsCurrent,
 			prometheus.GaugeValue,
-			server.OutboundConnectionsCurrent,
+			&server.OutboundConnectionsCurrent,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.OutboundConnectionsRefusedTotal,
 			prometheus.CounterValue,
-			server.OutboundConnectionsRefusedTotal,
+			&server.OutboundConnectionsRefusedTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.OutboundConnectionsTotal,
 			prometheus.CounterValue,
-			server.OutboundConnectionsTotal,
+			&server.OutboundConnectionsTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.QueueFilesOpen,
 			prometheus.GaugeValue,
-			server.QueueFilesOpen,
+			&server.QueueFilesOpen,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PickupDirectoryMessagesRetrievedTotal,
 			prometheus.CounterValue,
-			server.PickupDirectoryMessagesRetrievedTotal,
+			&server.PickupDirectoryMessagesRetrievedTotal,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.RemoteQueueLength,
 			prometheus.GaugeValue,
-			server.RemoteQueueLength,
+			&server.RemoteQueueLength,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.RemoteRetryQueueLength,
 			prometheus.GaugeValue,
-			server.RemoteRetryQueueLength,
+			&server.RemoteRetryQueueLength,
 			server.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.RoutingTableLookupsTotal,
 			prometheus.CounterValue,
-			server.RoutingTableLookupsTotal,
+			&server.RoutingTableLookupsTotal,
 			server.Name,
 		)
 
@@ -4,6 +4,10 @@ import (
 	"testing"
 )
 
+func TestSmtpCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "smtp", NewSMTPCollector)
+}
+
 func BenchmarkSmtpCollector(b *testing.B) {
 	benchmarkCollector(b, "smtp", NewSMTPCollector)
 }
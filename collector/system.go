@@ -3,7 +3,6 @@
 package collector
 
 import (
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -68,8 +67,7 @@ func NewSystemCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *SystemCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ctx, ch); err != nil {
-		log.Error("failed collecting system metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
@@ -88,39 +86,46 @@ type system struct {
 
 func (c *SystemCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []system
-	if err := unmarshalObject(ctx.perfObjects["System"], &dst); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["System"], &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ContextSwitchesTotal,
 		prometheus.CounterValue,
-		dst[0].ContextSwitchesPersec,
+		&dst[0].ContextSwitchesPersec,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ExceptionDispatchesTotal,
 		prometheus.CounterValue,
-		dst[0].ExceptionDispatchesPersec,
+		&dst[0].ExceptionDispatchesPersec,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ProcessorQueueLength,
 		prometheus.GaugeValue,
-		dst[0].ProcessorQueueLength,
+		&dst[0].ProcessorQueueLength,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SystemCallsTotal,
 		prometheus.CounterValue,
-		dst[0].SystemCallsPersec,
+		&dst[0].SystemCallsPersec,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SystemUpTime,
 		prometheus.GaugeValue,
-		dst[0].SystemUpTime,
+		&dst[0].SystemUpTime,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.Threads,
 		prometheus.GaugeValue,
-		dst[0].Threads,
+		&dst[0].Threads,
 	)
 	return nil, nil
 }
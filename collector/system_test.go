@@ -4,6 +4,10 @@ import (
 	"testing"
 )
 
+func TestSystemCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "system", NewSystemCollector)
+}
+
 func BenchmarkSystemCollector(b *testing.B) {
 	benchmarkCollector(b, "system", NewSystemCollector)
 }
@@ -3,7 +3,6 @@
 package collector
 
 import (
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -89,8 +88,7 @@ func NewTCPCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *TCPCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ctx, ch); err != nil {
-		log.Error("failed collecting tcp metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
@@ -111,59 +109,68 @@ type tcp struct {
 	SegmentsSentPersec          float64 `perflib:"Segments Sent/sec"`
 }
 
-func writeTCPCounters(metrics tcp, labels []string, c *TCPCollector, ch chan<- prometheus.Metric) {
-	ch <- prometheus.MustNewConstMetric(
+func writeTCPCounters(metrics *tcp, present perflibPresence, labels []string, c *TCPCollector, ch chan<- prometheus.Metric) {
+	present.emit(
+		ch,
 		c.ConnectionFailures,
 		prometheus.CounterValue,
-		metrics.ConnectionFailures,
+		&metrics.ConnectionFailures,
 		labels...,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ConnectionsActive,
 		prometheus.CounterValue,
-		metrics.ConnectionsActive,
+		&metrics.ConnectionsActive,
 		labels...,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ConnectionsEstablished,
 		prometheus.GaugeValue,
-		metrics.ConnectionsEstablished,
+		&metrics.ConnectionsEstablished,
 		labels...,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ConnectionsPassive,
 		prometheus.CounterValue,
-		metrics.ConnectionsPassive,
+		&metrics.ConnectionsPassive,
 		labels...,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ConnectionsReset,
 		prometheus.CounterValue,
-		metrics.ConnectionsReset,
+		&metrics.ConnectionsReset,
 		labels...,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SegmentsTotal,
 		prometheus.CounterValue,
-		metrics.SegmentsPersec,
+		&metrics.SegmentsPersec,
 		labels...,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SegmentsReceivedTotal,
 		prometheus.CounterValue,
-		metrics.SegmentsReceivedPersec,
+		&metrics.SegmentsReceivedPersec,
 		labels...,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SegmentsRetransmittedTotal,
 		prometheus.CounterValue,
-		metrics.SegmentsRetransmittedPersec,
+		&metrics.SegmentsRetransmittedPersec,
 		labels...,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.SegmentsSentTotal,
 		prometheus.CounterValue,
-		metrics.SegmentsSentPersec,
+		&metrics.SegmentsSentPersec,
 		labels...,
 	)
 }
@@ -172,19 +179,21 @@ func (c *TCPCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric)
 	var dst []tcp
 
 	// TCPv4 counters
-	if err := unmarshalObject(ctx.perfObjects["TCPv4"], &dst); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["TCPv4"], &dst)
+	if err != nil {
 		return nil, err
 	}
 	if len(dst) != 0 {
-		writeTCPCounters(dst[0], []string{"ipv4"}, c, ch)
+		writeTCPCounters(&dst[0], present, []string{"ipv4"}, c, ch)
 	}
 
 	// TCPv6 counters
-	if err := unmarshalObject(ctx.perfObjects["TCPv6"], &dst); err != nil {
+	present, err = unmarshalObject(ctx.perfObjects["TCPv6"], &dst)
+	if err != nil {
 		return nil, err
 	}
 	if len(dst) != 0 {
-		writeTCPCounters(dst[0], []string{"ipv6"}, c, ch)
+		writeTCPCounters(&dst[0], present, []string{"ipv6"}, c, ch)
 	}
 
 	return nil, nil
@@ -4,6 +4,10 @@ import (
 	"testing"
 )
 
+func TestTCPCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "tcp", NewTCPCollector)
+}
+
 func BenchmarkTCPCollector(b *testing.B) {
 	benchmarkCollector(b, "tcp", NewTCPCollector)
 }
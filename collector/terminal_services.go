@@ -5,8 +5,8 @@ package collector
 import (
 	"errors"
 	"strings"
+	"time"
 
-	"github.com/StackExchange/wmi"
 	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -17,26 +17,28 @@ func init() {
 	registerCollector("terminal_services", NewTerminalServicesCollector, "Terminal Services", "Terminal Services Session", "Remote Desktop Connection Broker Counterset")
 }
 
-var (
-	connectionBrokerEnabled = isConnectionBrokerServer()
-)
+var terminalServicesCacheTTL = registerWMICacheFlag("terminal_services", 5*time.Minute)
 
 type Win32_ServerFeature struct {
 	ID uint32
 }
 
-func isConnectionBrokerServer() bool {
+// isConnectionBrokerServer returns true if the Remote Desktop Connection
+// Broker feature is installed. Features are rarely installed, so the result
+// of the query is cached.
+func (c *TerminalServicesCollector) isConnectionBrokerServer() bool {
 	var dst []Win32_ServerFeature
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+	q := querySelect(&dst, "")
+	if err := c.cache.query(q, &dst); err != nil {
 		return false
 	}
-	for _, d := range dst {
+	for i := range dst {
+		d := &dst[i]
 		if d.ID == ConnectionBrokerFeatureID {
 			return true
 		}
 	}
-	log.Debug("host is not a connection broker skipping Connection Broker performance metrics.")
+	log.With("collector", "terminal_services").Debug("host is not a connection broker skipping Connection Broker performance metrics.")
 	return false
 }
 
@@ -62,6 +64,8 @@ type TerminalServicesCollector struct {
 	VirtualBytesPeak            *prometheus.Desc
 	WorkingSet                  *prometheus.Desc
 	WorkingSetPeak              *prometheus.Desc
+
+	cache *wmiCache
 }
 
 // NewTerminalServicesCollector ...
@@ -170,26 +174,24 @@ func NewTerminalServicesCollector() (Collector, error) {
 			[]string{"session_name"},
 			nil,
 		),
+		cache: newWMICache("terminal_services", *terminalServicesCacheTTL),
 	}, nil
 }
 
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *TerminalServicesCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collectTSSessionCount(ctx, ch); err != nil {
-		log.Error("failed collecting terminal services session count metrics:", desc, err)
-		return err
+	if _, err := c.collectTSSessionCount(ctx, ch); err != nil {
+		return withQuery("session_count", err)
 	}
-	if desc, err := c.collectTSSessionCounters(ctx, ch); err != nil {
-		log.Error("failed collecting terminal services session count metrics:", desc, err)
-		return err
+	if _, err := c.collectTSSessionCounters(ctx, ch); err != nil {
+		return withQuery("session", err)
 	}
 
 	// only collect CollectionBrokerPerformance if host is a Connection Broker
-	if connectionBrokerEnabled {
-		if desc, err := c.collectCollectionBrokerPerformanceCounter(ctx, ch); err != nil {
-			log.Error("failed collecting Connection Broker performance metrics:", desc, err)
-			return err
+	if c.isConnectionBrokerServer() {
+		if _, err := c.collectCollectionBrokerPerformanceCounter(ctx, ch); err != nil {
+			return withQuery("connection_broker", err)
 		}
 	}
 	return nil
@@ -203,7 +205,7 @@ type perflibTerminalServices struct {
 
 func (c *TerminalServicesCollector) collectTSSessionCount(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	dst := make([]perflibTerminalServices, 0)
-	err := unmarshalObject(ctx.perfObjects["Terminal Services"], &dst)
+	present, err := unmarshalObject(ctx.perfObjects["Terminal Services"], &dst)
 	if err != nil {
 		return nil, err
 	}
@@ -211,24 +213,27 @@ func (c *TerminalServicesCollector) collectTSSessionCount(ctx *ScrapeContext, ch
 		return nil, errors.New("WMI query returned empty result set")
 	}
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.LocalSessionCount,
 		prometheus.GaugeValue,
-		dst[0].ActiveSessions,
+		&dst[0].ActiveSessions,
 		"active",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.LocalSessionCount,
 		prometheus.GaugeValue,
-		dst[0].InactiveSessions,
+		&dst[0].InactiveSessions,
 		"inactive",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.LocalSessionCount,
 		prometheus.GaugeValue,
-		dst[0].TotalSessions,
+		&dst[0].TotalSessions,
 		"total",
 	)
 
@@ -256,105 +261,121 @@ type perflibTerminalServicesSession struct {
 
 func (c *TerminalServicesCollector) collectTSSessionCounters(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	dst := make([]perflibTerminalServicesSession, 0)
-	err := unmarshalObject(ctx.perfObjects["Terminal Services Session"], &dst)
+	present, err := unmarshalObject(ctx.perfObjects["Terminal Services Session"], &dst)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, d := range dst {
+	for i := range dst {
+		d := &dst[i]
 		// only connect metrics for remote named sessions
 		n := strings.ToLower(d.Name)
 		if n == "" || n == "services" || n == "console" {
 			continue
 		}
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.HandleCount,
 			prometheus.GaugeValue,
-			d.HandleCount,
+			&d.HandleCount,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PageFaultsPersec,
 			prometheus.CounterValue,
-			d.PageFaultsPersec,
+			&d.PageFaultsPersec,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PageFileBytes,
 			prometheus.GaugeValue,
-			d.PageFileBytes,
+			&d.PageFileBytes,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PageFileBytesPeak,
 			prometheus.GaugeValue,
-			d.PageFileBytesPeak,
+			&d.PageFileBytesPeak,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PercentPrivilegedTime,
 			prometheus.CounterValue,
-			d.PercentPrivilegedTime,
+			&d.PercentPrivilegedTime,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PercentProcessorTime,
 			prometheus.CounterValue,
-			d.PercentProcessorTime,
+			&d.PercentProcessorTime,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PercentUserTime,
 			prometheus.CounterValue,
-			d.PercentUserTime,
+			&d.PercentUserTime,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PoolNonpagedBytes,
 			prometheus.GaugeValue,
-			d.PoolNonpagedBytes,
+			&d.PoolNonpagedBytes,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PoolPagedBytes,
 			prometheus.GaugeValue,
-			d.PoolPagedBytes,
+			&d.PoolPagedBytes,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.PrivateBytes,
 			prometheus.GaugeValue,
-			d.PrivateBytes,
+			&d.PrivateBytes,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.ThreadCount,
 			prometheus.GaugeValue,
-			d.ThreadCount,
+			&d.ThreadCount,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.VirtualBytes,
 			prometheus.GaugeValue,
-			d.VirtualBytes,
+			&d.VirtualBytes,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.VirtualBytesPeak,
 			prometheus.GaugeValue,
-			d.VirtualBytesPeak,
+			&d.VirtualBytesPeak,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WorkingSet,
 			prometheus.GaugeValue,
-			d.WorkingSet,
+			&d.WorkingSet,
 			d.Name,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		present.emit(
+			ch,
 			c.WorkingSetPeak,
 			prometheus.GaugeValue,
-			d.WorkingSetPeak,
+			&d.WorkingSetPeak,
 			d.Name,
 		)
 	}
@@ -370,7 +391,7 @@ type perflibRemoteDesktopConnectionBrokerCounterset struct {
 func (c *TerminalServicesCollector) collectCollectionBrokerPerformanceCounter(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 
 	dst := make([]perflibRemoteDesktopConnectionBrokerCounterset, 0)
-	err := unmarshalObject(ctx.perfObjects["Remote Desktop Connection Broker Counterset"], &dst)
+	present, err := unmarshalObject(ctx.perfObjects["Remote Desktop Connection Broker Counterset"], &dst)
 	if err != nil {
 		return nil, err
 	}
@@ -378,24 +399,27 @@ func (c *TerminalServicesCollector) collectCollectionBrokerPerformanceCounter(ct
 		return nil, errors.New("WMI query returned empty result set")
 	}
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ConnectionBrokerPerformance,
 		prometheus.CounterValue,
-		dst[0].SuccessfulConnections,
+		&dst[0].SuccessfulConnections,
 		"Successful",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ConnectionBrokerPerformance,
 		prometheus.CounterValue,
-		dst[0].PendingConnections,
+		&dst[0].PendingConnections,
 		"Pending",
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ConnectionBrokerPerformance,
 		prometheus.CounterValue,
-		dst[0].FailedConnections,
+		&dst[0].FailedConnections,
 		"Failed",
 	)
 
@@ -4,6 +4,10 @@ import (
 	"testing"
 )
 
+func TestTerminalServicesCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "terminal_services", NewTerminalServicesCollector)
+}
+
 func BenchmarkTerminalServicesCollector(b *testing.B) {
 	benchmarkCollector(b, "terminal_services", NewTerminalServicesCollector)
 }
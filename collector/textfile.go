@@ -46,6 +46,12 @@ var (
 		[]string{"file"},
 		nil,
 	)
+
+	scrapeErrorDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "textfile", "scrape_error"),
+		"1 if there was an error opening or reading a file, 0 otherwise",
+		nil, nil,
+	)
 )
 
 type textFileCollector struct {
@@ -107,7 +113,7 @@ func convertMetricFamily(metricFamily *dto.MetricFamily, ch chan<- prometheus.Me
 
 	for _, metric := range metricFamily.Metric {
 		if metric.TimestampMs != nil {
-			log.Warnf("Ignoring unsupported custom timestamp on textfile collector metric %v", metric)
+			log.With("collector", "textfile", "metric", metricFamily.GetName()).Warn("Ignoring unsupported custom timestamp on textfile collector metric")
 		}
 
 		labels := metric.GetLabel()
@@ -177,7 +183,7 @@ func convertMetricFamily(metricFamily *dto.MetricFamily, ch chan<- prometheus.Me
 				buckets, values...,
 			)
 		default:
-			log.Errorf("unknown metric type for file")
+			log.With("collector", "textfile", "metric", metricFamily.GetName(), "type", metricType.String()).Error("Unknown metric type")
 			continue
 		}
 		if metricType == dto.MetricType_GAUGE || metricType == dto.MetricType_COUNTER || metricType == dto.MetricType_UNTYPED {
@@ -245,7 +251,7 @@ func (c *textFileCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Met
 	// Iterate over files and accumulate their metrics.
 	files, err := ioutil.ReadDir(c.path)
 	if err != nil && c.path != "" {
-		log.Errorf("Error reading textfile collector directory %q: %s", c.path, err)
+		log.With("collector", "textfile", "path", c.path).WithError(err).Error("Error reading textfile collector directory")
 		error = 1.0
 	}
 
@@ -259,34 +265,34 @@ fileLoop:
 			continue
 		}
 		path := filepath.Join(c.path, f.Name())
-		log.Debugf("Processing file %q", path)
+		log.With("collector", "textfile", "path", path).Debug("Processing file")
 		file, err := os.Open(path)
 		if err != nil {
-			log.Errorf("Error opening %q: %v", path, err)
+			log.With("collector", "textfile", "path", path).WithError(err).Error("Error opening file")
 			error = 1.0
 			continue
 		}
 		var parser expfmt.TextParser
 		r, encoding := utfbom.Skip(carriageReturnFilteringReader{r: file})
 		if err = checkBOM(encoding); err != nil {
-			log.Errorf("Invalid file encoding detected in %s: %s - file must be UTF8", path, err.Error())
+			log.With("collector", "textfile", "path", path).WithError(err).Error("Invalid file encoding detected, file must be UTF8")
 			error = 1.0
 			continue
 		}
 		parsedFamilies, err := parser.TextToMetricFamilies(r)
 		closeErr := file.Close()
 		if closeErr != nil {
-			log.Warnf("Error closing file: %v", err)
+			log.With("collector", "textfile", "path", path).WithError(closeErr).Warn("Error closing file")
 		}
 		if err != nil {
-			log.Errorf("Error parsing %q: %v", path, err)
+			log.With("collector", "textfile", "path", path).WithError(err).Error("Error parsing file")
 			error = 1.0
 			continue
 		}
 		for _, mf := range parsedFamilies {
 			for _, m := range mf.Metric {
 				if m.TimestampMs != nil {
-					log.Errorf("Textfile %q contains unsupported client-side timestamps, skipping entire file", path)
+					log.With("collector", "textfile", "path", path).Error("Textfile contains unsupported client-side timestamps, skipping entire file")
 					error = 1.0
 					continue fileLoop
 				}
@@ -307,7 +313,7 @@ fileLoop:
 	}
 
 	if duplicateMetricEntry(metricFamilies) {
-		log.Errorf("Duplicate metrics detected in files")
+		log.With("collector", "textfile").Error("Duplicate metrics detected in files")
 		error = 1.0
 	} else {
 		for _, mf := range metricFamilies {
@@ -317,17 +323,24 @@ fileLoop:
 	}
 
 	// Export if there were errors.
-	ch <- prometheus.MustNewConstMetric(
-		prometheus.NewDesc(
-			prometheus.BuildFQName(Namespace, "textfile", "scrape_error"),
-			"1 if there was an error opening or reading a file, 0 otherwise",
-			nil, nil,
-		),
-		prometheus.GaugeValue, error,
-	)
+	ch <- prometheus.MustNewConstMetric(scrapeErrorDesc, prometheus.GaugeValue, error)
 	return nil
 }
 
+// describeMetrics describes the metrics of the collector itself. Metrics read
+// from text files are not known in advance.
+func (c *textFileCollector) describeMetrics() ([]MetricInfo, error) {
+	mtime, err := describeMetric(mtimeDesc, "gauge")
+	if err != nil {
+		return nil, err
+	}
+	scrapeError, err := describeMetric(scrapeErrorDesc, "gauge")
+	if err != nil {
+		return nil, err
+	}
+	return []MetricInfo{mtime, scrapeError}, nil
+}
+
 func checkBOM(encoding utfbom.Encoding) error {
 	if encoding == utfbom.Unknown || encoding == utfbom.UTF8 {
 		return nil
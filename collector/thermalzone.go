@@ -1,8 +1,6 @@
 package collector
 
 import (
-	"github.com/StackExchange/wmi"
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -51,8 +49,7 @@ func NewThermalZoneCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *thermalZoneCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ch); err != nil {
-		log.Error("failed collecting thermalzone metrics:", desc, err)
+	if _, err := c.collect(ch); err != nil {
 		return err
 	}
 	return nil
@@ -70,8 +67,8 @@ type Win32_PerfRawData_Counters_ThermalZoneInformation struct {
 
 func (c *thermalZoneCollector) collect(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []Win32_PerfRawData_Counters_ThermalZoneInformation
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
 		return nil, err
 	}
 
@@ -4,6 +4,10 @@ import (
 	"testing"
 )
 
+func TestThermalZoneCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "thermalzone", NewThermalZoneCollector)
+}
+
 func BenchmarkThermalZoneCollector(b *testing.B) {
 	benchmarkCollector(b, "thermalzone", NewThermalZoneCollector)
 }
@@ -5,7 +5,6 @@ package collector
 import (
 	"errors"
 
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -73,8 +72,7 @@ func newTimeCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *TimeCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collect(ctx, ch); err != nil {
-		log.Error("failed collecting time metrics:", desc, err)
+	if _, err := c.collect(ctx, ch); err != nil {
 		return err
 	}
 	return nil
@@ -82,7 +80,7 @@ func (c *TimeCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric)
 
 // Perflib "Windows Time Service"
 type windowsTime struct {
-	ClockFrequencyAdjustmentPPBTotal float64 `perflib:"Clock Frequency Adjustment (ppb)"`
+	ClockFrequencyAdjustmentPPBTotal float64 `perflib:"Clock Frequency Adjustment (ppb),optional"`
 	ComputedTimeOffset               float64 `perflib:"Computed Time Offset"`
 	NTPClientTimeSourceCount         float64 `perflib:"NTP Client Time Source Count"`
 	NTPRoundtripDelay                float64 `perflib:"NTP Roundtrip Delay"`
@@ -92,39 +90,48 @@ type windowsTime struct {
 
 func (c *TimeCollector) collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
 	var dst []windowsTime // Single-instance class, array is required but will have single entry.
-	if err := unmarshalObject(ctx.perfObjects["Windows Time Service"], &dst); err != nil {
+	present, err := unmarshalObject(ctx.perfObjects["Windows Time Service"], &dst)
+	if err != nil {
 		return nil, err
 	}
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.ClockFrequencyAdjustmentPPBTotal,
 		prometheus.CounterValue,
-		dst[0].ClockFrequencyAdjustmentPPBTotal,
+		&dst[0].ClockFrequencyAdjustmentPPBTotal,
 	)
-	ch <- prometheus.MustNewConstMetric(
-		c.ComputedTimeOffset,
-		prometheus.GaugeValue,
-		dst[0].ComputedTimeOffset/1000000, // microseconds -> seconds
-	)
-	ch <- prometheus.MustNewConstMetric(
+	if present.has(&dst[0].ComputedTimeOffset) {
+		ch <- prometheus.MustNewConstMetric(
+			c.ComputedTimeOffset,
+			prometheus.GaugeValue,
+			dst[0].ComputedTimeOffset/1000000, // microseconds -> seconds
+		)
+	}
+	present.emit(
+		ch,
 		c.NTPClientTimeSourceCount,
 		prometheus.GaugeValue,
-		dst[0].NTPClientTimeSourceCount,
+		&dst[0].NTPClientTimeSourceCount,
 	)
-	ch <- prometheus.MustNewConstMetric(
-		c.NTPRoundtripDelay,
-		prometheus.GaugeValue,
-		dst[0].NTPRoundtripDelay/1000000, // microseconds -> seconds
-	)
-	ch <- prometheus.MustNewConstMetric(
+	if present.has(&dst[0].NTPRoundtripDelay) {
+		ch <- prometheus.MustNewConstMetric(
+			c.NTPRoundtripDelay,
+			prometheus.GaugeValue,
+			dst[0].NTPRoundtripDelay/1000000, // microseconds -> seconds
+		)
+	}
+	present.emit(
+		ch,
 		c.NTPServerIncomingRequestsTotal,
 		prometheus.CounterValue,
-		dst[0].NTPServerIncomingRequestsTotal,
+		&dst[0].NTPServerIncomingRequestsTotal,
 	)
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.NTPServerOutgoingResponsesTotal,
 		prometheus.CounterValue,
-		dst[0].NTPServerOutgoingResponsesTotal,
+		&dst[0].NTPServerOutgoingResponsesTotal,
 	)
 	return nil, nil
 }
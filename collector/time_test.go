@@ -2,8 +2,54 @@ package collector
 
 import (
 	"testing"
+
+	perflibCollector "github.com/leoluk/perflib_exporter/collector"
+	"github.com/leoluk/perflib_exporter/perflib"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+func TestTimeCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "time", newTimeCollector)
+}
+
 func BenchmarkTimeCollector(b *testing.B) {
 	benchmarkCollector(b, "time", newTimeCollector)
 }
+
+func TestTimeCollectorOptionalCounters(t *testing.T) {
+	var defs []*perflib.PerfCounterDef
+	var counters []*perflib.PerfCounter
+	for _, name := range []string{"Computed Time Offset", "NTP Client Time Source Count", "NTP Roundtrip Delay", "NTP Server Incoming Requests", "NTP Server Outgoing Responses"} {
+		def := &perflib.PerfCounterDef{Name: name, CounterType: perflibCollector.PERF_COUNTER_RAWCOUNT}
+		defs = append(defs, def)
+		counters = append(counters, &perflib.PerfCounter{Def: def, Value: 1})
+	}
+	obj := &perflib.PerfObject{
+		Name:        "Windows Time Service",
+		CounterDefs: defs,
+		Instances:   []*perflib.PerfInstance{{Counters: counters}},
+	}
+
+	c, err := newTimeCollector()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tc := c.(*TimeCollector)
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		if _, err := tc.collect(&ScrapeContext{perfObjects: map[string]*perflib.PerfObject{"Windows Time Service": obj}}, ch); err != nil {
+			t.Error(err)
+		}
+	}()
+	var n int
+	for m := range ch {
+		n++
+		if m.Desc() == tc.ClockFrequencyAdjustmentPPBTotal {
+			t.Errorf("expected no metric of the missing counter Clock Frequency Adjustment (ppb)")
+		}
+	}
+	if n != len(defs) {
+		t.Errorf("expected %d metrics, got %d", len(defs), n)
+	}
+}
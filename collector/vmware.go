@@ -5,16 +5,14 @@ package collector
 import (
 	"errors"
 
-	"github.com/StackExchange/wmi"
-	"github.com/prometheus-community/windows_exporter/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 func init() {
-	registerCollector("vmware", NewVmwareCollector)
+	registerCollector("vmware", NewVmwareCollector, "VM Memory", "VM Processor")
 }
 
-// A VmwareCollector is a Prometheus collector for WMI Win32_PerfRawData_vmGuestLib_VMem/Win32_PerfRawData_vmGuestLib_VCPU metrics
+// A VmwareCollector is a Prometheus collector for Perflib VM Memory/VM Processor metrics
 type VmwareCollector struct {
 	MemActive      *prometheus.Desc
 	MemBallooned   *prometheus.Desc
@@ -163,181 +161,219 @@ func NewVmwareCollector() (Collector, error) {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *VmwareCollector) Collect(ctx *ScrapeContext, ch chan<- prometheus.Metric) error {
-	if desc, err := c.collectMem(ch); err != nil {
-		log.Error("failed collecting vmware memory metrics:", desc, err)
-		return err
+	if _, err := c.collectMem(ctx, ch); err != nil {
+		return withQuery("memory", err)
 	}
-	if desc, err := c.collectCpu(ch); err != nil {
-		log.Error("failed collecting vmware cpu metrics:", desc, err)
-		return err
+	if _, err := c.collectCpu(ctx, ch); err != nil {
+		return withQuery("cpu", err)
 	}
 	return nil
 }
 
-type Win32_PerfRawData_vmGuestLib_VMem struct {
-	MemActiveMB      uint64
-	MemBalloonedMB   uint64
-	MemLimitMB       uint64
-	MemMappedMB      uint64
-	MemOverheadMB    uint64
-	MemReservationMB uint64
-	MemSharedMB      uint64
-	MemSharedSavedMB uint64
-	MemShares        uint64
-	MemSwappedMB     uint64
-	MemTargetSizeMB  uint64
-	MemUsedMB        uint64
+type vmwareMem struct {
+	MemActiveMB      float64 `perflib:"Memory Active in MB"`
+	MemBalloonedMB   float64 `perflib:"Memory Ballooned in MB"`
+	MemLimitMB       float64 `perflib:"Memory Limit in MB"`
+	MemMappedMB      float64 `perflib:"Memory Mapped in MB"`
+	MemOverheadMB    float64 `perflib:"Memory Overhead in MB"`
+	MemReservationMB float64 `perflib:"Memory Reservation in MB"`
+	MemSharedMB      float64 `perflib:"Memory Shared in MB"`
+	MemSharedSavedMB float64 `perflib:"Memory Shared Saved in MB"`
+	MemShares        float64 `perflib:"Memory Shares"`
+	MemSwappedMB     float64 `perflib:"Memory Swapped in MB"`
+	MemTargetSizeMB  float64 `perflib:"Memory Target Size"`
+	MemUsedMB        float64 `perflib:"Memory Used in MB"`
 }
 
-type Win32_PerfRawData_vmGuestLib_VCPU struct {
-	CpuLimitMHz           uint64
-	CpuReservationMHz     uint64
-	CpuShares             uint64
-	CpuStolenMs           uint64
-	CpuTimePercents       uint64
-	EffectiveVMSpeedMHz   uint64
-	HostProcessorSpeedMHz uint64
+type vmwareCPU struct {
+	Name string
+
+	CpuLimitMHz           float64 `perflib:"Limit in MHz"`
+	CpuReservationMHz     float64 `perflib:"Reservation in MHz"`
+	CpuShares             float64 `perflib:"Shares"`
+	CpuStolenSeconds      float64 `perflib:"CPU stolen time"`
+	CpuTimeSeconds        float64 `perflib:"% Processor Time"`
+	EffectiveVMSpeedMHz   float64 `perflib:"Effective VM Speed in MHz"`
+	HostProcessorSpeedMHz float64 `perflib:"Host processor speed in MHz"`
 }
 
-func (c *VmwareCollector) collectMem(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
-	var dst []Win32_PerfRawData_vmGuestLib_VMem
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+func (c *VmwareCollector) collectMem(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []vmwareMem
+	present, err := unmarshalObject(ctx.perfObjects["VM Memory"], &dst)
+	if err != nil {
 		return nil, err
 	}
 	if len(dst) == 0 {
-		return nil, errors.New("WMI query returned empty result set")
+		return nil, errors.New("perflib query for VM Memory returned empty result set")
 	}
 
-	ch <- prometheus.MustNewConstMetric(
-		c.MemActive,
-		prometheus.GaugeValue,
-		mbToBytes(dst[0].MemActiveMB),
-	)
+	if present.has(&dst[0].MemActiveMB) {
+		ch <- prometheus.MustNewConstMetric(
+			c.MemActive,
+			prometheus.GaugeValue,
+			mbToBytes(dst[0].MemActiveMB),
+		)
+	}
 
-	ch <- prometheus.MustNewConstMetric(
-		c.MemBallooned,
-		prometheus.GaugeValue,
-		mbToBytes(dst[0].MemBalloonedMB),
-	)
+	if present.has(&dst[0].MemBalloonedMB) {
+		ch <- prometheus.MustNewConstMetric(
+			c.MemBallooned,
+			prometheus.GaugeValue,
+			mbToBytes(dst[0].MemBalloonedMB),
+		)
+	}
 
-	ch <- prometheus.MustNewConstMetric(
-		c.MemLimit,
-		prometheus.GaugeValue,
-		mbToBytes(dst[0].MemLimitMB),
-	)
+	if present.has(&dst[0].MemLimitMB) {
+		ch <- prometheus.MustNewConstMetric(
+			c.MemLimit,
+			prometheus.GaugeValue,
+			mbToBytes(dst[0].MemLimitMB),
+		)
+	}
 
-	ch <- prometheus.MustNewConstMetric(
-		c.MemMapped,
-		prometheus.GaugeValue,
-		mbToBytes(dst[0].MemMappedMB),
-	)
+	if present.has(&dst[0].MemMappedMB) {
+		ch <- prometheus.MustNewConstMetric(
+			c.MemMapped,
+			prometheus.GaugeValue,
+			mbToBytes(dst[0].MemMappedMB),
+		)
+	}
 
-	ch <- prometheus.MustNewConstMetric(
-		c.MemOverhead,
-		prometheus.GaugeValue,
-		mbToBytes(dst[0].MemOverheadMB),
-	)
+	if present.has(&dst[0].MemOverheadMB) {
+		ch <- prometheus.MustNewConstMetric(
+			c.MemOverhead,
+			prometheus.GaugeValue,
+			mbToBytes(dst[0].MemOverheadMB),
+		)
+	}
 
-	ch <- prometheus.MustNewConstMetric(
-		c.MemReservation,
-		prometheus.GaugeValue,
-		mbToBytes(dst[0].MemReservationMB),
-	)
+	if present.has(&dst[0].MemReservationMB) {
+		ch <- prometheus.MustNewConstMetric(
+			c.MemReservation,
+			prometheus.GaugeValue,
+			mbToBytes(dst[0].MemReservationMB),
+		)
+	}
 
-	ch <- prometheus.MustNewConstMetric(
-		c.MemShared,
-		prometheus.GaugeValue,
-		mbToBytes(dst[0].MemSharedMB),
-	)
+	if present.has(&dst[0].MemSharedMB) {
+		ch <- prometheus.MustNewConstMetric(
+			c.MemShared,
+			prometheus.GaugeValue,
+			mbToBytes(dst[0].MemSharedMB),
+		)
+	}
 
-	ch <- prometheus.MustNewConstMetric(
-		c.MemSharedSaved,
-		prometheus.GaugeValue,
-		mbToBytes(dst[0].MemSharedSavedMB),
-	)
+	if present.has(&dst[0].MemSharedSavedMB) {
+		ch <- prometheus.MustNewConstMetric(
+			c.MemSharedSaved,
+			prometheus.GaugeValue,
+			mbToBytes(dst[0].MemSharedSavedMB),
+		)
+	}
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.MemShares,
 		prometheus.GaugeValue,
-		float64(dst[0].MemShares),
+		&dst[0].MemShares,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
-		c.MemSwapped,
-		prometheus.GaugeValue,
-		mbToBytes(dst[0].MemSwappedMB),
-	)
+	if present.has(&dst[0].MemSwappedMB) {
+		ch <- prometheus.MustNewConstMetric(
+			c.MemSwapped,
+			prometheus.GaugeValue,
+			mbToBytes(dst[0].MemSwappedMB),
+		)
+	}
 
-	ch <- prometheus.MustNewConstMetric(
-		c.MemTargetSize,
-		prometheus.GaugeValue,
-		mbToBytes(dst[0].MemTargetSizeMB),
-	)
+	if present.has(&dst[0].MemTargetSizeMB) {
+		ch <- prometheus.MustNewConstMetric(
+			c.MemTargetSize,
+			prometheus.GaugeValue,
+			mbToBytes(dst[0].MemTargetSizeMB),
+		)
+	}
 
-	ch <- prometheus.MustNewConstMetric(
-		c.MemUsed,
-		prometheus.GaugeValue,
-		mbToBytes(dst[0].MemUsedMB),
-	)
+	if present.has(&dst[0].MemUsedMB) {
+		ch <- prometheus.MustNewConstMetric(
+			c.MemUsed,
+			prometheus.GaugeValue,
+			mbToBytes(dst[0].MemUsedMB),
+		)
+	}
 
 	return nil, nil
 }
 
-func mbToBytes(mb uint64) float64 {
-	return float64(mb * 1024 * 1024)
+func mbToBytes(mb float64) float64 {
+	return mb * 1024 * 1024
 }
 
-func (c *VmwareCollector) collectCpu(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
-	var dst []Win32_PerfRawData_vmGuestLib_VCPU
-	q := queryAll(&dst)
-	if err := wmi.Query(q, &dst); err != nil {
+func (c *VmwareCollector) collectCpu(ctx *ScrapeContext, ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []vmwareCPU
+	present, err := unmarshalObject(ctx.perfObjects["VM Processor"], &dst)
+	if err != nil {
 		return nil, err
 	}
 	if len(dst) == 0 {
-		return nil, errors.New("WMI query returned empty result set")
+		return nil, errors.New("perflib query for VM Processor returned empty result set")
+	}
+	// VM Processor has an instance for every virtual processor besides _Total.
+	cpu := &dst[0]
+	for i := range dst {
+		if dst[i].Name == "_Total" {
+			cpu = &dst[i]
+			break
+		}
 	}
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.CpuLimitMHz,
 		prometheus.GaugeValue,
-		float64(dst[0].CpuLimitMHz),
+		&cpu.CpuLimitMHz,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.CpuReservationMHz,
 		prometheus.GaugeValue,
-		float64(dst[0].CpuReservationMHz),
+		&cpu.CpuReservationMHz,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.CpuShares,
 		prometheus.GaugeValue,
-		float64(dst[0].CpuShares),
+		&cpu.CpuShares,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.CpuStolenTotal,
 		prometheus.CounterValue,
-		float64(dst[0].CpuStolenMs)*ticksToSecondsScaleFactor,
+		&cpu.CpuStolenSeconds,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.CpuTimeTotal,
 		prometheus.CounterValue,
-		float64(dst[0].CpuTimePercents)*ticksToSecondsScaleFactor,
+		&cpu.CpuTimeSeconds,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.EffectiveVMSpeedMHz,
 		prometheus.GaugeValue,
-		float64(dst[0].EffectiveVMSpeedMHz),
+		&cpu.EffectiveVMSpeedMHz,
 	)
 
-	ch <- prometheus.MustNewConstMetric(
+	present.emit(
+		ch,
 		c.HostProcessorSpeedMHz,
 		prometheus.GaugeValue,
-		float64(dst[0].HostProcessorSpeedMHz),
+		&cpu.HostProcessorSpeedMHz,
 	)
 
 	return nil, nil
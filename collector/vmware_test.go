@@ -1,9 +1,216 @@
 package collector
 
 import (
+	"errors"
 	"testing"
+
+	"github.com/leoluk/perflib_exporter/perflib"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+func TestVmwareCollectorGolden(t *testing.T) {
+	testCollectorGolden(t, "vmware", NewVmwareCollector)
+}
+
+func TestVmwareCollectorParity(t *testing.T) {
+	testCollectorParity(t, "vmware", NewVmwareCollector, func(c Collector, ch chan<- prometheus.Metric) error {
+		if _, err := c.(*VmwareCollector).collectMemWMI(ch); err != nil {
+			return err
+		}
+		_, err := c.(*VmwareCollector).collectCpuWMI(ch)
+		return err
+	})
+}
+
+func TestVmwareCollectorQueryError(t *testing.T) {
+	c, err := NewVmwareCollector()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		objects map[string]*perflib.PerfObject
+		query   string
+	}{
+		{map[string]*perflib.PerfObject{}, "memory"},
+		{map[string]*perflib.PerfObject{"VM Memory": {Name: "VM Memory", Instances: []*perflib.PerfInstance{{}}}}, "cpu"},
+	} {
+		ch := make(chan prometheus.Metric, 100)
+		err := c.Collect(&ScrapeContext{perfObjects: tc.objects}, ch)
+		var queryErr *queryError
+		if !errors.As(err, &queryErr) || queryErr.query != tc.query {
+			t.Errorf("expected an error of query %s, got %v", tc.query, err)
+		}
+	}
+}
+
 func BenchmarkVmwareCollector(b *testing.B) {
 	benchmarkCollector(b, "vmware", NewVmwareCollector)
 }
+
+// The WMI implementation the collector was moved from, kept to check that
+// the perflib implementation exposes the same metrics.
+
+type Win32_PerfRawData_vmGuestLib_VMem struct {
+	MemActiveMB      uint64
+	MemBalloonedMB   uint64
+	MemLimitMB       uint64
+	MemMappedMB      uint64
+	MemOverheadMB    uint64
+	MemReservationMB uint64
+	MemSharedMB      uint64
+	MemSharedSavedMB uint64
+	MemShares        uint64
+	MemSwappedMB     uint64
+	MemTargetSizeMB  uint64
+	MemUsedMB        uint64
+}
+
+type Win32_PerfRawData_vmGuestLib_VCPU struct {
+	CpuLimitMHz           uint64
+	CpuReservationMHz     uint64
+	CpuShares             uint64
+	CpuStolenMs           uint64
+	CpuTimePercents       uint64
+	EffectiveVMSpeedMHz   uint64
+	HostProcessorSpeedMHz uint64
+}
+
+func (c *VmwareCollector) collectMemWMI(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []Win32_PerfRawData_vmGuestLib_VMem
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
+		return nil, err
+	}
+	if len(dst) == 0 {
+		return nil, errors.New("WMI query returned empty result set")
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.MemActive,
+		prometheus.GaugeValue,
+		mbToBytes(float64(dst[0].MemActiveMB)),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.MemBallooned,
+		prometheus.GaugeValue,
+		mbToBytes(float64(dst[0].MemBalloonedMB)),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.MemLimit,
+		prometheus.GaugeValue,
+		mbToBytes(float64(dst[0].MemLimitMB)),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.MemMapped,
+		prometheus.GaugeValue,
+		mbToBytes(float64(dst[0].MemMappedMB)),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.MemOverhead,
+		prometheus.GaugeValue,
+		mbToBytes(float64(dst[0].MemOverheadMB)),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.MemReservation,
+		prometheus.GaugeValue,
+		mbToBytes(float64(dst[0].MemReservationMB)),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.MemShared,
+		prometheus.GaugeValue,
+		mbToBytes(float64(dst[0].MemSharedMB)),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.MemSharedSaved,
+		prometheus.GaugeValue,
+		mbToBytes(float64(dst[0].MemSharedSavedMB)),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.MemShares,
+		prometheus.GaugeValue,
+		float64(dst[0].MemShares),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.MemSwapped,
+		prometheus.GaugeValue,
+		mbToBytes(float64(dst[0].MemSwappedMB)),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.MemTargetSize,
+		prometheus.GaugeValue,
+		mbToBytes(float64(dst[0].MemTargetSizeMB)),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.MemUsed,
+		prometheus.GaugeValue,
+		mbToBytes(float64(dst[0].MemUsedMB)),
+	)
+
+	return nil, nil
+}
+
+func (c *VmwareCollector) collectCpuWMI(ch chan<- prometheus.Metric) (*prometheus.Desc, error) {
+	var dst []Win32_PerfRawData_vmGuestLib_VCPU
+	q := querySelect(&dst, "")
+	if err := wmiQuery(q, &dst); err != nil {
+		return nil, err
+	}
+	if len(dst) == 0 {
+		return nil, errors.New("WMI query returned empty result set")
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.CpuLimitMHz,
+		prometheus.GaugeValue,
+		float64(dst[0].CpuLimitMHz),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.CpuReservationMHz,
+		prometheus.GaugeValue,
+		float64(dst[0].CpuReservationMHz),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.CpuShares,
+		prometheus.GaugeValue,
+		float64(dst[0].CpuShares),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.CpuStolenTotal,
+		prometheus.CounterValue,
+		float64(dst[0].CpuStolenMs)*ticksToSecondsScaleFactor,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.CpuTimeTotal,
+		prometheus.CounterValue,
+		float64(dst[0].CpuTimePercents)*ticksToSecondsScaleFactor,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.EffectiveVMSpeedMHz,
+		prometheus.GaugeValue,
+		float64(dst[0].EffectiveVMSpeedMHz),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.HostProcessorSpeedMHz,
+		prometheus.GaugeValue,
+		float64(dst[0].HostProcessorSpeedMHz),
+	)
+
+	return nil, nil
+}
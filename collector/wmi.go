@@ -2,18 +2,43 @@ package collector
 
 import (
 	"bytes"
+	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 
+	"github.com/StackExchange/wmi"
 	"github.com/prometheus-community/windows_exporter/log"
 )
 
+// wmiQuery and wmiQueryNamespace are used by all collectors to run WMI
+// queries. They are variables so that tests can replay recorded results.
+var (
+	wmiQuery = func(query string, dst interface{}, connectServerArgs ...interface{}) error {
+		return defaultWMIQueryLimiter().run(query, wmiDefaultNamespace, dst, func(q string, dst interface{}) error {
+			return wmi.Query(q, dst, connectServerArgs...)
+		})
+	}
+	wmiQueryNamespace = func(query string, dst interface{}, namespace string) error {
+		return defaultWMIQueryLimiter().run(query, namespace, dst, func(q string, dst interface{}) error {
+			return wmi.QueryNamespace(q, dst, namespace)
+		})
+	}
+)
+
 func className(src interface{}) string {
+	return structType(src).Name()
+}
+
+// structType returns the type of src, or of the elements of src if it is a
+// slice.
+func structType(src interface{}) reflect.Type {
 	s := reflect.Indirect(reflect.ValueOf(src))
 	t := s.Type()
 	if s.Kind() == reflect.Slice {
 		t = t.Elem()
 	}
-	return t.Name()
+	return t
 }
 
 func queryAll(src interface{}) string {
@@ -21,7 +46,7 @@ func queryAll(src interface{}) string {
 	b.WriteString("SELECT * FROM ")
 	b.WriteString(className(src))
 
-	log.Debugf("Generated WMI query %s", b.String())
+	log.With("query", b.String()).Debug("Generated WMI query")
 	return b.String()
 }
 
@@ -30,7 +55,7 @@ func queryAllForClass(src interface{}, class string) string {
 	b.WriteString("SELECT * FROM ")
 	b.WriteString(class)
 
-	log.Debugf("Generated WMI query %s", b.String())
+	log.With("query", b.String()).Debug("Generated WMI query")
 	return b.String()
 }
 
@@ -44,7 +69,7 @@ func queryAllWhere(src interface{}, where string) string {
 		b.WriteString(where)
 	}
 
-	log.Debugf("Generated WMI query %s", b.String())
+	log.With("query", b.String()).Debug("Generated WMI query")
 	return b.String()
 }
 
@@ -58,6 +83,211 @@ func queryAllForClassWhere(src interface{}, class string, where string) string {
 		b.WriteString(where)
 	}
 
-	log.Debugf("Generated WMI query %s", b.String())
+	log.With("query", b.String()).Debug("Generated WMI query")
+	return b.String()
+}
+
+// querySelect returns a query for the properties read into the struct src
+// from the class named like the struct, instead of all properties. Providers
+// compute some properties on demand, so this is often considerably cheaper.
+func querySelect(src interface{}, where wqlCondition) string {
+	return querySelectForClass(src, className(src), where)
+}
+
+// querySelectForClass is like querySelect, for a class not named like the
+// struct.
+func querySelectForClass(src interface{}, class string, where wqlCondition) string {
+	var b bytes.Buffer
+	b.WriteString("SELECT ")
+	b.WriteString(selectList(structType(src)))
+	b.WriteString(" FROM ")
+	b.WriteString(class)
+
+	if where != "" {
+		b.WriteString(" WHERE ")
+		b.WriteString(string(where))
+	}
+
+	log.With("query", b.String()).Debug("Generated WMI query")
+	return b.String()
+}
+
+// selectList returns the properties set by the wmi package when loading
+// objects into the struct type t, which are its exported fields.
+func selectList(t reflect.Type) string {
+	fields := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.PkgPath == "" {
+			fields = append(fields, f.Name)
+		}
+	}
+	if len(fields) == 0 {
+		return "*"
+	}
+	return strings.Join(fields, ", ")
+}
+
+var (
+	selectAllMtx sync.Mutex
+	// selectAllQueries holds the projected queries which failed, and are run
+	// as SELECT * instead.
+	selectAllQueries = make(map[string]bool)
+)
+
+// withSelectAllFallback runs query. Structs may have fields for properties
+// which only exist in some versions of a class, which the wmi package skips
+// when loading objects, but which make a projected query invalid. If query
+// selects specific properties and is rejected as invalid, it is run again
+// selecting all properties, and so on every following run.
+func withSelectAllFallback(query string, run func(query string) error) error {
+	all, projected := selectAllQuery(query)
+	if !projected {
+		return run(query)
+	}
+
+	selectAllMtx.Lock()
+	useAll := selectAllQueries[query]
+	selectAllMtx.Unlock()
+	if useAll {
+		return run(all)
+	}
+
+	err := run(query)
+	if err == nil || !isInvalidQuery(err) {
+		return err
+	}
+	log.With("query", query).WithError(err).Debug("WMI query is invalid, selecting all properties instead")
+	if err := run(all); err != nil {
+		return err
+	}
+	selectAllMtx.Lock()
+	selectAllQueries[query] = true
+	selectAllMtx.Unlock()
+	return nil
+}
+
+// selectAllQuery returns query selecting all properties, if it selects
+// specific ones.
+func selectAllQuery(query string) (string, bool) {
+	const selectPrefix = "SELECT "
+	from := strings.Index(query, " FROM ")
+	if !strings.HasPrefix(query, selectPrefix) || from < 0 {
+		return "", false
+	}
+	if strings.TrimSpace(query[len(selectPrefix):from]) == "*" {
+		return "", false
+	}
+	return selectPrefix + "*" + query[from:], true
+}
+
+// isInvalidQuery returns true for WBEM_E_INVALID_QUERY errors.
+func isInvalidQuery(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "invalid query") || strings.Contains(msg, "80041017")
+}
+
+// wqlCondition is a condition of a WQL WHERE clause, built with the wql*
+// functions, which quote and escape values. The empty condition matches all
+// objects.
+type wqlCondition string
+
+// wqlRaw returns a condition from a WQL expression, e.g. a where clause
+// given by the user.
+func wqlRaw(expr string) wqlCondition {
+	return wqlCondition(strings.TrimSpace(expr))
+}
+
+// wqlEquals returns a condition matching objects where property is value,
+// which may be a string, bool or number. WQL compares strings ignoring case.
+func wqlEquals(property string, value interface{}) wqlCondition {
+	return wqlCondition(property + " = " + wqlValue(value))
+}
+
+// wqlIn returns a condition matching objects where property is one of the
+// values. WQL has no IN operator for queries, so the comparisons are joined
+// with OR. Without values, the condition matches all objects.
+func wqlIn(property string, values ...string) wqlCondition {
+	conds := make([]wqlCondition, 0, len(values))
+	for _, v := range values {
+		conds = append(conds, wqlEquals(property, v))
+	}
+	return wqlOr(conds...)
+}
+
+// wqlLike returns a condition matching objects where property matches the
+// LIKE pattern. Use wqlEscapeLike for parts of the pattern to be matched
+// literally.
+func wqlLike(property string, pattern string) wqlCondition {
+	return wqlCondition(property + " LIKE " + wqlString(pattern))
+}
+
+// wqlEscapeLike escapes the LIKE wildcards in s: %, _ and [.
+func wqlEscapeLike(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '%', '_', '[':
+			b.WriteByte('[')
+			b.WriteRune(r)
+			b.WriteByte(']')
+		default:
+			b.WriteRune(r)
+		}
+	}
 	return b.String()
 }
+
+// wqlAnd returns a condition matching objects which match all conditions.
+// Empty conditions are ignored.
+func wqlAnd(conds ...wqlCondition) wqlCondition {
+	return wqlJoin("AND", conds)
+}
+
+// wqlOr returns a condition matching objects which match any of the
+// conditions. Empty conditions are ignored.
+func wqlOr(conds ...wqlCondition) wqlCondition {
+	return wqlJoin("OR", conds)
+}
+
+// wqlNot returns a condition matching objects which do not match cond.
+func wqlNot(cond wqlCondition) wqlCondition {
+	if cond == "" {
+		return ""
+	}
+	return "NOT (" + cond + ")"
+}
+
+func wqlJoin(op string, conds []wqlCondition) wqlCondition {
+	parts := make([]string, 0, len(conds))
+	for _, c := range conds {
+		if c != "" {
+			parts = append(parts, string(c))
+		}
+	}
+	switch len(parts) {
+	case 0:
+		return ""
+	case 1:
+		return wqlCondition(parts[0])
+	}
+	return wqlCondition("(" + strings.Join(parts, ") "+op+" (") + ")")
+}
+
+func wqlValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return wqlString(v)
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// wqlString quotes s as a WQL string literal.
+func wqlString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,178 @@
+package collector
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// registerWMICacheFlag registers the --collector.<collector>.wmi-cache-ttl
+// flag on the default kingpin application, for collectors querying WMI
+// classes which rarely change.
+func registerWMICacheFlag(collector string, defaultTTL time.Duration) *time.Duration {
+	return kingpin.Flag(
+		"collector."+collector+".wmi-cache-ttl",
+		fmt.Sprintf("How long the results of WMI queries of the %s collector for rarely changing data are reused. 0 queries WMI on every scrape.", collector),
+	).Default(defaultTTL.String()).Duration()
+}
+
+// wmiCache reuses the results of the WMI queries of a collector for a class
+// until they are older than its TTL. A TTL of 0 disables caching.
+type wmiCache struct {
+	collector string
+	ttl       time.Duration
+}
+
+func newWMICache(collector string, ttl time.Duration) *wmiCache {
+	return &wmiCache{collector: collector, ttl: ttl}
+}
+
+// query is like wmiQuery, returning a cached result if it is recent enough.
+func (c *wmiCache) query(query string, dst interface{}) error {
+	return c.cached("", query, dst, func() error {
+		return wmiQuery(query, dst)
+	})
+}
+
+// queryNamespace is like wmiQueryNamespace, returning a cached result if it
+// is recent enough.
+func (c *wmiCache) queryNamespace(query string, dst interface{}, namespace string) error {
+	return c.cached(namespace, query, dst, func() error {
+		return wmiQueryNamespace(query, dst, namespace)
+	})
+}
+
+// invalidate removes the cached result of the query, e.g. if it is
+// inconsistent with data queried without the cache.
+func (c *wmiCache) invalidate(query string, namespace string) {
+	wmiCacheMtx.Lock()
+	defer wmiCacheMtx.Unlock()
+	if e, ok := wmiCacheEntries[wmiCacheKey{c.collector, namespace, query}]; ok {
+		e.value = reflect.Value{}
+	}
+}
+
+func (c *wmiCache) cached(namespace string, query string, dst interface{}, run func() error) error {
+	if c.ttl <= 0 {
+		return run()
+	}
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("%v is not a pointer to slice", rv.Type())
+	}
+
+	key := wmiCacheKey{c.collector, namespace, query}
+	wmiCacheMtx.Lock()
+	e, ok := wmiCacheEntries[key]
+	if !ok {
+		e = &wmiCacheEntry{class: wqlClass(query)}
+		wmiCacheEntries[key] = e
+	}
+	if e.value.IsValid() && e.value.Type() == rv.Elem().Type() && wmiCacheNow().Sub(e.updated) < c.ttl {
+		e.hits++
+		rv.Elem().Set(copySlice(e.value))
+		wmiCacheMtx.Unlock()
+		return nil
+	}
+	e.misses++
+	wmiCacheMtx.Unlock()
+
+	// Concurrent misses of the same query run it more than once, which is
+	// preferable to holding the lock while querying.
+	if err := run(); err != nil {
+		return err
+	}
+
+	wmiCacheMtx.Lock()
+	e.value = copySlice(rv.Elem())
+	e.updated = wmiCacheNow()
+	wmiCacheMtx.Unlock()
+	return nil
+}
+
+// copySlice returns a copy of the slice v, so the callers cannot modify the
+// cached elements.
+func copySlice(v reflect.Value) reflect.Value {
+	c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+	reflect.Copy(c, v)
+	return c
+}
+
+type wmiCacheKey struct {
+	collector string
+	namespace string
+	query     string
+}
+
+type wmiCacheEntry struct {
+	class   string
+	value   reflect.Value
+	updated time.Time
+	hits    uint64
+	misses  uint64
+}
+
+var (
+	wmiCacheMtx     sync.Mutex
+	wmiCacheEntries = make(map[wmiCacheKey]*wmiCacheEntry)
+	// wmiCacheNow is a variable so that tests can control the age of entries.
+	wmiCacheNow = time.Now
+)
+
+var wqlClassPattern = regexp.MustCompile(`(?i)\bFROM\s+(\w+)`)
+
+// wqlClass returns the class a WQL query selects from.
+func wqlClass(query string) string {
+	if m := wqlClassPattern.FindStringSubmatch(query); m != nil {
+		return m[1]
+	}
+	return query
+}
+
+// WMICacheStats holds the statistics of the cached WMI queries of a
+// collector for a class.
+type WMICacheStats struct {
+	Class string
+	// Age is the age of the oldest cached result, or 0 if none is cached.
+	Age    time.Duration
+	Hits   uint64
+	Misses uint64
+}
+
+// WMICacheStatistics returns the statistics of the cached WMI queries of the
+// collector, sorted by class.
+func WMICacheStatistics(collector string) []WMICacheStats {
+	wmiCacheMtx.Lock()
+	defer wmiCacheMtx.Unlock()
+
+	byClass := make(map[string]*WMICacheStats)
+	for key, e := range wmiCacheEntries {
+		if key.collector != collector {
+			continue
+		}
+		s, ok := byClass[e.class]
+		if !ok {
+			s = &WMICacheStats{Class: e.class}
+			byClass[e.class] = s
+		}
+		s.Hits += e.hits
+		s.Misses += e.misses
+		if e.value.IsValid() {
+			if age := wmiCacheNow().Sub(e.updated); age > s.Age {
+				s.Age = age
+			}
+		}
+	}
+
+	stats := make([]WMICacheStats, 0, len(byClass))
+	for _, s := range byClass {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Class < stats[j].Class })
+	return stats
+}
@@ -0,0 +1,110 @@
+package collector
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// replayWMICache replaces the WMI query functions and the clock of the cache,
+// and returns a function restoring them. Queries return one row per query
+// run so far.
+func replayWMICache(t *testing.T, now *time.Time) (*int, func()) {
+	t.Helper()
+	origQuery, origNow, origEntries := wmiQuery, wmiCacheNow, wmiCacheEntries
+	runs := 0
+	wmiQuery = func(query string, dst interface{}, _ ...interface{}) error {
+		runs++
+		rows := dst.(*[]fakeWmiClass)
+		*rows = (*rows)[:0]
+		for i := 0; i < runs; i++ {
+			*rows = append(*rows, fakeWmiClass{Name: "row", SomeProperty: i})
+		}
+		return nil
+	}
+	wmiCacheNow = func() time.Time { return *now }
+	wmiCacheEntries = make(map[wmiCacheKey]*wmiCacheEntry)
+	return &runs, func() {
+		wmiQuery, wmiCacheNow, wmiCacheEntries = origQuery, origNow, origEntries
+	}
+}
+
+func TestWMICache(t *testing.T) {
+	now := time.Unix(1000, 0)
+	runs, restore := replayWMICache(t, &now)
+	defer restore()
+
+	c := newWMICache("test", time.Minute)
+	q := querySelect(&[]fakeWmiClass{}, "")
+	query := func() []fakeWmiClass {
+		var dst []fakeWmiClass
+		if err := c.query(q, &dst); err != nil {
+			t.Fatal(err)
+		}
+		return dst
+	}
+
+	first := query()
+	now = now.Add(30 * time.Second)
+	cached := query()
+	if *runs != 1 || !reflect.DeepEqual(first, cached) {
+		t.Errorf("expected cached result %v after %d runs, got %v", first, *runs, cached)
+	}
+	// The cached rows are copied.
+	cached[0].Name = "modified"
+	if again := query(); again[0].Name != "row" {
+		t.Errorf("cached result was modified: %v", again)
+	}
+
+	expected := []WMICacheStats{{Class: "fakeWmiClass", Age: 30 * time.Second, Hits: 2, Misses: 1}}
+	if stats := WMICacheStatistics("test"); !reflect.DeepEqual(stats, expected) {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+
+	now = now.Add(time.Minute)
+	if expired := query(); *runs != 2 || len(expired) != 2 {
+		t.Errorf("expected expired result to be queried again, got %v after %d runs", expired, *runs)
+	}
+
+	c.invalidate(q, "")
+	if invalidated := query(); *runs != 3 || len(invalidated) != 3 {
+		t.Errorf("expected invalidated result to be queried again, got %v after %d runs", invalidated, *runs)
+	}
+
+	if stats := WMICacheStatistics("other"); len(stats) != 0 {
+		t.Errorf("expected no statistics for other collector, got %+v", stats)
+	}
+}
+
+func TestWMICacheDisabled(t *testing.T) {
+	now := time.Unix(1000, 0)
+	runs, restore := replayWMICache(t, &now)
+	defer restore()
+
+	c := newWMICache("test", 0)
+	q := querySelect(&[]fakeWmiClass{}, "")
+	for i := 0; i < 2; i++ {
+		var dst []fakeWmiClass
+		if err := c.query(q, &dst); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if *runs != 2 {
+		t.Errorf("expected 2 queries, got %d", *runs)
+	}
+	if stats := WMICacheStatistics("test"); len(stats) != 0 {
+		t.Errorf("expected no statistics, got %+v", stats)
+	}
+}
+
+func TestWQLClass(t *testing.T) {
+	for query, expected := range map[string]string{
+		"SELECT * FROM Win32_Service":                        "Win32_Service",
+		"SELECT Name FROM Win32_Service WHERE Name = 'from'": "Win32_Service",
+		"select name from win32_service":                     "win32_service",
+	} {
+		if class := wqlClass(query); class != expected {
+			t.Errorf("%q: expected %q, got %q", query, expected, class)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package collector
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// wmiDefaultNamespace is the namespace of queries run with wmiQuery.
+const wmiDefaultNamespace = `root\cimv2`
+
+var (
+	wmiMaxConcurrentQueries = kingpin.Flag(
+		"wmi.max-concurrent-queries",
+		"Maximum number of WMI queries run at the same time by all collectors. Further queries wait for a running query to finish. 0 means no limit.",
+	).Default("4").Int()
+	wmiQueryTimeout = kingpin.Flag(
+		"wmi.query-timeout",
+		"Maximum time a WMI query may take, including waiting to be run. Queries taking longer fail, but still occupy their slot until WMI returns. 0 means no timeout.",
+	).Default("0s").Duration()
+)
+
+var (
+	wmiQueryDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: "exporter",
+			Name:      "wmi_query_duration_seconds",
+			Help:      "windows_exporter: Duration of WMI queries, excluding the time waiting to be run.",
+			Buckets:   []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"class", "namespace"},
+	)
+	wmiQueryErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "exporter",
+			Name:      "wmi_query_errors_total",
+			Help:      "windows_exporter: Number of failed WMI queries, by reason: error or timeout.",
+		},
+		[]string{"class", "namespace", "reason"},
+	)
+	wmiQueriesWaiting = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Subsystem: "exporter",
+			Name:      "wmi_queries_waiting",
+			Help:      "windows_exporter: Number of WMI queries waiting for a running query to finish.",
+		},
+	)
+)
+
+// WMIQueryMetrics returns a collector of the metrics of all WMI queries run
+// by the collectors.
+func WMIQueryMetrics() prometheus.Collector {
+	return wmiQueryMetrics{}
+}
+
+type wmiQueryMetrics struct{}
+
+func (wmiQueryMetrics) Describe(ch chan<- *prometheus.Desc) {
+	wmiQueryDuration.Describe(ch)
+	wmiQueryErrors.Describe(ch)
+	wmiQueriesWaiting.Describe(ch)
+}
+
+func (wmiQueryMetrics) Collect(ch chan<- prometheus.Metric) {
+	wmiQueryDuration.Collect(ch)
+	wmiQueryErrors.Collect(ch)
+	wmiQueriesWaiting.Collect(ch)
+}
+
+// wmiQueryLimiter limits the number of concurrent WMI queries and the time
+// each may take.
+type wmiQueryLimiter struct {
+	maxConcurrent int
+	// slots holds a value for every running query, if the number is limited.
+	slots   chan struct{}
+	timeout time.Duration
+}
+
+func newWMIQueryLimiter(maxConcurrent int, timeout time.Duration) *wmiQueryLimiter {
+	l := &wmiQueryLimiter{maxConcurrent: maxConcurrent, timeout: timeout}
+	if maxConcurrent > 0 {
+		l.slots = make(chan struct{}, maxConcurrent)
+	}
+	return l
+}
+
+var (
+	wmiLimiterMtx sync.Mutex
+	wmiLimiter    *wmiQueryLimiter
+)
+
+// defaultWMIQueryLimiter returns the limiter configured by the flags, which
+// are only parsed after the package is initialised. The limiter is replaced
+// when the flags change with the remote configuration; queries running by
+// then keep their slot in the previous limiter until they return.
+func defaultWMIQueryLimiter() *wmiQueryLimiter {
+	wmiLimiterMtx.Lock()
+	defer wmiLimiterMtx.Unlock()
+	if wmiLimiter == nil || wmiLimiter.maxConcurrent != *wmiMaxConcurrentQueries || wmiLimiter.timeout != *wmiQueryTimeout {
+		wmiLimiter = newWMIQueryLimiter(*wmiMaxConcurrentQueries, *wmiQueryTimeout)
+	}
+	return wmiLimiter
+}
+
+// wmiQueryError is the error of a WMI query, logged with the class and
+// namespace queried.
+type wmiQueryError struct {
+	class, namespace string
+	err              error
+}
+
+func (e *wmiQueryError) Error() string { return e.err.Error() }
+func (e *wmiQueryError) Unwrap() error { return e.err }
+
+// LogFields returns the fields log.WithError adds to the log entry.
+func (e *wmiQueryError) LogFields() []interface{} {
+	return []interface{}{"wmi_class", e.class, "wmi_namespace", e.namespace}
+}
+
+// run runs query into dst, which must be a pointer, with the query function
+// of the wmi package. The query is run on a copy of dst, so that dst is not
+// written to by a query which timed out.
+func (l *wmiQueryLimiter) run(query string, namespace string, dst interface{}, queryFunc func(query string, dst interface{}) error) error {
+	class := wqlClass(query)
+	if err := l.runLimited(query, class, namespace, dst, queryFunc); err != nil {
+		return &wmiQueryError{class: class, namespace: namespace, err: err}
+	}
+	return nil
+}
+
+func (l *wmiQueryLimiter) runLimited(query, class, namespace string, dst interface{}, queryFunc func(query string, dst interface{}) error) error {
+	var timeout <-chan time.Time
+	if l.timeout > 0 {
+		timer := time.NewTimer(l.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		default:
+			wmiQueriesWaiting.Inc()
+			select {
+			case l.slots <- struct{}{}:
+				wmiQueriesWaiting.Dec()
+			case <-timeout:
+				wmiQueriesWaiting.Dec()
+				wmiQueryErrors.WithLabelValues(class, namespace, "timeout").Inc()
+				return fmt.Errorf("timed out after %s waiting to run WMI query %q", l.timeout, query)
+			}
+		}
+	}
+
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		l.release()
+		return fmt.Errorf("%v is nil or not a pointer", rv.Type())
+	}
+	result := reflect.New(rv.Elem().Type())
+	done := make(chan error, 1)
+	go func() {
+		defer l.release()
+		begin := time.Now()
+		err := withSelectAllFallback(query, func(q string) error {
+			return queryFunc(q, result.Interface())
+		})
+		wmiQueryDuration.WithLabelValues(class, namespace).Observe(time.Since(begin).Seconds())
+		if err != nil {
+			wmiQueryErrors.WithLabelValues(class, namespace, "error").Inc()
+		}
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return err
+		}
+		rv.Elem().Set(result.Elem())
+		return nil
+	case <-timeout:
+		wmiQueryErrors.WithLabelValues(class, namespace, "timeout").Inc()
+		return fmt.Errorf("WMI query %q timed out after %s", query, l.timeout)
+	}
+}
+
+func (l *wmiQueryLimiter) release() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
@@ -0,0 +1,125 @@
+package collector
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestWMIQueryLimiterConcurrency(t *testing.T) {
+	l := newWMIQueryLimiter(2, 0)
+
+	var (
+		mtx                 sync.Mutex
+		running, maxRunning int
+		wg                  sync.WaitGroup
+	)
+	query := func(_ string, dst interface{}) error {
+		mtx.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mtx.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mtx.Lock()
+		running--
+		mtx.Unlock()
+		*dst.(*[]fakeWmiClass) = []fakeWmiClass{{Name: "row"}}
+		return nil
+	}
+
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var dst []fakeWmiClass
+			if err := l.run("SELECT * FROM fakeWmiClass", wmiDefaultNamespace, &dst, query); err != nil {
+				t.Error(err)
+			}
+			if len(dst) != 1 {
+				t.Errorf("expected 1 row, got %v", dst)
+			}
+		}()
+	}
+	wg.Wait()
+	if maxRunning != 2 {
+		t.Errorf("expected 2 queries to run concurrently, got %d", maxRunning)
+	}
+}
+
+func TestWMIQueryLimiterTimeout(t *testing.T) {
+	l := newWMIQueryLimiter(1, 20*time.Millisecond)
+	class := "timeoutClass"
+	before := testutil.ToFloat64(wmiQueryErrors.WithLabelValues(class, wmiDefaultNamespace, "timeout"))
+
+	release := make(chan struct{})
+	finished := make(chan struct{})
+	slow := func(_ string, dst interface{}) error {
+		<-release
+		*dst.(*[]fakeWmiClass) = []fakeWmiClass{{Name: "late"}}
+		close(finished)
+		return nil
+	}
+
+	var dst []fakeWmiClass
+	if err := l.run("SELECT * FROM "+class, wmiDefaultNamespace, &dst, slow); err == nil {
+		t.Errorf("expected a timeout, but got ok")
+	}
+	// The slot is held until the query returns, so the next query times out
+	// waiting.
+	if err := l.run("SELECT * FROM "+class, wmiDefaultNamespace, &dst, slow); err == nil {
+		t.Errorf("expected a timeout, but got ok")
+	}
+	close(release)
+	<-finished
+	if dst != nil {
+		t.Errorf("expected the result of the timed out query to be discarded, got %v", dst)
+	}
+
+	if timeouts := testutil.ToFloat64(wmiQueryErrors.WithLabelValues(class, wmiDefaultNamespace, "timeout")) - before; timeouts != 2 {
+		t.Errorf("expected 2 timeouts, got %v", timeouts)
+	}
+}
+
+func TestWMIQueryLimiterError(t *testing.T) {
+	l := newWMIQueryLimiter(0, 0)
+	class := "errorClass"
+	before := testutil.ToFloat64(wmiQueryErrors.WithLabelValues(class, `root\test`, "error"))
+
+	failure := errors.New("Exception occurred. (Invalid class )")
+	var dst []fakeWmiClass
+	err := l.run("SELECT * FROM "+class, `root\test`, &dst, func(string, interface{}) error { return failure })
+	if !errors.Is(err, failure) {
+		t.Errorf("expected %v, got %v", failure, err)
+	}
+	var queryErr *wmiQueryError
+	if !errors.As(err, &queryErr) || !reflect.DeepEqual(queryErr.LogFields(), []interface{}{"wmi_class", class, "wmi_namespace", `root\test`}) {
+		t.Errorf("expected the class and namespace as log fields, got %#v", err)
+	}
+	if errs := testutil.ToFloat64(wmiQueryErrors.WithLabelValues(class, `root\test`, "error")) - before; errs != 1 {
+		t.Errorf("expected 1 error, got %v", errs)
+	}
+}
+
+func TestDefaultWMIQueryLimiterFlagsChanged(t *testing.T) {
+	maxConcurrent, timeout := *wmiMaxConcurrentQueries, *wmiQueryTimeout
+	defer func() { *wmiMaxConcurrentQueries, *wmiQueryTimeout = maxConcurrent, timeout }()
+
+	*wmiMaxConcurrentQueries, *wmiQueryTimeout = 4, 0
+	l := defaultWMIQueryLimiter()
+	if defaultWMIQueryLimiter() != l {
+		t.Errorf("expected the limiter to be reused while the flags are unchanged")
+	}
+
+	// The flags are bound again when the remote configuration changes.
+	*wmiMaxConcurrentQueries, *wmiQueryTimeout = 2, time.Second
+	changed := defaultWMIQueryLimiter()
+	if changed == l || cap(changed.slots) != 2 || changed.timeout != time.Second {
+		t.Errorf("expected a limiter of 2 queries with a timeout of 1s, got %d queries with a timeout of %s", cap(changed.slots), changed.timeout)
+	}
+}
@@ -1,6 +1,7 @@
 package collector
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -113,3 +114,105 @@ func TestCreateQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestQuerySelect(t *testing.T) {
+	cases := []struct {
+		desc     string
+		query    string
+		expected string
+	}{
+		{
+			desc:     "single instance",
+			query:    querySelect(fakeWmiClass{}, ""),
+			expected: "SELECT Name, SomeProperty FROM fakeWmiClass",
+		},
+		{
+			desc:     "slice pointer",
+			query:    querySelect(&[]fakeWmiClass{}, wqlEquals("Name", "foo")),
+			expected: "SELECT Name, SomeProperty FROM fakeWmiClass WHERE Name = 'foo'",
+		},
+		{
+			desc:     "class",
+			query:    querySelectForClass(&[]fakeWmiClass{}, "someClass", wqlRaw(" foo = bar ")),
+			expected: "SELECT Name, SomeProperty FROM someClass WHERE foo = bar",
+		},
+		{
+			desc:     "unexported fields",
+			query:    querySelect(struct{ Name, other string }{}, ""),
+			expected: "SELECT Name FROM ",
+		},
+	}
+	for _, c := range cases {
+		if c.query != c.expected {
+			t.Errorf("Case %q failed: Expected %q, got %q", c.desc, c.expected, c.query)
+		}
+	}
+}
+
+func TestWQLConditions(t *testing.T) {
+	cases := []struct {
+		desc     string
+		cond     wqlCondition
+		expected wqlCondition
+	}{
+		{"string", wqlEquals("Name", "foo"), "Name = 'foo'"},
+		{"escaped string", wqlEquals("Name", `it's C:\temp`), `Name = 'it\'s C:\\temp'`},
+		{"number", wqlEquals("ProcessId", uint32(4)), "ProcessId = 4"},
+		{"bool", wqlEquals("Started", true), "Started = TRUE"},
+		{"in", wqlIn("Name", "a", "b'c"), `(Name = 'a') OR (Name = 'b\'c')`},
+		{"in single", wqlIn("Name", "a"), "Name = 'a'"},
+		{"in empty", wqlIn("Name"), ""},
+		{"like", wqlLike("Name", wqlEscapeLike("sql_[1]%")+"%"), "Name LIKE 'sql[_][[]1][%]%'"},
+		{"and", wqlAnd(wqlRaw("State = 'Running' OR State = 'Paused'"), "", wqlEquals("Name", "a")), "(State = 'Running' OR State = 'Paused') AND (Name = 'a')"},
+		{"and empty", wqlAnd("", ""), ""},
+		{"or", wqlOr(wqlEquals("Name", "a"), wqlNot(wqlEquals("Name", "b"))), "(Name = 'a') OR (NOT (Name = 'b'))"},
+		{"not empty", wqlNot(""), ""},
+	}
+	for _, c := range cases {
+		if c.cond != c.expected {
+			t.Errorf("Case %q failed: Expected %q, got %q", c.desc, c.expected, c.cond)
+		}
+	}
+}
+
+func TestWithSelectAllFallback(t *testing.T) {
+	defer func(queries map[string]bool) {
+		selectAllQueries = queries
+	}(selectAllQueries)
+	selectAllQueries = make(map[string]bool)
+
+	var queries []string
+	run := func(query string) error {
+		queries = append(queries, query)
+		if query == "SELECT Name, Missing FROM fakeWmiClass" {
+			return errors.New("Exception occurred. (Invalid query )")
+		}
+		return nil
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := withSelectAllFallback("SELECT Name, Missing FROM fakeWmiClass", run); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := []string{
+		"SELECT Name, Missing FROM fakeWmiClass",
+		"SELECT * FROM fakeWmiClass",
+		"SELECT * FROM fakeWmiClass",
+	}
+	if len(queries) != len(expected) {
+		t.Fatalf("Expected queries %q, got %q", expected, queries)
+	}
+	for i := range expected {
+		if queries[i] != expected[i] {
+			t.Errorf("Expected queries %q, got %q", expected, queries)
+		}
+	}
+
+	// Other errors are returned as they are.
+	failure := errors.New("Exception occurred. (Invalid class )")
+	err := withSelectAllFallback("SELECT Name FROM fakeWmiClass", func(string) error { return failure })
+	if err != failure {
+		t.Errorf("Expected %v, got %v", failure, err)
+	}
+}
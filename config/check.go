@@ -0,0 +1,228 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// Problem is an error in a configuration file, concerning the value of key.
+type Problem struct {
+	File    string
+	Key     string
+	Message string
+	// Ignored is true if the key is ignored, rather than set to an invalid
+	// value which fails parsing the flags.
+	Ignored bool
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s: %s", p.File, p.Key, p.Message)
+}
+
+// CheckError is returned by Check for a configuration with problems.
+type CheckError struct {
+	Problems []Problem
+}
+
+func (e *CheckError) Error() string {
+	lines := make([]string, 0, len(e.Problems)+1)
+	lines = append(lines, fmt.Sprintf("configuration has %d problem(s):", len(e.Problems)))
+	for _, p := range e.Problems {
+		lines = append(lines, "  "+p.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Ignorable returns true if all problems are keys which are ignored, so that
+// the configuration can still be bound.
+func (e *CheckError) Ignorable() bool {
+	for _, p := range e.Problems {
+		if !p.Ignored {
+			return false
+		}
+	}
+	return true
+}
+
+// commandLineOnlyPrefix is the prefix of the flags configuring how the
+// configuration is loaded, which are read before any file is.
+const commandLineOnlyPrefix = "config."
+
+// validator is implemented by flag values which can check a value without
+// setting it, e.g. repeatable values which append every value set.
+type validator interface {
+	Validate(value string) error
+}
+
+// Check validates the configuration files against the flags of app and its
+// commands. Every key must name a flag, and every value must be valid for
+// the type of the flag. Values are validated by setting them on a new value
+// of the type of the flag, as Bind does when the flags are parsed, so that
+// the flags of app are left unchanged. Lists are validated as Bind sets them.
+func (c *Resolver) Check(app *kingpin.Application) error {
+	flags := flagModels(app.Model())
+
+	keys := make([]string, 0, len(c.flags))
+	for k := range c.flags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var problems []Problem
+	for _, key := range keys {
+		flag, ok := flags[key]
+		if !ok {
+			msg := "unknown key"
+			if s := suggest(key, flags); s != "" {
+				msg += fmt.Sprintf(", did you mean %q?", s)
+			}
+			problems = append(problems, Problem{File: c.sources[key], Key: key, Message: msg, Ignored: true})
+			continue
+		}
+		if strings.HasPrefix(key, commandLineOnlyPrefix) {
+			problems = append(problems, Problem{File: c.sources[key], Key: key, Message: "can only be set on the command line or by an environment variable", Ignored: true})
+			continue
+		}
+		if value, err := validateValues(flag.Value, c.flags[key]); err != nil {
+			problems = append(problems, Problem{File: c.sources[key], Key: key, Message: fmt.Sprintf("invalid value %q: %v", value, err)})
+		}
+	}
+	if len(problems) > 0 {
+		return &CheckError{Problems: problems}
+	}
+	return nil
+}
+
+// flagModels returns the flags of the application and all of its commands by
+// name.
+func flagModels(app *kingpin.ApplicationModel) map[string]*kingpin.FlagModel {
+	flags := make(map[string]*kingpin.FlagModel)
+	for _, f := range app.Flags {
+		flags[f.Name] = f
+	}
+	var addCommands func(cmds []*kingpin.CmdModel)
+	addCommands = func(cmds []*kingpin.CmdModel) {
+		for _, cmd := range cmds {
+			for _, f := range cmd.Flags {
+				flags[f.Name] = f
+			}
+			addCommands(cmd.Commands)
+		}
+	}
+	addCommands(app.Commands)
+	return flags
+}
+
+// validateValues validates the values of a key as Bind sets them, and
+// returns the invalid value.
+func validateValues(v kingpin.Value, values []string) (string, error) {
+	if !isRepeatable(v) {
+		values = []string{joinValues(values)}
+	}
+	for _, value := range values {
+		if err := validateValue(v, value); err != nil {
+			return value, err
+		}
+	}
+	return "", nil
+}
+
+func validateValue(v kingpin.Value, value string) error {
+	if val, ok := v.(validator); ok {
+		return val.Validate(value)
+	}
+	if isRepeatable(v) {
+		return nil
+	}
+	newValue, ok := scratchValues[reflect.TypeOf(v)]
+	if !ok {
+		// Values of other types, e.g. enums whose options are unknown here,
+		// are only validated when the flags are parsed.
+		return nil
+	}
+	return newValue().Set(value)
+}
+
+// scratchValues returns new values of the kingpin value types, by the type
+// of the values. Setting a new value validates a value without changing the
+// registered flag, which would change the running configuration.
+var scratchValues = func() map[reflect.Type]func() kingpin.Value {
+	values := make(map[reflect.Type]func() kingpin.Value)
+	for _, define := range []func(f *kingpin.FlagClause){
+		func(f *kingpin.FlagClause) { f.String() },
+		func(f *kingpin.FlagClause) { f.Bool() },
+		func(f *kingpin.FlagClause) { f.Int() },
+		func(f *kingpin.FlagClause) { f.Int64() },
+		func(f *kingpin.FlagClause) { f.Uint() },
+		func(f *kingpin.FlagClause) { f.Uint64() },
+		func(f *kingpin.FlagClause) { f.Float64() },
+		func(f *kingpin.FlagClause) { f.Duration() },
+		func(f *kingpin.FlagClause) { f.Regexp() },
+		func(f *kingpin.FlagClause) { f.IP() },
+		func(f *kingpin.FlagClause) { f.URL() },
+		func(f *kingpin.FlagClause) { f.Bytes() },
+	} {
+		define := define
+		newValue := func() kingpin.Value {
+			app := kingpin.New("check", "")
+			define(app.Flag("value", ""))
+			return flagModels(app.Model())["value"].Value
+		}
+		values[reflect.TypeOf(newValue())] = newValue
+	}
+	return values
+}()
+
+// suggest returns the name of the visible flag closest to key, if it is
+// close enough to be a likely typo.
+func suggest(key string, flags map[string]*kingpin.FlagModel) string {
+	maxDistance := len(key) / 4
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+	best, bestDistance := "", maxDistance+1
+	for name, f := range flags {
+		if f.Hidden {
+			continue
+		}
+		if d := levenshtein(key, name); d < bestDistance || (d == bestDistance && name < best) {
+			best, bestDistance = name, d
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
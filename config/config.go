@@ -14,8 +14,11 @@
 package config
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/prometheus-community/windows_exporter/log"
 	"gopkg.in/alecthomas/kingpin.v2"
@@ -28,46 +31,175 @@ type getFlagger interface {
 
 // Resolver represents a configuration file resolver for kingpin.
 type Resolver struct {
-	flags map[string]string
+	files []string
+	flags map[string][]string
+	// lists holds the keys given as lists.
+	lists map[string]bool
+	// sources holds the file each key was last set in.
+	sources map[string]string
 }
 
-// NewResolver returns a Resolver structure.
-func NewResolver(file string) (*Resolver, error) {
-	flags := map[string]string{}
-	log.Infof("Loading configuration file: %v", file)
-	if _, err := os.Stat(file); err != nil {
+// Files is a repeatable flag value holding configuration files and
+// directories, as passed to NewResolver.
+type Files []string
+
+func (f *Files) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func (f *Files) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *Files) IsCumulative() bool {
+	return true
+}
+
+func (f *Files) Reset() {
+	*f = nil
+}
+
+// repeatableValue is implemented by the values of repeatable flags, which
+// are set once for every value in a list.
+type repeatableValue interface {
+	IsCumulative() bool
+}
+
+// resettableValue is implemented by repeatable values which can be emptied.
+// Values set when parsing the command line are appended again when it is
+// parsed once more after binding, unless the value is reset.
+type resettableValue interface {
+	Reset()
+}
+
+func isRepeatable(v kingpin.Value) bool {
+	r, ok := v.(repeatableValue)
+	return ok && r.IsCumulative()
+}
+
+// NewResolver returns a Resolver structure, for the configuration files
+// and directories in paths. The *.yml and *.yaml files in a directory are
+// loaded in lexical order. A key set in several files takes the value of
+// the last one, and must be a list in all of them or in none.
+func NewResolver(paths ...string) (*Resolver, error) {
+	c := &Resolver{
+		flags:   map[string][]string{},
+		lists:   map[string]bool{},
+		sources: map[string]string{},
+	}
+	for _, path := range paths {
+		files, err := configFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if err := c.load(file); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return c, nil
+}
+
+// configFiles returns path if it is a file, and the configuration files in
+// it if it is a directory.
+func configFiles(path string) ([]string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
 		return nil, err
 	}
-	b, err := ioutil.ReadFile(file)
+	if !fi.IsDir() {
+		return []string{path}, nil
+	}
+	entries, err := ioutil.ReadDir(path)
 	if err != nil {
 		return nil, err
 	}
+	var files []string
+	for _, e := range entries {
+		if ext := filepath.Ext(e.Name()); !e.IsDir() && (ext == ".yml" || ext == ".yaml") {
+			files = append(files, filepath.Join(path, e.Name()))
+		}
+	}
+	if len(files) == 0 {
+		log.Warnf("No configuration files in directory %s", path)
+	}
+	// ReadDir sorts by file name.
+	return files, nil
+}
+
+func (c *Resolver) load(file string) error {
+	log.Infof("Loading configuration file: %v", file)
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	return c.Load(file, b)
+}
 
+// Load adds the configuration in b, read from file, which may also be a URL,
+// as if it was the last of the files passed to NewResolver.
+func (c *Resolver) Load(file string, b []byte) error {
 	var rawValues map[string]interface{}
-	err = yaml.Unmarshal(b, &rawValues)
+	err := yaml.Unmarshal(b, &rawValues)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("%s: %v", file, err)
 	}
 	// Flatten nested YAML values
-	flattenedValues := flatten(rawValues)
+	flattenedValues, lists, err := flatten(rawValues)
+	if err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
 	for k, v := range flattenedValues {
-		if _, ok := flags[k]; !ok {
-			flags[k] = v
+		// Expand environment variables in values
+		for i := range v {
+			if v[i], err = expandEnv(v[i], os.LookupEnv); err != nil {
+				return fmt.Errorf("%s: %s: %v", file, k, err)
+			}
 		}
+		if prev, ok := c.sources[k]; ok && c.lists[k] != lists[k] {
+			return fmt.Errorf("%s is a %s in %s, but a %s in %s", k, listOrValue(c.lists[k]), prev, listOrValue(lists[k]), file)
+		}
+		c.flags[k] = v
+		c.lists[k] = lists[k]
+		c.sources[k] = file
 	}
-	return &Resolver{flags: flags}, nil
+	c.files = append(c.files, file)
+	return nil
+}
+
+// Files returns the configuration files loaded, in order.
+func (c *Resolver) Files() []string {
+	return c.files
+}
+
+func listOrValue(list bool) string {
+	if list {
+		return "list"
+	}
+	return "single value"
 }
 
 func (c *Resolver) setDefault(v getFlagger) {
-	for name, value := range c.flags {
+	for name, values := range c.flags {
 		f := v.GetFlag(name)
-		if f != nil {
-			f.Default(value)
+		if f == nil || strings.HasPrefix(name, commandLineOnlyPrefix) {
+			continue
+		}
+		value := f.Model().Value
+		if isRepeatable(value) {
+			f.Default(values...)
+		} else {
+			f.Default(joinValues(values))
 		}
 	}
 }
 
 // Bind sets active flags with their default values from the configuration file(s).
+// Lists are set as the values of repeatable flags, and joined by commas for
+// other flags. Repeatable flags are reset, so that the application must be
+// parsed again.
 func (c *Resolver) Bind(app *kingpin.Application, args []string) error {
 	// Parse the command line arguments to get the selected command.
 	pc, err := app.ParseContext(args)
@@ -79,6 +211,69 @@ func (c *Resolver) Bind(app *kingpin.Application, args []string) error {
 	if pc.SelectedCommand != nil {
 		c.setDefault(pc.SelectedCommand)
 	}
+	for _, f := range flagModels(app.Model()) {
+		if r, ok := f.Value.(resettableValue); ok {
+			r.Reset()
+		}
+	}
 
 	return nil
 }
+
+// Defaults are the default values of the flags of an application, and the
+// values of the flags without a default, before a configuration is bound.
+type Defaults struct {
+	defaults map[string][]string
+	values   map[string]string
+}
+
+// SaveDefaults returns the defaults of the flags of app and its commands. Call
+// it before binding a configuration.
+func SaveDefaults(app *kingpin.Application) *Defaults {
+	d := &Defaults{defaults: make(map[string][]string), values: make(map[string]string)}
+	for name, f := range flagModels(app.Model()) {
+		d.defaults[name] = f.Default
+		if len(f.Default) == 0 && !isRepeatable(f.Value) {
+			d.values[name] = f.Value.String()
+		}
+	}
+	return d
+}
+
+// Restore sets the default values of the flags of app back, so that another
+// configuration can be bound. Parsing doesn't set flags without a default,
+// so their values are set back as well.
+func (d *Defaults) Restore(app *kingpin.Application) {
+	for name, f := range flagClauses(app) {
+		defaults, ok := d.defaults[name]
+		if !ok {
+			continue
+		}
+		f.Default(defaults...)
+		if value, ok := d.values[name]; ok {
+			// Values such as enums may not accept their zero value, but then
+			// can't have been set by a configuration either.
+			_ = f.Model().Value.Set(value)
+		}
+	}
+}
+
+// flagClauses returns the flags of app and all of its commands by name.
+func flagClauses(app *kingpin.Application) map[string]*kingpin.FlagClause {
+	flags := make(map[string]*kingpin.FlagClause)
+	for _, f := range app.Model().Flags {
+		flags[f.Name] = app.GetFlag(f.Name)
+	}
+	var addCommands func(cmds []*kingpin.CmdModel, get func(string) *kingpin.CmdClause)
+	addCommands = func(cmds []*kingpin.CmdModel, get func(string) *kingpin.CmdClause) {
+		for _, cmd := range cmds {
+			clause := get(cmd.Name)
+			for _, f := range cmd.Flags {
+				flags[f.Name] = clause.GetFlag(f.Name)
+			}
+			addCommands(cmd.Commands, clause.GetCommand)
+		}
+	}
+	addCommands(app.Model().Commands, app.GetCommand)
+	return flags
+}
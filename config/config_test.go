@@ -0,0 +1,437 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// writeConfig writes a configuration file to a temporary directory, and
+// returns its path and a function removing it.
+func writeConfig(t *testing.T, content string) (string, func()) {
+	t.Helper()
+	dir, cleanup := writeConfigDir(t, map[string]string{"config.yml": content})
+	return filepath.Join(dir, "config.yml"), cleanup
+}
+
+// writeConfigDir writes files to a temporary directory, and returns its path
+// and a function removing it.
+func writeConfigDir(t *testing.T, files map[string]string) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "windows_exporter_config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			os.RemoveAll(dir)
+			t.Fatal(err)
+		}
+	}
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func TestNewResolver(t *testing.T) {
+	file, cleanup := writeConfig(t, `
+collectors:
+  enabled: cpu,net
+collector:
+  service:
+    services-where: "Name='windows_exporter'"
+  process:
+    include:
+      - firefox.+
+      - process_id=4
+`)
+	defer cleanup()
+
+	r, err := NewResolver(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][]string{
+		"collectors.enabled":               {"cpu,net"},
+		"collector.service.services-where": {"Name='windows_exporter'"},
+		"collector.process.include":        {"firefox.+", "process_id=4"},
+	}
+	if !reflect.DeepEqual(r.flags, expected) {
+		t.Errorf("expected %v, got %v", expected, r.flags)
+	}
+}
+
+func TestNewResolverErrors(t *testing.T) {
+	if _, err := NewResolver(filepath.Join(os.TempDir(), "windows_exporter_missing.yml")); err == nil {
+		t.Errorf("missing file: expected an error, but got ok")
+	}
+
+	for name, content := range map[string]string{
+		"invalid YAML":  "collectors: [cpu\n",
+		"list of maps":  "listeners:\n  - addr: ':9182'\n",
+		"list of lists": "collectors:\n  enabled: [[cpu]]\n",
+	} {
+		file, cleanup := writeConfig(t, content)
+		if _, err := NewResolver(file); err == nil {
+			t.Errorf("%s: expected an error, but got ok", name)
+		}
+		cleanup()
+	}
+}
+
+func TestNewResolverLayered(t *testing.T) {
+	dir, cleanup := writeConfigDir(t, map[string]string{
+		"10-base.yml": `
+collectors:
+  enabled: [cpu, os, mssql]
+log:
+  level: info
+`,
+		"20-mssql.yaml": `
+collectors:
+  enabled: [cpu, os, mssql, iis]
+collector:
+  mssql:
+    classes-enabled: accessmethods
+`,
+		// Only YAML files are loaded.
+		"README.txt": "collectors: [",
+	})
+	defer cleanup()
+	override, cleanupOverride := writeConfig(t, "log:\n  level: debug\n")
+	defer cleanupOverride()
+
+	r, err := NewResolver(dir, override)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][]string{
+		"collectors.enabled":              {"cpu", "os", "mssql", "iis"},
+		"log.level":                       {"debug"},
+		"collector.mssql.classes-enabled": {"accessmethods"},
+	}
+	if !reflect.DeepEqual(r.flags, expected) {
+		t.Errorf("expected %v, got %v", expected, r.flags)
+	}
+	expectedSources := map[string]string{
+		"collectors.enabled":              filepath.Join(dir, "20-mssql.yaml"),
+		"log.level":                       override,
+		"collector.mssql.classes-enabled": filepath.Join(dir, "20-mssql.yaml"),
+	}
+	if !reflect.DeepEqual(r.sources, expectedSources) {
+		t.Errorf("expected sources %v, got %v", expectedSources, r.sources)
+	}
+	expectedFiles := []string{filepath.Join(dir, "10-base.yml"), filepath.Join(dir, "20-mssql.yaml"), override}
+	if !reflect.DeepEqual(r.files, expectedFiles) {
+		t.Errorf("expected files %v, got %v", expectedFiles, r.files)
+	}
+}
+
+func TestNewResolverLayeredListConflict(t *testing.T) {
+	dir, cleanup := writeConfigDir(t, map[string]string{
+		"10-base.yml": "collectors:\n  enabled: [cpu, os]\n",
+		"20-iis.yml":  "collectors:\n  enabled: cpu,os,iis\n",
+	})
+	defer cleanup()
+
+	_, err := NewResolver(dir)
+	expected := fmt.Sprintf("collectors.enabled is a list in %s, but a single value in %s", filepath.Join(dir, "10-base.yml"), filepath.Join(dir, "20-iis.yml"))
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, got %v", expected, err)
+	}
+}
+
+func checkApp() *kingpin.Application {
+	app := kingpin.New("test", "")
+	app.Flag("collectors.enabled", "").Default("cpu").String()
+	app.Flag("telemetry.max-requests", "").Default("5").Int()
+	app.Flag("collectors.max-series", "").Default("0").Int()
+	app.Flag("scrape.timeout-margin", "").Default("0.5").Float64()
+	app.Flag("collector.process.whitelist", "").Hidden().String()
+	app.Flag("collector.process.include", "").Strings()
+	app.Flag("config.url", "").String()
+	run := app.Command("run", "").Default()
+	run.Flag("wmi.query-timeout", "").Default("0s").Duration()
+	return app
+}
+
+func TestCheck(t *testing.T) {
+	cases := []struct {
+		name      string
+		flags     map[string][]string
+		problems  []Problem
+		ignorable bool
+	}{
+		{
+			name: "valid",
+			flags: map[string][]string{
+				"collectors.enabled":          {"cpu", "net"},
+				"telemetry.max-requests":      {"10"},
+				"scrape.timeout-margin":       {"1.5"},
+				"collector.process.whitelist": {"firefox"},
+				"collector.process.include":   {"firefox", "chrome"},
+				"wmi.query-timeout":           {"5s"},
+			},
+		},
+		{
+			name:  "typo",
+			flags: map[string][]string{"collector.proces.whitelist": {"firefox"}},
+			problems: []Problem{
+				// Hidden flags are not suggested.
+				{Key: "collector.proces.whitelist", Message: `unknown key`},
+			},
+			ignorable: true,
+		},
+		{
+			name: "unknown keys",
+			flags: map[string][]string{
+				"collectors.enabeld":       {"cpu"},
+				"collector.process.includ": {"firefox"},
+				"web.listen-address":       {":9182"},
+			},
+			problems: []Problem{
+				{Key: "collector.process.includ", Message: `unknown key, did you mean "collector.process.include"?`},
+				{Key: "collectors.enabeld", Message: `unknown key, did you mean "collectors.enabled"?`},
+				{Key: "web.listen-address", Message: `unknown key`},
+			},
+			ignorable: true,
+		},
+		{
+			name:  "command line only",
+			flags: map[string][]string{"config.url": {"https://config.example.com"}},
+			problems: []Problem{
+				{Key: "config.url", Message: "can only be set on the command line or by an environment variable"},
+			},
+			ignorable: true,
+		},
+		{
+			name: "invalid values",
+			flags: map[string][]string{
+				"telemetry.max-requests": {"five"},
+				"scrape.timeout-margin":  {"0.5s"},
+				"wmi.query-timeout":      {"5"},
+				"collectors.max-series":  {"1", "2"},
+			},
+			problems: []Problem{
+				// The error of the value follows, and differs between Go versions.
+				{Key: "collectors.max-series", Message: `invalid value "1,2": `},
+				{Key: "scrape.timeout-margin", Message: `invalid value "0.5s": `},
+				{Key: "telemetry.max-requests", Message: `invalid value "five": `},
+				{Key: "wmi.query-timeout", Message: `invalid value "5": `},
+			},
+		},
+	}
+	for _, c := range cases {
+		r := &Resolver{flags: c.flags, sources: map[string]string{}}
+		for key := range c.flags {
+			r.sources[key] = "config.yml"
+		}
+		err := r.Check(checkApp())
+		if c.problems == nil {
+			if err != nil {
+				t.Errorf("%s: %v", c.name, err)
+			}
+			continue
+		}
+		checkErr, ok := err.(*CheckError)
+		if !ok {
+			t.Errorf("%s: expected a *CheckError, got %v", c.name, err)
+			continue
+		}
+		if !matchProblems(checkErr.Problems, c.problems) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.problems, checkErr.Problems)
+		}
+		if checkErr.Ignorable() != c.ignorable {
+			t.Errorf("%s: expected ignorable %v, got %v", c.name, c.ignorable, checkErr.Ignorable())
+		}
+		for _, p := range checkErr.Problems {
+			if p.File != "config.yml" {
+				t.Errorf("%s: expected problem in config.yml, got %v", c.name, p)
+			}
+		}
+		if !strings.HasPrefix(err.Error(), "configuration has ") {
+			t.Errorf("%s: unexpected error message %q", c.name, err.Error())
+		}
+	}
+}
+
+func TestCheckKeepsFlags(t *testing.T) {
+	app := kingpin.New("test", "")
+	enabled := app.Flag("collectors.enabled", "").Default("cpu").String()
+	maxRequests := app.Flag("telemetry.max-requests", "").Default("5").Int()
+	timeout := app.Flag("wmi.query-timeout", "").Default("0s").Duration()
+	if _, err := app.Parse([]string{}); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &Resolver{
+		flags: map[string][]string{
+			"collectors.enabled":     {"net"},
+			"telemetry.max-requests": {"10"},
+			"wmi.query-timeout":      {"five seconds"},
+		},
+		sources: map[string]string{},
+	}
+	if err := r.Check(app); err == nil {
+		t.Fatal("expected an error, but got ok")
+	}
+	if *enabled != "cpu" || *maxRequests != 5 || *timeout != 0 {
+		t.Errorf("expected the flags to be unchanged, got %q, %d, %s", *enabled, *maxRequests, *timeout)
+	}
+}
+
+// matchProblems compares problems to the expected ones. Expected messages
+// ending with ": " only need to be a prefix of the message.
+func matchProblems(problems []Problem, expected []Problem) bool {
+	if len(problems) != len(expected) {
+		return false
+	}
+	for i, p := range problems {
+		e := expected[i]
+		if p.Key != e.Key {
+			return false
+		}
+		if strings.HasSuffix(e.Message, ": ") {
+			if !strings.HasPrefix(p.Message, e.Message) {
+				return false
+			}
+		} else if p.Message != e.Message {
+			return false
+		}
+	}
+	return true
+}
+
+// resettableStrings is a repeatable flag value which can be reset.
+type resettableStrings []string
+
+func (s *resettableStrings) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+func (s *resettableStrings) String() string     { return strings.Join(*s, ",") }
+func (s *resettableStrings) IsCumulative() bool { return true }
+func (s *resettableStrings) Reset()             { *s = nil }
+
+func TestBind(t *testing.T) {
+	cases := []struct {
+		name    string
+		args    []string
+		enabled string
+		include []string
+		exclude []string
+	}{
+		{
+			name:    "config file",
+			enabled: "cpu,net",
+			include: []string{"firefox.+", "process_id=4"},
+			exclude: []string{"svchost"},
+		},
+		{
+			name:    "command line overrides",
+			args:    []string{"--collectors.enabled=os", "--collector.process.include=chrome", "--collector.process.include=edge"},
+			enabled: "os",
+			include: []string{"chrome", "edge"},
+			exclude: []string{"svchost"},
+		},
+	}
+	for _, c := range cases {
+		r := &Resolver{flags: map[string][]string{
+			"collectors.enabled":        {"cpu", "net"},
+			"collector.process.include": {"firefox.+", "process_id=4"},
+			"collector.process.exclude": {"svchost"},
+		}}
+		var include, exclude resettableStrings
+		app := kingpin.New("test", "")
+		enabled := app.Flag("collectors.enabled", "").Default("cpu").String()
+		app.Flag("collector.process.include", "").SetValue(&include)
+		app.Flag("collector.process.exclude", "").SetValue(&exclude)
+
+		// The application is parsed before and after binding, as in main.
+		if _, err := app.Parse(c.args); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.Bind(app, c.args); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := app.Parse(c.args); err != nil {
+			t.Fatal(err)
+		}
+
+		if *enabled != c.enabled {
+			t.Errorf("%s: expected enabled collectors %q, got %q", c.name, c.enabled, *enabled)
+		}
+		if !reflect.DeepEqual([]string(include), c.include) {
+			t.Errorf("%s: expected include %v, got %v", c.name, c.include, include)
+		}
+		if !reflect.DeepEqual([]string(exclude), c.exclude) {
+			t.Errorf("%s: expected exclude %v, got %v", c.name, c.exclude, exclude)
+		}
+	}
+}
+
+func TestRebind(t *testing.T) {
+	var include resettableStrings
+	app := kingpin.New("test", "")
+	enabled := app.Flag("collectors.enabled", "").Default("cpu").String()
+	app.Flag("collector.process.include", "").SetValue(&include)
+	run := app.Command("run", "").Default()
+	timeout := run.Flag("wmi.query-timeout", "").Default("0s").Duration()
+	class := run.Flag("wmi.class", "").String()
+
+	defaults := SaveDefaults(app)
+	first, _ := NewResolver()
+	if err := first.Load("https://config.example.com/a.yml", []byte("collectors:\n  enabled: cpu,net\ncollector:\n  process:\n    include: [firefox]\nwmi:\n  query-timeout: 5s\n  class: Win32_Process\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := first.Bind(app, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	if *enabled != "cpu,net" || include.String() != "firefox" || timeout.String() != "5s" || *class != "Win32_Process" {
+		t.Fatalf("expected first configuration, got %q, %q, %s, %q", *enabled, include.String(), timeout, *class)
+	}
+
+	// Keys removed from the configuration return to their defaults.
+	defaults.Restore(app)
+	second, _ := NewResolver()
+	if err := second.Load("https://config.example.com/a.yml", []byte("collector:\n  process:\n    include: [chrome]\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := second.Bind(app, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	if *enabled != "cpu" || include.String() != "chrome" || timeout.String() != "0s" || *class != "" {
+		t.Errorf("expected second configuration, got %q, %q, %s, %q", *enabled, include.String(), timeout, *class)
+	}
+	if files := second.Files(); !reflect.DeepEqual(files, []string{"https://config.example.com/a.yml"}) {
+		t.Errorf("expected the URL as file, got %v", files)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	for _, c := range []struct {
+		a, b     string
+		distance int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"process", "proces", 1},
+		{"enabled", "enabeld", 2},
+		{"kitten", "sitting", 3},
+	} {
+		if d := levenshtein(c.a, c.b); d != c.distance {
+			t.Errorf("%q, %q: expected %d, got %d", c.a, c.b, c.distance, d)
+		}
+	}
+}
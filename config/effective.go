@@ -0,0 +1,176 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+	"gopkg.in/yaml.v2"
+)
+
+// Source is where the effective value of a flag was set.
+type Source string
+
+const (
+	SourceDefault     Source = "default"
+	SourceFile        Source = "file"
+	SourceEnvironment Source = "environment"
+	SourceCommandLine Source = "command line"
+)
+
+// Redacted replaces secrets in the effective configuration.
+const Redacted = "<secret>"
+
+// Consumer is a collector reading a flag.
+type Consumer struct {
+	Collector string `json:"collector"`
+	Enabled   bool   `json:"enabled"`
+}
+
+// Setting is the effective value of a flag, and where it was set.
+type Setting struct {
+	Flag   string `json:"flag"`
+	Value  string `json:"value"`
+	Source Source `json:"source"`
+	// Origin is the configuration file or environment variable the value
+	// was set in.
+	Origin    string     `json:"origin,omitempty"`
+	Consumers []Consumer `json:"consumers,omitempty"`
+}
+
+// secretFlags matches the names of flags whose values are redacted.
+var secretFlags = regexp.MustCompile(`(?i)password|secret|token`)
+
+// Settings returns the effective values of the flags of app and the command
+// selected by args, after they have been parsed. resolver is the
+// configuration bound to app, if any. consumers returns the collectors
+// reading a flag. Hidden flags are only returned if they are set.
+func Settings(app *kingpin.Application, args []string, resolver *Resolver, consumers func(flag string) []Consumer) ([]Setting, error) {
+	pc, err := app.ParseContext(args)
+	if err != nil {
+		return nil, err
+	}
+	commandLine := make(map[string]bool)
+	for _, e := range pc.Elements {
+		if f, ok := e.Clause.(*kingpin.FlagClause); ok {
+			commandLine[f.Model().Name] = true
+		}
+	}
+
+	flags := app.Model().Flags
+	if pc.SelectedCommand != nil {
+		flags = append(flags, pc.SelectedCommand.Model().Flags...)
+	}
+	settings := make([]Setting, 0, len(flags))
+	for _, f := range flags {
+		if kingpinFlags[f.Name] {
+			continue
+		}
+		s := Setting{Flag: f.Name, Value: f.Value.String(), Source: SourceDefault}
+		switch {
+		case commandLine[f.Name]:
+			s.Source = SourceCommandLine
+		case f.Envar != "" && hasEnvarValue(f.Envar):
+			s.Source, s.Origin = SourceEnvironment, f.Envar
+		case resolver != nil && resolver.sources[f.Name] != "":
+			s.Source, s.Origin = SourceFile, resolver.sources[f.Name]
+		}
+		if f.Hidden && s.Source == SourceDefault {
+			continue
+		}
+		s.Value = redact(f.Name, s.Value)
+		if consumers != nil {
+			s.Consumers = consumers(f.Name)
+		}
+		settings = append(settings, s)
+	}
+	sort.Slice(settings, func(i, j int) bool { return settings[i].Flag < settings[j].Flag })
+	return settings, nil
+}
+
+// hasEnvarValue reports whether kingpin sets a flag from the environment
+// variable, which it does if it isn't empty.
+func hasEnvarValue(envar string) bool {
+	v, _ := os.LookupEnv(envar)
+	return v != ""
+}
+
+// redact returns value, with secrets replaced: the value of flags named like
+// secrets, and the passwords of URLs.
+func redact(flag, value string) string {
+	if value == "" {
+		return value
+	}
+	if secretFlags.MatchString(flag) {
+		return Redacted
+	}
+	if u, err := url.Parse(value); err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), "redacted")
+			return strings.Replace(u.String(), ":redacted@", ":"+Redacted+"@", 1)
+		}
+	}
+	return value
+}
+
+// WebConfig returns the web configuration file of the exporter toolkit, with
+// the passwords of the basic auth users redacted.
+func WebConfig(file string) (map[string]interface{}, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(b, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	cfg, err := convertValue(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	m, _ := cfg.(map[string]interface{})
+	if users, ok := m["basic_auth_users"].(map[string]interface{}); ok {
+		for user := range users {
+			users[user] = Redacted
+		}
+	}
+	return m, nil
+}
+
+// convertValue converts the maps unmarshalled from YAML to maps with string
+// keys, which can be encoded as JSON.
+func convertValue(v interface{}) (interface{}, error) {
+	switch typed := v.(type) {
+	case map[interface{}]interface{}:
+		m, err := convertMap(typed)
+		if err != nil {
+			return nil, err
+		}
+		return convertValue(m)
+	case map[string]interface{}:
+		for k, v := range typed {
+			c, err := convertValue(v)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%v", k, err)
+			}
+			typed[k] = c
+		}
+		return typed, nil
+	case []interface{}:
+		for i, v := range typed {
+			c, err := convertValue(v)
+			if err != nil {
+				return nil, err
+			}
+			typed[i] = c
+		}
+		return typed, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// EnvarPrefix is the prefix of the environment variables setting flags.
+const EnvarPrefix = "WINDOWS_EXPORTER_"
+
+// kingpinFlags are the flags added by kingpin itself, which can't be set by
+// environment variables.
+var kingpinFlags = map[string]bool{
+	"help":                   true,
+	"help-long":              true,
+	"help-man":               true,
+	"version":                true,
+	"completion-bash":        true,
+	"completion-script-bash": true,
+	"completion-script-zsh":  true,
+}
+
+var envarInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+// Envar returns the name of the environment variable setting the flag, e.g.
+// WINDOWS_EXPORTER_COLLECTORS_ENABLED for collectors.enabled.
+func Envar(flag string) string {
+	return EnvarPrefix + strings.ToUpper(envarInvalidChars.ReplaceAllString(flag, "_"))
+}
+
+// SetEnvars lets all flags of app and its commands be set by environment
+// variables, which take precedence over the configuration file, as it sets
+// the default values of the flags. Flags given on the command line take
+// precedence over both. Call it after all flags have been added.
+func SetEnvars(app *kingpin.Application) {
+	for name, f := range flagClauses(app) {
+		if !kingpinFlags[name] {
+			f.Envar(Envar(name))
+		}
+	}
+}
+
+// expandEnv replaces ${VAR} in s by the value of the environment variable
+// VAR, and ${VAR:-default} by default if VAR is unset or empty. $${ is a
+// literal ${. Referencing an unset variable without a default is an error,
+// so that a missing variable isn't silently replaced by an empty value.
+func expandEnv(s string, lookup func(string) (string, bool)) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+	var b strings.Builder
+	for {
+		i := strings.Index(s, "${")
+		if i < 0 {
+			b.WriteString(s)
+			return b.String(), nil
+		}
+		if i > 0 && s[i-1] == '$' {
+			b.WriteString(s[:i])
+			b.WriteString("{")
+			s = s[i+2:]
+			continue
+		}
+		b.WriteString(s[:i])
+		end := strings.Index(s[i:], "}")
+		if end < 0 {
+			return "", fmt.Errorf("unterminated variable reference %q", s[i:])
+		}
+		ref := s[i+2 : i+end]
+		s = s[i+end+1:]
+
+		name, def, hasDefault := ref, "", false
+		if j := strings.Index(ref, ":-"); j >= 0 {
+			name, def, hasDefault = ref[:j], ref[j+2:], true
+		}
+		if name == "" {
+			return "", fmt.Errorf("empty variable name in %q", "${"+ref+"}")
+		}
+		value, ok := lookup(name)
+		switch {
+		case hasDefault && value == "":
+			value = def
+		case !ok:
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		b.WriteString(value)
+	}
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+func TestEnvar(t *testing.T) {
+	for flag, expected := range map[string]string{
+		"collectors.enabled":              "WINDOWS_EXPORTER_COLLECTORS_ENABLED",
+		"collector.process.include":       "WINDOWS_EXPORTER_COLLECTOR_PROCESS_INCLUDE",
+		"collector.mssql.classes-enabled": "WINDOWS_EXPORTER_COLLECTOR_MSSQL_CLASSES_ENABLED",
+	} {
+		if envar := Envar(flag); envar != expected {
+			t.Errorf("%s: expected %s, got %s", flag, expected, envar)
+		}
+	}
+}
+
+// setenv sets environment variables, and returns a function restoring them.
+func setenv(t *testing.T, vars map[string]string) func() {
+	t.Helper()
+	orig := make(map[string]*string)
+	for k, v := range vars {
+		if old, ok := os.LookupEnv(k); ok {
+			orig[k] = &old
+		} else {
+			orig[k] = nil
+		}
+		if err := os.Setenv(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return func() {
+		for k, v := range orig {
+			if v == nil {
+				os.Unsetenv(k)
+			} else {
+				os.Setenv(k, *v)
+			}
+		}
+	}
+}
+
+func TestSetEnvars(t *testing.T) {
+	defer setenv(t, map[string]string{
+		"WINDOWS_EXPORTER_TELEMETRY_ADDR":         ":9183",
+		"WINDOWS_EXPORTER_TELEMETRY_PATH":         "/env",
+		"WINDOWS_EXPORTER_COLLECTORS_ENABLED":     "env",
+		"WINDOWS_EXPORTER_WMI_QUERY_TIMEOUT":      "5s",
+		"WINDOWS_EXPORTER_SCRAPE_TIMEOUT_MARGIN":  "1",
+		"WINDOWS_EXPORTER_TELEMETRY_MAX_REQUESTS": "7",
+		// Flags added by kingpin are not set by environment variables.
+		"WINDOWS_EXPORTER_HELP": "true",
+	})()
+
+	app := kingpin.New("test", "")
+	addr := app.Flag("telemetry.addr", "").Default(":9182").String()
+	path := app.Flag("telemetry.path", "").Default("/metrics").String()
+	enabled := app.Flag("collectors.enabled", "").Default("cpu").String()
+	maxRequests := app.Flag("telemetry.max-requests", "").Default("5").Int()
+	margin := app.Flag("scrape.timeout-margin", "").Default("0.5").Float64()
+	run := app.Command("run", "").Default()
+	timeout := run.Flag("wmi.query-timeout", "").Default("0s").Duration()
+	SetEnvars(app)
+
+	// Flags set the default values from the configuration file, which the
+	// environment variables override.
+	r := &Resolver{flags: map[string][]string{
+		"telemetry.path":        {"/file"},
+		"scrape.timeout-margin": {"2"},
+	}}
+	args := []string{"--telemetry.addr=:9184"}
+	if _, err := app.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Bind(app, args); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+
+	got := []interface{}{*addr, *path, *enabled, *maxRequests, *margin, timeout.String()}
+	expected := []interface{}{":9184", "/env", "env", 7, 1.0, "5s"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	env := map[string]string{"HOST": "server", "EMPTY": ""}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+	for s, expected := range map[string]string{
+		"plain":                       "plain",
+		"regexp$":                     "regexp$",
+		"${HOST}":                     "server",
+		"${HOST}:9182":                "server:9182",
+		"${HOST}.${HOST}":             "server.server",
+		"${UNSET:-default}":           "default",
+		"${EMPTY:-default}":           "default",
+		"${HOST:-default}":            "server",
+		"${EMPTY}":                    "",
+		"${UNSET:-}":                  "",
+		"$${HOST} is ${HOST}":         "${HOST} is server",
+		"Name='${UNSET:-a b}' or x=1": "Name='a b' or x=1",
+	} {
+		got, err := expandEnv(s, lookup)
+		if err != nil {
+			t.Errorf("%q: %v", s, err)
+			continue
+		}
+		if got != expected {
+			t.Errorf("%q: expected %q, got %q", s, expected, got)
+		}
+	}
+	for _, s := range []string{"${UNSET}", "${HOST", "${}", "${:-default}"} {
+		if got, err := expandEnv(s, lookup); err == nil {
+			t.Errorf("%q: expected an error, got %q", s, got)
+		}
+	}
+}
+
+func TestNewResolverExpandEnv(t *testing.T) {
+	defer setenv(t, map[string]string{"WINDOWS_EXPORTER_TEST_COLLECTORS": "cpu,net"})()
+
+	file, cleanup := writeConfig(t, `
+collectors:
+  enabled: ${WINDOWS_EXPORTER_TEST_COLLECTORS}
+collector:
+  process:
+    include:
+      - ${WINDOWS_EXPORTER_TEST_PROCESS:-firefox}
+      - chrome
+`)
+	defer cleanup()
+	r, err := NewResolver(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][]string{
+		"collectors.enabled":        {"cpu,net"},
+		"collector.process.include": {"firefox", "chrome"},
+	}
+	if !reflect.DeepEqual(r.flags, expected) {
+		t.Errorf("expected %v, got %v", expected, r.flags)
+	}
+
+	file, cleanup = writeConfig(t, "collectors:\n  enabled: ${WINDOWS_EXPORTER_TEST_UNSET}\n")
+	defer cleanup()
+	if _, err := NewResolver(file); err == nil {
+		t.Errorf("expected an error for an unset variable, but got ok")
+	}
+}
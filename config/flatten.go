@@ -1,61 +1,92 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // flatten flattens the nested struct.
 //
-// All keys will be joined by dot
-// e.g. {"a": {"b":"c"}} => {"a.b":"c"}
-// or {"a": {"b":[1,2]}} => {"a.b.0":1, "a.b.1": 2}
-func flatten(data map[string]interface{}) map[string]string {
-	ret := make(map[string]string)
+// All keys will be joined by dot, and lists of values are kept as lists,
+// to be set as the values of a repeatable flag or joined for other flags.
+// e.g. {"a": {"b":"c"}} => {"a.b":["c"]}
+// or {"a": {"b":[1,2]}} => {"a.b":["1","2"]}, with lists {"a.b":true}
+// Lists can't contain maps or lists, as there are no flags they could be
+// values of.
+func flatten(data map[string]interface{}) (map[string][]string, map[string]bool, error) {
+	values := make(map[string][]string)
+	lists := make(map[string]bool)
+	if err := flattenInto(values, lists, "", data); err != nil {
+		return nil, nil, err
+	}
+	return values, lists, nil
+}
+
+// flattenInto adds the flattened values of data to values, with the keys
+// prefixed by prefix, and the keys of lists to lists.
+func flattenInto(values map[string][]string, lists map[string]bool, prefix string, data map[string]interface{}) error {
 	for k, v := range data {
+		key := prefix + k
 		switch typed := v.(type) {
 		case map[interface{}]interface{}:
-			for fk, fv := range flatten(convertMap(typed)) {
-				ret[fmt.Sprintf("%s.%s", k, fk)] = fv
+			m, err := convertMap(typed)
+			if err != nil {
+				return fmt.Errorf("%s.%v", key, err)
+			}
+			if err := flattenInto(values, lists, key+".", m); err != nil {
+				return err
 			}
 		case map[string]interface{}:
-			for fk, fv := range flatten(typed) {
-				ret[fmt.Sprintf("%s.%s", k, fk)] = fv
+			if err := flattenInto(values, lists, key+".", typed); err != nil {
+				return err
 			}
 		case []interface{}:
-			for fk, fv := range flattenSlice(typed) {
-				ret[fmt.Sprintf("%s.%s", k, fk)] = fv
+			list, err := flattenSlice(typed)
+			if err != nil {
+				return fmt.Errorf("%s: %v", key, err)
 			}
+			values[key] = list
+			lists[key] = true
+		case nil:
+			// An empty value, e.g. "include:" without a value, is the
+			// empty string and not "<nil>".
+			values[key] = []string{""}
 		default:
-			ret[k] = fmt.Sprint(typed)
+			values[key] = []string{fmt.Sprint(typed)}
 		}
 	}
-	return ret
+	return nil
 }
-func flattenSlice(data []interface{}) map[string]string {
-	ret := make(map[string]string)
+
+func flattenSlice(data []interface{}) ([]string, error) {
+	ret := make([]string, 0, len(data))
 	for idx, v := range data {
 		switch typed := v.(type) {
-		case map[interface{}]interface{}:
-			for fk, fv := range flatten(convertMap(typed)) {
-				ret[fmt.Sprintf("%d,%s", idx, fk)] = fv
-			}
-		case map[string]interface{}:
-			for fk, fv := range flatten(typed) {
-				ret[fmt.Sprintf("%d,%s", idx, fk)] = fv
-			}
-		case []interface{}:
-			for fk, fv := range flattenSlice(typed) {
-				ret[fmt.Sprintf("%d,%s", idx, fk)] = fv
-			}
+		case map[interface{}]interface{}, map[string]interface{}, []interface{}:
+			return nil, fmt.Errorf("list item %d is not a single value", idx)
+		case nil:
+			ret = append(ret, "")
 		default:
-			ret[fmt.Sprint(idx)] = fmt.Sprint(typed)
+			ret = append(ret, fmt.Sprint(typed))
 		}
 	}
-	return ret
+	return ret, nil
 }
 
-func convertMap(originalMap map[interface{}]interface{}) map[string]interface{} {
+func convertMap(originalMap map[interface{}]interface{}) (map[string]interface{}, error) {
 	convertedMap := map[string]interface{}{}
 	for key, value := range originalMap {
-		convertedMap[key.(string)] = value
+		k, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("%v: key is not a string", key)
+		}
+		convertedMap[k] = value
 	}
-	return convertedMap
+	return convertedMap, nil
+}
+
+// joinValues joins the values of a list for a flag which is not repeatable.
+// Such flags take comma-separated lists, e.g. the enabled collectors.
+func joinValues(values []string) string {
+	return strings.Join(values, ",")
 }
@@ -21,13 +21,86 @@ func TestConfigFlattening(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedResult := map[string]string{
-		"collectors.enabled": "cpu,net,service",
-		"log.level":          "debug",
+	expectedResult := map[string][]string{
+		"collectors.enabled": {"cpu,net,service"},
+		"log.level":          {"debug"},
+	}
+	flattenedValues, _, err := flatten(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(expectedResult, flattenedValues) {
+		t.Errorf("Flattened values do not match!\nExpected result: %s\nActual result: %s", expectedResult, flattenedValues)
+	}
+}
+
+// Unmarshal configuration file with lists and confirm they are kept as lists
+func TestConfigFlatteningLists(t *testing.T) {
+	listYamlConfig := []byte(`---
+
+    collectors:
+      enabled:
+        - cpu
+        - net
+        - service
+
+    collector:
+      mssql:
+        classes-enabled: [accessmethods, bufman]
+      process:
+        include:
+          - firefox.+
+          - process_id=4
+        exclude: []
+      service:
+        ports: [80, 443]`)
+	var data map[string]interface{}
+	err := yaml.Unmarshal(listYamlConfig, &data)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expectedResult := map[string][]string{
+		"collectors.enabled":              {"cpu", "net", "service"},
+		"collector.mssql.classes-enabled": {"accessmethods", "bufman"},
+		"collector.process.include":       {"firefox.+", "process_id=4"},
+		"collector.process.exclude":       {},
+		"collector.service.ports":         {"80", "443"},
+	}
+	expectedLists := map[string]bool{
+		"collectors.enabled":              true,
+		"collector.mssql.classes-enabled": true,
+		"collector.process.include":       true,
+		"collector.process.exclude":       true,
+		"collector.service.ports":         true,
+	}
+	flattenedValues, lists, err := flatten(data)
+	if err != nil {
+		t.Fatal(err)
 	}
-	flattenedValues := flatten(data)
 
 	if !reflect.DeepEqual(expectedResult, flattenedValues) {
 		t.Errorf("Flattened values do not match!\nExpected result: %s\nActual result: %s", expectedResult, flattenedValues)
 	}
+	if !reflect.DeepEqual(expectedLists, lists) {
+		t.Errorf("Lists do not match!\nExpected result: %v\nActual result: %v", expectedLists, lists)
+	}
+}
+
+// Lists of maps or lists can't be the values of a flag
+func TestConfigFlatteningNestedLists(t *testing.T) {
+	for config, expectedErr := range map[string]string{
+		"collectors:\n  enabled: [[cpu]]":                  "collectors.enabled: list item 0 is not a single value",
+		"collector:\n  process:\n    include: [a, {b: c}]": "collector.process.include: list item 1 is not a single value",
+	} {
+		var data map[string]interface{}
+		if err := yaml.Unmarshal([]byte(config), &data); err != nil {
+			t.Fatal(err)
+		}
+		_, _, err := flatten(data)
+		if err == nil || err.Error() != expectedErr {
+			t.Errorf("%q: expected error %q, got %v", config, expectedErr, err)
+		}
+	}
 }
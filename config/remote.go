@@ -0,0 +1,264 @@
+package config
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SignatureHeader is the response header holding the base64 encoded Ed25519
+// signature of a remote configuration.
+const SignatureHeader = "X-Windows-Exporter-Signature"
+
+// maxRemoteSize is the maximum size of a remote configuration.
+const maxRemoteSize = 10 << 20
+
+// RemoteOptions configures a Remote.
+type RemoteOptions struct {
+	URL string
+	// CacheFile is the file the last verified configuration is written to,
+	// to start with if the URL can't be fetched. Empty disables caching. With
+	// a public key, the signature is written to CacheFile.sig, and verified
+	// again when the cache is used.
+	CacheFile string
+	// SHA256 is the hex encoded SHA-256 checksum the configuration must have,
+	// if not empty.
+	SHA256 string
+	// PublicKeyFile is a PEM encoded Ed25519 public key, which must verify
+	// the signature of the configuration in the SignatureHeader, if not empty.
+	PublicKeyFile string
+	Timeout       time.Duration
+}
+
+// Remote fetches a configuration file over HTTP(S). Fetch sends the ETag of
+// the last response, so that a configuration is only transferred and
+// verified again if it changed.
+type Remote struct {
+	url       string
+	cacheFile string
+	checksum  []byte
+	publicKey ed25519.PublicKey
+	client    *http.Client
+
+	etag string
+	last []byte
+	// signature is the signature of last, if any.
+	signature string
+}
+
+// ErrNotModified is returned by Fetch if the configuration didn't change
+// since the last fetch.
+var ErrNotModified = errors.New("remote configuration not modified")
+
+// NewRemote returns a Remote for the options.
+func NewRemote(o RemoteOptions) (*Remote, error) {
+	r := &Remote{
+		url:       o.URL,
+		cacheFile: o.CacheFile,
+		client:    &http.Client{Timeout: o.Timeout},
+	}
+	if !strings.HasPrefix(o.URL, "http://") && !strings.HasPrefix(o.URL, "https://") {
+		return nil, fmt.Errorf("configuration URL %q is not an HTTP(S) URL", o.URL)
+	}
+	if o.SHA256 != "" {
+		checksum, err := hex.DecodeString(o.SHA256)
+		if err != nil || len(checksum) != sha256.Size {
+			return nil, fmt.Errorf("invalid SHA-256 checksum %q", o.SHA256)
+		}
+		r.checksum = checksum
+	}
+	if o.PublicKeyFile != "" {
+		key, err := readPublicKey(o.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		r.publicKey = key
+	}
+	return r, nil
+}
+
+func readPublicKey(file string) (ed25519.PublicKey, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM encoded public key", file)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	edKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: public key is a %T, not an Ed25519 key", file, key)
+	}
+	return edKey, nil
+}
+
+// URL returns the URL the configuration is fetched from.
+func (r *Remote) URL() string {
+	return r.url
+}
+
+// Fetch returns the configuration, after verifying it. It returns ErrNotModified if the server reports that the
+// configuration didn't change since the last call.
+func (r *Remote) Fetch() ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, r.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "windows_exporter")
+	if r.etag != "" {
+		req.Header.Set("If-None-Match", r.etag)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && r.last != nil:
+		return nil, ErrNotModified
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("fetching %s: unexpected status %s", r.url, resp.Status)
+	}
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxRemoteSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %v", r.url, err)
+	}
+	if len(b) > maxRemoteSize {
+		return nil, fmt.Errorf("fetching %s: configuration is larger than %d bytes", r.url, maxRemoteSize)
+	}
+	if err := r.verify(b, resp.Header.Get(SignatureHeader)); err != nil {
+		return nil, fmt.Errorf("fetching %s: %v", r.url, err)
+	}
+	if bytes.Equal(b, r.last) {
+		// The server doesn't support ETags, or changed it without changing
+		// the configuration.
+		r.etag = resp.Header.Get("ETag")
+		return nil, ErrNotModified
+	}
+
+	r.etag = resp.Header.Get("ETag")
+	r.last = b
+	r.signature = resp.Header.Get(SignatureHeader)
+	return b, nil
+}
+
+// Cached returns the configuration written to the cache file by Cache.
+func (r *Remote) Cached() ([]byte, error) {
+	if r.cacheFile == "" {
+		return nil, errors.New("no cache file configured")
+	}
+	b, err := ioutil.ReadFile(r.cacheFile)
+	if err != nil {
+		return nil, err
+	}
+	var signature string
+	if r.publicKey != nil {
+		sig, err := ioutil.ReadFile(r.signatureFile())
+		if err != nil {
+			return nil, err
+		}
+		signature = string(sig)
+	}
+	// The cache file may have been changed since it was written, so it is
+	// verified as when it was fetched.
+	if err := r.verify(b, signature); err != nil {
+		return nil, fmt.Errorf("%s: %v", r.cacheFile, err)
+	}
+	r.last, r.signature = b, signature
+	return b, nil
+}
+
+// signatureFile returns the file the signature of the cached configuration
+// is written to.
+func (r *Remote) signatureFile() string {
+	return r.cacheFile + ".sig"
+}
+
+func (r *Remote) verify(b []byte, signature string) error {
+	if err := r.verifyChecksum(b); err != nil {
+		return err
+	}
+	if r.publicKey == nil {
+		return nil
+	}
+	if signature == "" {
+		return fmt.Errorf("missing %s header", SignatureHeader)
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("invalid %s header: %v", SignatureHeader, err)
+	}
+	if !ed25519.Verify(r.publicKey, b, sig) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func (r *Remote) verifyChecksum(b []byte) error {
+	if r.checksum == nil {
+		return nil
+	}
+	sum := sha256.Sum256(b)
+	if !bytes.Equal(sum[:], r.checksum) {
+		return fmt.Errorf("SHA-256 checksum is %x, expected %x", sum, r.checksum)
+	}
+	return nil
+}
+
+// Cache writes the configuration b, returned by the last call to Fetch, to
+// the cache file, to start with if the URL can't be fetched. Call it once b
+// has been applied, so that only a good configuration is cached.
+func (r *Remote) Cache(b []byte) error {
+	if r.cacheFile == "" {
+		return nil
+	}
+	if r.publicKey != nil {
+		if !bytes.Equal(b, r.last) {
+			return errors.New("configuration to cache is not the last fetched configuration, whose signature is known")
+		}
+		// If writing the configuration fails after writing its signature,
+		// Cached refuses the mismatching cache.
+		if err := writeFileAtomic(r.signatureFile(), []byte(r.signature)); err != nil {
+			return err
+		}
+	}
+	return writeFileAtomic(r.cacheFile, b)
+}
+
+// writeFileAtomic writes b to file, which is replaced once it has been
+// written completely.
+func writeFileAtomic(file string, b []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(file), filepath.Base(file)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
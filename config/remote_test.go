@@ -0,0 +1,265 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// configServer serves a configuration with an ETag, and optionally a
+// signature.
+type configServer struct {
+	mtx         sync.Mutex
+	config      string
+	etag        string
+	signature   string
+	requests    int
+	notModified int
+}
+
+func (s *configServer) set(config, etag string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.config, s.etag = config, etag
+}
+
+func (s *configServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.requests++
+	if s.etag != "" {
+		if r.Header.Get("If-None-Match") == s.etag {
+			s.notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", s.etag)
+	}
+	if s.signature != "" {
+		w.Header().Set(SignatureHeader, s.signature)
+	}
+	w.Write([]byte(s.config))
+}
+
+func TestRemoteFetch(t *testing.T) {
+	s := &configServer{config: "collectors:\n  enabled: cpu\n", etag: `"1"`}
+	server := httptest.NewServer(s)
+	defer server.Close()
+	dir, cleanup := writeConfigDir(t, nil)
+	defer cleanup()
+	cacheFile := filepath.Join(dir, "cache.yml")
+
+	r, err := NewRemote(RemoteOptions{URL: server.URL, CacheFile: cacheFile, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := r.Fetch()
+	if err != nil || string(b) != s.config {
+		t.Fatalf("expected %q, got %q, %v", s.config, b, err)
+	}
+	if _, err := ioutil.ReadFile(cacheFile); err == nil {
+		t.Errorf("expected the configuration to be cached only once applied")
+	}
+	if err := r.Cache(b); err != nil {
+		t.Fatal(err)
+	}
+	if cached, err := ioutil.ReadFile(cacheFile); err != nil || string(cached) != s.config {
+		t.Errorf("expected cached %q, got %q, %v", s.config, cached, err)
+	}
+
+	if _, err := r.Fetch(); err != ErrNotModified {
+		t.Errorf("expected ErrNotModified, got %v", err)
+	}
+	if s.notModified != 1 {
+		t.Errorf("expected the ETag to be sent, got %d not modified responses", s.notModified)
+	}
+
+	s.set("collectors:\n  enabled: cpu,net\n", `"2"`)
+	b, err = r.Fetch()
+	if err != nil || string(b) != s.config {
+		t.Fatalf("expected %q, got %q, %v", s.config, b, err)
+	}
+	if err := r.Cache(b); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without ETags, unchanged configurations are detected by their content.
+	s.set(s.config, "")
+	if _, err := r.Fetch(); err != ErrNotModified {
+		t.Errorf("expected ErrNotModified, got %v", err)
+	}
+
+	// The cache is used if the server is unreachable.
+	server.Close()
+	if _, err := r.Fetch(); err == nil {
+		t.Errorf("expected an error, but got ok")
+	}
+	offline, err := NewRemote(RemoteOptions{URL: server.URL, CacheFile: cacheFile, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err := offline.Cached(); err != nil || string(b) != s.config {
+		t.Errorf("expected cached %q, got %q, %v", s.config, b, err)
+	}
+}
+
+func TestRemoteFetchError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	r, err := NewRemote(RemoteOptions{URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Fetch(); err == nil {
+		t.Errorf("expected an error, but got ok")
+	}
+	if _, err := r.Cached(); err == nil {
+		t.Errorf("expected an error without cache file, but got ok")
+	}
+}
+
+func TestRemoteChecksum(t *testing.T) {
+	config := "collectors:\n  enabled: cpu\n"
+	s := &configServer{config: config}
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	sum := sha256.Sum256([]byte(config))
+	r, err := NewRemote(RemoteOptions{URL: server.URL, SHA256: hex.EncodeToString(sum[:])})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Fetch(); err != nil {
+		t.Error(err)
+	}
+
+	s.set("collectors:\n  enabled: cpu,net\n", "")
+	if _, err := r.Fetch(); err == nil {
+		t.Errorf("expected a checksum error, but got ok")
+	}
+
+	if _, err := NewRemote(RemoteOptions{URL: server.URL, SHA256: "abc"}); err == nil {
+		t.Errorf("expected an error for an invalid checksum, but got ok")
+	}
+}
+
+func TestRemoteSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, cleanup := writeConfigDir(t, map[string]string{
+		"key.pem": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	})
+	defer cleanup()
+
+	config := "collectors:\n  enabled: cpu\n"
+	s := &configServer{config: config}
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	r, err := NewRemote(RemoteOptions{URL: server.URL, PublicKeyFile: filepath.Join(dir, "key.pem")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Fetch(); err == nil {
+		t.Errorf("expected an error without signature, but got ok")
+	}
+
+	s.signature = base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(config)))
+	if b, err := r.Fetch(); err != nil || string(b) != config {
+		t.Errorf("expected %q, got %q, %v", config, b, err)
+	}
+
+	s.set("collectors:\n  enabled: cpu,net\n", "")
+	if _, err := r.Fetch(); err == nil {
+		t.Errorf("expected an error for a signature of other content, but got ok")
+	}
+}
+
+func TestRemoteCachedSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, cleanup := writeConfigDir(t, map[string]string{
+		"key.pem": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	})
+	defer cleanup()
+	cacheFile := filepath.Join(dir, "cache.yml")
+
+	config := "collectors:\n  enabled: cpu\n"
+	s := &configServer{config: config, signature: base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(config)))}
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	o := RemoteOptions{URL: server.URL, CacheFile: cacheFile, PublicKeyFile: filepath.Join(dir, "key.pem")}
+	r, err := NewRemote(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := r.Fetch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Cache([]byte("collectors:\n  enabled: os\n")); err == nil {
+		t.Errorf("expected an error caching a configuration without signature, but got ok")
+	}
+	if err := r.Cache(b); err != nil {
+		t.Fatal(err)
+	}
+
+	offline, err := NewRemote(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached, err := offline.Cached(); err != nil || string(cached) != config {
+		t.Errorf("expected cached %q, got %q, %v", config, cached, err)
+	}
+
+	// A changed cache file is refused.
+	if err := ioutil.WriteFile(cacheFile, []byte("collectors:\n  enabled: os\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := offline.Cached(); err == nil {
+		t.Errorf("expected an error for a changed cache file, but got ok")
+	}
+
+	// As is a cache without signature.
+	if err := os.Remove(cacheFile + ".sig"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(cacheFile, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := offline.Cached(); err == nil {
+		t.Errorf("expected an error without signature file, but got ok")
+	}
+}
+
+func TestNewRemoteInvalidURL(t *testing.T) {
+	if _, err := NewRemote(RemoteOptions{URL: `C:\config.yml`}); err == nil {
+		t.Errorf("expected an error, but got ok")
+	}
+}
@@ -0,0 +1,113 @@
+// +build windows
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/prometheus-community/windows_exporter/collector"
+	"github.com/prometheus-community/windows_exporter/config"
+	"gopkg.in/yaml.v2"
+)
+
+// effectiveConfig is the configuration served on /config and printed with
+// --config.print.
+type effectiveConfig struct {
+	Settings []config.Setting `json:"settings"`
+	// WebConfigFile is the web configuration file, if one is used, and
+	// WebConfig its contents, with secrets redacted.
+	WebConfigFile string                 `json:"web_config_file,omitempty"`
+	WebConfig     map[string]interface{} `json:"web_config,omitempty"`
+}
+
+// flagConsumers returns a function returning the collector reading a flag,
+// and whether it is enabled.
+func flagConsumers(enabled []string) func(flag string) []config.Consumer {
+	isEnabled := make(map[string]bool, len(enabled))
+	for _, name := range enabled {
+		isEnabled[name] = true
+	}
+	return func(flag string) []config.Consumer {
+		name := collector.FlagCollector(flag)
+		if name == "" {
+			return nil
+		}
+		return []config.Consumer{{Collector: name, Enabled: isEnabled[name]}}
+	}
+}
+
+// loadEffectiveConfig returns the effective configuration, reading the web
+// configuration file again, as the exporter toolkit does on every request.
+func loadEffectiveConfig(settings []config.Setting, webConfigFile string) (effectiveConfig, error) {
+	c := effectiveConfig{Settings: settings, WebConfigFile: webConfigFile}
+	if webConfigFile == "" {
+		return c, nil
+	}
+	webConfig, err := config.WebConfig(webConfigFile)
+	if err != nil {
+		return c, fmt.Errorf("could not read web config: %v", err)
+	}
+	c.WebConfig = webConfig
+	return c, nil
+}
+
+// writeEffectiveConfig writes the effective configuration as a table of the
+// flags, followed by the web configuration.
+func writeEffectiveConfig(w io.Writer, c effectiveConfig) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FLAG\tVALUE\tSOURCE\tCOLLECTORS")
+	for _, s := range c.Settings {
+		source := string(s.Source)
+		if s.Origin != "" {
+			source += " " + s.Origin
+		}
+		consumers := make([]string, 0, len(s.Consumers))
+		for _, c := range s.Consumers {
+			if c.Enabled {
+				consumers = append(consumers, c.Collector)
+			} else {
+				consumers = append(consumers, c.Collector+" (not enabled)")
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", s.Flag, s.Value, source, strings.Join(consumers, ", "))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if c.WebConfig == nil {
+		return nil
+	}
+	b, err := yaml.Marshal(c.WebConfig)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "\nWeb config %s:\n%s", c.WebConfigFile, b)
+	return err
+}
+
+// configHandler serves the effective configuration returned by settings, as
+// JSON or, with ?format=text, as printed by --config.print.
+func configHandler(settings func() []config.Setting, webConfigFile string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c, err := loadEffectiveConfig(settings(), webConfigFile)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if r.URL.Query().Get("format") == "text" {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			err = writeEffectiveConfig(w, c)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			err = json.NewEncoder(w).Encode(c)
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error encoding config: %s", err), http.StatusInternalServerError)
+		}
+	}
+}
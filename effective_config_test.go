@@ -0,0 +1,58 @@
+// +build windows
+
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/config"
+)
+
+func TestFlagConsumers(t *testing.T) {
+	consumers := flagConsumers([]string{"process", "os"})
+	for flag, expected := range map[string][]config.Consumer{
+		"collector.process.include": {{Collector: "process", Enabled: true}},
+		"collector.service.include": {{Collector: "service", Enabled: false}},
+		"telemetry.addr":            nil,
+	} {
+		if got := consumers(flag); !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s: expected %v, got %v", flag, expected, got)
+		}
+	}
+}
+
+func TestWriteEffectiveConfig(t *testing.T) {
+	c := effectiveConfig{
+		Settings: []config.Setting{
+			{Flag: "collector.process.include", Value: "firefox", Source: config.SourceFile, Origin: `C:\conf.d\process.yml`, Consumers: []config.Consumer{{Collector: "process"}}},
+			{Flag: "collectors.enabled", Value: "cpu,process", Source: config.SourceCommandLine},
+			{Flag: "telemetry.addr", Value: ":9182", Source: config.SourceDefault},
+		},
+		WebConfigFile: "web.yml",
+		WebConfig:     map[string]interface{}{"basic_auth_users": map[string]interface{}{"alice": config.Redacted}},
+	}
+	var b bytes.Buffer
+	if err := writeEffectiveConfig(&b, c); err != nil {
+		t.Fatal(err)
+	}
+	expected := `FLAG                       VALUE        SOURCE                      COLLECTORS
+collector.process.include  firefox      file C:\conf.d\process.yml  process (not enabled)
+collectors.enabled         cpu,process  command line
+telemetry.addr             :9182        default
+
+Web config web.yml:
+basic_auth_users:
+  alice: <secret>
+`
+	// The table is padded up to the empty last column.
+	lines := strings.Split(b.String(), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " ")
+	}
+	if got := strings.Join(lines, "\n"); got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+}
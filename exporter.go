@@ -8,7 +8,6 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"os"
-	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -31,6 +30,7 @@ import (
 type windowsCollector struct {
 	maxScrapeDuration time.Duration
 	collectors        map[string]collector.Collector
+	seriesLimits      *seriesLimits
 }
 
 // Same struct prometheus uses for their /version endpoint.
@@ -75,6 +75,42 @@ var (
 		nil,
 		nil,
 	)
+	perflibUnresolvedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.Namespace, "exporter", "perflib_unresolved_objects"),
+		"windows_exporter: Perflib objects used by the collector which were not found in the perflib name tables.",
+		[]string{"collector", "object"},
+		nil,
+	)
+	perflibUnresolvedCountersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.Namespace, "exporter", "perflib_unresolved_counters"),
+		"windows_exporter: Perflib counters used by the collector which were not found in the perflib name tables in the last scrape.",
+		[]string{"collector", "object", "counter"},
+		nil,
+	)
+	perflibMissingCountersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.Namespace, "exporter", "perflib_missing_counters"),
+		"windows_exporter: Perflib counters used by the collector which were not found in the last scrape.",
+		[]string{"collector", "object", "counter"},
+		nil,
+	)
+	wmiCacheAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.Namespace, "exporter", "wmi_cache_age_seconds"),
+		"windows_exporter: Age of the oldest cached WMI query result of the collector for the class.",
+		[]string{"collector", "class"},
+		nil,
+	)
+	wmiCacheHitsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.Namespace, "exporter", "wmi_cache_hits_total"),
+		"windows_exporter: Number of WMI queries of the collector for the class answered from the cache.",
+		[]string{"collector", "class"},
+		nil,
+	)
+	wmiCacheMissesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.Namespace, "exporter", "wmi_cache_misses_total"),
+		"windows_exporter: Number of cacheable WMI queries of the collector for the class sent to WMI.",
+		[]string{"collector", "class"},
+		nil,
+	)
 )
 
 // Describe sends all the descriptors of the collectors included to
@@ -82,6 +118,9 @@ var (
 func (coll windowsCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- scrapeDurationDesc
 	ch <- scrapeSuccessDesc
+	if coll.seriesLimits.enabled() {
+		ch <- seriesDroppedDesc
+	}
 }
 
 type collectorOutcome int
@@ -106,6 +145,16 @@ func (coll windowsCollector) Collect(ch chan<- prometheus.Metric) {
 		prometheus.GaugeValue,
 		time.Since(t).Seconds(),
 	)
+	for _, name := range cs {
+		for _, object := range collector.UnresolvedPerfObjects(name) {
+			ch <- prometheus.MustNewConstMetric(
+				perflibUnresolvedDesc,
+				prometheus.GaugeValue,
+				1,
+				name, object,
+			)
+		}
+	}
 	if err != nil {
 		ch <- prometheus.NewInvalidMetric(scrapeSuccessDesc, fmt.Errorf("failed to prepare scrape: %v", err))
 		return
@@ -131,7 +180,17 @@ func (coll windowsCollector) Collect(ch chan<- prometheus.Metric) {
 		}
 	}()
 
+	// When series limits are enabled, the output of each collector is held back
+	// until the scrape is finished, so the same series are dropped every time.
+	var limiter *seriesLimiter
+	if coll.seriesLimits.enabled() {
+		limiter = newSeriesLimiter(coll.seriesLimits)
+	}
+
 	for name, c := range coll.collectors {
+		if limiter != nil {
+			c = limiter.wrap(name, c)
+		}
 		go func(name string, c collector.Collector) {
 			defer wg.Done()
 			outcome := execute(name, c, scrapeContext, metricsBuffer)
@@ -160,11 +219,14 @@ func (coll windowsCollector) Collect(ch chan<- prometheus.Metric) {
 	finished = true
 
 	remainingCollectorNames := make([]string, 0)
+	finishedCollectorNames := make([]string, 0, len(collectorOutcomes))
 	for name, outcome := range collectorOutcomes {
 		var successValue, timeoutValue float64
 		if outcome == pending {
 			timeoutValue = 1.0
 			remainingCollectorNames = append(remainingCollectorNames, name)
+		} else {
+			finishedCollectorNames = append(finishedCollectorNames, name)
 		}
 		if outcome == success {
 			successValue = 1.0
@@ -184,8 +246,40 @@ func (coll windowsCollector) Collect(ch chan<- prometheus.Metric) {
 		)
 	}
 
+	for _, name := range finishedCollectorNames {
+		for _, stats := range collector.WMICacheStatistics(name) {
+			ch <- prometheus.MustNewConstMetric(wmiCacheAgeDesc, prometheus.GaugeValue, stats.Age.Seconds(), name, stats.Class)
+			ch <- prometheus.MustNewConstMetric(wmiCacheHitsDesc, prometheus.CounterValue, float64(stats.Hits), name, stats.Class)
+			ch <- prometheus.MustNewConstMetric(wmiCacheMissesDesc, prometheus.CounterValue, float64(stats.Misses), name, stats.Class)
+		}
+		for object, counters := range collector.MissingPerfCounters(name) {
+			for _, counter := range counters {
+				ch <- prometheus.MustNewConstMetric(
+					perflibMissingCountersDesc,
+					prometheus.GaugeValue,
+					1,
+					name, object, counter,
+				)
+			}
+		}
+		for object, counters := range collector.UnresolvedPerfCounters(name) {
+			for _, counter := range counters {
+				ch <- prometheus.MustNewConstMetric(
+					perflibUnresolvedCountersDesc,
+					prometheus.GaugeValue,
+					1,
+					name, object, counter,
+				)
+			}
+		}
+	}
+
+	if limiter != nil {
+		limiter.flush(ch, finishedCollectorNames, remainingCollectorNames)
+	}
+
 	if len(remainingCollectorNames) > 0 {
-		log.Warn("Collection timed out, still waiting for ", remainingCollectorNames)
+		log.With("collectors", strings.Join(remainingCollectorNames, ",")).Warn("Collection timed out, still waiting for collectors")
 	}
 
 	l.Unlock()
@@ -202,11 +296,13 @@ func execute(name string, c collector.Collector, ctx *collector.ScrapeContext, c
 		name,
 	)
 
+	l := log.With("collector", name, "duration", duration)
 	if err != nil {
-		log.Errorf("collector %s failed after %fs: %s", name, duration, err)
+		log.CollectorErrors.Error(name, l, "Collector failed", err)
 		return failed
 	}
-	log.Debugf("collector %s succeeded after %fs.", name, duration)
+	log.CollectorErrors.Success(name, l)
+	l.Debug("Collector succeeded")
 	return success
 }
 
@@ -255,10 +351,47 @@ func initWbem() {
 }
 
 func main() {
+	configFiles := &config.Files{}
+	kingpin.Flag(
+		"config.file",
+		"YAML configuration file, or directory of *.yml and *.yaml files loaded in lexical order, to use. May be given multiple times, and later files override earlier ones. Values set in these files will be overriden by CLI flags.",
+	).SetValue(configFiles)
 	var (
-		configFile = kingpin.Flag(
-			"config.file",
-			"YAML configuration file to use. Values set in this file will be overriden by CLI flags.",
+		configCheck = kingpin.Flag(
+			"config.check",
+			"Check the configuration files for unknown keys and invalid values, and exit.",
+		).Bool()
+		configStrict = kingpin.Flag(
+			"config.strict",
+			"Refuse to start if the configuration files have unknown keys or invalid values, instead of logging them as warnings.",
+		).Bool()
+		configPrint = kingpin.Flag(
+			"config.print",
+			"Print the effective value of every flag, where it was set, and the collectors reading it, and exit. The same is served on /config.",
+		).Bool()
+		configURL = kingpin.Flag(
+			"config.url",
+			"HTTP(S) URL of a YAML configuration file, applied after the files given by --config.file.",
+		).String()
+		configURLRefresh = kingpin.Flag(
+			"config.url-refresh-interval",
+			"Interval to check --config.url for changes, and apply them. 0 to disable.",
+		).Default("5m").Duration()
+		configURLTimeout = kingpin.Flag(
+			"config.url-timeout",
+			"Timeout for fetching --config.url.",
+		).Default("10s").Duration()
+		configURLCache = kingpin.Flag(
+			"config.url-cache-file",
+			"File the last configuration applied from --config.url is written to, and started with if it can't be fetched.",
+		).String()
+		configURLSHA256 = kingpin.Flag(
+			"config.url-sha256",
+			"Hex encoded SHA-256 checksum the configuration fetched from --config.url must have.",
+		).String()
+		configURLPublicKey = kingpin.Flag(
+			"config.url-public-key-file",
+			"PEM encoded Ed25519 public key verifying the signature of the configuration fetched from --config.url, sent in the "+config.SignatureHeader+" header.",
 		).String()
 		webConfig     = webflag.AddFlags(kingpin.CommandLine)
 		listenAddress = kingpin.Flag(
@@ -279,51 +412,130 @@ func main() {
 			Default(defaultCollectors).String()
 		printCollectors = kingpin.Flag(
 			"collectors.print",
-			"If true, print available collectors and exit.",
-		).Bool()
+			"Print available collectors and exit. With 'json' or 'markdown', print the metrics exposed by the enabled collectors instead.",
+		).PlaceHolder("list").Enum(printFormatList, printFormatJSON, printFormatMarkdown)
 		timeoutMargin = kingpin.Flag(
 			"scrape.timeout-margin",
 			"Seconds to subtract from the timeout allowed by the client. Tune to allow for overhead or high loads.",
 		).Default("0.5").Float64()
+		maxSeries = kingpin.Flag(
+			"scrape.max-series",
+			"Maximum number of series exposed by all collectors in a single scrape, shared in proportion to the number of series of each collector. 0 to disable.",
+		).Default("0").Int()
+		collectorMaxSeries = kingpin.Flag(
+			"collectors.max-series",
+			"Maximum number of series exposed by a single collector per scrape, optionally followed by per-collector overrides, e.g. '5000,process=2000'. 0 to disable.",
+		).Default("0").String()
 	)
 
+	// Running the exporter is the default command.
+	kingpin.Command("run", "Run the exporter.").Default()
+	perflibCmd := addPerflibCommand(kingpin.CommandLine)
+
 	log.AddFlags(kingpin.CommandLine)
 	kingpin.Version(version.Print("windows_exporter"))
 	kingpin.HelpFlag.Short('h')
+	config.SetEnvars(kingpin.CommandLine)
+
+	args := legacyPrintArgs(os.Args[1:])
 
 	// Load values from configuration file(s). Executable flags must first be parsed, in order
 	// to load the specified file(s).
-	kingpin.Parse()
+	command := kingpin.MustParse(kingpin.CommandLine.Parse(args))
 
-	if *configFile != "" {
-		resolver, err := config.NewResolver(*configFile)
+	var remote *config.Remote
+	if *configURL != "" {
+		var err error
+		remote, err = config.NewRemote(config.RemoteOptions{
+			URL:           *configURL,
+			CacheFile:     *configURLCache,
+			SHA256:        *configURLSHA256,
+			PublicKeyFile: *configURLPublicKey,
+			Timeout:       *configURLTimeout,
+		})
 		if err != nil {
-			log.Fatalf("could not load config file: %v\n", err)
+			log.Fatalf("%v\n", err)
+		}
+	}
+	if *configCheck && len(*configFiles) == 0 && remote == nil {
+		log.Fatalf("--config.check requires --config.file or --config.url\n")
+	}
+	loader := &configLoader{
+		app:    kingpin.CommandLine,
+		args:   args,
+		files:  *configFiles,
+		remote: remote,
+		strict: *configStrict,
+	}
+	var resolver *config.Resolver
+	if len(*configFiles) > 0 || remote != nil {
+		body, err := loader.initial()
+		if err != nil {
+			log.Fatalf("could not load remote config: %v\n", err)
 		}
-		err = resolver.Bind(kingpin.CommandLine, os.Args[1:])
+		resolver, err = loader.resolve(body)
 		if err != nil {
+			log.Fatalf("could not load config file: %v\n", err)
+		}
+		err = resolver.Check(kingpin.CommandLine)
+		switch {
+		case *configCheck && err != nil:
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		case *configCheck:
+			fmt.Printf("configuration in %s is valid\n", strings.Join(resolver.Files(), ","))
+			return
+		case loader.rejects(err):
 			log.Fatalf("%v\n", err)
+		case err != nil:
+			log.Warnf("%v", err)
 		}
 		// Parse flags once more to include those discovered in configuration file(s).
-		kingpin.Parse()
+		command = kingpin.MustParse(loader.bind(resolver))
+		loader.cache(body)
 	}
 
-	if *printCollectors {
-		collectors := collector.Available()
-		collectorNames := make(sort.StringSlice, 0, len(collectors))
-		for _, n := range collectors {
-			collectorNames = append(collectorNames, n)
+	switch command {
+	case perflibCmd.list.FullCommand(), perflibCmd.show.FullCommand():
+		if err := perflibCmd.run(os.Stdout, command); err != nil {
+			log.Fatalf("%v\n", err)
+		}
+		return
+	}
+
+	if *printCollectors == printFormatList {
+		printCollectorList(os.Stdout)
+		return
+	}
+
+	settings, err := config.Settings(kingpin.CommandLine, args, resolver, flagConsumers(expandEnabledCollectors(*enabledCollectors)))
+	if err != nil {
+		log.Fatalf("Couldn't get effective configuration: %s", err)
+	}
+	if *configPrint {
+		c, err := loadEffectiveConfig(settings, *webConfig)
+		if err != nil {
+			log.Fatalf("%v\n", err)
 		}
-		collectorNames.Sort()
-		fmt.Printf("Available collectors:\n")
-		for _, n := range collectorNames {
-			fmt.Printf(" - %s\n", n)
+		if err := writeEffectiveConfig(os.Stdout, c); err != nil {
+			log.Fatalf("Couldn't print effective configuration: %s", err)
 		}
 		return
 	}
 
 	initWbem()
 
+	if *printCollectors != "" {
+		collectors, err := loadCollectors(*enabledCollectors)
+		if err != nil {
+			log.Fatalf("Couldn't load collectors: %s", err)
+		}
+		if err := printMetricCatalogue(os.Stdout, *printCollectors, collectors); err != nil {
+			log.Fatalf("Couldn't print metrics: %s", err)
+		}
+		return
+	}
+
 	isInteractive, err := svc.IsAnInteractiveSession()
 	if err != nil {
 		log.Fatal(err)
@@ -339,12 +551,31 @@ func main() {
 		}()
 	}
 
-	collectors, err := loadCollectors(*enabledCollectors)
-	if err != nil {
-		log.Fatalf("Couldn't load collectors: %s", err)
+	running := &runningConfig{}
+	// apply loads the collectors and series limits configured by the flags.
+	apply := func() error {
+		collectors, err := loadCollectors(*enabledCollectors)
+		if err != nil {
+			return fmt.Errorf("couldn't load collectors: %s", err)
+		}
+		limits, err := parseSeriesLimits(*maxSeries, *collectorMaxSeries)
+		if err != nil {
+			return fmt.Errorf("couldn't parse series limits: %s", err)
+		}
+		settings, err := config.Settings(kingpin.CommandLine, args, loader.resolver, flagConsumers(expandEnabledCollectors(*enabledCollectors)))
+		if err != nil {
+			return fmt.Errorf("couldn't get effective configuration: %s", err)
+		}
+		running.collectors, running.limits, running.settings = collectors, limits, settings
+		log.Infof("Enabled collectors: %v", strings.Join(keys(collectors), ", "))
+		return nil
+	}
+	if err := apply(); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if remote != nil && *configURLRefresh > 0 {
+		go loader.watch(*configURLRefresh, running, apply)
 	}
-
-	log.Infof("Enabled collectors: %v", strings.Join(keys(collectors), ", "))
 
 	h := &metricsHandler{
 		timeoutMargin: *timeoutMargin,
@@ -352,10 +583,10 @@ func main() {
 			filteredCollectors := make(map[string]collector.Collector)
 			// scrape all enabled collectors if no collector is requested
 			if len(requestedCollectors) == 0 {
-				filteredCollectors = collectors
+				filteredCollectors = running.collectors
 			}
 			for _, name := range requestedCollectors {
-				col, exists := collectors[name]
+				col, exists := running.collectors[name]
 				if !exists {
 					return fmt.Errorf("unavailable collector: %s", name), nil
 				}
@@ -364,12 +595,16 @@ func main() {
 			return nil, &windowsCollector{
 				collectors:        filteredCollectors,
 				maxScrapeDuration: timeout,
+				seriesLimits:      running.limits,
 			}
 		},
 	}
 
-	http.HandleFunc(*metricsPath, withConcurrencyLimit(*maxRequests, h.ServeHTTP))
+	// Scrapes hold the read lock, so that a new remote configuration is only
+	// applied between them.
+	http.HandleFunc(*metricsPath, withConcurrencyLimit(*maxRequests, running.readLocked(h.ServeHTTP)))
 	http.HandleFunc("/health", healthCheck)
+	http.HandleFunc("/config", configHandler(running.Settings, *webConfig))
 	http.HandleFunc("/version", func(w http.ResponseWriter, r *http.Request) {
 		// we can't use "version" directly as it is a package, and not an object that
 		// can be serialized.
@@ -508,6 +743,7 @@ func (mh *metricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	reg.MustRegister(wc)
 	reg.MustRegister(
+		collector.WMIQueryMetrics(),
 		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
 		prometheus.NewGoCollector(),
 		version.NewCollector("windows_exporter"),
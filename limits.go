@@ -0,0 +1,303 @@
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus-community/windows_exporter/collector"
+	"github.com/prometheus-community/windows_exporter/log"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+var (
+	seriesDroppedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.Namespace, "exporter", "collector_series_dropped_total"),
+		"windows_exporter: Number of series dropped because a series limit was exceeded or the collector timed out.",
+		[]string{"collector"},
+		nil,
+	)
+
+	droppedSeries = newDroppedSeriesTracker()
+)
+
+// seriesLimits holds the maximum number of series exposed per scrape. A
+// limit of 0 disables the respective check.
+type seriesLimits struct {
+	global       int
+	perCollector int
+	overrides    map[string]int
+}
+
+// parseSeriesLimits parses the value of --collectors.max-series, a
+// comma-separated list of a default limit and/or name=limit overrides, e.g.
+// "5000,process=2000,iis=1000".
+func parseSeriesLimits(global int, perCollector string) (*seriesLimits, error) {
+	if global < 0 {
+		return nil, fmt.Errorf("invalid global series limit %d", global)
+	}
+	limits := &seriesLimits{global: global, overrides: map[string]int{}}
+	for _, part := range strings.Split(perCollector, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value := "", part
+		if i := strings.Index(part, "="); i >= 0 {
+			name, value = strings.TrimSpace(part[:i]), strings.TrimSpace(part[i+1:])
+			if name == "" {
+				return nil, fmt.Errorf("missing collector name in series limit %q", part)
+			}
+		}
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid series limit %q", part)
+		}
+		if name == "" {
+			limits.perCollector = limit
+		} else {
+			limits.overrides[name] = limit
+		}
+	}
+	return limits, nil
+}
+
+func (l *seriesLimits) enabled() bool {
+	if l == nil {
+		return false
+	}
+	if l.global > 0 || l.perCollector > 0 {
+		return true
+	}
+	for _, limit := range l.overrides {
+		if limit > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *seriesLimits) forCollector(name string) int {
+	if limit, ok := l.overrides[name]; ok {
+		return limit
+	}
+	return l.perCollector
+}
+
+// droppedSeriesTracker keeps the number of dropped series per collector
+// across scrapes, and makes sure each collector is only logged about once.
+type droppedSeriesTracker struct {
+	mtx     sync.Mutex
+	dropped map[string]float64
+	logged  map[string]bool
+}
+
+func newDroppedSeriesTracker() *droppedSeriesTracker {
+	return &droppedSeriesTracker{
+		dropped: map[string]float64{},
+		logged:  map[string]bool{},
+	}
+}
+
+func (t *droppedSeriesTracker) add(name string, count int, reason string) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.dropped[name] += float64(count)
+	if !t.logged[name] {
+		t.logged[name] = true
+		log.With("collector", name, "dropped", count, "reason", reason).Warn("Dropped series. Further drops are only reported by windows_exporter_collector_series_dropped_total.")
+	}
+}
+
+func (t *droppedSeriesTracker) total(name string) float64 {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.dropped[name]
+}
+
+// seriesLimiter buffers the series of all collectors during a single scrape,
+// so that limits can be applied in the same order on every scrape regardless
+// of which collector finishes first.
+type seriesLimiter struct {
+	limits *seriesLimits
+
+	mtx    sync.Mutex
+	series map[string][]sortableMetric
+	// flushed is set once the scrape has been answered. The series of
+	// collectors finishing afterwards are dropped.
+	flushed bool
+}
+
+type sortableMetric struct {
+	key    string
+	metric prometheus.Metric
+}
+
+func newSeriesLimiter(limits *seriesLimits) *seriesLimiter {
+	return &seriesLimiter{
+		limits: limits,
+		series: map[string][]sortableMetric{},
+	}
+}
+
+// wrap returns a collector which stores the series of c in the limiter
+// instead of sending them to the scrape channel.
+func (s *seriesLimiter) wrap(name string, c collector.Collector) collector.Collector {
+	return &bufferedCollector{name: name, c: c, limiter: s}
+}
+
+func (s *seriesLimiter) store(name string, metrics []prometheus.Metric) {
+	sorted := make([]sortableMetric, 0, len(metrics))
+	for _, m := range metrics {
+		sorted = append(sorted, sortableMetric{key: seriesKey(m), metric: m})
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].key < sorted[j].key
+	})
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.flushed {
+		droppedSeries.add(name, len(sorted), "collector timed out")
+		return
+	}
+	s.series[name] = sorted
+}
+
+// flush sends the buffered series of all collectors that have finished to ch,
+// in order of metric name and label values, dropping series once the
+// per-collector limit or the collector's share of the global limit is
+// reached. The series of the collectors which timed out are dropped when they
+// finish, so the dropped series counter of a collector includes its last
+// timeout from the next scrape on.
+func (s *seriesLimiter) flush(ch chan<- prometheus.Metric, finished []string, timedOut []string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.flushed = true
+
+	sorted := make([]string, len(finished))
+	copy(sorted, finished)
+	sort.Strings(sorted)
+
+	counts := make(map[string]int, len(sorted))
+	for _, name := range sorted {
+		series := s.series[name]
+		if limit := s.limits.forCollector(name); limit > 0 && len(series) > limit {
+			droppedSeries.add(name, len(series)-limit, fmt.Sprintf("collector limit of %d series exceeded", limit))
+			series = series[:limit]
+		}
+		s.series[name] = series
+		counts[name] = len(series)
+	}
+
+	var shares map[string]int
+	if s.limits.global > 0 {
+		shares = shareSeriesLimit(s.limits.global, sorted, counts)
+	}
+	for _, name := range sorted {
+		series := s.series[name]
+		if share, ok := shares[name]; ok && len(series) > share {
+			droppedSeries.add(name, len(series)-share, fmt.Sprintf("global limit of %d series exceeded", s.limits.global))
+			series = series[:share]
+		}
+
+		for _, m := range series {
+			ch <- m.metric
+		}
+		ch <- prometheus.MustNewConstMetric(
+			seriesDroppedDesc,
+			prometheus.CounterValue,
+			droppedSeries.total(name),
+			name,
+		)
+	}
+	for _, name := range timedOut {
+		ch <- prometheus.MustNewConstMetric(
+			seriesDroppedDesc,
+			prometheus.CounterValue,
+			droppedSeries.total(name),
+			name,
+		)
+	}
+}
+
+// shareSeriesLimit splits the global limit among the collectors in
+// proportion to their number of series. Series left over by rounding down go
+// to the collectors with the largest remainders, in lexical order on ties. If
+// the limit is not exceeded, every collector keeps all of its series.
+func shareSeriesLimit(limit int, names []string, counts map[string]int) map[string]int {
+	total := 0
+	for _, name := range names {
+		total += counts[name]
+	}
+	shares := make(map[string]int, len(names))
+	if total <= limit {
+		for _, name := range names {
+			shares[name] = counts[name]
+		}
+		return shares
+	}
+
+	remainders := make(map[string]int, len(names))
+	left := limit
+	for _, name := range names {
+		shares[name] = limit * counts[name] / total
+		remainders[name] = limit * counts[name] % total
+		left -= shares[name]
+	}
+	byRemainder := make([]string, len(names))
+	copy(byRemainder, names)
+	sort.SliceStable(byRemainder, func(i, j int) bool {
+		return remainders[byRemainder[i]] > remainders[byRemainder[j]]
+	})
+	for _, name := range byRemainder[:left] {
+		shares[name]++
+	}
+	return shares
+}
+
+// seriesKey returns a string identifying the series of m, used for ordering.
+func seriesKey(m prometheus.Metric) string {
+	var b strings.Builder
+	b.WriteString(m.Desc().String())
+
+	pb := &dto.Metric{}
+	if err := m.Write(pb); err != nil {
+		return b.String()
+	}
+	for _, lp := range pb.Label {
+		b.WriteByte(0)
+		b.WriteString(lp.GetName())
+		b.WriteByte(0)
+		b.WriteString(lp.GetValue())
+	}
+	return b.String()
+}
+
+type bufferedCollector struct {
+	name    string
+	c       collector.Collector
+	limiter *seriesLimiter
+}
+
+func (b *bufferedCollector) Collect(ctx *collector.ScrapeContext, ch chan<- prometheus.Metric) error {
+	buf := make(chan prometheus.Metric)
+	done := make(chan []prometheus.Metric)
+	go func() {
+		var metrics []prometheus.Metric
+		for m := range buf {
+			metrics = append(metrics, m)
+		}
+		done <- metrics
+	}()
+
+	err := b.c.Collect(ctx, buf)
+	close(buf)
+	b.limiter.store(b.name, <-done)
+	return err
+}
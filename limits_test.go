@@ -0,0 +1,143 @@
+// +build windows
+
+package main
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/collector"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestParseSeriesLimits(t *testing.T) {
+	cases := []struct {
+		input       string
+		expected    *seriesLimits
+		expectError bool
+	}{
+		{"0", &seriesLimits{overrides: map[string]int{}}, false},
+		{"", &seriesLimits{overrides: map[string]int{}}, false},
+		{"5000", &seriesLimits{perCollector: 5000, overrides: map[string]int{}}, false},
+		{"process=2000", &seriesLimits{overrides: map[string]int{"process": 2000}}, false},
+		{"5000, process=2000,iis=0", &seriesLimits{perCollector: 5000, overrides: map[string]int{"process": 2000, "iis": 0}}, false},
+		{"=2000", nil, true},
+		{"process=many", nil, true},
+		{"-1", nil, true},
+	}
+
+	for _, c := range cases {
+		limits, err := parseSeriesLimits(0, c.input)
+		if err != nil && !c.expectError {
+			t.Errorf("%q: did not expect error, got %q", c.input, err)
+		}
+		if err == nil && c.expectError {
+			t.Errorf("%q: expected an error, but got ok", c.input)
+		}
+		if err == nil && !reflect.DeepEqual(limits, c.expected) {
+			t.Errorf("%q: expected %+v, got %+v", c.input, c.expected, limits)
+		}
+	}
+}
+
+var testSeriesDesc = prometheus.NewDesc("test_series", "Test series.", []string{"name"}, nil)
+
+type fakeCollector []string
+
+func (f fakeCollector) Collect(ctx *collector.ScrapeContext, ch chan<- prometheus.Metric) error {
+	for _, name := range f {
+		ch <- prometheus.MustNewConstMetric(testSeriesDesc, prometheus.GaugeValue, 1, name)
+	}
+	return nil
+}
+
+func TestSeriesLimiter(t *testing.T) {
+	limiter := newSeriesLimiter(&seriesLimits{global: 4, perCollector: 2, overrides: map[string]int{"b": 3}})
+	collectors := map[string]fakeCollector{
+		"a": {"a3", "a1", "a2"},
+		"b": {"b2", "b4", "b1", "b3"},
+		"c": {"c1"},
+	}
+	for name, c := range collectors {
+		if err := limiter.wrap(name, c).Collect(nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		limiter.flush(ch, []string{"c", "b", "a"}, nil)
+		close(ch)
+	}()
+
+	var series []string
+	dropped := map[string]float64{}
+	for m := range ch {
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatal(err)
+		}
+		if m.Desc() == seriesDroppedDesc {
+			dropped[pb.Label[0].GetValue()] = pb.Counter.GetValue()
+			continue
+		}
+		series = append(series, pb.Label[0].GetValue())
+	}
+
+	// The global limit of 4 is shared in proportion to the 2, 3 and 1 series
+	// left after the per-collector limits.
+	expectedSeries := []string{"a1", "b1", "b2", "c1"}
+	if !reflect.DeepEqual(series, expectedSeries) {
+		t.Errorf("expected series %v, got %v", expectedSeries, series)
+	}
+	expectedDropped := map[string]float64{"a": 2, "b": 2, "c": 0}
+	if !reflect.DeepEqual(dropped, expectedDropped) {
+		t.Errorf("expected dropped series %v, got %v", expectedDropped, dropped)
+	}
+}
+
+func TestShareSeriesLimit(t *testing.T) {
+	cases := []struct {
+		limit    int
+		counts   map[string]int
+		expected map[string]int
+	}{
+		{10, map[string]int{"a": 3, "b": 4}, map[string]int{"a": 3, "b": 4}},
+		{10, map[string]int{"a": 10, "b": 30}, map[string]int{"a": 3, "b": 7}},
+		{3, map[string]int{"a": 2, "b": 2, "c": 2}, map[string]int{"a": 1, "b": 1, "c": 1}},
+		{2, map[string]int{"a": 2, "b": 2, "c": 2}, map[string]int{"a": 1, "b": 1, "c": 0}},
+		{5, map[string]int{"a": 1, "b": 9}, map[string]int{"a": 1, "b": 4}},
+	}
+	for _, c := range cases {
+		names := make([]string, 0, len(c.counts))
+		for name := range c.counts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if shares := shareSeriesLimit(c.limit, names, c.counts); !reflect.DeepEqual(shares, c.expected) {
+			t.Errorf("limit %d of %v: expected %v, got %v", c.limit, c.counts, c.expected, shares)
+		}
+	}
+}
+
+func TestSeriesLimiterTimedOutCollector(t *testing.T) {
+	limiter := newSeriesLimiter(&seriesLimits{global: 10})
+	before := droppedSeries.total("slow")
+
+	ch := make(chan prometheus.Metric, 10)
+	limiter.flush(ch, nil, []string{"slow"})
+	close(ch)
+	if n := len(ch); n != 1 {
+		t.Fatalf("expected only the dropped series counter, got %d metrics", n)
+	}
+
+	// The collector finishes after the scrape was answered.
+	if err := limiter.wrap("slow", fakeCollector{"s1", "s2"}).Collect(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if dropped := droppedSeries.total("slow") - before; dropped != 2 {
+		t.Errorf("expected 2 dropped series, got %v", dropped)
+	}
+}
@@ -0,0 +1,143 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultRepeatInterval is the default value of --log.error-repeat-interval.
+const defaultRepeatInterval = "10m"
+
+// repeatIntervals holds the intervals at which a repeated error is logged
+// again, by collector.
+type repeatIntervals struct {
+	interval  time.Duration
+	overrides map[string]time.Duration
+}
+
+// parseRepeatIntervals parses the value of --log.error-repeat-interval, a
+// comma-separated list of a default interval and/or name=interval overrides,
+// e.g. "10m,mssql=1h,thermalzone=24h".
+func parseRepeatIntervals(s string) (repeatIntervals, error) {
+	intervals := repeatIntervals{overrides: map[string]time.Duration{}}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value := "", part
+		if i := strings.Index(part, "="); i >= 0 {
+			name, value = strings.TrimSpace(part[:i]), strings.TrimSpace(part[i+1:])
+			if name == "" {
+				return intervals, fmt.Errorf("missing collector name in error repeat interval %q", part)
+			}
+		}
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval < 0 {
+			return intervals, fmt.Errorf("invalid error repeat interval %q", part)
+		}
+		if name == "" {
+			intervals.interval = interval
+		} else {
+			intervals.overrides[name] = interval
+		}
+	}
+	return intervals, nil
+}
+
+// forKey returns the interval of the collector of key, which is the part
+// before the first "/".
+func (r repeatIntervals) forKey(key string) time.Duration {
+	name := key
+	if i := strings.Index(key, "/"); i >= 0 {
+		name = key[:i]
+	}
+	if interval, ok := r.overrides[name]; ok {
+		return interval
+	}
+	return r.interval
+}
+
+// repeatedError is the last error logged for a key.
+type repeatedError struct {
+	err      string
+	logged   time.Time
+	repeated int
+}
+
+// Deduplicator suppresses repeats of the last error of a key, e.g. of a
+// collector failing on every scrape. A repeated error is logged again once
+// the interval of the key has passed since it was last logged, with the
+// number of times it was repeated in between.
+type Deduplicator struct {
+	mtx       sync.Mutex
+	intervals repeatIntervals
+	errors    map[string]*repeatedError
+	now       func() time.Time
+}
+
+// NewDeduplicator returns a Deduplicator logging every error again after
+// interval.
+func NewDeduplicator(interval time.Duration) *Deduplicator {
+	return &Deduplicator{
+		intervals: repeatIntervals{interval: interval},
+		errors:    map[string]*repeatedError{},
+		now:       time.Now,
+	}
+}
+
+// CollectorErrors deduplicates the errors of collectors, with the intervals
+// set by --log.error-repeat-interval. Keys are collector names, optionally
+// followed by "/" and e.g. the name of a child collector.
+var CollectorErrors = NewDeduplicator(0)
+
+func (d *Deduplicator) setIntervals(intervals repeatIntervals) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.intervals = intervals
+}
+
+// Error logs err with l at error level, unless it is a repeat of the last
+// error of key logged less than the interval of key ago. Then it is only
+// counted, and the number of repeats is logged with the next error logged,
+// in the field "repeated".
+func (d *Deduplicator) Error(key string, l Logger, msg string, err error) {
+	d.mtx.Lock()
+	now := d.now()
+	last := d.errors[key]
+	if last != nil && last.err == err.Error() && now.Sub(last.logged) < d.intervals.forKey(key) {
+		last.repeated++
+		d.mtx.Unlock()
+		return
+	}
+	var repeated int
+	if last != nil && last.err == err.Error() {
+		repeated = last.repeated
+	}
+	d.errors[key] = &repeatedError{err: err.Error(), logged: now}
+	d.mtx.Unlock()
+
+	if last != nil && last.err != err.Error() && last.repeated > 0 {
+		l.With("repeated", last.repeated).Warnf("Previous error was repeated %d times: %s", last.repeated, last.err)
+	}
+	if repeated > 0 {
+		l.With("repeated", repeated).WithError(err).Errorf("%s (repeated %d times)", msg, repeated)
+		return
+	}
+	l.WithError(err).Error(msg)
+}
+
+// Success records that key succeeded, logging the number of times its last
+// error was repeated since it was last logged, if any.
+func (d *Deduplicator) Success(key string, l Logger) {
+	d.mtx.Lock()
+	last := d.errors[key]
+	delete(d.errors, key)
+	d.mtx.Unlock()
+
+	if last != nil && last.repeated > 0 {
+		l.With("repeated", last.repeated).Infof("Recovered, the last error was repeated %d times: %s", last.repeated, last.err)
+	}
+}
@@ -0,0 +1,121 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRepeatIntervals(t *testing.T) {
+	intervals, err := parseRepeatIntervals("10m, mssql=1h,thermalzone=0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, expected := range map[string]time.Duration{
+		"cpu":                        10 * time.Minute,
+		"mssql":                      time.Hour,
+		"mssql/accessmethods/SQL01":  time.Hour,
+		"thermalzone":                0,
+		"thermalzones/not-a-prefix/": 10 * time.Minute,
+	} {
+		if got := intervals.forKey(key); got != expected {
+			t.Errorf("%s: expected %s, got %s", key, expected, got)
+		}
+	}
+
+	for _, invalid := range []string{"10", "=1h", "mssql=-1h", "mssql=often"} {
+		if _, err := parseRepeatIntervals(invalid); err == nil {
+			t.Errorf("%s: expected an error, but got ok", invalid)
+		}
+	}
+}
+
+// logEntries decodes the JSON log entries in buf, keeping the level, message
+// and repeated fields.
+func logEntries(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var entries []map[string]interface{}
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var entry map[string]interface{}
+		if err := dec.Decode(&entry); err != nil {
+			t.Fatal(err)
+		}
+		for k := range entry {
+			if k != "level" && k != "msg" && k != "repeated" {
+				delete(entry, k)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestDeduplicator(t *testing.T) {
+	l, buf := jsonLogger()
+	now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	d := NewDeduplicator(0)
+	d.setIntervals(repeatIntervals{interval: time.Minute, overrides: map[string]time.Duration{"os": 0}})
+	d.now = func() time.Time { return now }
+
+	stopped := errors.New("service is stopped")
+	scrape := func(key string, err error) {
+		if err != nil {
+			d.Error(key, l, "Collector failed", err)
+		} else {
+			d.Success(key, l)
+		}
+		now = now.Add(15 * time.Second)
+	}
+
+	// Logged, suppressed 3 times, then logged with the number of repeats.
+	for i := 0; i < 5; i++ {
+		scrape("mssql", stopped)
+	}
+	// Another error is logged right away, after the repeats of the previous.
+	scrape("mssql", errors.New("access denied"))
+	scrape("mssql", errors.New("access denied"))
+	// Recovering logs the repeats.
+	scrape("mssql", nil)
+	scrape("mssql", nil)
+	// An error after recovering is logged right away.
+	scrape("mssql", stopped)
+	// Errors of collectors with an interval of 0 are always logged.
+	scrape("os", stopped)
+	scrape("os", stopped)
+
+	expected := []map[string]interface{}{
+		{"level": "error", "msg": "Collector failed"},
+		{"level": "error", "msg": "Collector failed (repeated 3 times)", "repeated": 3.0},
+		{"level": "error", "msg": "Collector failed"},
+		{"level": "info", "msg": "Recovered, the last error was repeated 1 times: access denied", "repeated": 1.0},
+		{"level": "error", "msg": "Collector failed"},
+		{"level": "error", "msg": "Collector failed"},
+		{"level": "error", "msg": "Collector failed"},
+	}
+	if entries := logEntries(t, buf); !reflect.DeepEqual(entries, expected) {
+		t.Errorf("expected\n%v\ngot\n%v", expected, entries)
+	}
+}
+
+func TestDeduplicatorChangedError(t *testing.T) {
+	l, buf := jsonLogger()
+	now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	d := NewDeduplicator(time.Hour)
+	d.now = func() time.Time { return now }
+
+	d.Error("thermalzone", l, "Collector failed", errors.New("not supported"))
+	d.Error("thermalzone", l, "Collector failed", errors.New("not supported"))
+	d.Error("thermalzone", l, "Collector failed", errors.New("access denied"))
+
+	expected := []map[string]interface{}{
+		{"level": "error", "msg": "Collector failed"},
+		{"level": "warning", "msg": "Previous error was repeated 1 times: not supported", "repeated": 1.0},
+		{"level": "error", "msg": "Collector failed"},
+	}
+	if entries := logEntries(t, buf); !reflect.DeepEqual(entries, expected) {
+		t.Errorf("expected\n%v\ngot\n%v", expected, entries)
+	}
+}
@@ -20,6 +20,8 @@ package log
 import (
 	"fmt"
 	"os"
+	"strings"
+	"sync"
 
 	"golang.org/x/sys/windows/svc/eventlog"
 
@@ -38,11 +40,18 @@ func init() {
 			l.Errorf("can't connect logger to eventlog: %v", err)
 			return err
 		}
-		l.entry.Logger.Formatter = fmter
+		l.entry.Logger.SetFormatter(fmter)
 		return nil
 	}
 }
 
+var (
+	// eventlogs holds the event logs opened, by name, as the format is set
+	// again every time the flags are parsed.
+	eventlogs    = map[string]*eventlog.Log{}
+	eventlogsMtx sync.Mutex
+)
+
 type eventlogger struct {
 	log         *eventlog.Log
 	debugAsInfo bool
@@ -50,9 +59,16 @@ type eventlogger struct {
 }
 
 func newEventlogger(name string, debugAsInfo bool, fmter logrus.Formatter) (*eventlogger, error) {
-	logHandle, err := eventlog.Open(name)
-	if err != nil {
-		return nil, err
+	eventlogsMtx.Lock()
+	defer eventlogsMtx.Unlock()
+	logHandle, ok := eventlogs[name]
+	if !ok {
+		var err error
+		logHandle, err = eventlog.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		eventlogs[name] = logHandle
 	}
 	return &eventlogger{log: logHandle, debugAsInfo: debugAsInfo, wrap: fmter}, nil
 }
@@ -64,23 +80,30 @@ func (s *eventlogger) Format(e *logrus.Entry) ([]byte, error) {
 		return data, err
 	}
 
+	// The event log records the time and level, but the fields are part of
+	// the message. JSON is passed on as is, for log pipelines parsing it.
+	msg := messageWithFields(e)
+	if _, ok := s.wrap.(*logrus.JSONFormatter); ok {
+		msg = strings.TrimSpace(string(data))
+	}
+
 	switch e.Level {
 	case logrus.PanicLevel:
 		fallthrough
 	case logrus.FatalLevel:
 		fallthrough
 	case logrus.ErrorLevel:
-		err = s.log.Error(102, e.Message)
+		err = s.log.Error(102, msg)
 	case logrus.WarnLevel:
-		err = s.log.Warning(101, e.Message)
+		err = s.log.Warning(101, msg)
 	case logrus.InfoLevel:
-		err = s.log.Info(100, e.Message)
+		err = s.log.Info(100, msg)
 	case logrus.DebugLevel:
 		if s.debugAsInfo {
-			err = s.log.Info(100, e.Message)
+			err = s.log.Info(100, msg)
 		}
 	default:
-		err = s.log.Info(100, e.Message)
+		err = s.log.Info(100, msg)
 	}
 
 	if err != nil {
@@ -0,0 +1,358 @@
+package log
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxSizeMB  = 100
+	defaultMaxBackups = 5
+
+	// retryInterval is the time after a failed rotation before the file is
+	// rotated again, e.g. while another process holds it open, and after
+	// failing to open the file before it is opened again.
+	retryInterval = time.Minute
+
+	// backupTimeFormat is the time a log file was rotated at, in the name of
+	// the backup. It sorts in time order, and is valid in Windows file names.
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+	compressSuffix   = ".gz"
+)
+
+// fileOptions configures a rotatingFile.
+type fileOptions struct {
+	path string
+	// maxSize is the size in bytes at which the file is rotated.
+	maxSize int64
+	// rotateInterval is the age at which the file is rotated, or 0 to only
+	// rotate it by size.
+	rotateInterval time.Duration
+	// maxBackups is the number of rotated files kept, or 0 to keep all.
+	maxBackups int
+	// maxAge is the age at which rotated files are removed, or 0 to keep
+	// them regardless of their age.
+	maxAge   time.Duration
+	compress bool
+}
+
+// parseFileOptions returns the options of the file logger in the query of a
+// log format, e.g. logger:file?path=C:\ProgramData\windows_exporter\logs\windows_exporter.log&max_size_mb=10.
+func parseFileOptions(q url.Values) (fileOptions, error) {
+	o := fileOptions{
+		path:       q.Get("path"),
+		maxSize:    defaultMaxSizeMB << 20,
+		maxBackups: defaultMaxBackups,
+	}
+	if o.path == "" {
+		return o, fmt.Errorf("missing path parameter")
+	}
+	for _, p := range []struct {
+		name string
+		set  func(int)
+	}{
+		{"max_size_mb", func(v int) { o.maxSize = int64(v) << 20 }},
+		{"max_backups", func(v int) { o.maxBackups = v }},
+		{"max_age_days", func(v int) { o.maxAge = time.Duration(v) * 24 * time.Hour }},
+	} {
+		raw := q.Get(p.name)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 0 || (p.name == "max_size_mb" && v == 0) {
+			return o, fmt.Errorf("invalid %s parameter %q", p.name, raw)
+		}
+		p.set(v)
+	}
+	if raw := q.Get("rotate_interval"); raw != "" {
+		interval, err := time.ParseDuration(raw)
+		if err != nil || interval < 0 {
+			return o, fmt.Errorf("invalid rotate_interval parameter %q", raw)
+		}
+		o.rotateInterval = interval
+	}
+	if raw := q.Get("compress"); raw != "" {
+		compress, err := strconv.ParseBool(raw)
+		if err != nil {
+			return o, fmt.Errorf("invalid compress parameter %q", raw)
+		}
+		o.compress = compress
+	}
+	return o, nil
+}
+
+// rotatingFile is a log file which is rotated when a write would make it
+// larger than the maximum size, or once it is older than the rotation
+// interval. The file is renamed to a backup named after the time it was
+// rotated at, e.g. windows_exporter-2021-03-01T10-00-00.000.log,
+// which is compressed if configured. Backups beyond the maximum number or
+// age are removed.
+type rotatingFile struct {
+	fileOptions
+
+	mtx  sync.Mutex
+	file *os.File
+	// closed is set by Close. Otherwise file is only nil if it couldn't be
+	// opened, which is retried.
+	closed bool
+	size   int64
+	// started is the time the first message was written to the file.
+	started time.Time
+	// renameFailed and openFailed are the times the last rotation or opening
+	// of the file failed at, if it failed.
+	renameFailed time.Time
+	openFailed   time.Time
+	now          func() time.Time
+
+	// cleanupMtx serialises compressing and removing backups, which is done
+	// in the background after rotating.
+	cleanupMtx sync.Mutex
+	cleanupWg  sync.WaitGroup
+}
+
+func newRotatingFile(o fileOptions) (*rotatingFile, error) {
+	f := &rotatingFile{fileOptions: o, now: time.Now}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	// Backups may have expired while the exporter wasn't running.
+	f.startCleanup()
+	return f, nil
+}
+
+func (f *rotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	fi, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file, f.size, f.started = file, fi.Size(), f.now()
+	if f.size > 0 {
+		// An existing file was started when the last backup was rotated.
+		if backups, err := f.backups(); err == nil && len(backups) > 0 && backups[0].rotated.Before(f.started) {
+			f.started = backups[0].rotated
+		}
+	}
+	return nil
+}
+
+// Write writes p to the file, rotating it first if p would make it larger
+// than the maximum size, or if the file is older than the rotation age.
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if f.closed {
+		return 0, fmt.Errorf("log file %s is closed", f.path)
+	}
+	if f.file == nil {
+		if err := f.retryOpen(); err != nil {
+			return 0, err
+		}
+	}
+	if f.shouldRotate(len(p)) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *rotatingFile) shouldRotate(n int) bool {
+	if f.size == 0 {
+		return false
+	}
+	now := f.now()
+	if !f.renameFailed.IsZero() && now.Sub(f.renameFailed) < retryInterval {
+		return false
+	}
+	return f.size+int64(n) > f.maxSize || (f.rotateInterval > 0 && now.Sub(f.started) >= f.rotateInterval)
+}
+
+// rotate renames the file to a backup and opens a new file. If the file
+// can't be renamed, it is reopened and only rotated again after
+// retryInterval.
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+	now := f.now()
+	if err := os.Rename(f.path, f.backupName(now)); err != nil {
+		// Keep writing to the file rather than losing messages.
+		fmt.Fprintf(os.Stderr, "can't rotate log file %s, retrying in %s: %v\n", f.path, retryInterval, err)
+		f.renameFailed = now
+		return f.retryOpen()
+	}
+	f.renameFailed = time.Time{}
+	if err := f.retryOpen(); err != nil {
+		return err
+	}
+	f.startCleanup()
+	return nil
+}
+
+// retryOpen opens the file, unless opening it failed less than retryInterval
+// ago, so that writes don't fail on every attempt while e.g. the disk is full.
+func (f *rotatingFile) retryOpen() error {
+	now := f.now()
+	if !f.openFailed.IsZero() && now.Sub(f.openFailed) < retryInterval {
+		return fmt.Errorf("log file %s can't be opened, retrying in %s", f.path, retryInterval-now.Sub(f.openFailed))
+	}
+	if err := f.open(); err != nil {
+		f.openFailed = now
+		return err
+	}
+	f.openFailed = time.Time{}
+	return nil
+}
+
+func (f *rotatingFile) startCleanup() {
+	f.cleanupWg.Add(1)
+	go func() {
+		defer f.cleanupWg.Done()
+		if err := f.cleanup(); err != nil {
+			fmt.Fprintf(os.Stderr, "can't clean up log files of %s: %v\n", f.path, err)
+		}
+	}()
+}
+
+// Close closes the file.
+func (f *rotatingFile) Close() error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.closed = true
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// backupName returns the name of the backup of the file rotated at t.
+func (f *rotatingFile) backupName(t time.Time) string {
+	dir, prefix, ext := f.nameParts()
+	return filepath.Join(dir, prefix+t.Format(backupTimeFormat)+ext)
+}
+
+func (f *rotatingFile) nameParts() (dir, prefix, ext string) {
+	dir, name := filepath.Dir(f.path), filepath.Base(f.path)
+	ext = filepath.Ext(name)
+	return dir, strings.TrimSuffix(name, ext) + "-", ext
+}
+
+type backup struct {
+	path       string
+	rotated    time.Time
+	compressed bool
+}
+
+// backups returns the backups of the file, newest first.
+func (f *rotatingFile) backups() ([]backup, error) {
+	dir, prefix, ext := f.nameParts()
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var backups []backup
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		b := backup{path: filepath.Join(dir, name)}
+		if strings.HasSuffix(name, ext+compressSuffix) {
+			b.compressed = true
+			name = strings.TrimSuffix(name, compressSuffix)
+		}
+		if !strings.HasSuffix(name, ext) {
+			continue
+		}
+		t, err := time.ParseInLocation(backupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext), time.Local)
+		if err != nil {
+			continue
+		}
+		b.rotated = t
+		backups = append(backups, b)
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].rotated.After(backups[j].rotated) })
+	return backups, nil
+}
+
+// cleanup removes the backups beyond the maximum number and age, and
+// compresses the others if configured.
+func (f *rotatingFile) cleanup() error {
+	f.cleanupMtx.Lock()
+	defer f.cleanupMtx.Unlock()
+
+	backups, err := f.backups()
+	if err != nil {
+		return err
+	}
+	now := f.now()
+	for i, b := range backups {
+		if (f.maxBackups > 0 && i >= f.maxBackups) || (f.maxAge > 0 && now.Sub(b.rotated) > f.maxAge) {
+			if err := os.Remove(b.path); err != nil {
+				return err
+			}
+			continue
+		}
+		if f.compress && !b.compressed {
+			if err := compressFile(b.path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// compressFile compresses path to path.gz, and removes path.
+func compressFile(path string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(path+compressSuffix, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(out)
+	if _, err := io.Copy(gz, in); err != nil {
+		out.Close()
+		os.Remove(out.Name())
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		out.Close()
+		os.Remove(out.Name())
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(out.Name())
+		return err
+	}
+	in.Close()
+	return os.Remove(path)
+}
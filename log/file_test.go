@@ -0,0 +1,358 @@
+package log
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFileOptions(t *testing.T) {
+	for _, c := range []struct {
+		query    string
+		expected fileOptions
+		err      bool
+	}{
+		{
+			query:    `path=C:\ProgramData\windows_exporter\logs\windows_exporter.log`,
+			expected: fileOptions{path: `C:\ProgramData\windows_exporter\logs\windows_exporter.log`, maxSize: 100 << 20, maxBackups: 5},
+		},
+		{
+			query:    "path=exporter.log&max_size_mb=10&rotate_interval=24h&max_backups=0&max_age_days=7&compress=true",
+			expected: fileOptions{path: "exporter.log", maxSize: 10 << 20, rotateInterval: 24 * time.Hour, maxAge: 7 * 24 * time.Hour, compress: true},
+		},
+		{query: "max_size_mb=10", err: true},
+		{query: "path=exporter.log&max_size_mb=0", err: true},
+		{query: "path=exporter.log&max_backups=-1", err: true},
+		{query: "path=exporter.log&rotate_interval=-1h", err: true},
+		{query: "path=exporter.log&rotate_interval=24", err: true},
+		{query: "path=exporter.log&compress=maybe", err: true},
+	} {
+		q, err := url.ParseQuery(c.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		o, err := parseFileOptions(q)
+		if c.err {
+			if err == nil {
+				t.Errorf("%s: expected an error, but got ok", c.query)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", c.query, err)
+		} else if o != c.expected {
+			t.Errorf("%s: expected %+v, got %+v", c.query, c.expected, o)
+		}
+	}
+}
+
+// logFiles returns the names of the files in dir.
+func logFiles(t *testing.T, dir string) []string {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestRotatingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "windows_exporter_log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.Local)
+	f, err := newRotatingFile(fileOptions{path: filepath.Join(dir, "logs", "exporter.log"), maxSize: 10, maxBackups: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	f.cleanupWg.Wait()
+	f.now = func() time.Time { return now }
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+		f.cleanupWg.Wait()
+		now = now.Add(time.Minute)
+	}
+
+	expected := []string{"exporter-2021-03-01T10-02-00.000.log", "exporter-2021-03-01T10-03-00.000.log", "exporter.log"}
+	if files := logFiles(t, filepath.Join(dir, "logs")); !reflect.DeepEqual(files, expected) {
+		t.Fatalf("expected %v, got %v", expected, files)
+	}
+	for name, content := range map[string]string{
+		"exporter-2021-03-01T10-02-00.000.log": "second\n",
+		"exporter-2021-03-01T10-03-00.000.log": "third\n",
+		"exporter.log":                         "fourth\n",
+	} {
+		if b, err := ioutil.ReadFile(filepath.Join(dir, "logs", name)); err != nil || string(b) != content {
+			t.Errorf("%s: expected %q, got %q, %v", name, content, b, err)
+		}
+	}
+
+	// Reopening appends to the file.
+	f.Close()
+	f, err = newRotatingFile(f.fileOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	f.cleanupWg.Wait()
+	if f.size != int64(len("fourth\n")) {
+		t.Errorf("expected the size of the existing file, got %d", f.size)
+	}
+}
+
+func TestRotatingFileAge(t *testing.T) {
+	dir, err := ioutil.TempDir("", "windows_exporter_log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.Local)
+	f := &rotatingFile{
+		fileOptions: fileOptions{path: filepath.Join(dir, "exporter.log"), maxSize: 100 << 20, rotateInterval: time.Hour},
+		now:         func() time.Time { return now },
+	}
+	if err := f.open(); err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+		f.cleanupWg.Wait()
+		now = now.Add(40 * time.Minute)
+	}
+
+	expected := []string{"exporter-2021-03-01T11-20-00.000.log", "exporter.log"}
+	if files := logFiles(t, dir); !reflect.DeepEqual(files, expected) {
+		t.Fatalf("expected %v, got %v", expected, files)
+	}
+	if b, err := ioutil.ReadFile(filepath.Join(dir, expected[0])); err != nil || string(b) != "first\nsecond\n" {
+		t.Errorf("expected the first two lines in the backup, got %q, %v", b, err)
+	}
+
+	// A reopened file is as old as the last backup.
+	f.Close()
+	now = now.Add(-30 * time.Minute)
+	if err := f.open(); err != nil {
+		t.Fatal(err)
+	}
+	if !f.started.Equal(time.Date(2021, 3, 1, 11, 20, 0, 0, time.Local)) {
+		t.Errorf("expected the file to be started when the last backup was rotated, got %s", f.started)
+	}
+}
+
+func TestRotatingFileRenameFailed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "windows_exporter_log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.Local)
+	f := &rotatingFile{
+		fileOptions: fileOptions{path: filepath.Join(dir, "exporter.log"), maxSize: 10},
+		now:         func() time.Time { return now },
+	}
+	if err := f.open(); err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// A non-empty directory in place of the backup makes the rename fail.
+	blocked := f.backupName(now)
+	if err := os.MkdirAll(filepath.Join(blocked, "dir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"first\n", "second\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !f.renameFailed.Equal(now) {
+		t.Fatalf("expected the failed rename to be recorded")
+	}
+	if err := os.RemoveAll(blocked); err != nil {
+		t.Fatal(err)
+	}
+
+	// The file isn't rotated again until the retry interval passed.
+	now = now.Add(retryInterval / 2)
+	if _, err := f.Write([]byte("third\n")); err != nil {
+		t.Fatal(err)
+	}
+	if files := logFiles(t, dir); !reflect.DeepEqual(files, []string{"exporter.log"}) {
+		t.Fatalf("expected no rotation, got %v", files)
+	}
+	now = now.Add(retryInterval)
+	if _, err := f.Write([]byte("fourth\n")); err != nil {
+		t.Fatal(err)
+	}
+	f.cleanupWg.Wait()
+
+	backup := filepath.Base(f.backupName(now))
+	if files := logFiles(t, dir); !reflect.DeepEqual(files, []string{backup, "exporter.log"}) {
+		t.Fatalf("expected the file to be rotated, got %v", files)
+	}
+	if b, err := ioutil.ReadFile(filepath.Join(dir, backup)); err != nil || string(b) != "first\nsecond\nthird\n" {
+		t.Errorf("expected the messages written while the rename failed in the backup, got %q, %v", b, err)
+	}
+}
+
+func TestRotatingFileOpenFailed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "windows_exporter_log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.Local)
+	f := &rotatingFile{
+		fileOptions: fileOptions{path: filepath.Join(dir, "exporter.log"), maxSize: 10},
+		now:         func() time.Time { return now },
+	}
+	if err := f.open(); err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.Write([]byte("first\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	// As if opening the file failed after rotating it, with a directory in
+	// place of the file making opening it fail again.
+	f.file.Close()
+	f.file = nil
+	if err := os.Rename(f.path, f.backupName(now)); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(f.path, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("second\n")); err == nil {
+		t.Fatal("expected an error opening the file, but got ok")
+	}
+	if err := os.Remove(f.path); err != nil {
+		t.Fatal(err)
+	}
+
+	// Opening the file is retried once the retry interval passed.
+	now = now.Add(retryInterval / 2)
+	if _, err := f.Write([]byte("third\n")); err == nil {
+		t.Fatal("expected an error before the retry interval passed, but got ok")
+	}
+	now = now.Add(retryInterval)
+	if _, err := f.Write([]byte("fourth\n")); err != nil {
+		t.Fatal(err)
+	}
+	f.cleanupWg.Wait()
+	if b, err := ioutil.ReadFile(f.path); err != nil || string(b) != "fourth\n" {
+		t.Errorf("expected the message written after opening the file again, got %q, %v", b, err)
+	}
+}
+
+func TestRotatingFileCleanup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "windows_exporter_log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{
+		"exporter-2021-01-01T00-00-00.000.log.gz",
+		"exporter-2021-02-25T00-00-00.000.log",
+		"exporter-2021-02-28T00-00-00.000.log",
+		"exporter-notatime.log",
+		"other-2021-01-01T00-00-00.000.log",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte("log line\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f := &rotatingFile{
+		fileOptions: fileOptions{path: filepath.Join(dir, "exporter.log"), maxAge: 30 * 24 * time.Hour, compress: true},
+		now:         func() time.Time { return time.Date(2021, 3, 1, 10, 0, 0, 0, time.Local) },
+	}
+	if err := f.cleanup(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"exporter-2021-02-25T00-00-00.000.log.gz",
+		"exporter-2021-02-28T00-00-00.000.log.gz",
+		"exporter-notatime.log",
+		"other-2021-01-01T00-00-00.000.log",
+	}
+	if files := logFiles(t, dir); !reflect.DeepEqual(files, expected) {
+		t.Fatalf("expected %v, got %v", expected, files)
+	}
+	r, err := os.Open(filepath.Join(dir, expected[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err := ioutil.ReadAll(gz); err != nil || !strings.HasPrefix(string(b), "log line") {
+		t.Errorf("expected the compressed log, got %q, %v", b, err)
+	}
+}
+
+func TestSetFormatFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "windows_exporter_log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	l := NewLogger(os.Stderr).(logger)
+	format := "logger:file?path=" + url.QueryEscape(filepath.Join(dir, "exporter.log"))
+	if err := l.SetFormat(format); err != nil {
+		t.Fatal(err)
+	}
+	f, ok := l.entry.Logger.Out.(*rotatingFile)
+	if !ok {
+		t.Fatalf("expected a log file, got %T", l.entry.Logger.Out)
+	}
+	// Setting the same format again keeps the file open.
+	if err := l.SetFormat(format); err != nil {
+		t.Fatal(err)
+	}
+	if l.entry.Logger.Out != f {
+		t.Errorf("expected the log file to be kept")
+	}
+	l.Info("to the file")
+
+	if err := l.SetFormat("logger:stderr"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("closed")); err == nil {
+		t.Errorf("expected the log file to be closed")
+	}
+	f.cleanupWg.Wait()
+	if b, err := ioutil.ReadFile(filepath.Join(dir, "exporter.log")); err != nil || !strings.Contains(string(b), "to the file") {
+		t.Errorf("expected the message in the log file, got %q, %v", b, err)
+	}
+}
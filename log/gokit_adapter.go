@@ -1,45 +1,59 @@
 package log
 
 import (
+	"fmt"
+
 	"github.com/go-kit/kit/log/level"
 )
 
 // Returns an adapter implementing the go-kit/kit/log.Logger interface on our
 // logrus logger
 func NewToolkitAdapter() *logAdapter {
-	return &logAdapter{}
+	return &logAdapter{logger: baseLogger}
 }
 
-type logAdapter struct{}
+type logAdapter struct {
+	logger logger
+}
 
-func (*logAdapter) Log(keyvals ...interface{}) error {
+// Log logs the message of keyvals at its level. The other keys and values
+// are added as fields.
+func (a *logAdapter) Log(keyvals ...interface{}) error {
 	var lvl level.Value
 	var msg string
+	rest := make([]interface{}, 0, len(keyvals))
 	for i := 0; i < len(keyvals); i += 2 {
+		var v interface{} = "(MISSING)"
+		if i+1 < len(keyvals) {
+			v = keyvals[i+1]
+		}
 		switch keyvals[i] {
 		case "level":
-			tlvl, ok := keyvals[i+1].(level.Value)
+			tlvl, ok := v.(level.Value)
 			if !ok {
-				Warnf("Could not cast level of type %T", keyvals[i+1])
+				Warnf("Could not cast level of type %T", v)
 			} else {
 				lvl = tlvl
 			}
 		case "msg":
-			msg = keyvals[i+1].(string)
+			msg = fmt.Sprint(v)
+		default:
+			rest = append(rest, keyvals[i], v)
 		}
 	}
 
+	entry := a.logger.entry.WithFields(fields(rest))
 	switch lvl {
 	case level.ErrorValue():
-		Errorln(msg)
+		entry.Errorln(msg)
 	case level.WarnValue():
-		Warnln(msg)
+		entry.Warnln(msg)
 	case level.InfoValue():
-		Infoln(msg)
+		entry.Infoln(msg)
 	case level.DebugValue():
-		Debugln(msg)
+		entry.Debugln(msg)
 	default:
-		Warnf("Unmatched log level: '%v' for message %q", lvl, msg)
+		entry.Warnf("Unmatched log level: '%v' for message %q", lvl, msg)
 	}
 
 	return nil
@@ -24,6 +24,7 @@ import (
 	"net/url"
 	"os"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -37,13 +38,10 @@ var setSyslogFormatter func(logger, string, string) error
 // setEventlogFormatter is nil if the target OS does not support Eventlog (i.e., is not Windows).
 var setEventlogFormatter func(logger, string, bool) error
 
-func setJSONFormatter() {
-	origLogger.Formatter = &logrus.JSONFormatter{}
-}
-
 type loggerSettings struct {
-	level  string
-	format string
+	level          string
+	format         string
+	repeatInterval string
 }
 
 func (s *loggerSettings) apply(ctx *kingpin.ParseContext) error {
@@ -51,6 +49,11 @@ func (s *loggerSettings) apply(ctx *kingpin.ParseContext) error {
 	if err != nil {
 		return err
 	}
+	intervals, err := parseRepeatIntervals(s.repeatInterval)
+	if err != nil {
+		return err
+	}
+	CollectorErrors.setIntervals(intervals)
 	err = baseLogger.SetFormat(s.format)
 	return err
 }
@@ -63,9 +66,12 @@ func AddFlags(a *kingpin.Application) {
 		Default(origLogger.Level.String()).
 		StringVar(&s.level)
 	defaultFormat := url.URL{Scheme: "logger", Opaque: "stderr"}
-	a.Flag("log.format", `Set the log target and format. Example: "logger:syslog?appname=bob&local=7" or "logger:stdout?json=true"`).
+	a.Flag("log.format", `Set the log target and format. Example: "logger:syslog?appname=bob&local=7", "logger:stdout?json=true" or "logger:file?path=C:\ProgramData\windows_exporter\logs\windows_exporter.log&max_size_mb=100&max_backups=5&max_age_days=30&compress=true"`).
 		Default(defaultFormat.String()).
 		StringVar(&s.format)
+	a.Flag("log.error-repeat-interval", "Interval at which a collector error repeated on every scrape is logged again, with the number of times it was repeated. Optionally followed by per-collector overrides, e.g. '10m,thermalzone=24h'. 0 logs every error.").
+		Default(defaultRepeatInterval).
+		StringVar(&s.repeatInterval)
 	a.Action(s.apply)
 }
 
@@ -91,7 +97,8 @@ type Logger interface {
 	Fatalln(...interface{})
 	Fatalf(string, ...interface{})
 
-	With(key string, value interface{}) Logger
+	With(keyvals ...interface{}) Logger
+	WithError(err error) Logger
 
 	SetFormat(string) error
 	SetLevel(string) error
@@ -101,8 +108,71 @@ type logger struct {
 	entry *logrus.Entry
 }
 
-func (l logger) With(key string, value interface{}) Logger {
-	return logger{l.entry.WithField(key, value)}
+// With adds fields to the logger, given as alternating keys and values.
+func (l logger) With(keyvals ...interface{}) Logger {
+	return logger{l.entry.WithFields(fields(keyvals))}
+}
+
+// WithError adds err to the logger, and the fields of the errors it wraps
+// which implement fieldError.
+func (l logger) WithError(err error) Logger {
+	entry := l.entry.WithError(err)
+	for e := err; e != nil; e = unwrap(e) {
+		if f, ok := e.(fieldError); ok {
+			entry = entry.WithFields(fields(f.LogFields()))
+		}
+	}
+	return logger{entry}
+}
+
+// fieldError is implemented by errors carrying fields describing where they
+// occurred, e.g. the WMI class of a failed query, which WithError adds to the
+// log entry.
+type fieldError interface {
+	error
+	LogFields() []interface{}
+}
+
+func unwrap(err error) error {
+	u, ok := err.(interface{ Unwrap() error })
+	if !ok {
+		return nil
+	}
+	return u.Unwrap()
+}
+
+// fields converts alternating keys and values to logrus fields. A key without
+// a value gets the value "(MISSING)", as with go-kit loggers.
+func fields(keyvals []interface{}) logrus.Fields {
+	f := make(logrus.Fields, (len(keyvals)+1)/2)
+	for i := 0; i < len(keyvals); i += 2 {
+		var v interface{} = "(MISSING)"
+		if i+1 < len(keyvals) {
+			v = keyvals[i+1]
+		}
+		f[fmt.Sprint(keyvals[i])] = v
+	}
+	return f
+}
+
+// messageWithFields returns the message of e followed by its fields as
+// key=value pairs sorted by key, for targets which only take a message.
+func messageWithFields(e *logrus.Entry) string {
+	keys := make([]string, 0, len(e.Data))
+	for k := range e.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString(e.Message)
+	for _, k := range keys {
+		v := fmt.Sprint(e.Data[k])
+		if strings.ContainsAny(v, " \t\n\"=") {
+			v = strconv.Quote(v)
+		}
+		fmt.Fprintf(&b, " %s=%s", k, v)
+	}
+	return b.String()
 }
 
 // Debug logs a message at level Debug on the standard logger.
@@ -186,7 +256,7 @@ func (l logger) SetLevel(level string) error {
 		return err
 	}
 
-	l.entry.Logger.Level = lvl
+	l.entry.Logger.SetLevel(lvl)
 	return nil
 }
 
@@ -198,10 +268,13 @@ func (l logger) SetFormat(format string) error {
 	if u.Scheme != "logger" {
 		return fmt.Errorf("invalid scheme %s", u.Scheme)
 	}
-	jsonq := u.Query().Get("json")
-	if jsonq == "true" {
-		setJSONFormatter()
+	// The format is set again every time the flags are parsed, so the
+	// formatter is replaced rather than wrapped again.
+	var formatter logrus.Formatter = &logrus.TextFormatter{}
+	if u.Query().Get("json") == "true" {
+		formatter = &logrus.JSONFormatter{}
 	}
+	l.entry.Logger.SetFormatter(formatter)
 
 	switch u.Opaque {
 	case "syslog":
@@ -223,15 +296,38 @@ func (l logger) SetFormat(format string) error {
 		}
 		return setEventlogFormatter(l, name, debugAsInfo)
 	case "stdout":
-		l.entry.Logger.Out = os.Stdout
+		l.setOutput(os.Stdout)
 	case "stderr":
-		l.entry.Logger.Out = os.Stderr
+		l.setOutput(os.Stderr)
+	case "file":
+		o, err := parseFileOptions(u.Query())
+		if err != nil {
+			return err
+		}
+		if f, ok := l.entry.Logger.Out.(*rotatingFile); ok && f.fileOptions == o {
+			return nil
+		}
+		f, err := newRotatingFile(o)
+		if err != nil {
+			return err
+		}
+		l.setOutput(f)
 	default:
 		return fmt.Errorf("unsupported logger %q", u.Opaque)
 	}
 	return nil
 }
 
+// setOutput sets the output of the logger to w, closing the log file written
+// to before, if any.
+func (l logger) setOutput(w io.Writer) {
+	prev := l.entry.Logger.Out
+	l.entry.Logger.SetOutput(w)
+	if f, ok := prev.(*rotatingFile); ok && prev != w {
+		f.Close()
+	}
+}
+
 // sourced adds a source field to the logger that contains
 // the file name and line where the logging happened.
 func (l logger) sourced() *logrus.Entry {
@@ -268,9 +364,16 @@ func NewNopLogger() Logger {
 	return logger{entry: logrus.NewEntry(l)}
 }
 
-// With adds a field to the logger.
-func With(key string, value interface{}) Logger {
-	return baseLogger.With(key, value)
+// With adds fields to the logger, given as alternating keys and values, e.g.
+// log.With("collector", name, "duration", duration).Error(err).
+func With(keyvals ...interface{}) Logger {
+	return baseLogger.With(keyvals...)
+}
+
+// WithError adds err to the logger, with the fields describing where it
+// occurred, e.g. the WMI class of a failed query.
+func WithError(err error) Logger {
+	return baseLogger.WithError(err)
 }
 
 // Debug logs a message at level Debug on the standard logger.
@@ -0,0 +1,131 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/go-kit/kit/log/level"
+	"github.com/sirupsen/logrus"
+)
+
+// fieldsError is an error carrying log fields.
+type fieldsError struct {
+	err    error
+	fields []interface{}
+}
+
+func (e *fieldsError) Error() string            { return e.err.Error() }
+func (e *fieldsError) Unwrap() error            { return e.err }
+func (e *fieldsError) LogFields() []interface{} { return e.fields }
+
+// jsonLogger returns a logger writing JSON to the returned buffer.
+func jsonLogger() (logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	l := logrus.New()
+	l.Out = &buf
+	l.Formatter = &logrus.JSONFormatter{}
+	return logger{entry: logrus.NewEntry(l)}, &buf
+}
+
+func decode(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("%v: %s", err, buf)
+	}
+	delete(entry, "time")
+	delete(entry, "source")
+	return entry
+}
+
+func TestWith(t *testing.T) {
+	l, buf := jsonLogger()
+	l.With("collector", "cpu", "duration", 0.5, "odd").Info("Collector succeeded")
+
+	expected := map[string]interface{}{
+		"level":     "info",
+		"msg":       "Collector succeeded",
+		"collector": "cpu",
+		"duration":  0.5,
+		"odd":       "(MISSING)",
+	}
+	if entry := decode(t, buf); !reflect.DeepEqual(entry, expected) {
+		t.Errorf("expected %v, got %v", expected, entry)
+	}
+}
+
+func TestWithError(t *testing.T) {
+	l, buf := jsonLogger()
+	err := &fieldsError{
+		err: fmt.Errorf("query failed: %w", &fieldsError{
+			err:    errors.New("access denied"),
+			fields: []interface{}{"perf_object", "Processor"},
+		}),
+		fields: []interface{}{"wmi_class", "Win32_Service"},
+	}
+	l.With("collector", "service").WithError(err).Error("Collector failed")
+
+	expected := map[string]interface{}{
+		"level":       "error",
+		"msg":         "Collector failed",
+		"collector":   "service",
+		"error":       "query failed: access denied",
+		"wmi_class":   "Win32_Service",
+		"perf_object": "Processor",
+	}
+	if entry := decode(t, buf); !reflect.DeepEqual(entry, expected) {
+		t.Errorf("expected %v, got %v", expected, entry)
+	}
+}
+
+func TestMessageWithFields(t *testing.T) {
+	e := logrus.NewEntry(logrus.New()).WithFields(logrus.Fields{
+		"collector": "mssql",
+		"error":     errors.New("service is stopped"),
+		"duration":  0.25,
+	})
+	e.Message = "Collector failed"
+	expected := `Collector failed collector=mssql duration=0.25 error="service is stopped"`
+	if got := messageWithFields(e); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestToolkitAdapter(t *testing.T) {
+	l, buf := jsonLogger()
+	a := &logAdapter{logger: l}
+	if err := a.Log("level", level.InfoValue(), "msg", "Listening on", "address", ":9182"); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"level":   "info",
+		"msg":     "Listening on",
+		"address": ":9182",
+	}
+	if entry := decode(t, buf); !reflect.DeepEqual(entry, expected) {
+		t.Errorf("expected %v, got %v", expected, entry)
+	}
+}
+
+func TestSetFormat(t *testing.T) {
+	l := logger{entry: logrus.NewEntry(logrus.New())}
+	if err := l.SetFormat("logger:stdout?json=true"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := l.entry.Logger.Formatter.(*logrus.JSONFormatter); !ok {
+		t.Errorf("expected a JSON formatter, got %T", l.entry.Logger.Formatter)
+	}
+	// The format is set again when the flags are parsed again.
+	if err := l.SetFormat("logger:stderr"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := l.entry.Logger.Formatter.(*logrus.TextFormatter); !ok {
+		t.Errorf("expected a text formatter, got %T", l.entry.Logger.Formatter)
+	}
+	if err := l.SetFormat("logger:unknown"); err == nil {
+		t.Errorf("expected an error, but got ok")
+	}
+}
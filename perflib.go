@@ -0,0 +1,154 @@
+// +build windows
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/prometheus-community/windows_exporter/collector"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// Output formats of the perflib subcommands.
+const (
+	perflibFormatText = "text"
+	perflibFormatJSON = "json"
+)
+
+// perflibCommand browses the perflib objects and counters, to help writing
+// perflib based collectors.
+type perflibCommand struct {
+	list *kingpin.CmdClause
+	show *kingpin.CmdClause
+
+	object   *string
+	format   *string
+	dumpFile *string
+}
+
+func addPerflibCommand(app *kingpin.Application) *perflibCommand {
+	c := &perflibCommand{}
+	cmd := app.Command("perflib", "Browse the perflib objects and counters of the system.")
+	c.format = cmd.Flag("format", "Output format, text or json. JSON output can be read back with --dump.").
+		Default(perflibFormatText).Enum(perflibFormatText, perflibFormatJSON)
+	c.dumpFile = cmd.Flag("dump", "Read the objects from a file recorded with --format=json instead of the system.").String()
+	c.list = cmd.Command("list", "List all perflib objects.")
+	c.show = cmd.Command("show", "Show the counters and instances of a perflib object.")
+	c.object = c.show.Arg("object", "English name or index of the object.").Required().String()
+	return c
+}
+
+// run runs the selected perflib subcommand.
+func (c *perflibCommand) run(w io.Writer, command string) error {
+	dump, err := c.load(command)
+	if err != nil {
+		return err
+	}
+
+	if command == c.show.FullCommand() {
+		obj, err := findPerfObject(dump, *c.object)
+		if err != nil {
+			return err
+		}
+		dump = &collector.PerfDump{Perflib: []collector.PerfObjectDump{obj}}
+	}
+
+	if *c.format == perflibFormatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(dump)
+	}
+	if command == c.show.FullCommand() {
+		return printPerfObject(w, dump.Perflib[0])
+	}
+	return printPerfObjectList(w, dump)
+}
+
+// load reads the objects needed by command from the dump file or the system.
+func (c *perflibCommand) load(command string) (*collector.PerfDump, error) {
+	if *c.dumpFile != "" {
+		f, err := os.Open(*c.dumpFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		var dump collector.PerfDump
+		if err := json.NewDecoder(f).Decode(&dump); err != nil {
+			return nil, fmt.Errorf("failed to read dump %s: %v", *c.dumpFile, err)
+		}
+		for i := range dump.Perflib {
+			dump.Perflib[i].LinkBaseCounters()
+		}
+		return &dump, nil
+	}
+
+	query := "Global"
+	if command == c.show.FullCommand() {
+		index, err := strconv.ParseUint(*c.object, 10, 32)
+		if err != nil {
+			i, ok := collector.LookupPerfObjectIndex(*c.object)
+			if !ok {
+				return nil, fmt.Errorf("unknown perflib object %q", *c.object)
+			}
+			index = uint64(i)
+		}
+		query = strconv.FormatUint(index, 10)
+	}
+	return collector.QueryPerfDump(query)
+}
+
+// findPerfObject returns the object with the given name or index.
+func findPerfObject(dump *collector.PerfDump, object string) (collector.PerfObjectDump, error) {
+	for _, obj := range dump.Perflib {
+		if obj.Name == object || strconv.FormatUint(uint64(obj.Index), 10) == object {
+			return obj, nil
+		}
+	}
+	return collector.PerfObjectDump{}, fmt.Errorf("perflib object %q not found", object)
+}
+
+func printPerfObjectList(w io.Writer, dump *collector.PerfDump) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "Index\tName\tCounters\tInstances")
+	for _, obj := range dump.Perflib {
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%d\n", obj.Index, obj.Name, len(obj.Counters), len(obj.Instances))
+	}
+	return tw.Flush()
+}
+
+func printPerfObject(w io.Writer, obj collector.PerfObjectDump) error {
+	fmt.Fprintf(w, "Object %s (index %d, frequency %d)\n\n", obj.Name, obj.Index, obj.Frequency)
+
+	// The tag shows how to read the counter with unmarshalObject.
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "Index\tName\tType\tTag")
+	for _, c := range obj.Counters {
+		tag := c.Name
+		if c.BaseOf != "" {
+			tag = c.BaseOf + ",base"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\tperflib:%q\n", c.Index, c.Name, c.Type, tag)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, instance := range obj.Instances {
+		fmt.Fprintf(w, "\nInstance %q\n", instance.Name)
+		tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+		for i, v := range instance.Values {
+			if i < len(obj.Counters) {
+				fmt.Fprintf(tw, "  %s\t%d\n", obj.Counters[i].Name, v)
+			}
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// +build windows
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/collector"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const perflibTestDump = "collector/testdata/fixtures/logical_disk.json"
+
+func runPerflibCommand(t *testing.T, args ...string) string {
+	t.Helper()
+	app := kingpin.New("windows_exporter", "")
+	app.Command("run", "").Default()
+	cmd := addPerflibCommand(app)
+	command, err := app.Parse(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := cmd.run(&out, command); err != nil {
+		t.Fatal(err)
+	}
+	return out.String()
+}
+
+func TestPerflibList(t *testing.T) {
+	out := runPerflibCommand(t, "perflib", "list", "--dump", perflibTestDump)
+	expected := "Index  Name         Counters  Instances\n" +
+		"0      LogicalDisk  18        3\n"
+	if out != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, out)
+	}
+}
+
+func TestPerflibShow(t *testing.T) {
+	out := runPerflibCommand(t, "perflib", "show", "LogicalDisk", "--dump", perflibTestDump)
+	for _, line := range []string{
+		"Object LogicalDisk (index 0, frequency 10000000)",
+		`0      % Free Space               PERF_RAW_FRACTION           perflib:"% Free Space"`,
+		`0      % Free Space               PERF_RAW_BASE               perflib:"% Free Space,base"`,
+		`Instance "HarddiskVolume1"`,
+		"  Free Megabytes             372",
+	} {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("expected output to contain %q, got:\n%s", line, out)
+		}
+	}
+
+	var dump collector.PerfDump
+	out = runPerflibCommand(t, "perflib", "show", "LogicalDisk", "--dump", perflibTestDump, "--format", "json")
+	if err := json.Unmarshal([]byte(out), &dump); err != nil {
+		t.Fatal(err)
+	}
+	if len(dump.Perflib) != 1 || dump.Perflib[0].Name != "LogicalDisk" {
+		t.Errorf("unexpected dump %+v", dump)
+	}
+}
+
+func TestPerflibShowUnknownObject(t *testing.T) {
+	app := kingpin.New("windows_exporter", "")
+	cmd := addPerflibCommand(app)
+	command, err := app.Parse([]string{"perflib", "show", "Processor", "--dump", perflibTestDump})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.run(&bytes.Buffer{}, command); err == nil {
+		t.Errorf("expected an error, but got ok")
+	}
+}
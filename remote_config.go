@@ -0,0 +1,180 @@
+// +build windows
+
+package main
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/collector"
+	"github.com/prometheus-community/windows_exporter/config"
+	"github.com/prometheus-community/windows_exporter/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// runningConfig is the part of the configuration which is applied again when
+// the remote configuration changes. Scrapes hold the read lock while they
+// run, so that collectors aren't replaced during a scrape.
+type runningConfig struct {
+	sync.RWMutex
+	collectors map[string]collector.Collector
+	limits     *seriesLimits
+	settings   []config.Setting
+}
+
+// Settings returns the effective configuration.
+func (c *runningConfig) Settings() []config.Setting {
+	c.RLock()
+	defer c.RUnlock()
+	return c.settings
+}
+
+// readLocked returns next, holding the read lock while it runs.
+func (c *runningConfig) readLocked(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c.RLock()
+		defer c.RUnlock()
+		next(w, r)
+	}
+}
+
+// configLoader binds the configuration files and the remote configuration to
+// the flags of the application.
+type configLoader struct {
+	app    *kingpin.Application
+	args   []string
+	files  []string
+	remote *config.Remote
+	strict bool
+
+	defaults *config.Defaults
+	// resolver is the configuration currently bound.
+	resolver *config.Resolver
+}
+
+// initial returns the remote configuration, or the cached one if it can't be
+// fetched, or nil if there is no remote configuration.
+func (l *configLoader) initial() ([]byte, error) {
+	if l.remote == nil {
+		return nil, nil
+	}
+	b, err := l.remote.Fetch()
+	if err == nil {
+		return b, nil
+	}
+	log.Warnf("Couldn't fetch configuration, using the cached configuration: %v", err)
+	return l.remote.Cached()
+}
+
+// resolve returns the configuration of the files, followed by the remote
+// configuration b, if it isn't nil.
+func (l *configLoader) resolve(b []byte) (*config.Resolver, error) {
+	resolver, err := config.NewResolver(l.files...)
+	if err != nil {
+		return nil, err
+	}
+	if b != nil {
+		if err := resolver.Load(l.remote.URL(), b); err != nil {
+			return nil, err
+		}
+	}
+	return resolver, nil
+}
+
+// rejects returns true if the problems found by checking a configuration
+// prevent binding it. Unknown keys are only ignored if not strict.
+func (l *configLoader) rejects(err error) bool {
+	if err == nil {
+		return false
+	}
+	if l.strict {
+		return true
+	}
+	checkErr, ok := err.(*config.CheckError)
+	return !ok || !checkErr.Ignorable()
+}
+
+// bind binds resolver to the flags, and parses them again, returning the
+// selected command.
+func (l *configLoader) bind(resolver *config.Resolver) (string, error) {
+	if l.defaults == nil {
+		l.defaults = config.SaveDefaults(l.app)
+	} else {
+		l.defaults.Restore(l.app)
+	}
+	if err := resolver.Bind(l.app, l.args); err != nil {
+		return "", err
+	}
+	command, err := l.app.Parse(l.args)
+	if err != nil {
+		return "", err
+	}
+	l.resolver = resolver
+	return command, nil
+}
+
+// reload binds the configuration of the files and the remote configuration
+// b, and applies it. If that fails, the current configuration is bound
+// again, as binding sets the values of the flags.
+func (l *configLoader) reload(b []byte, apply func() error) error {
+	current := l.resolver
+	resolver, err := l.resolve(b)
+	if err != nil {
+		return err
+	}
+	// Checking leaves the flags unchanged, so a rejected configuration
+	// doesn't need to be undone.
+	err = resolver.Check(l.app)
+	if l.rejects(err) {
+		return err
+	}
+	if err != nil {
+		log.Warnf("%v", err)
+	}
+	_, err = l.bind(resolver)
+	if err == nil {
+		err = apply()
+	}
+	if err != nil {
+		if _, bindErr := l.bind(current); bindErr != nil {
+			log.Errorf("Couldn't bind the current configuration again: %v", bindErr)
+		}
+		return err
+	}
+	l.cache(b)
+	return nil
+}
+
+// cache writes the remote configuration b, once applied, to the cache file.
+func (l *configLoader) cache(b []byte) {
+	if b == nil {
+		return
+	}
+	if err := l.remote.Cache(b); err != nil {
+		log.Warnf("Couldn't cache configuration from %s: %v", l.remote.URL(), err)
+	}
+}
+
+// watch fetches the remote configuration every interval, and applies it
+// with apply, holding the write lock of running, when it changed.
+func (l *configLoader) watch(interval time.Duration, running *runningConfig, apply func() error) {
+	for range time.Tick(interval) {
+		b, err := l.remote.Fetch()
+		if err == config.ErrNotModified {
+			continue
+		}
+		if err != nil {
+			log.Warnf("Couldn't fetch configuration: %v", err)
+			continue
+		}
+		running.Lock()
+		err = l.reload(b, apply)
+		running.Unlock()
+		if err != nil {
+			log.Errorf("Couldn't apply configuration from %s, keeping the current configuration: %v", l.remote.URL(), err)
+			continue
+		}
+		log.Infof("Applied configuration from %s", l.remote.URL())
+	}
+}
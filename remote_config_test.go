@@ -0,0 +1,103 @@
+// +build windows
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/config"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+func TestConfigLoaderReload(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+	remote, err := config.NewRemote(config.RemoteOptions{URL: server.URL, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := kingpin.New("test", "")
+	enabled := app.Flag("collectors.enabled", "").Default("cpu").String()
+	maxSeries := app.Flag("scrape.max-series", "").Default("0").Int()
+	l := &configLoader{app: app, remote: remote}
+
+	fetch := func(config string) []byte {
+		body = config
+		b, err := remote.Fetch()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	apply := func() error { return nil }
+
+	resolver, err := l.resolve(fetch("collectors:\n  enabled: cpu,net\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.bind(resolver); err != nil {
+		t.Fatal(err)
+	}
+	if *enabled != "cpu,net" {
+		t.Fatalf("expected enabled collectors %q, got %q", "cpu,net", *enabled)
+	}
+
+	for _, c := range []struct {
+		name      string
+		config    string
+		apply     func() error
+		err       bool
+		enabled   string
+		maxSeries int
+	}{
+		{
+			name:      "applied",
+			config:    "collectors:\n  enabled: os\nscrape:\n  max-series: 100\n",
+			enabled:   "os",
+			maxSeries: 100,
+		},
+		{
+			name:    "removed keys are reset",
+			config:  "collectors:\n  enabled: os\n",
+			enabled: "os",
+		},
+		{
+			name:    "unknown keys are ignored",
+			config:  "collectors:\n  enabled: net\ncolectors:\n  enabled: os\n",
+			enabled: "net",
+		},
+		{
+			name:    "invalid values are rejected",
+			config:  "collectors:\n  enabled: os\nscrape:\n  max-series: many\n",
+			err:     true,
+			enabled: "net",
+		},
+		{
+			name:    "apply errors are rolled back",
+			config:  "collectors:\n  enabled: unknown\nscrape:\n  max-series: 100\n",
+			apply:   func() error { return errors.New("unknown collector") },
+			err:     true,
+			enabled: "net",
+		},
+	} {
+		a := apply
+		if c.apply != nil {
+			a = c.apply
+		}
+		err := l.reload(fetch(c.config), a)
+		if c.err != (err != nil) {
+			t.Errorf("%s: expected error %t, got %v", c.name, c.err, err)
+		}
+		if *enabled != c.enabled || *maxSeries != c.maxSeries {
+			t.Errorf("%s: expected %q and %d, got %q and %d", c.name, c.enabled, c.maxSeries, *enabled, *maxSeries)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+// metric-types determines the type of every metric emitted by the collectors
+// and writes collector/catalogue_types.go, with a Describe method sending the
+// descriptors of every collector and a metricTypes method returning their
+// types, which are used to build the metric catalogue printed by
+// --collectors.print.
+//
+// prometheus.Desc does not know the type of a metric, so it is taken from the
+// prometheus.MustNewConstMetric (or histogram/summary) and perflibPresence.emit
+// calls in the collector methods instead. Run it with `go generate ./collector/`.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+)
+
+var valueTypes = map[string]string{
+	"CounterValue": "counter",
+	"GaugeValue":   "gauge",
+	"UntypedValue": "untyped",
+}
+
+var constructors = map[string]string{
+	"MustNewConstHistogram": "histogram",
+	"NewConstHistogram":     "histogram",
+	"MustNewConstSummary":   "summary",
+	"NewConstSummary":       "summary",
+}
+
+func main() {
+	dir := flag.String("dir", ".", "Directory containing the collector sources.")
+	out := flag.String("out", "catalogue_types.go", "File to write, relative to -dir.")
+	flag.Parse()
+
+	types, order, err := metricTypes(*dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	src, err := render(types, order)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := ioutil.WriteFile(*dir+"/"+*out, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// metricTypes returns the metric type of every *prometheus.Desc field used
+// by a collector method or function, keyed by "<type>.<field>", and the
+// *prometheus.Desc fields of every struct type, in the order they are
+// declared.
+func metricTypes(dir string) (map[string]string, map[string][]string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != "catalogue_types.go"
+	}, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	types := map[string]string{}
+	order := map[string][]string{}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				if gen, ok := decl.(*ast.GenDecl); ok {
+					descFields(gen, order)
+					continue
+				}
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Body == nil {
+					continue
+				}
+				vars := collectorVars(fn)
+				if len(vars) == 0 {
+					continue
+				}
+
+				var inspectErr error
+				ast.Inspect(fn.Body, func(n ast.Node) bool {
+					call, ok := n.(*ast.CallExpr)
+					if !ok {
+						return true
+					}
+					recvType, field, typ := metricCall(call, vars)
+					if field == "" {
+						return true
+					}
+					key := recvType + "." + field
+					if existing, ok := types[key]; ok && existing != typ {
+						inspectErr = fmt.Errorf("%s: %s is used as both %s and %s", fset.Position(call.Pos()), key, existing, typ)
+						return false
+					}
+					types[key] = typ
+					return true
+				})
+				if inspectErr != nil {
+					return nil, nil, inspectErr
+				}
+			}
+		}
+	}
+	return types, order, nil
+}
+
+// descFields adds the *prometheus.Desc fields of the struct types declared
+// by decl to order.
+func descFields(decl *ast.GenDecl, order map[string][]string) {
+	for _, spec := range decl.Specs {
+		ts, ok := spec.(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+		st, ok := ts.Type.(*ast.StructType)
+		if !ok {
+			continue
+		}
+		for _, field := range st.Fields.List {
+			star, ok := field.Type.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+			sel, ok := star.X.(*ast.SelectorExpr)
+			if !ok || !isIdent(sel.X, "prometheus") || sel.Sel.Name != "Desc" {
+				continue
+			}
+			for _, ident := range field.Names {
+				order[ts.Name.Name] = append(order[ts.Name.Name], ident.Name)
+			}
+		}
+	}
+}
+
+// collectorVars returns the receiver and parameters of fn which may hold
+// descriptors, mapped to the name of their type.
+func collectorVars(fn *ast.FuncDecl) map[string]string {
+	vars := map[string]string{}
+	var fields []*ast.Field
+	if fn.Recv != nil {
+		fields = append(fields, fn.Recv.List...)
+	}
+	fields = append(fields, fn.Type.Params.List...)
+	for _, field := range fields {
+		name := typeName(field.Type)
+		if name == "" {
+			continue
+		}
+		for _, ident := range field.Names {
+			vars[ident.Name] = name
+		}
+	}
+	return vars
+}
+
+// metricCall returns the collector type, Desc field and metric type of a call
+// creating a const metric from a Desc held by one of vars, if call is one.
+func metricCall(call *ast.CallExpr, vars map[string]string) (string, string, string) {
+	fun, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", "", ""
+	}
+	args, name := call.Args, fun.Sel.Name
+	if name == "emit" && len(args) > 0 {
+		// perflibPresence.emit(ch, desc, valueType, field, labelValues...)
+		// sends a const metric unless the counter of field is missing.
+		args, name = args[1:], "MustNewConstMetric"
+	} else if !isIdent(fun.X, "prometheus") {
+		return "", "", ""
+	}
+	if len(args) < 2 {
+		return "", "", ""
+	}
+	desc, ok := args[0].(*ast.SelectorExpr)
+	if !ok {
+		return "", "", ""
+	}
+	ident, ok := desc.X.(*ast.Ident)
+	if !ok || vars[ident.Name] == "" {
+		return "", "", ""
+	}
+	recvType := vars[ident.Name]
+
+	if typ, ok := constructors[name]; ok {
+		return recvType, desc.Sel.Name, typ
+	}
+	if name != "MustNewConstMetric" && name != "NewConstMetric" {
+		return "", "", ""
+	}
+	valueType, ok := args[1].(*ast.SelectorExpr)
+	if !ok || !isIdent(valueType.X, "prometheus") {
+		return "", "", ""
+	}
+	typ, ok := valueTypes[valueType.Sel.Name]
+	if !ok {
+		return "", "", ""
+	}
+	return recvType, desc.Sel.Name, typ
+}
+
+func typeName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+func isIdent(expr ast.Expr, name string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == name
+}
+
+func render(types map[string]string, order map[string][]string) ([]byte, error) {
+	byType := map[string][]string{}
+	for key := range types {
+		typeName := strings.SplitN(key, ".", 2)[0]
+		byType[typeName] = nil
+	}
+	typeNames := make([]string, 0, len(byType))
+	for typeName := range byType {
+		for _, field := range order[typeName] {
+			if _, ok := types[typeName+"."+field]; ok {
+				byType[typeName] = append(byType[typeName], field)
+			}
+		}
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	var buf bytes.Buffer
+	buf.WriteString("package collector\n\n")
+	buf.WriteString("import \"github.com/prometheus/client_golang/prometheus\"\n")
+	for _, typeName := range typeNames {
+		fmt.Fprintf(&buf, "\n// Describe sends the descriptors of the metrics exposed by the collector.\n")
+		fmt.Fprintf(&buf, "func (c *%s) Describe(ch chan<- *prometheus.Desc) {\n", typeName)
+		for _, field := range byType[typeName] {
+			fmt.Fprintf(&buf, "ch <- c.%s\n", field)
+		}
+		buf.WriteString("}\n")
+		fmt.Fprintf(&buf, "\n// metricTypes returns the type of the metrics exposed for each descriptor.\n")
+		fmt.Fprintf(&buf, "func (c *%s) metricTypes() map[*prometheus.Desc]string {\n", typeName)
+		buf.WriteString("return map[*prometheus.Desc]string{\n")
+		for _, field := range byType[typeName] {
+			fmt.Fprintf(&buf, "c.%s: %q,\n", field, types[typeName+"."+field])
+		}
+		buf.WriteString("}\n}\n")
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	// The build constraint is added once formatted, as newer versions of
+	// gofmt would add a //go:build line, unlike in the other files.
+	return append([]byte("// Code generated by tools/metric-types; DO NOT EDIT.\n\n// +build windows\n\n"), src...), nil
+}
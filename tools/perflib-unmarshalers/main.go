@@ -0,0 +1,286 @@
+// perflib-unmarshalers generates a typed unmarshal function for every struct
+// in the collector package with fields tagged with perflib counters, and
+// writes them to collector/perflib_unmarshalers.go.
+//
+// unmarshalObject looks up the counters of every instance by name using
+// reflection, which is noticeable for objects with many instances, like
+// Process. The generated functions look up the positions of the counters
+// once per object instead, and set the fields directly. unmarshalObject uses
+// them for the generated types, and falls back to reflection for others.
+// Run it with `go generate ./collector/`.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// field is a struct field tagged with a perflib counter. The options are
+// validated the same way as by perflibFields in the collector package.
+type field struct {
+	index    int
+	name     string
+	counter  string
+	value    string
+	required bool
+	optional bool
+}
+
+// structType is a struct read by unmarshalObject.
+type structType struct {
+	name   string
+	fields []field
+
+	// Fields set from the instance rather than from counters.
+	hasName         bool
+	hasInstanceName bool
+	hasProcessID    bool
+}
+
+func main() {
+	dir := flag.String("dir", ".", "Directory containing the collector sources.")
+	out := flag.String("out", "perflib_unmarshalers.go", "File to write, relative to -dir.")
+	flag.Parse()
+
+	types, err := perflibTypes(*dir, *out)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	src, err := render(types)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := ioutil.WriteFile(*dir+"/"+*out, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// perflibTypes returns the struct types declared in the package in dir which
+// have fields tagged with perflib counters, sorted by name.
+func perflibTypes(dir, out string) ([]structType, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != out
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var types []structType
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					t, err := parseStruct(ts.Name.Name, st)
+					if err != nil {
+						return nil, fmt.Errorf("%s: %v", fset.Position(ts.Pos()), err)
+					}
+					if len(t.fields) > 0 {
+						types = append(types, t)
+					}
+				}
+			}
+		}
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].name < types[j].name })
+	return types, nil
+}
+
+func parseStruct(name string, st *ast.StructType) (structType, error) {
+	t := structType{name: name}
+	index := 0
+	for _, f := range st.Fields.List {
+		typ := typeName(f.Type)
+		var tag string
+		if f.Tag != nil {
+			s, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				return t, err
+			}
+			tag = reflect.StructTag(s).Get("perflib")
+		}
+		if len(f.Names) == 0 {
+			if tag != "" {
+				return t, fmt.Errorf("embedded field %s of %s cannot be tagged", typ, name)
+			}
+			index++
+			continue
+		}
+
+		for _, ident := range f.Names {
+			switch {
+			case tag != "":
+				if !ident.IsExported() {
+					return t, fmt.Errorf("tagged field %v cannot be written to", ident.Name)
+				}
+				if typ != "float64" {
+					return t, fmt.Errorf("tagged field %v has wrong type %v, must be float64", ident.Name, typ)
+				}
+				pf, err := parseTag(index, ident.Name, tag)
+				if err != nil {
+					return t, err
+				}
+				t.fields = append(t.fields, pf)
+			case ident.Name == "Name" && typ == "string":
+				t.hasName = true
+			case ident.Name == "InstanceName" && typ == "string":
+				t.hasInstanceName = true
+			case ident.Name == "ProcessID" && typ == "int":
+				t.hasProcessID = true
+			}
+			index++
+		}
+	}
+	return t, nil
+}
+
+func parseTag(index int, name, tag string) (field, error) {
+	// Options are separated by commas, while a comma followed by a space is
+	// part of the counter name, as in the collector package.
+	parts := strings.Split(tag, ",")
+	counter := parts[0]
+	i := 1
+	for ; i < len(parts) && strings.HasPrefix(parts[i], " "); i++ {
+		counter += "," + parts[i]
+	}
+	f := field{index: index, name: name, counter: counter}
+	for _, option := range parts[i:] {
+		switch option {
+		case "base", "ratio":
+			f.value = option
+		case "required":
+			f.required = true
+		case "optional":
+			f.optional = true
+		default:
+			return f, fmt.Errorf("tagged field %v has unknown option %q", name, option)
+		}
+	}
+	if f.required && f.optional {
+		return f, fmt.Errorf("tagged field %v cannot be both required and optional", name)
+	}
+	return f, nil
+}
+
+func typeName(expr ast.Expr) string {
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// exported returns name with the first letter in upper case, to build the
+// names of the generated identifiers.
+func exported(name string) string {
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func render(types []structType) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("package collector\n\n")
+	buf.WriteString("import (\n\"fmt\"\n\"reflect\"\n\n\"github.com/leoluk/perflib_exporter/perflib\"\n)\n\n")
+
+	buf.WriteString("func init() {\n")
+	buf.WriteString("perflibUnmarshalers = map[reflect.Type]perflibUnmarshaler{\n")
+	for _, t := range types {
+		fmt.Fprintf(&buf, "reflect.TypeOf((*[]%s)(nil)): func(obj *perflib.PerfObject, vs interface{}) (perflibPresence, error) {\n", t.name)
+		fmt.Fprintf(&buf, "return unmarshal%s(obj, vs.(*[]%s))\n},\n", exported(t.name), t.name)
+	}
+	buf.WriteString("}\n}\n")
+
+	for _, t := range types {
+		renderType(&buf, t)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	// The types are declared in files only built on Windows.
+	header := "// Code generated by tools/perflib-unmarshalers; DO NOT EDIT.\n\n// +build windows\n\n"
+	return append([]byte(header), src...), nil
+}
+
+func renderType(buf *bytes.Buffer, t structType) {
+	fieldsVar := "perflibFieldsOf" + exported(t.name)
+	fmt.Fprintf(buf, "\nvar %s = []perflibField{\n", fieldsVar)
+	for _, f := range t.fields {
+		fmt.Fprintf(buf, "{index: %d, counter: %q", f.index, f.counter)
+		if f.value != "" {
+			fmt.Fprintf(buf, ", value: %q", f.value)
+		}
+		if f.required {
+			buf.WriteString(", required: true")
+		}
+		if f.optional {
+			buf.WriteString(", optional: true")
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n\n")
+
+	fmt.Fprintf(buf, "func unmarshal%s(obj *perflib.PerfObject, vs *[]%s) (perflibPresence, error) {\n", exported(t.name), t.name)
+	buf.WriteString("var present perflibPresence\n")
+	buf.WriteString("if obj == nil {\nreturn present, fmt.Errorf(\"counter not found\")\n}\n")
+	fmt.Fprintf(buf, "layout, err := checkCounters(obj, reflect.TypeOf((*%s)(nil)).Elem(), %s)\n", t.name, fieldsVar)
+	buf.WriteString("if err != nil {\nreturn present, err\n}\n\n")
+	buf.WriteString("if cap(*vs) < len(obj.Instances) {\n")
+	fmt.Fprintf(buf, "*vs = make([]%s, len(obj.Instances))\n}\n\n", t.name)
+
+	buf.WriteString("for i, instance := range obj.Instances {\n")
+	buf.WriteString("v := &(*vs)[i]\n")
+	for k, f := range t.fields {
+		switch f.value {
+		case "":
+			fmt.Fprintf(buf, "if c := layout.counter(instance, %d); c != nil {\n", k)
+			fmt.Fprintf(buf, "v.%s = counterValue(obj, c)\n", f.name)
+		case "base":
+			fmt.Fprintf(buf, "if c, b := layout.counter(instance, %d), layout.base(instance, %d); c != nil && b != nil {\n", k, k)
+			fmt.Fprintf(buf, "v.%s = float64(b.Value)\n", f.name)
+		case "ratio":
+			fmt.Fprintf(buf, "if c, b := layout.counter(instance, %d), layout.base(instance, %d); c != nil && b != nil {\n", k, k)
+			fmt.Fprintf(buf, "if b.Value != 0 {\nv.%s = counterValue(obj, c) / float64(b.Value)\n}\n", f.name)
+		}
+		fmt.Fprintf(buf, "} else {\npresent.setAbsent(&v.%s)\n}\n", f.name)
+	}
+	if t.hasName {
+		buf.WriteString("if instance.Name != \"\" {\nv.Name = instance.Name\n}\n")
+	}
+	if t.hasInstanceName || t.hasProcessID {
+		buf.WriteString("id := instanceIdentity(instance)\n")
+		if t.hasInstanceName {
+			buf.WriteString("v.InstanceName = id.Name\n")
+		}
+		if t.hasProcessID {
+			buf.WriteString("v.ProcessID = id.ProcessID\n")
+		}
+	}
+	buf.WriteString("}\n\nreturn present, nil\n}\n")
+}